@@ -8,38 +8,34 @@
 // Key types:
 //   - [Fallback] - Interface for resolving unknown statuses to workflow recommendations
 //   - [ClaudeFallback] - Production implementation using Claude CLI via /bmad-help
+//   - [CachingFallback] - Wrapper reusing resolutions per status value
 //   - [MockFallback] - Test implementation with configurable responses
 //   - [Recommendation] - The resolved workflow name and expected next status
 package bmadhelp
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	"bmaduum/internal/claude"
 	"bmaduum/internal/status"
 )
 
-// knownWorkflows is the set of standard workflow names that can be extracted
-// from a /bmad-help response. Order matters: earlier entries are preferred
+// defaultWorkflows is the set of standard workflows that can be extracted
+// from a /bmad-help response when no workflows are configured, with the
+// status each one leads to. Order matters: earlier entries are preferred
 // when multiple workflow names appear in the response.
-var knownWorkflows = []string{
-	"create-story",
-	"dev-story",
-	"code-review",
-	"test-automation",
-	"git-commit",
-}
-
-// workflowNextStatus maps workflow names to their expected next status.
-// Used when bmad-help doesn't explicitly provide a next status.
-var workflowNextStatus = map[string]status.Status{
-	"create-story":    status.StatusReadyForDev,
-	"dev-story":       status.StatusReview,
-	"code-review":     status.StatusDone,
-	"test-automation": status.StatusDone,
-	"git-commit":      status.StatusDone,
+var defaultWorkflows = []Recommendation{
+	{Workflow: "create-story", NextStatus: status.StatusReadyForDev},
+	{Workflow: "dev-story", NextStatus: status.StatusReview},
+	{Workflow: "code-review", NextStatus: status.StatusDone},
+	{Workflow: "test-automation", NextStatus: status.StatusDone},
+	{Workflow: "git-commit", NextStatus: status.StatusDone},
 }
 
 // Recommendation is the result of a bmad-help fallback resolution.
@@ -72,12 +68,38 @@ type Fallback interface {
 // Create instances using [NewClaudeFallback]. The executor should be the same
 // Claude executor used for workflow execution.
 type ClaudeFallback struct {
-	executor claude.Executor
+	executor  claude.Executor
+	workflows []Recommendation
+
+	timeout time.Duration
+
+	heartbeatInterval time.Duration
+	heartbeat         func(elapsed time.Duration)
 }
 
 // NewClaudeFallback creates a new [ClaudeFallback] with the given Claude executor.
-func NewClaudeFallback(executor claude.Executor) *ClaudeFallback {
-	return &ClaudeFallback{executor: executor}
+//
+// workflows lists the workflows bmad-help may recommend, in order of
+// preference, with the status each leads to; pass the router's lifecycle
+// chain so custom workflow names from a manifest are recognized. If
+// workflows is empty, the standard BMAD workflows are used.
+func NewClaudeFallback(executor claude.Executor, workflows []Recommendation) *ClaudeFallback {
+	return &ClaudeFallback{executor: executor, workflows: workflows}
+}
+
+// SetTimeout limits how long a single bmad-help invocation may take. When
+// the limit is reached, Claude is killed and ResolveWorkflow fails. Zero
+// (the default) means no limit beyond the context passed to ResolveWorkflow.
+func (f *ClaudeFallback) SetTimeout(timeout time.Duration) {
+	f.timeout = timeout
+}
+
+// SetHeartbeat calls heartbeat every interval while bmad-help is running,
+// with the time elapsed since it started, so a UI can show that the fallback
+// is still working. A nil heartbeat or non-positive interval disables it.
+func (f *ClaudeFallback) SetHeartbeat(interval time.Duration, heartbeat func(elapsed time.Duration)) {
+	f.heartbeatInterval = interval
+	f.heartbeat = heartbeat
 }
 
 // ResolveWorkflow invokes /bmad-help to determine the next workflow for a story
@@ -87,9 +109,17 @@ func NewClaudeFallback(executor claude.Executor) *ClaudeFallback {
 // to find a known workflow name. If no recognizable workflow is found in the
 // response, an error is returned.
 func (f *ClaudeFallback) ResolveWorkflow(ctx context.Context, storyKey string, currentStatus status.Status) (string, status.Status, error) {
+	workflows := f.workflows
+	if len(workflows) == 0 {
+		workflows = defaultWorkflows
+	}
+	names := make([]string, len(workflows))
+	for i, w := range workflows {
+		names[i] = w.Workflow
+	}
 	prompt := fmt.Sprintf(
-		`/bmad-help The story %s has status "%s" which is not a standard status. What is the next workflow step to run? Please respond with the workflow name (create-story, dev-story, code-review, test-automation, or git-commit).`,
-		storyKey, currentStatus,
+		`/bmad-help The story %s has status "%s" which is not a standard status. What is the next workflow step to run? Please respond with the workflow name (one of: %s).`,
+		storyKey, currentStatus, strings.Join(names, ", "),
 	)
 
 	// Collect text from Claude's response
@@ -100,7 +130,21 @@ func (f *ClaudeFallback) ResolveWorkflow(ctx context.Context, storyKey string, c
 		}
 	}
 
-	exitCode, err := f.executor.ExecuteWithResult(ctx, prompt, handler, "")
+	runCtx := ctx
+	if f.timeout > 0 {
+		var cancel context.CancelFunc
+		runCtx, cancel = context.WithTimeout(ctx, f.timeout)
+		defer cancel()
+	}
+	if f.heartbeat != nil && f.heartbeatInterval > 0 {
+		stop := f.startHeartbeat()
+		defer stop()
+	}
+
+	exitCode, err := f.executor.ExecuteWithResult(runCtx, prompt, handler, "")
+	if f.timeout > 0 && ctx.Err() == nil && errors.Is(runCtx.Err(), context.DeadlineExceeded) {
+		return "", "", fmt.Errorf("bmad-help timed out after %s", f.timeout)
+	}
 	if err != nil {
 		return "", "", fmt.Errorf("bmad-help execution failed: %w", err)
 	}
@@ -108,35 +152,176 @@ func (f *ClaudeFallback) ResolveWorkflow(ctx context.Context, storyKey string, c
 		return "", "", fmt.Errorf("bmad-help returned exit code %d", exitCode)
 	}
 
-	rec, err := ParseResponse(responseText.String())
+	rec, err := ParseResponse(responseText.String(), workflows)
 	if err != nil {
 		return "", "", err
 	}
 	return rec.Workflow, rec.NextStatus, nil
 }
 
+// startHeartbeat calls the heartbeat every interval until the returned
+// function is called. No heartbeat is delivered after it returns.
+func (f *ClaudeFallback) startHeartbeat() (stop func()) {
+	start := time.Now()
+	ticker := time.NewTicker(f.heartbeatInterval)
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				f.heartbeat(time.Since(start))
+			}
+		}
+	}()
+	return func() {
+		ticker.Stop()
+		close(done)
+		wg.Wait()
+	}
+}
+
 // ParseResponse extracts a workflow recommendation from a /bmad-help response.
 //
-// It scans the response text for known workflow names and returns the first
-// match with its expected next status. Returns an error if no recognizable
-// workflow name is found.
-func ParseResponse(response string) (*Recommendation, error) {
-	lower := strings.ToLower(response)
-
-	for _, workflow := range knownWorkflows {
-		if strings.Contains(lower, workflow) {
-			nextStatus, ok := workflowNextStatus[workflow]
-			if !ok {
-				nextStatus = status.StatusDone
-			}
-			return &Recommendation{
-				Workflow:   workflow,
-				NextStatus: nextStatus,
-			}, nil
+// It finds every mention of a name in workflows (case-insensitive, as whole
+// words, so a workflow named "test" does not match "test-automation") and
+// looks at the words leading up to each mention within its clause:
+//   - Negated mentions ("don't run create-story", "instead of create-story",
+//     "skip create-story") are ignored.
+//   - Mentions introduced by a recommendation ("run", "execute", "recommend",
+//     "suggest", "next step") are preferred over bare mentions.
+//
+// Among the preferred mentions, or else among all mentions that are not
+// negated, the workflow listed earliest in workflows wins. The match is
+// returned with its expected next status (done if the entry has none). If
+// workflows is empty, the standard BMAD workflows are used. Returns an error
+// if no usable workflow name is found.
+func ParseResponse(response string, workflows []Recommendation) (*Recommendation, error) {
+	if len(workflows) == 0 {
+		workflows = defaultWorkflows
+	}
+	text := strings.ToLower(strings.ReplaceAll(response, "’", "'"))
+
+	// Collect every mention of every workflow, in text order
+	type mention struct {
+		index      int // position of the workflow in workflows
+		start, end int
+	}
+	var mentions []mention
+	for i, w := range workflows {
+		if w.Workflow == "" {
+			continue
+		}
+		name := strings.ToLower(w.Workflow)
+		for _, start := range wordIndexes(text, name) {
+			mentions = append(mentions, mention{index: i, start: start, end: start + len(name)})
+		}
+	}
+	sort.Slice(mentions, func(a, b int) bool { return mentions[a].start < mentions[b].start })
+
+	best, bestRecommended := -1, false
+	prevEnd := 0
+	for _, m := range mentions {
+		lead := leadingWords(text[prevEnd:m.start])
+		prevEnd = m.end
+		if containsPhrase(lead, negationPhrases) {
+			continue
 		}
+		recommended := containsPhrase(lead, recommendationPhrases)
+		switch {
+		case best < 0,
+			recommended && !bestRecommended,
+			recommended == bestRecommended && m.index < best:
+			best, bestRecommended = m.index, recommended
+		}
+	}
+	if best < 0 {
+		return nil, fmt.Errorf("bmad-help response did not contain a recognizable workflow recommendation")
 	}
 
-	return nil, fmt.Errorf("bmad-help response did not contain a recognizable workflow recommendation")
+	nextStatus := workflows[best].NextStatus
+	if nextStatus == "" {
+		nextStatus = status.StatusDone
+	}
+	return &Recommendation{
+		Workflow:   workflows[best].Workflow,
+		NextStatus: nextStatus,
+	}, nil
+}
+
+// negationPhrases mark a workflow mention as advice against running it.
+var negationPhrases = []string{
+	"not", "don't", "dont", "doesn't", "shouldn't", "never", "avoid", "skip",
+	"instead of", "rather than", "no need",
+}
+
+// recommendationPhrases mark a workflow mention as the recommended step.
+var recommendationPhrases = []string{
+	"run", "running", "execute", "executing", "invoke", "recommend",
+	"recommends", "recommended", "suggest", "suggests", "next step",
+}
+
+// clauseBreaks end the clause a workflow mention belongs to.
+var clauseBreaks = map[string]bool{
+	"and": true, "but": true, "then": true, "so": true, "or": true, "instead": true,
+}
+
+// leadingWords returns the words of text that belong to the clause ending
+// at the end of text, i.e. after the last punctuation mark or conjunction.
+// "instead of" is kept together so it can negate what follows.
+func leadingWords(text string) []string {
+	if i := strings.LastIndexAny(text, ".,;:!?()\n"); i >= 0 {
+		text = text[i+1:]
+	}
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r == '\'')
+	})
+	for i := len(words) - 1; i >= 0; i-- {
+		if clauseBreaks[words[i]] && !(words[i] == "instead" && i+1 < len(words) && words[i+1] == "of") {
+			return words[i+1:]
+		}
+	}
+	return words
+}
+
+// containsPhrase reports whether words contains any of the phrases as a
+// run of whole words.
+func containsPhrase(words []string, phrases []string) bool {
+	joined := " " + strings.Join(words, " ") + " "
+	for _, phrase := range phrases {
+		if strings.Contains(joined, " "+phrase+" ") {
+			return true
+		}
+	}
+	return false
+}
+
+// wordIndexes returns the positions at which word occurs in text with no
+// workflow-name characters (letters, digits, '-' or '_') directly before or
+// after it.
+func wordIndexes(text, word string) []int {
+	var indexes []int
+	for start := 0; ; {
+		i := strings.Index(text[start:], word)
+		if i < 0 {
+			return indexes
+		}
+		i += start
+		end := i + len(word)
+		if (i == 0 || !isNameByte(text[i-1])) && (end == len(text) || !isNameByte(text[end])) {
+			indexes = append(indexes, i)
+		}
+		start = i + 1
+	}
+}
+
+// isNameByte reports whether b can be part of a workflow name.
+func isNameByte(b byte) bool {
+	return b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z' || b >= '0' && b <= '9' || b == '-' || b == '_'
 }
 
 // MockFallback implements [Fallback] for testing.
@@ -3,7 +3,9 @@ package bmadhelp
 import (
 	"context"
 	"errors"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -68,6 +70,41 @@ func TestParseResponse(t *testing.T) {
 			wantWorkflow: "dev-story",
 			wantStatus:   status.StatusReview,
 		},
+		{
+			name:         "negated workflow is ignored",
+			response:     "Don't run create-story, instead run dev-story.",
+			wantWorkflow: "dev-story",
+			wantStatus:   status.StatusReview,
+		},
+		{
+			name:         "first in chain but contradicted",
+			response:     "Instead of create-story, the next step is code-review.",
+			wantWorkflow: "code-review",
+			wantStatus:   status.StatusDone,
+		},
+		{
+			name:         "curly apostrophe negation",
+			response:     "The story file exists, so you don’t need create-story. Run dev-story.",
+			wantWorkflow: "dev-story",
+			wantStatus:   status.StatusReview,
+		},
+		{
+			name:         "recommended workflow preferred over earlier mention",
+			response:     "The create-story output looks complete. I recommend code-review now.",
+			wantWorkflow: "code-review",
+			wantStatus:   status.StatusDone,
+		},
+		{
+			name:         "skip one and run another in one clause",
+			response:     "Skip create-story and run dev-story.",
+			wantWorkflow: "dev-story",
+			wantStatus:   status.StatusReview,
+		},
+		{
+			name:     "only negated workflows",
+			response: "Do not run git-commit yet.",
+			wantErr:  true,
+		},
 		{
 			name:     "no recognizable workflow",
 			response: "I'm not sure what to do with this story. Please check the sprint status.",
@@ -87,7 +124,7 @@ func TestParseResponse(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			rec, err := ParseResponse(tt.response)
+			rec, err := ParseResponse(tt.response, nil)
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -183,7 +220,7 @@ func TestClaudeFallback_ResolveWorkflow(t *testing.T) {
 				Error:    tt.execErr,
 			}
 
-			fallback := NewClaudeFallback(mock)
+			fallback := NewClaudeFallback(mock, nil)
 			workflow, nextStatus, err := fallback.ResolveWorkflow(context.Background(), "STORY-1", status.Status("custom-status"))
 
 			if tt.wantErr {
@@ -216,7 +253,7 @@ func TestClaudeFallback_PromptFormat(t *testing.T) {
 		ExitCode: 0,
 	}
 
-	fallback := NewClaudeFallback(mock)
+	fallback := NewClaudeFallback(mock, nil)
 	_, _, _ = fallback.ResolveWorkflow(context.Background(), "7-3-implement-auth", status.Status("pending-review"))
 
 	require.Len(t, mock.RecordedPrompts, 1)
@@ -264,3 +301,142 @@ func TestMockFallback(t *testing.T) {
 		assert.Empty(t, workflow)
 	})
 }
+
+func TestParseResponse_ConfiguredWorkflows(t *testing.T) {
+	workflows := []Recommendation{
+		{Workflow: "plan", NextStatus: status.StatusReadyForDev},
+		{Workflow: "implement", NextStatus: status.StatusReview},
+		{Workflow: "test"},
+	}
+
+	tests := []struct {
+		name         string
+		response     string
+		wantWorkflow string
+		wantStatus   status.Status
+		wantErr      bool
+	}{
+		{
+			name:         "custom workflow name",
+			response:     "Run implement next.",
+			wantWorkflow: "implement",
+			wantStatus:   status.StatusReview,
+		},
+		{
+			name:         "missing next status defaults to done",
+			response:     "Now run test.",
+			wantWorkflow: "test",
+			wantStatus:   status.StatusDone,
+		},
+		{
+			name:     "partial word does not match",
+			response: "Run test-automation, then planning.",
+			wantErr:  true,
+		},
+		{
+			name:     "standard workflows are not recognized",
+			response: "Run dev-story next.",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec, err := ParseResponse(tt.response, workflows)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantWorkflow, rec.Workflow)
+			assert.Equal(t, tt.wantStatus, rec.NextStatus)
+		})
+	}
+}
+
+func TestClaudeFallback_ConfiguredWorkflows(t *testing.T) {
+	mock := &claude.MockExecutor{
+		Events: []claude.Event{
+			{Type: claude.EventTypeAssistant, Text: "Run implement next."},
+		},
+	}
+
+	fallback := NewClaudeFallback(mock, []Recommendation{
+		{Workflow: "plan", NextStatus: status.StatusReadyForDev},
+		{Workflow: "implement", NextStatus: status.StatusReview},
+	})
+	workflow, nextStatus, err := fallback.ResolveWorkflow(context.Background(), "STORY-1", status.Status("custom-status"))
+
+	require.NoError(t, err)
+	assert.Equal(t, "implement", workflow)
+	assert.Equal(t, status.StatusReview, nextStatus)
+	require.Len(t, mock.RecordedPrompts, 1)
+	assert.Contains(t, mock.RecordedPrompts[0], "(one of: plan, implement)")
+}
+
+// slowExecutor answers after delay, or fails once ctx is done.
+type slowExecutor struct {
+	claude.MockExecutor
+	delay time.Duration
+}
+
+func (e *slowExecutor) ExecuteWithResult(ctx context.Context, prompt string, handler claude.EventHandler, model string) (int, error) {
+	select {
+	case <-ctx.Done():
+		return 1, ctx.Err()
+	case <-time.After(e.delay):
+		return e.MockExecutor.ExecuteWithResult(ctx, prompt, handler, model)
+	}
+}
+
+func TestClaudeFallback_Timeout(t *testing.T) {
+	executor := &slowExecutor{delay: time.Minute}
+
+	fallback := NewClaudeFallback(executor, nil)
+	fallback.SetTimeout(20 * time.Millisecond)
+	_, _, err := fallback.ResolveWorkflow(context.Background(), "STORY-1", status.Status("custom-status"))
+
+	assert.EqualError(t, err, "bmad-help timed out after 20ms")
+}
+
+func TestClaudeFallback_Canceled(t *testing.T) {
+	executor := &slowExecutor{delay: time.Minute}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	fallback := NewClaudeFallback(executor, nil)
+	fallback.SetTimeout(time.Minute)
+	_, _, err := fallback.ResolveWorkflow(ctx, "STORY-1", status.Status("custom-status"))
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Contains(t, err.Error(), "bmad-help execution failed")
+}
+
+func TestClaudeFallback_Heartbeat(t *testing.T) {
+	executor := &slowExecutor{delay: 100 * time.Millisecond}
+	executor.Events = []claude.Event{{Type: claude.EventTypeAssistant, Text: "Run dev-story."}}
+
+	var mu sync.Mutex
+	var beats []time.Duration
+	fallback := NewClaudeFallback(executor, nil)
+	fallback.SetHeartbeat(10*time.Millisecond, func(elapsed time.Duration) {
+		mu.Lock()
+		defer mu.Unlock()
+		beats = append(beats, elapsed)
+	})
+	workflow, _, err := fallback.ResolveWorkflow(context.Background(), "STORY-1", status.Status("custom-status"))
+	require.NoError(t, err)
+	assert.Equal(t, "dev-story", workflow)
+
+	mu.Lock()
+	count := len(beats)
+	mu.Unlock()
+	require.NotZero(t, count)
+	assert.GreaterOrEqual(t, beats[count-1], beats[0], "elapsed time grows")
+
+	// No heartbeat is delivered once the fallback has returned
+	time.Sleep(30 * time.Millisecond)
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Len(t, beats, count)
+}
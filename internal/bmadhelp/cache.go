@@ -0,0 +1,79 @@
+package bmadhelp
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"bmaduum/internal/status"
+)
+
+// CachingFallback wraps a [Fallback] and reuses its resolutions per status
+// value, so stories sharing an unknown status such as "pending-qa" cost one
+// bmad-help call instead of one each.
+//
+// Only successful resolutions are cached; a failed one is retried for the
+// next story. It is safe for concurrent use.
+//
+// Create instances using [NewCachingFallback].
+type CachingFallback struct {
+	fallback Fallback
+	ttl      time.Duration
+
+	mu      sync.Mutex
+	entries map[status.Status]cacheEntry
+}
+
+// cacheEntry is a cached resolution and when it was made.
+type cacheEntry struct {
+	rec      Recommendation
+	resolved time.Time
+}
+
+// NewCachingFallback creates a [CachingFallback] that resolves statuses it
+// has not seen with fallback. Entries expire ttl after they were resolved;
+// a ttl of zero keeps them until [CachingFallback.Clear].
+func NewCachingFallback(fallback Fallback, ttl time.Duration) *CachingFallback {
+	return &CachingFallback{
+		fallback: fallback,
+		ttl:      ttl,
+		entries:  make(map[status.Status]cacheEntry),
+	}
+}
+
+// ResolveWorkflow returns the cached resolution for currentStatus if there
+// is one that has not expired, and otherwise resolves and caches it.
+func (c *CachingFallback) ResolveWorkflow(ctx context.Context, storyKey string, currentStatus status.Status) (string, status.Status, error) {
+	c.mu.Lock()
+	entry, ok := c.entries[currentStatus]
+	c.mu.Unlock()
+	if ok && (c.ttl <= 0 || time.Since(entry.resolved) < c.ttl) {
+		return entry.rec.Workflow, entry.rec.NextStatus, nil
+	}
+
+	workflow, nextStatus, err := c.fallback.ResolveWorkflow(ctx, storyKey, currentStatus)
+	if err != nil {
+		return "", "", err
+	}
+
+	c.mu.Lock()
+	c.entries[currentStatus] = cacheEntry{
+		rec:      Recommendation{Workflow: workflow, NextStatus: nextStatus},
+		resolved: time.Now(),
+	}
+	c.mu.Unlock()
+	return workflow, nextStatus, nil
+}
+
+// Clear forgets every cached resolution, e.g. between independent runs in
+// one process.
+func (c *CachingFallback) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	clear(c.entries)
+}
+
+// Fallback returns the wrapped fallback.
+func (c *CachingFallback) Fallback() Fallback {
+	return c.fallback
+}
@@ -0,0 +1,70 @@
+package bmadhelp
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"bmaduum/internal/status"
+)
+
+func TestCachingFallback_ReusesResolution(t *testing.T) {
+	mock := &MockFallback{Rec: &Recommendation{Workflow: "dev-story", NextStatus: status.StatusReview}}
+	cache := NewCachingFallback(mock, 0)
+
+	for _, storyKey := range []string{"1-1", "1-2"} {
+		workflow, nextStatus, err := cache.ResolveWorkflow(context.Background(), storyKey, "pending-qa")
+		require.NoError(t, err)
+		assert.Equal(t, "dev-story", workflow)
+		assert.Equal(t, status.StatusReview, nextStatus)
+	}
+	require.Len(t, mock.Calls, 1, "second story reuses the first resolution")
+	assert.Equal(t, "1-1", mock.Calls[0].StoryKey)
+
+	_, _, err := cache.ResolveWorkflow(context.Background(), "1-3", "blocked-on-design")
+	require.NoError(t, err)
+	assert.Len(t, mock.Calls, 2, "each status is resolved separately")
+}
+
+func TestCachingFallback_DoesNotCacheErrors(t *testing.T) {
+	mock := &MockFallback{Err: errors.New("bmad-help returned exit code 1")}
+	cache := NewCachingFallback(mock, 0)
+
+	_, _, err := cache.ResolveWorkflow(context.Background(), "1-1", "pending-qa")
+	require.Error(t, err)
+
+	mock.Err = nil
+	mock.Rec = &Recommendation{Workflow: "code-review", NextStatus: status.StatusDone}
+	workflow, _, err := cache.ResolveWorkflow(context.Background(), "1-2", "pending-qa")
+	require.NoError(t, err)
+	assert.Equal(t, "code-review", workflow)
+	assert.Len(t, mock.Calls, 2)
+}
+
+func TestCachingFallback_Clear(t *testing.T) {
+	mock := &MockFallback{Rec: &Recommendation{Workflow: "dev-story", NextStatus: status.StatusReview}}
+	cache := NewCachingFallback(mock, 0)
+
+	_, _, _ = cache.ResolveWorkflow(context.Background(), "1-1", "pending-qa")
+	cache.Clear()
+	_, _, _ = cache.ResolveWorkflow(context.Background(), "1-2", "pending-qa")
+
+	assert.Len(t, mock.Calls, 2)
+}
+
+func TestCachingFallback_Expiry(t *testing.T) {
+	mock := &MockFallback{Rec: &Recommendation{Workflow: "dev-story", NextStatus: status.StatusReview}}
+	cache := NewCachingFallback(mock, 20*time.Millisecond)
+
+	_, _, _ = cache.ResolveWorkflow(context.Background(), "1-1", "pending-qa")
+	_, _, _ = cache.ResolveWorkflow(context.Background(), "1-2", "pending-qa")
+	require.Len(t, mock.Calls, 1)
+
+	time.Sleep(30 * time.Millisecond)
+	_, _, _ = cache.ResolveWorkflow(context.Background(), "1-3", "pending-qa")
+	assert.Len(t, mock.Calls, 2, "expired entries are resolved again")
+}
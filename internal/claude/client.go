@@ -2,11 +2,13 @@ package claude
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"io"
 	"os/exec"
+	"strings"
 	"sync"
 )
 
@@ -39,6 +41,23 @@ type Executor interface {
 	ExecuteWithResult(ctx context.Context, prompt string, handler EventHandler, model string) (int, error)
 }
 
+// SessionResumer is implemented by executors that can continue an earlier
+// Claude session, keeping its conversation context. [DefaultExecutor] and
+// [MockExecutor] implement it.
+type SessionResumer interface {
+	// ResumeWithResult is like [Executor.ExecuteWithResult], but sends the
+	// prompt as the next turn of the session with the given ID (see
+	// [Event.SessionID]) instead of starting a new session.
+	ResumeWithResult(ctx context.Context, sessionID, prompt string, handler EventHandler, model string) (int, error)
+}
+
+// BinaryChecker is implemented by executors that can verify the Claude CLI
+// is installed before any workflow runs. [DefaultExecutor] implements it.
+type BinaryChecker interface {
+	// CheckBinary returns an error if the Claude CLI cannot be run.
+	CheckBinary() error
+}
+
 // EventHandler is a callback function invoked for each [Event] received from Claude.
 //
 // The handler is called synchronously in the order events are received. Handlers
@@ -74,6 +93,66 @@ type ExecutorConfig struct {
 	// If nil, stderr output is silently discarded.
 	// Set this to capture error messages or debug output from Claude.
 	StderrHandler func(line string)
+
+	// Recorder receives a verbatim copy of Claude's stdout stream, if set,
+	// written a whole line at a time.
+	// The recorded stream-json lines can later be replayed through the
+	// parser and printer without calling Claude.
+	Recorder io.Writer
+
+	// ExtraArgs are appended to the Claude CLI arguments of every run, for
+	// flags bmaduum does not expose itself (e.g. "--max-turns", "50").
+	// They must not repeat flags the executor sets; see [ValidateExtraArgs].
+	ExtraArgs []string
+}
+
+// ModelAliases are the model aliases Claude CLI accepts for --model, in
+// addition to full model names such as "claude-sonnet-4-5".
+var ModelAliases = []string{"default", "opus", "sonnet", "haiku", "opusplan"}
+
+// IsKnownModel reports whether model is a Claude CLI model alias or looks
+// like a full Claude model name.
+func IsKnownModel(model string) bool {
+	for _, alias := range ModelAliases {
+		if model == alias {
+			return true
+		}
+	}
+	return strings.HasPrefix(model, "claude-")
+}
+
+// reservedFlags are the Claude CLI flags the executor sets, always or for
+// some runs, which extra arguments must not specify again, with a hint
+// where bmaduum offers its own way to set them.
+var reservedFlags = []struct {
+	flag string
+	hint string
+}{
+	{flag: "--output-format"},
+	{flag: "-p"},
+	{flag: "--print"},
+	{flag: "--verbose"},
+	{flag: "--model", hint: "use the --model flag or claude.model instead"},
+	{flag: "--resume", hint: "use the --resume flag of workflow commands instead"},
+	{flag: "-r", hint: "use the --resume flag of workflow commands instead"},
+}
+
+// ValidateExtraArgs reports an error if args specify a flag the executor
+// already passes to Claude CLI, such as --output-format or --model, in
+// either the "--flag value" or the "--flag=value" form.
+func ValidateExtraArgs(args []string) error {
+	for _, arg := range args {
+		for _, reserved := range reservedFlags {
+			if arg != reserved.flag && !strings.HasPrefix(arg, reserved.flag+"=") {
+				continue
+			}
+			if reserved.hint != "" {
+				return fmt.Errorf("%s is set by bmaduum and cannot be passed as an extra argument; %s", reserved.flag, reserved.hint)
+			}
+			return fmt.Errorf("%s is set by bmaduum and cannot be passed as an extra argument", reserved.flag)
+		}
+	}
+	return nil
 }
 
 // DefaultExecutor implements [Executor] by spawning Claude as a subprocess.
@@ -86,6 +165,10 @@ type ExecutorConfig struct {
 type DefaultExecutor struct {
 	config ExecutorConfig
 	parser Parser
+
+	// Result of the first CheckBinary call, reused by later calls
+	checkOnce sync.Once
+	checkErr  error
 }
 
 // NewExecutor creates a new [DefaultExecutor] with the given configuration.
@@ -115,6 +198,33 @@ func NewExecutor(config ExecutorConfig) *DefaultExecutor {
 	}
 }
 
+// AddExtraArgs appends args to [ExecutorConfig.ExtraArgs] for all later
+// runs. Callers should check them with [ValidateExtraArgs] first.
+func (e *DefaultExecutor) AddExtraArgs(args ...string) {
+	e.config.ExtraArgs = append(e.config.ExtraArgs, args...)
+}
+
+// AddRecorder also copies Claude's stdout stream of all later runs to w, in
+// addition to [ExecutorConfig.Recorder]. w receives whole lines only.
+func (e *DefaultExecutor) AddRecorder(w io.Writer) {
+	if e.config.Recorder != nil {
+		w = io.MultiWriter(e.config.Recorder, w)
+	}
+	e.config.Recorder = w
+}
+
+// CheckBinary verifies that [ExecutorConfig.BinaryPath] resolves to an
+// executable file. The lookup is done once; later calls return the same
+// result.
+func (e *DefaultExecutor) CheckBinary() error {
+	e.checkOnce.Do(func() {
+		if _, err := exec.LookPath(e.config.BinaryPath); err != nil {
+			e.checkErr = fmt.Errorf("claude CLI not found at %q; set BMADUUM_CLAUDE_PATH or claude.binary_path: %w", e.config.BinaryPath, err)
+		}
+	})
+	return e.checkErr
+}
+
 // Execute runs Claude with the given prompt and returns a channel of [Event] objects.
 //
 // The returned channel emits events as they are parsed from Claude's streaming output.
@@ -127,12 +237,7 @@ func NewExecutor(config ExecutorConfig) *DefaultExecutor {
 // intentionally not propagated. Use [DefaultExecutor.ExecuteWithResult] if you need
 // to check whether Claude completed successfully.
 func (e *DefaultExecutor) Execute(ctx context.Context, prompt string) (<-chan Event, error) {
-	cmd := exec.CommandContext(ctx, e.config.BinaryPath,
-		"--dangerously-skip-permissions",
-		"--output-format", e.config.OutputFormat,
-		"--verbose",
-		"-p", prompt,
-	)
+	cmd := exec.CommandContext(ctx, e.config.BinaryPath, e.args(prompt, "", "")...)
 
 	stdout, err := cmd.StdoutPipe()
 	if err != nil {
@@ -152,7 +257,7 @@ func (e *DefaultExecutor) Execute(ctx context.Context, prompt string) (<-chan Ev
 	go e.handleStderr(stderr, nil)
 
 	// Parse stdout and return events channel
-	events := e.parser.Parse(stdout)
+	events := e.parser.Parse(e.recordStream(stdout))
 
 	// Wait for command completion in background.
 	// Note: Exit status is intentionally not propagated; use ExecuteWithResult if needed.
@@ -172,12 +277,29 @@ func (e *DefaultExecutor) Execute(ctx context.Context, prompt string) (<-chan Ev
 //   - 0: Claude completed successfully
 //   - Non-zero: Claude exited with an error (check stderr via [ExecutorConfig.StderrHandler])
 //
+// If ctx is canceled or times out, Claude is killed and ctx.Err() is returned.
+//
 // The handler may be nil if you only need the exit code without processing events.
 // If the handler is provided, it is called synchronously for each event before
-// this method returns.
+// this method returns. Each stderr line is also passed to it as an
+// [EventTypeStderr] event, after [ExecutorConfig.StderrHandler]; handler calls
+// never overlap.
 //
 // The model parameter is optional. If empty, the Claude CLI will use its default model.
 func (e *DefaultExecutor) ExecuteWithResult(ctx context.Context, prompt string, handler EventHandler, model string) (int, error) {
+	return e.runWithResult(ctx, e.args(prompt, model, ""), handler)
+}
+
+// ResumeWithResult continues the Claude session with the given ID by
+// passing --resume to Claude CLI, then behaves like
+// [DefaultExecutor.ExecuteWithResult].
+func (e *DefaultExecutor) ResumeWithResult(ctx context.Context, sessionID, prompt string, handler EventHandler, model string) (int, error) {
+	return e.runWithResult(ctx, e.args(prompt, model, sessionID), handler)
+}
+
+// args builds the Claude CLI arguments for a prompt, with optional model
+// and session to resume, followed by the configured extra arguments.
+func (e *DefaultExecutor) args(prompt, model, sessionID string) []string {
 	args := []string{
 		"--dangerously-skip-permissions",
 		"--output-format", e.config.OutputFormat,
@@ -187,6 +309,15 @@ func (e *DefaultExecutor) ExecuteWithResult(ctx context.Context, prompt string,
 	if model != "" {
 		args = append(args, "--model", model)
 	}
+	if sessionID != "" {
+		args = append(args, "--resume", sessionID)
+	}
+	return append(args, e.config.ExtraArgs...)
+}
+
+// runWithResult runs Claude with args, passing events to handler, and
+// returns its exit code.
+func (e *DefaultExecutor) runWithResult(ctx context.Context, args []string, handler EventHandler) (int, error) {
 	cmd := exec.CommandContext(ctx, e.config.BinaryPath, args...)
 
 	stdout, err := cmd.StdoutPipe()
@@ -203,13 +334,24 @@ func (e *DefaultExecutor) ExecuteWithResult(ctx context.Context, prompt string,
 		return 1, fmt.Errorf("failed to start claude: %w", err)
 	}
 
+	// Stderr lines reach the handler from another goroutine; serialize calls
+	if handler != nil {
+		var mu sync.Mutex
+		unsafeHandler := handler
+		handler = func(event Event) {
+			mu.Lock()
+			defer mu.Unlock()
+			unsafeHandler(event)
+		}
+	}
+
 	// Handle stderr in background with synchronization
 	var stderrWg sync.WaitGroup
 	stderrWg.Add(1)
-	go e.handleStderr(stderr, &stderrWg)
+	go e.handleStderrEvents(stderr, &stderrWg, handler)
 
 	// Process events with context cancellation check
-	events := e.parser.Parse(stdout)
+	events := e.parser.Parse(e.recordStream(stdout))
 eventLoop:
 	for {
 		select {
@@ -225,6 +367,15 @@ eventLoop:
 		}
 	}
 
+	// Claude is killed once ctx is canceled or times out. Wait first: it
+	// closes the pipes even if a process Claude started still holds them,
+	// which ends the stderr reader.
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		_ = cmd.Wait() //nolint:errcheck // The process was killed; ctx.Err() explains why
+		stderrWg.Wait()
+		return 1, ctxErr
+	}
+
 	// Wait for stderr to be fully read
 	stderrWg.Wait()
 
@@ -244,19 +395,65 @@ eventLoop:
 	return exitCode, nil
 }
 
+// recordStream tees stdout to the configured [ExecutorConfig.Recorder], if any.
+func (e *DefaultExecutor) recordStream(stdout io.Reader) io.Reader {
+	if e.config.Recorder == nil {
+		return stdout
+	}
+	return &lineTee{r: stdout, w: e.config.Recorder}
+}
+
+// lineTee is an [io.TeeReader] that writes whole lines only, one write per
+// read, so the streams of concurrent runs sharing a recorder do not mix
+// within a line. A final unterminated line is written at EOF.
+type lineTee struct {
+	r       io.Reader
+	w       io.Writer
+	partial []byte
+}
+
+func (t *lineTee) Read(p []byte) (int, error) {
+	n, err := t.r.Read(p)
+	t.partial = append(t.partial, p[:n]...)
+	end := bytes.LastIndexByte(t.partial, '\n') + 1
+	if err == io.EOF {
+		end = len(t.partial)
+	}
+	if end > 0 {
+		if _, werr := t.w.Write(t.partial[:end]); werr != nil {
+			return n, werr
+		}
+		t.partial = append(t.partial[:0], t.partial[end:]...)
+	}
+	return n, err
+}
+
 func (e *DefaultExecutor) handleStderr(stderr io.ReadCloser, wg *sync.WaitGroup) {
+	e.handleStderrEvents(stderr, wg, nil)
+}
+
+// handleStderrEvents passes each stderr line to the configured
+// [ExecutorConfig.StderrHandler] and, as an [EventTypeStderr] event, to
+// handler. Either may be nil.
+func (e *DefaultExecutor) handleStderrEvents(stderr io.ReadCloser, wg *sync.WaitGroup, handler EventHandler) {
 	if wg != nil {
 		defer wg.Done()
 	}
 
-	if e.config.StderrHandler == nil {
+	if e.config.StderrHandler == nil && handler == nil {
 		_, _ = io.Copy(io.Discard, stderr) //nolint:errcheck // Intentionally discarding stderr
 		return
 	}
 
 	scanner := bufio.NewScanner(stderr)
 	for scanner.Scan() {
-		e.config.StderrHandler(scanner.Text())
+		line := scanner.Text()
+		if e.config.StderrHandler != nil {
+			e.config.StderrHandler(line)
+		}
+		if handler != nil {
+			handler(Event{Type: EventTypeStderr, Stderr: line})
+		}
 	}
 }
 
@@ -295,6 +492,15 @@ type MockExecutor struct {
 	// RecordedPrompts accumulates all prompts passed to Execute/ExecuteWithResult.
 	// Use this in tests to verify the correct prompts were sent.
 	RecordedPrompts []string
+
+	// RecordedModels accumulates the model passed to each ExecuteWithResult call.
+	// Entries are empty strings when no model was requested.
+	RecordedModels []string
+
+	// RecordedSessions accumulates the session resumed by each
+	// ExecuteWithResult or ResumeWithResult call. Entries are empty strings
+	// for new sessions.
+	RecordedSessions []string
 }
 
 // Execute returns the pre-configured [MockExecutor.Events] via a channel.
@@ -331,9 +537,17 @@ func (m *MockExecutor) Execute(ctx context.Context, prompt string) (<-chan Event
 // If [MockExecutor.Error] is set, it returns 1 and the error immediately.
 // Otherwise, all [MockExecutor.Events] are passed to the handler synchronously,
 // then the configured exit code is returned.
-// The model parameter is ignored in the mock.
+// The model is recorded in [MockExecutor.RecordedModels].
 func (m *MockExecutor) ExecuteWithResult(ctx context.Context, prompt string, handler EventHandler, model string) (int, error) {
+	return m.ResumeWithResult(ctx, "", prompt, handler, model)
+}
+
+// ResumeWithResult behaves like [MockExecutor.ExecuteWithResult] and also
+// records sessionID in [MockExecutor.RecordedSessions].
+func (m *MockExecutor) ResumeWithResult(ctx context.Context, sessionID, prompt string, handler EventHandler, model string) (int, error) {
 	m.RecordedPrompts = append(m.RecordedPrompts, prompt)
+	m.RecordedModels = append(m.RecordedModels, model)
+	m.RecordedSessions = append(m.RecordedSessions, sessionID)
 
 	if m.Error != nil {
 		return 1, m.Error
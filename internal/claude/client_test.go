@@ -1,9 +1,16 @@
 package claude
 
 import (
+	"bytes"
 	"context"
 	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
 	"testing"
+	"testing/iotest"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -149,6 +156,19 @@ func TestNewExecutor_WithStderrHandler(t *testing.T) {
 	assert.NotNil(t, exec.config.StderrHandler)
 }
 
+func TestDefaultExecutor_CheckBinary(t *testing.T) {
+	assert.NoError(t, NewExecutor(ExecutorConfig{BinaryPath: "sh"}).CheckBinary())
+
+	executor := NewExecutor(ExecutorConfig{BinaryPath: "/nonexistent/claude"})
+	err := executor.CheckBinary()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `claude CLI not found at "/nonexistent/claude"; set BMADUUM_CLAUDE_PATH`)
+
+	// The result is cached
+	executor.config.BinaryPath = "sh"
+	assert.Equal(t, err, executor.CheckBinary())
+}
+
 func TestMockExecutor_ExecuteWithResult_WithError(t *testing.T) {
 	mock := &MockExecutor{
 		Error: assert.AnError,
@@ -227,3 +247,209 @@ func TestNewExecutor_UsesProvidedParser(t *testing.T) {
 
 	assert.Equal(t, customParser, exec.parser)
 }
+
+func TestDefaultExecutor_RecordStream(t *testing.T) {
+	stream := "{\"type\":\"system\",\"subtype\":\"init\"}\n{\"type\":\"result\"}\n"
+
+	// Without a recorder the stream passes through untouched
+	plain := NewExecutor(ExecutorConfig{})
+	data, err := io.ReadAll(plain.recordStream(strings.NewReader(stream)))
+	require.NoError(t, err)
+	assert.Equal(t, stream, string(data))
+
+	// With a recorder, everything read is copied verbatim
+	var recorded bytes.Buffer
+	recording := NewExecutor(ExecutorConfig{Recorder: &recorded})
+	var events []Event
+	for event := range recording.parser.Parse(recording.recordStream(strings.NewReader(stream))) {
+		events = append(events, event)
+	}
+	assert.Len(t, events, 2)
+	assert.Equal(t, stream, recorded.String())
+}
+
+// chunkWriter records each write it receives.
+type chunkWriter struct {
+	writes []string
+}
+
+func (w *chunkWriter) Write(p []byte) (int, error) {
+	w.writes = append(w.writes, string(p))
+	return len(p), nil
+}
+
+func TestDefaultExecutor_RecordStreamWholeLines(t *testing.T) {
+	// One-byte reads split every line; the recorder still gets whole lines
+	stream := "{\"type\":\"system\"}\n{\"type\":\"result\"}\nunterminated"
+	recorder := &chunkWriter{}
+	executor := NewExecutor(ExecutorConfig{Recorder: recorder})
+	data, err := io.ReadAll(executor.recordStream(iotest.OneByteReader(strings.NewReader(stream))))
+	require.NoError(t, err)
+	assert.Equal(t, stream, string(data))
+	assert.Equal(t, []string{"{\"type\":\"system\"}\n", "{\"type\":\"result\"}\n", "unterminated"}, recorder.writes)
+}
+
+func TestDefaultExecutor_AddRecorder(t *testing.T) {
+	stream := "{\"type\":\"result\"}\n"
+	var configured, added bytes.Buffer
+	executor := NewExecutor(ExecutorConfig{Recorder: &configured})
+	executor.AddRecorder(&added)
+	_, err := io.ReadAll(executor.recordStream(strings.NewReader(stream)))
+	require.NoError(t, err)
+	assert.Equal(t, stream, configured.String())
+	assert.Equal(t, stream, added.String())
+}
+
+func TestDefaultExecutor_ExecuteWithResult_Canceled(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script as the Claude binary")
+	}
+	// The child sleep keeps the output pipes open after the script is killed
+	binary := filepath.Join(t.TempDir(), "claude")
+	script := "#!/bin/sh\necho '{\"type\":\"system\",\"subtype\":\"init\"}'\nsleep 30\nexit 0\n"
+	require.NoError(t, os.WriteFile(binary, []byte(script), 0755))
+
+	executor := NewExecutor(ExecutorConfig{BinaryPath: binary, OutputFormat: "stream-json"})
+	ctx, cancel := context.WithCancel(context.Background())
+	started := make(chan struct{})
+	var once bool
+	go func() {
+		<-started
+		cancel()
+	}()
+
+	begin := time.Now()
+	exitCode, err := executor.ExecuteWithResult(ctx, "prompt", func(Event) {
+		if !once {
+			once = true
+			close(started)
+		}
+	}, "")
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, exitCode)
+	assert.Less(t, time.Since(begin), 10*time.Second)
+}
+
+func TestDefaultExecutor_ExecuteWithResult_Deadline(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script as the Claude binary")
+	}
+	binary := filepath.Join(t.TempDir(), "claude")
+	script := "#!/bin/sh\nsleep 30\nexit 0\n"
+	require.NoError(t, os.WriteFile(binary, []byte(script), 0755))
+
+	executor := NewExecutor(ExecutorConfig{BinaryPath: binary, OutputFormat: "stream-json"})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	begin := time.Now()
+	exitCode, err := executor.ExecuteWithResult(ctx, "prompt", nil, "")
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, 1, exitCode)
+	assert.Less(t, time.Since(begin), 10*time.Second, "Claude is killed at the deadline")
+}
+
+func TestDefaultExecutor_ExecuteWithResult_StderrEvents(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script as the Claude binary")
+	}
+	binary := filepath.Join(t.TempDir(), "claude")
+	script := "#!/bin/sh\necho '{\"type\":\"system\",\"subtype\":\"init\"}'\necho 'API Error: 529 overloaded' >&2\nexit 1\n"
+	require.NoError(t, os.WriteFile(binary, []byte(script), 0755))
+
+	var stderrLines []string
+	executor := NewExecutor(ExecutorConfig{
+		BinaryPath:    binary,
+		OutputFormat:  "stream-json",
+		StderrHandler: func(line string) { stderrLines = append(stderrLines, line) },
+	})
+
+	var stderrEvents []string
+	exitCode, err := executor.ExecuteWithResult(context.Background(), "prompt", func(event Event) {
+		if event.Type == EventTypeStderr {
+			stderrEvents = append(stderrEvents, event.Stderr)
+		}
+	}, "")
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, exitCode)
+	assert.Equal(t, []string{"API Error: 529 overloaded"}, stderrLines)
+	assert.Equal(t, []string{"API Error: 529 overloaded"}, stderrEvents)
+}
+
+func TestMockExecutor_ResumeWithResult(t *testing.T) {
+	mock := &MockExecutor{ExitCode: 0}
+	var _ SessionResumer = mock
+
+	_, err := mock.ExecuteWithResult(context.Background(), "first", nil, "")
+	require.NoError(t, err)
+	_, err = mock.ResumeWithResult(context.Background(), "session-1", "second", nil, "opus")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"first", "second"}, mock.RecordedPrompts)
+	assert.Equal(t, []string{"", "opus"}, mock.RecordedModels)
+	assert.Equal(t, []string{"", "session-1"}, mock.RecordedSessions)
+}
+
+func TestDefaultExecutor_Args(t *testing.T) {
+	exec := NewExecutor(ExecutorConfig{})
+	var _ SessionResumer = exec
+
+	assert.Equal(t, []string{"--dangerously-skip-permissions", "--output-format", "stream-json", "--verbose", "-p", "go"},
+		exec.args("go", "", ""))
+	assert.Equal(t, []string{"--dangerously-skip-permissions", "--output-format", "stream-json", "--verbose", "-p", "go", "--model", "opus", "--resume", "abc"},
+		exec.args("go", "opus", "abc"))
+}
+
+func TestDefaultExecutor_ArgsExtra(t *testing.T) {
+	exec := NewExecutor(ExecutorConfig{ExtraArgs: []string{"--max-turns", "50"}})
+	exec.AddExtraArgs("--add-dir", "../shared")
+
+	assert.Equal(t, []string{"--dangerously-skip-permissions", "--output-format", "stream-json", "--verbose", "-p", "go", "--model", "opus",
+		"--max-turns", "50", "--add-dir", "../shared"},
+		exec.args("go", "opus", ""))
+}
+
+func TestValidateExtraArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{name: "empty", args: nil},
+		{name: "unreserved flags", args: []string{"--max-turns", "50", "--add-dir", "../shared"}},
+		{name: "output format", args: []string{"--output-format", "json"}, wantErr: "--output-format is set by bmaduum"},
+		{name: "output format with value", args: []string{"--output-format=json"}, wantErr: "--output-format is set by bmaduum"},
+		{name: "print", args: []string{"-p", "hello"}, wantErr: "-p is set by bmaduum"},
+		{name: "verbose", args: []string{"--verbose"}, wantErr: "--verbose is set by bmaduum"},
+		{name: "model", args: []string{"--model", "opus"}, wantErr: "use the --model flag or claude.model instead"},
+		{name: "model with value", args: []string{"--model=opus"}, wantErr: "use the --model flag or claude.model instead"},
+		{name: "resume", args: []string{"--resume", "abc123"}, wantErr: "use the --resume flag of workflow commands instead"},
+		{name: "resume with value", args: []string{"--resume=abc123"}, wantErr: "use the --resume flag of workflow commands instead"},
+		{name: "resume short", args: []string{"-r", "abc123"}, wantErr: "-r is set by bmaduum"},
+		{name: "flag with a reserved prefix", args: []string{"--model-fallback", "x", "--printer"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateExtraArgs(tt.args)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestIsKnownModel(t *testing.T) {
+	for _, model := range []string{"opus", "sonnet", "haiku", "claude-sonnet-4-5", "claude-opus-4-1-20250805"} {
+		assert.True(t, IsKnownModel(model), model)
+	}
+	for _, model := range []string{"", "gpt-4", "Opus", "sonet"} {
+		assert.False(t, IsKnownModel(model), model)
+	}
+}
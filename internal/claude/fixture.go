@@ -0,0 +1,304 @@
+package claude
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// FixtureVersion is the format version written to fixture files.
+const FixtureVersion = 1
+
+// Fixture is a recorded set of Claude runs, written by [RecordingExecutor]
+// and replayed by [ReplayExecutor].
+type Fixture struct {
+	Version int          `json:"version"`
+	Runs    []FixtureRun `json:"runs"`
+}
+
+// FixtureRun is one recorded Claude run: the prompt it was sent and what
+// Claude produced.
+type FixtureRun struct {
+	Prompt string `json:"prompt"`
+	Model  string `json:"model,omitempty"`
+
+	// SessionID is the session the run resumed, if any.
+	SessionID string `json:"session_id,omitempty"`
+
+	ExitCode int `json:"exit_code"`
+
+	// Error is the message of the error the run returned, if any.
+	Error string `json:"error,omitempty"`
+
+	Events []FixtureEvent `json:"events"`
+}
+
+// FixtureEvent is a recorded [Event]: either the stream-json event it was
+// parsed from or, for [EventTypeStderr] events, the stderr line.
+type FixtureEvent struct {
+	Stream *StreamEvent `json:"stream,omitempty"`
+	Stderr string       `json:"stderr,omitempty"`
+}
+
+// newFixtureEvent records event. ok is false for events that cannot be
+// recorded: those created without a [StreamEvent] that are not stderr lines.
+func newFixtureEvent(event Event) (fe FixtureEvent, ok bool) {
+	if event.Type == EventTypeStderr {
+		return FixtureEvent{Stderr: event.Stderr}, true
+	}
+	if event.Raw == nil {
+		return FixtureEvent{}, false
+	}
+	return FixtureEvent{Stream: event.Raw}, true
+}
+
+// event returns the recorded [Event].
+func (fe FixtureEvent) event() Event {
+	if fe.Stream == nil {
+		return Event{Type: EventTypeStderr, Stderr: fe.Stderr}
+	}
+	return NewEventFromStream(fe.Stream)
+}
+
+// LoadFixture reads a fixture file written by [RecordingExecutor].
+//
+// Returns an error if the file cannot be read or parsed, or has an
+// unsupported version.
+func LoadFixture(path string) (*Fixture, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixture: %w", err)
+	}
+	var fixture Fixture
+	if err := json.Unmarshal(data, &fixture); err != nil {
+		return nil, fmt.Errorf("failed to parse fixture %s: %w", path, err)
+	}
+	if fixture.Version != FixtureVersion {
+		return nil, fmt.Errorf("fixture %s has unsupported version %d (want %d)", path, fixture.Version, FixtureVersion)
+	}
+	return &fixture, nil
+}
+
+// RecordingExecutor is an [Executor] that runs Claude through another
+// executor and records every prompt and the events it produced to a
+// fixture file, for replay with [ReplayExecutor].
+//
+// The fixture is rewritten after each run, so an interrupted session keeps
+// the runs that finished. Events created without a [StreamEvent] (other than
+// stderr lines) are not recorded. It is safe for concurrent use.
+//
+// Create instances using [NewRecordingExecutor].
+type RecordingExecutor struct {
+	executor Executor
+	path     string
+
+	mu      sync.Mutex
+	fixture Fixture
+}
+
+// NewRecordingExecutor creates a [RecordingExecutor] that runs Claude with
+// executor and writes the fixture to path, replacing any existing file.
+func NewRecordingExecutor(executor Executor, path string) *RecordingExecutor {
+	return &RecordingExecutor{
+		executor: executor,
+		path:     path,
+		fixture:  Fixture{Version: FixtureVersion},
+	}
+}
+
+// Execute runs the wrapped executor's [Executor.Execute], recording the
+// events as they are received. The run is written to the fixture once the
+// channel is closed, with exit code 0 since it is not reported.
+func (r *RecordingExecutor) Execute(ctx context.Context, prompt string) (<-chan Event, error) {
+	events, err := r.executor.Execute(ctx, prompt)
+	if err != nil {
+		return nil, r.record(FixtureRun{Prompt: prompt, ExitCode: 1}, err)
+	}
+
+	recorded := make(chan Event)
+	go func() {
+		defer close(recorded)
+		run := FixtureRun{Prompt: prompt}
+		for event := range events {
+			if fe, ok := newFixtureEvent(event); ok {
+				run.Events = append(run.Events, fe)
+			}
+			recorded <- event
+		}
+		_ = r.record(run, nil)
+	}()
+	return recorded, nil
+}
+
+// ExecuteWithResult runs the wrapped executor's [Executor.ExecuteWithResult]
+// and records the run.
+func (r *RecordingExecutor) ExecuteWithResult(ctx context.Context, prompt string, handler EventHandler, model string) (int, error) {
+	run := FixtureRun{Prompt: prompt, Model: model}
+	exitCode, err := r.executor.ExecuteWithResult(ctx, prompt, run.recorder(handler), model)
+	run.ExitCode = exitCode
+	return exitCode, r.record(run, err)
+}
+
+// ResumeWithResult runs the wrapped executor's
+// [SessionResumer.ResumeWithResult] and records the run. It fails if the
+// wrapped executor cannot resume sessions.
+func (r *RecordingExecutor) ResumeWithResult(ctx context.Context, sessionID, prompt string, handler EventHandler, model string) (int, error) {
+	resumer, ok := r.executor.(SessionResumer)
+	if !ok {
+		return 1, errors.New("recorded executor cannot resume Claude sessions")
+	}
+	run := FixtureRun{Prompt: prompt, Model: model, SessionID: sessionID}
+	exitCode, err := resumer.ResumeWithResult(ctx, sessionID, prompt, run.recorder(handler), model)
+	run.ExitCode = exitCode
+	return exitCode, r.record(run, err)
+}
+
+// CheckBinary delegates to the wrapped executor, if it is a
+// [BinaryChecker].
+func (r *RecordingExecutor) CheckBinary() error {
+	if checker, ok := r.executor.(BinaryChecker); ok {
+		return checker.CheckBinary()
+	}
+	return nil
+}
+
+// recorder returns a handler that records each event in run before passing
+// it to handler, which may be nil.
+func (run *FixtureRun) recorder(handler EventHandler) EventHandler {
+	return func(event Event) {
+		if fe, ok := newFixtureEvent(event); ok {
+			run.Events = append(run.Events, fe)
+		}
+		if handler != nil {
+			handler(event)
+		}
+	}
+}
+
+// record appends run, with the message of runErr, to the fixture and
+// rewrites the fixture file. It returns runErr, or the write error if
+// runErr is nil.
+func (r *RecordingExecutor) record(run FixtureRun, runErr error) error {
+	if runErr != nil {
+		run.Error = runErr.Error()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.fixture.Runs = append(r.fixture.Runs, run)
+	data, err := json.MarshalIndent(r.fixture, "", "  ")
+	if err == nil {
+		err = os.WriteFile(r.path, append(data, '\n'), 0644)
+	}
+	if runErr != nil {
+		return runErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write fixture: %w", err)
+	}
+	return nil
+}
+
+// ReplayExecutor is an [Executor] that replays the runs of a [Fixture]
+// instead of running Claude, so a recorded lifecycle can be tested
+// deterministically.
+//
+// Each call replays the first run not yet replayed that was recorded for
+// the same prompt, so runs may be replayed in a different order than they
+// were recorded, as with parallel stories. The model and resumed session
+// are not matched. A call whose prompt has no run left fails. It is safe
+// for concurrent use.
+//
+// Create instances using [NewReplayExecutor].
+type ReplayExecutor struct {
+	mu       sync.Mutex
+	runs     []FixtureRun
+	replayed []bool
+}
+
+// NewReplayExecutor creates a [ReplayExecutor] replaying the runs of
+// fixture; see [LoadFixture].
+func NewReplayExecutor(fixture *Fixture) *ReplayExecutor {
+	return &ReplayExecutor{
+		runs:     fixture.Runs,
+		replayed: make([]bool, len(fixture.Runs)),
+	}
+}
+
+// Execute replays the run recorded for prompt, sending its events to the
+// returned channel. The channel is closed when all events have been sent or
+// ctx is canceled.
+func (r *ReplayExecutor) Execute(ctx context.Context, prompt string) (<-chan Event, error) {
+	run, err := r.next(prompt)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan Event)
+	go func() {
+		defer close(events)
+		for _, fe := range run.Events {
+			select {
+			case <-ctx.Done():
+				return
+			case events <- fe.event():
+			}
+		}
+	}()
+	return events, nil
+}
+
+// ExecuteWithResult replays the run recorded for prompt, passing its events
+// to handler, and returns its recorded exit code and error.
+func (r *ReplayExecutor) ExecuteWithResult(ctx context.Context, prompt string, handler EventHandler, model string) (int, error) {
+	run, err := r.next(prompt)
+	if err != nil {
+		return 1, err
+	}
+
+	for _, fe := range run.Events {
+		if handler != nil {
+			handler(fe.event())
+		}
+	}
+	if run.Error != "" {
+		return run.ExitCode, errors.New(run.Error)
+	}
+	return run.ExitCode, nil
+}
+
+// ResumeWithResult behaves like [ReplayExecutor.ExecuteWithResult]; the
+// session is ignored.
+func (r *ReplayExecutor) ResumeWithResult(ctx context.Context, sessionID, prompt string, handler EventHandler, model string) (int, error) {
+	return r.ExecuteWithResult(ctx, prompt, handler, model)
+}
+
+// Remaining returns the number of recorded runs not yet replayed.
+func (r *ReplayExecutor) Remaining() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	remaining := 0
+	for _, replayed := range r.replayed {
+		if !replayed {
+			remaining++
+		}
+	}
+	return remaining
+}
+
+// next marks and returns the first run recorded for prompt that has not
+// been replayed.
+func (r *ReplayExecutor) next(prompt string) (FixtureRun, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, run := range r.runs {
+		if !r.replayed[i] && run.Prompt == prompt {
+			r.replayed[i] = true
+			return run, nil
+		}
+	}
+	return FixtureRun{}, fmt.Errorf("no recorded run left for prompt %q", prompt)
+}
@@ -0,0 +1,164 @@
+package claude
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustParse(t *testing.T, line string) Event {
+	t.Helper()
+	event, err := ParseSingle(line)
+	require.NoError(t, err)
+	return event
+}
+
+func collectEvents(t *testing.T, events *[]Event) EventHandler {
+	t.Helper()
+	return func(event Event) {
+		*events = append(*events, event)
+	}
+}
+
+func TestRecordingExecutor_RoundTrip(t *testing.T) {
+	events := []Event{
+		mustParse(t, `{"type":"system","subtype":"init","session_id":"abc"}`),
+		mustParse(t, `{"type":"assistant","message":{"content":[{"type":"tool_use","name":"Bash","input":{"command":"ls","custom":[1,2]}}]}}`),
+		{Type: EventTypeStderr, Stderr: "warning: slow"},
+		{Type: EventTypeAssistant, Text: "not recorded"},
+		mustParse(t, `{"type":"result","subtype":"success"}`),
+	}
+	path := filepath.Join(t.TempDir(), "fixture.json")
+
+	recorder := NewRecordingExecutor(&MockExecutor{Events: events, ExitCode: 3}, path)
+	var seen []Event
+	exitCode, err := recorder.ExecuteWithResult(context.Background(), "/dev-story 1-1", collectEvents(t, &seen), "opus")
+	require.NoError(t, err)
+	assert.Equal(t, 3, exitCode)
+	assert.Equal(t, events, seen, "events should reach the wrapped handler unchanged")
+
+	fixture, err := LoadFixture(path)
+	require.NoError(t, err)
+	require.Len(t, fixture.Runs, 1)
+	run := fixture.Runs[0]
+	assert.Equal(t, "/dev-story 1-1", run.Prompt)
+	assert.Equal(t, "opus", run.Model)
+	assert.Equal(t, 3, run.ExitCode)
+	assert.Len(t, run.Events, 4, "events without a stream event should not be recorded")
+
+	replay := NewReplayExecutor(fixture)
+	var replayed []Event
+	exitCode, err = replay.ExecuteWithResult(context.Background(), "/dev-story 1-1", collectEvents(t, &replayed), "")
+	require.NoError(t, err)
+	assert.Equal(t, 3, exitCode)
+	require.Len(t, replayed, 4)
+	assert.Equal(t, "abc", replayed[0].SessionID)
+	assert.Equal(t, "Bash", replayed[1].ToolName)
+	assert.Equal(t, "ls", replayed[1].ToolCommand)
+	assert.JSONEq(t, `{"command":"ls","custom":[1,2]}`, string(replayed[1].ToolInputRaw))
+	assert.Equal(t, Event{Type: EventTypeStderr, Stderr: "warning: slow"}, replayed[2])
+	assert.True(t, replayed[3].SessionComplete)
+	assert.Equal(t, 0, replay.Remaining())
+}
+
+func TestRecordingExecutor_RecordsErrors(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fixture.json")
+	recorder := NewRecordingExecutor(&MockExecutor{Error: errors.New("connection failed")}, path)
+
+	_, err := recorder.ExecuteWithResult(context.Background(), "prompt", nil, "")
+	require.EqualError(t, err, "connection failed")
+
+	fixture, err := LoadFixture(path)
+	require.NoError(t, err)
+	replay := NewReplayExecutor(fixture)
+	exitCode, err := replay.ExecuteWithResult(context.Background(), "prompt", nil, "")
+	assert.EqualError(t, err, "connection failed")
+	assert.Equal(t, 1, exitCode)
+}
+
+func TestRecordingExecutor_Resume(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fixture.json")
+	mock := &MockExecutor{}
+	recorder := NewRecordingExecutor(mock, path)
+
+	_, err := recorder.ResumeWithResult(context.Background(), "abc", "fix it", nil, "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"abc"}, mock.RecordedSessions)
+
+	fixture, err := LoadFixture(path)
+	require.NoError(t, err)
+	require.Len(t, fixture.Runs, 1)
+	assert.Equal(t, "abc", fixture.Runs[0].SessionID)
+}
+
+func TestRecordingExecutor_Execute(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fixture.json")
+	events := []Event{mustParse(t, `{"type":"assistant","message":{"content":[{"type":"text","text":"Hi"}]}}`)}
+	recorder := NewRecordingExecutor(&MockExecutor{Events: events}, path)
+
+	ch, err := recorder.Execute(context.Background(), "prompt")
+	require.NoError(t, err)
+	var collected []Event
+	for event := range ch {
+		collected = append(collected, event)
+	}
+	assert.Equal(t, events, collected)
+
+	fixture, err := LoadFixture(path)
+	require.NoError(t, err)
+	replay := NewReplayExecutor(fixture)
+	ch, err = replay.Execute(context.Background(), "prompt")
+	require.NoError(t, err)
+	var replayed []Event
+	for event := range ch {
+		replayed = append(replayed, event)
+	}
+	require.Len(t, replayed, 1)
+	assert.Equal(t, "Hi", replayed[0].Text)
+}
+
+func TestReplayExecutor_MatchesPrompt(t *testing.T) {
+	replay := NewReplayExecutor(&Fixture{Version: FixtureVersion, Runs: []FixtureRun{
+		{Prompt: "a", ExitCode: 1},
+		{Prompt: "b", ExitCode: 2},
+		{Prompt: "a", ExitCode: 3},
+	}})
+
+	exitCode, err := replay.ExecuteWithResult(context.Background(), "b", nil, "")
+	require.NoError(t, err)
+	assert.Equal(t, 2, exitCode)
+
+	exitCode, err = replay.ExecuteWithResult(context.Background(), "a", nil, "")
+	require.NoError(t, err)
+	assert.Equal(t, 1, exitCode)
+
+	exitCode, err = replay.ExecuteWithResult(context.Background(), "a", nil, "")
+	require.NoError(t, err)
+	assert.Equal(t, 3, exitCode)
+
+	_, err = replay.ExecuteWithResult(context.Background(), "a", nil, "")
+	assert.EqualError(t, err, `no recorded run left for prompt "a"`)
+	_, err = replay.Execute(context.Background(), "c")
+	assert.Error(t, err)
+}
+
+func TestLoadFixture_Errors(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := LoadFixture(filepath.Join(dir, "missing.json"))
+	assert.ErrorContains(t, err, "failed to read fixture")
+
+	path := filepath.Join(dir, "bad.json")
+	require.NoError(t, os.WriteFile(path, []byte("{"), 0644))
+	_, err = LoadFixture(path)
+	assert.ErrorContains(t, err, "failed to parse fixture")
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"version": 99, "runs": []}`), 0644))
+	_, err = LoadFixture(path)
+	assert.ErrorContains(t, err, "unsupported version 99")
+}
@@ -2,10 +2,18 @@ package claude
 
 import (
 	"bufio"
+	"bytes"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
+	"os"
 )
 
+// ErrorTypeStreamRead is the [StreamError.Type] of the error event
+// [DefaultParser.Parse] sends when reading Claude's output fails.
+const ErrorTypeStreamRead = "stream_read_error"
+
 // Parser parses streaming JSON output from Claude CLI.
 //
 // The parser expects Claude's stream-json format, where each line of output is a
@@ -15,7 +23,8 @@ import (
 // The channel returned by Parse is closed when:
 //   - EOF is reached (normal completion)
 //   - The underlying reader is closed
-//   - An unrecoverable read error occurs
+//   - An unrecoverable read error occurs, after an [EventTypeError] event
+//     reporting it
 //
 // Malformed JSON lines are silently skipped to provide resilience against
 // partial or corrupted output.
@@ -28,7 +37,7 @@ type Parser interface {
 
 // DefaultParser implements [Parser] for Claude's stream-json format.
 //
-// DefaultParser uses a buffered scanner to read JSON lines from Claude's stdout.
+// DefaultParser uses a buffered reader to read JSON lines from Claude's stdout.
 // The BufferSize field controls the maximum allowed line length, which is important
 // because Claude may output large JSON objects (e.g., file contents in tool results).
 //
@@ -36,9 +45,18 @@ type Parser interface {
 // proper default values.
 type DefaultParser struct {
 	// BufferSize is the maximum size in bytes for a single JSON line.
-	// Lines exceeding this size will cause a scanner error and stop parsing.
+	// Lines exceeding this size are skipped and parsing continues with the
+	// next line.
 	// Defaults to 10MB (10 * 1024 * 1024) if not set or <= 0.
 	BufferSize int
+
+	// ChannelBuffer is the number of parsed events Parse may hold for a
+	// consumer that has not received them yet. Zero (the default) leaves the
+	// channel unbuffered, so reading stops until each event is received, and
+	// a slow consumer can leave Claude blocked writing to its stdout pipe.
+	// A buffer lets the parser read ahead of the consumer at the cost of
+	// holding up to that many events, and their tool output, in memory.
+	ChannelBuffer int
 }
 
 // NewParser creates a new [DefaultParser] with default settings.
@@ -59,50 +77,90 @@ func NewParser() *DefaultParser {
 // Error handling behavior:
 //   - Empty lines are silently skipped
 //   - Lines that fail JSON parsing are silently skipped (resilience against partial output)
-//   - Scanner errors (e.g., line too long) terminate parsing and close the channel
-//   - EOF closes the channel normally
-//
-// The scanner buffer is configured based on [DefaultParser.BufferSize] to handle
-// large JSON objects that may appear in Claude's output.
+//   - Lines longer than [DefaultParser.BufferSize] are discarded without
+//     being held in memory and skipped, so one huge tool result does not end
+//     the stream; an [EventTypeNotice] event reports each skipped line and
+//     its size
+//   - Read errors end parsing with an [EventTypeError] event whose
+//     [StreamError.Type] is [ErrorTypeStreamRead]; the rest of the output is
+//     discarded so the writer does not block
+//   - EOF, or the reader being closed, closes the channel normally
 func (p *DefaultParser) Parse(reader io.Reader) <-chan Event {
-	events := make(chan Event)
+	events := make(chan Event, max(p.ChannelBuffer, 0))
 
 	go func() {
 		defer close(events)
 
-		scanner := bufio.NewScanner(reader)
-
-		// Set up buffer for large JSON lines
-		bufSize := p.BufferSize
-		if bufSize <= 0 {
-			bufSize = 10 * 1024 * 1024
+		maxLine := p.BufferSize
+		if maxLine <= 0 {
+			maxLine = 10 * 1024 * 1024
 		}
-		buf := make([]byte, 0, 1024*1024)
-		scanner.Buffer(buf, bufSize)
+		buffered := bufio.NewReaderSize(reader, min(64*1024, maxLine))
 
-		for scanner.Scan() {
-			line := scanner.Text()
-			if line == "" {
+		var line []byte
+		skipped := 0 // length of the over-long line being dropped, if any
+		for {
+			chunk, err := buffered.ReadSlice('\n')
+			length := len(bytes.TrimRight(chunk, "\r\n"))
+			if skipped == 0 && len(line)+length > maxLine {
+				// Drop the line as it arrives rather than growing without bound
+				skipped = len(bytes.TrimRight(line, "\r\n"))
+				line = line[:0]
+			}
+			if skipped > 0 {
+				skipped += length
+			} else {
+				line = append(line, chunk...)
+			}
+			if errors.Is(err, bufio.ErrBufferFull) {
 				continue
 			}
 
-			var streamEvent StreamEvent
-			if err := json.Unmarshal([]byte(line), &streamEvent); err != nil {
-				// Skip unparseable lines
-				continue
+			if skipped > 0 {
+				events <- Event{
+					Type:   EventTypeNotice,
+					Notice: fmt.Sprintf("skipped %d-byte line exceeding the %d-byte buffer", skipped, maxLine),
+				}
+			} else if event, ok := parseLine(line); ok {
+				events <- event
 			}
+			line = line[:0]
+			skipped = 0
 
-			event := NewEventFromStream(&streamEvent)
-			events <- event
+			if err == nil {
+				continue
+			}
+			// EOF and a closed pipe end the stream normally; anything else
+			// means output was lost and the run must not look successful
+			if !errors.Is(err, io.EOF) && !errors.Is(err, os.ErrClosed) && !errors.Is(err, io.ErrClosedPipe) {
+				events <- NewEventFromStream(&StreamEvent{
+					Type:  string(EventTypeError),
+					Error: &StreamError{Type: ErrorTypeStreamRead, Message: fmt.Sprintf("failed to read Claude output: %v", err)},
+				})
+				_, _ = io.Copy(io.Discard, reader)
+			}
+			return
 		}
-
-		// Note: scanner.Err() is intentionally not checked here
-		// as we want to gracefully handle EOF and pipe closure
 	}()
 
 	return events
 }
 
+// parseLine parses one line of stream-json output. ok is false for empty
+// and unparseable lines.
+func parseLine(line []byte) (event Event, ok bool) {
+	line = bytes.TrimRight(line, "\r\n")
+	if len(line) == 0 {
+		return Event{}, false
+	}
+
+	var streamEvent StreamEvent
+	if err := json.Unmarshal(line, &streamEvent); err != nil {
+		return Event{}, false
+	}
+	return NewEventFromStream(&streamEvent), true
+}
+
 // ParseSingle parses a single JSON line into an [Event].
 //
 // This is a utility function useful for testing and debugging. It parses a single
@@ -1,8 +1,13 @@
 package claude
 
 import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -39,6 +44,136 @@ func TestDefaultParser_Parse(t *testing.T) {
 	assert.True(t, collected[2].SessionComplete)
 }
 
+func TestDefaultParser_Parse_ErrorEvent(t *testing.T) {
+	input := `{"type":"system","subtype":"init"}
+{"type":"error","error":{"type":"overloaded_error","message":"Overloaded"}}
+{"type":"error","error":"Stream interrupted"}
+{"type":"error"}
+{"type":"result"}`
+
+	parser := NewParser()
+	events := parser.Parse(strings.NewReader(input))
+
+	var collected []Event
+	for event := range events {
+		collected = append(collected, event)
+	}
+
+	require.Len(t, collected, 5)
+	assert.Equal(t, EventTypeError, collected[1].Type)
+	assert.Equal(t, "Overloaded", collected[1].ErrorMessage)
+	assert.Equal(t, "overloaded_error", collected[1].Raw.Error.Type)
+	assert.Equal(t, "Stream interrupted", collected[2].ErrorMessage, "bare string error")
+	assert.Equal(t, "unknown error", collected[3].ErrorMessage)
+	assert.Empty(t, collected[4].ErrorMessage)
+}
+
+func TestDefaultParser_Parse_ChannelBuffer(t *testing.T) {
+	input := `{"type":"system","subtype":"init"}
+{"type":"assistant","message":{"content":[{"type":"text","text":"Hello!"}]}}
+{"type":"result"}`
+
+	// Unbuffered by default
+	unbuffered := NewParser().Parse(strings.NewReader(input))
+	assert.Equal(t, 0, cap(unbuffered))
+	for range unbuffered {
+	}
+
+	parser := NewParser()
+	parser.ChannelBuffer = 3
+	events := parser.Parse(strings.NewReader(input))
+	assert.Equal(t, 3, cap(events))
+
+	// The parser reads every event without waiting for the consumer
+	require.Eventually(t, func() bool { return len(events) == 3 }, time.Second, time.Millisecond)
+
+	var collected []Event
+	for event := range events {
+		collected = append(collected, event)
+	}
+	require.Len(t, collected, 3)
+	assert.True(t, collected[2].SessionComplete)
+}
+
+func TestDefaultParser_Parse_LineTooLong(t *testing.T) {
+	input := `{"type":"system","subtype":"init"}
+{"type":"assistant","message":{"content":[{"type":"text","text":"` + strings.Repeat("x", 200) + `"}]}}
+{"type":"assistant","message":{"content":[{"type":"text","text":"Hello!"}]}}
+{"type":"result"}`
+
+	parser := &DefaultParser{BufferSize: 100}
+
+	var collected []Event
+	for event := range parser.Parse(strings.NewReader(input)) {
+		collected = append(collected, event)
+	}
+
+	require.Len(t, collected, 4, "only the long line is skipped")
+	assert.True(t, collected[0].SessionStarted)
+	longLine := strings.Split(input, "\n")[1]
+	assert.Equal(t, EventTypeNotice, collected[1].Type, "the skipped line is reported")
+	assert.Equal(t, fmt.Sprintf("skipped %d-byte line exceeding the 100-byte buffer", len(longLine)), collected[1].Notice)
+	assert.Equal(t, "Hello!", collected[2].Text)
+	assert.True(t, collected[3].SessionComplete)
+}
+
+func TestDefaultParser_Parse_LineAtLimit(t *testing.T) {
+	line := `{"type":"assistant","message":{"content":[{"type":"text","text":"Hello!"}]}}`
+	parser := &DefaultParser{BufferSize: len(line)}
+
+	var collected []Event
+	for event := range parser.Parse(strings.NewReader(line + "\r\n" + line)) {
+		collected = append(collected, event)
+	}
+
+	require.Len(t, collected, 2)
+	assert.Equal(t, "Hello!", collected[1].Text)
+}
+
+func TestDefaultParser_Parse_ReadError(t *testing.T) {
+	reader := &closedReader{data: `{"type":"system","subtype":"init"}` + "\n", err: errors.New("read failed")}
+
+	var collected []Event
+	for event := range NewParser().Parse(reader) {
+		collected = append(collected, event)
+	}
+
+	require.Len(t, collected, 2)
+	assert.True(t, collected[0].SessionStarted)
+	assert.Equal(t, EventTypeError, collected[1].Type)
+	assert.Equal(t, ErrorTypeStreamRead, collected[1].Raw.Error.Type)
+	assert.Equal(t, "failed to read Claude output: read failed", collected[1].ErrorMessage)
+}
+
+// closedReader returns its data, then err.
+type closedReader struct {
+	data string
+	err  error
+}
+
+func (r *closedReader) Read(p []byte) (int, error) {
+	if r.data == "" {
+		return 0, r.err
+	}
+	n := copy(p, r.data)
+	r.data = r.data[n:]
+	return n, nil
+}
+
+func TestDefaultParser_Parse_ClosedPipe(t *testing.T) {
+	for _, err := range []error{os.ErrClosed, io.ErrClosedPipe} {
+		reader := &closedReader{data: `{"type":"system","subtype":"init"}` + "\n", err: err}
+
+		var collected []Event
+		for event := range NewParser().Parse(reader) {
+			collected = append(collected, event)
+		}
+
+		require.Len(t, collected, 1, "a closed pipe ends the stream normally")
+		assert.True(t, collected[0].SessionStarted)
+	}
+}
+
 func TestDefaultParser_Parse_SkipsInvalidJSON(t *testing.T) {
 	input := `{"type":"system","subtype":"init"}
 not valid json
@@ -152,3 +287,85 @@ func TestParseSingle(t *testing.T) {
 		})
 	}
 }
+
+func TestDefaultParser_Parse_ResultUsage(t *testing.T) {
+	input := `{"type":"result","subtype":"success","total_cost_usd":0.0421,"duration_ms":83500,"usage":{"input_tokens":1200,"output_tokens":340}}`
+
+	event := <-NewParser().Parse(strings.NewReader(input))
+
+	assert.True(t, event.SessionComplete)
+	assert.Equal(t, 1200, event.InputTokens)
+	assert.Equal(t, 340, event.OutputTokens)
+	assert.InDelta(t, 0.0421, event.CostUSD, 1e-9)
+	assert.Equal(t, 83500*time.Millisecond, event.Duration)
+}
+
+func TestDefaultParser_Parse_ResultWithoutUsage(t *testing.T) {
+	event := <-NewParser().Parse(strings.NewReader(`{"type":"result"}`))
+
+	assert.True(t, event.SessionComplete)
+	assert.Zero(t, event.InputTokens)
+	assert.Zero(t, event.OutputTokens)
+	assert.Zero(t, event.CostUSD)
+	assert.Zero(t, event.Duration)
+}
+
+func TestDefaultParser_Parse_ResultCostShapes(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		wantCost   float64
+		wantInput  int
+		wantOutput int
+	}{
+		{
+			name:       "top-level total_cost_usd",
+			input:      `{"type":"result","total_cost_usd":0.25,"usage":{"input_tokens":10,"output_tokens":2}}`,
+			wantCost:   0.25,
+			wantInput:  10,
+			wantOutput: 2,
+		},
+		{
+			name:       "legacy top-level cost_usd",
+			input:      `{"type":"result","cost_usd":0.12,"usage":{"input_tokens":10,"output_tokens":2}}`,
+			wantCost:   0.12,
+			wantInput:  10,
+			wantOutput: 2,
+		},
+		{
+			name:       "cost nested under usage",
+			input:      `{"type":"result","usage":{"input_tokens":10,"output_tokens":2,"total_cost_usd":0.31}}`,
+			wantCost:   0.31,
+			wantInput:  10,
+			wantOutput: 2,
+		},
+		{
+			name:     "cost_usd nested under usage",
+			input:    `{"type":"result","usage":{"cost_usd":0.07}}`,
+			wantCost: 0.07,
+		},
+		{
+			name:     "total_cost_usd preferred over cost_usd",
+			input:    `{"type":"result","total_cost_usd":0.5,"cost_usd":0.1}`,
+			wantCost: 0.5,
+		},
+		{
+			name:       "usage on message",
+			input:      `{"type":"result","total_cost_usd":0.02,"message":{"usage":{"input_tokens":7,"output_tokens":3}}}`,
+			wantCost:   0.02,
+			wantInput:  7,
+			wantOutput: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := <-NewParser().Parse(strings.NewReader(tt.input))
+
+			assert.True(t, event.SessionComplete)
+			assert.InDelta(t, tt.wantCost, event.CostUSD, 1e-9)
+			assert.Equal(t, tt.wantInput, event.InputTokens)
+			assert.Equal(t, tt.wantOutput, event.OutputTokens)
+		})
+	}
+}
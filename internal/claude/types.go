@@ -13,7 +13,10 @@
 // real processes.
 package claude
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"time"
+)
 
 // Usage represents token usage from Claude API.
 //
@@ -32,6 +35,11 @@ type Usage struct {
 
 	// CacheCreationInputTokens is the number of tokens used to create cache.
 	CacheCreationInputTokens int `json:"cache_creation_input_tokens,omitempty"`
+
+	// CostUSD and TotalCostUSD carry the session cost when a Claude CLI
+	// version nests it under usage rather than at the top of the result event.
+	CostUSD      float64 `json:"cost_usd,omitempty"`
+	TotalCostUSD float64 `json:"total_cost_usd,omitempty"`
 }
 
 // TotalTokens returns the total number of tokens (input + output).
@@ -55,6 +63,51 @@ type StreamEvent struct {
 	Message       *MessageContent `json:"message,omitempty"`
 	ToolUseResult *ToolResult     `json:"tool_use_result,omitempty"`
 	Usage         *Usage          `json:"usage,omitempty"`
+
+	// TotalCostUSD is the session cost reported by result events.
+	TotalCostUSD float64 `json:"total_cost_usd,omitempty"`
+
+	// CostUSD is the session cost field used by older Claude CLI versions.
+	CostUSD float64 `json:"cost_usd,omitempty"`
+
+	// DurationMS is the session wall-clock time reported by result events.
+	DurationMS int64 `json:"duration_ms,omitempty"`
+
+	// SessionID identifies the Claude session; see [Event.SessionID].
+	SessionID string `json:"session_id,omitempty"`
+
+	// IsError and Result report the outcome of result events; see
+	// [Event.ErrorText].
+	IsError bool   `json:"is_error,omitempty"`
+	Result  string `json:"result,omitempty"`
+
+	// Error describes the failure reported by error events; see
+	// [Event.ErrorMessage].
+	Error *StreamError `json:"error,omitempty"`
+}
+
+// StreamError is the error carried by an error event in Claude's streaming
+// output, such as {"type":"overloaded_error","message":"Overloaded"}.
+type StreamError struct {
+	Type    string `json:"type,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// UnmarshalJSON accepts the error either as an object or, as some Claude CLI
+// versions write it, as a bare message string.
+func (e *StreamError) UnmarshalJSON(data []byte) error {
+	var message string
+	if err := json.Unmarshal(data, &message); err == nil {
+		*e = StreamError{Message: message}
+		return nil
+	}
+	type streamErrorAlias StreamError
+	var alias streamErrorAlias
+	if err := json.Unmarshal(data, &alias); err != nil {
+		return err
+	}
+	*e = StreamError(alias)
+	return nil
 }
 
 // MessageContent represents the content of a message in Claude's streaming output.
@@ -135,6 +188,19 @@ func (c *ContentBlock) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// MarshalJSON writes InputRaw as the input field when it is set, so a block
+// survives a JSON round trip without losing the parameters of unknown tools.
+func (c ContentBlock) MarshalJSON() ([]byte, error) {
+	type contentBlockAlias ContentBlock
+	if len(c.InputRaw) == 0 {
+		return json.Marshal(contentBlockAlias(c))
+	}
+	return json.Marshal(struct {
+		contentBlockAlias
+		Input json.RawMessage `json:"input"`
+	}{contentBlockAlias(c), c.InputRaw})
+}
+
 // ToolInput represents the input parameters for a tool invocation.
 //
 // Different tools use different fields:
@@ -255,6 +321,21 @@ const (
 	// EventTypeResult indicates the session has completed.
 	// Check [Event.SessionComplete] which will be true for result events.
 	EventTypeResult EventType = "result"
+
+	// EventTypeError indicates Claude reported an error mid-stream, such as
+	// an API failure. The message is in [Event.ErrorMessage].
+	EventTypeError EventType = "error"
+
+	// EventTypeStderr indicates a line Claude wrote to stderr. These events
+	// are not part of the stream-json output; [DefaultExecutor.ExecuteWithResult]
+	// passes them to the handler alongside the parsed events.
+	EventTypeStderr EventType = "stderr"
+
+	// EventTypeNotice indicates that some of Claude's output could not be
+	// shown, such as a line too long for the parser's buffer. These events
+	// are not part of the stream-json output; [DefaultParser.Parse] sends
+	// them in place of the lost output. The text is in [Event.Notice].
+	EventTypeNotice EventType = "notice"
 )
 
 // SubtypeInit is the subtype value for system initialization events.
@@ -262,6 +343,20 @@ const (
 // the Claude session has started.
 const SubtypeInit = "init"
 
+// Subtypes of result events, reported in [Event.ResultSubtype]. Claude may
+// report other error subtypes; see [Event.IsError].
+const (
+	// ResultSubtypeSuccess marks a session that completed successfully.
+	ResultSubtypeSuccess = "success"
+
+	// ResultSubtypeErrorMaxTurns marks a session stopped by the turn limit.
+	ResultSubtypeErrorMaxTurns = "error_max_turns"
+
+	// ResultSubtypeErrorDuringExecution marks a session that failed while
+	// running.
+	ResultSubtypeErrorDuringExecution = "error_during_execution"
+)
+
 // Event is a parsed event from Claude's streaming output.
 //
 // This is the primary type that users interact with when processing Claude's output.
@@ -275,7 +370,8 @@ type Event struct {
 	// the parsed fields are insufficient.
 	Raw *StreamEvent
 
-	// Type is the parsed event type (system, assistant, user, or result).
+	// Type is the parsed event type (system, assistant, user, result, or
+	// error).
 	Type EventType
 
 	// Subtype provides additional classification for certain event types.
@@ -371,6 +467,12 @@ type Event struct {
 	// Claude session has begun.
 	SessionStarted bool
 
+	// SessionID is the ID of the Claude session the event belongs to, as
+	// carried by the init event (and by later events in newer Claude CLI
+	// versions). Pass it to [SessionResumer.ResumeWithResult] to continue
+	// the conversation.
+	SessionID string
+
 	// SessionComplete is true for result events, indicating the
 	// Claude session has finished.
 	SessionComplete bool
@@ -384,18 +486,49 @@ type Event struct {
 	// For assistant events, this is per-message. For result events,
 	// this is the total for the session.
 	OutputTokens int
+
+	// CostUSD is the total cost of the session in US dollars.
+	// Populated for result events; zero when Claude does not report it.
+	CostUSD float64
+
+	// Duration is the session wall-clock time reported by Claude.
+	// Populated for result events; zero when Claude does not report it.
+	Duration time.Duration
+
+	// ResultSubtype is the subtype of a result event, such as
+	// [ResultSubtypeSuccess] or [ResultSubtypeErrorMaxTurns]. Empty for other
+	// events and when Claude does not report one.
+	ResultSubtype string
+
+	// ErrorText is the result message of a result event reporting that the
+	// session failed, e.g. "Claude AI usage limit reached|1760000000".
+	// Empty for successful sessions and other events.
+	ErrorText string
+
+	// ErrorMessage is the message of an [EventTypeError] event, falling back
+	// to the error type, or "unknown error", when Claude sends no message.
+	// Empty for other events.
+	ErrorMessage string
+
+	// Stderr is the line of an [EventTypeStderr] event.
+	Stderr string
+
+	// Notice is the text of an [EventTypeNotice] event, e.g. "skipped
+	// 12582912-byte line exceeding the 10485760-byte buffer".
+	Notice string
 }
 
 // NewEventFromStream creates an [Event] from a raw [StreamEvent].
 //
 // This function parses the StreamEvent and extracts relevant fields into the
 // Event's convenience properties based on the event type. It handles all event
-// types (system, assistant, user, result) and populates the appropriate fields.
+// types (system, assistant, user, result, error) and populates the appropriate fields.
 func NewEventFromStream(raw *StreamEvent) Event {
 	e := Event{
-		Raw:     raw,
-		Type:    EventType(raw.Type),
-		Subtype: raw.Subtype,
+		Raw:       raw,
+		Type:      EventType(raw.Type),
+		Subtype:   raw.Subtype,
+		SessionID: raw.SessionID,
 	}
 
 	switch e.Type {
@@ -483,16 +616,70 @@ func NewEventFromStream(raw *StreamEvent) Event {
 
 	case EventTypeResult:
 		e.SessionComplete = true
-		// Extract final token usage from result event
-		if raw.Usage != nil {
-			e.InputTokens = raw.Usage.InputTokens
-			e.OutputTokens = raw.Usage.OutputTokens
+		e.ResultSubtype = raw.Subtype
+		// Extract final token usage from result event, falling back to the
+		// message usage some Claude CLI versions report instead
+		usage := raw.Usage
+		if usage == nil && raw.Message != nil {
+			usage = raw.Message.Usage
+		}
+		if usage != nil {
+			e.InputTokens = usage.InputTokens
+			e.OutputTokens = usage.OutputTokens
+		}
+		e.CostUSD = resultCost(raw)
+		e.Duration = time.Duration(raw.DurationMS) * time.Millisecond
+		if raw.IsError {
+			e.ErrorText = raw.Result
+		}
+
+	case EventTypeError:
+		e.ErrorMessage = "unknown error"
+		if raw.Error != nil && raw.Error.Message != "" {
+			e.ErrorMessage = raw.Error.Message
+		} else if raw.Error != nil && raw.Error.Type != "" {
+			e.ErrorMessage = raw.Error.Type
 		}
 	}
 
 	return e
 }
 
+// resultCost returns the session cost from a result event.
+//
+// Claude CLI versions report cost in different places, so the fields are
+// tried in order: total_cost_usd, cost_usd, then the same two names nested
+// under usage. Returns 0 if none is set.
+func resultCost(raw *StreamEvent) float64 {
+	if raw.TotalCostUSD != 0 {
+		return raw.TotalCostUSD
+	}
+	if raw.CostUSD != 0 {
+		return raw.CostUSD
+	}
+	if raw.Usage != nil {
+		if raw.Usage.TotalCostUSD != 0 {
+			return raw.Usage.TotalCostUSD
+		}
+		return raw.Usage.CostUSD
+	}
+	return 0
+}
+
+// IsError returns true if this is a result event reporting that the session
+// ended in error, even though it completed.
+//
+// A result is an error when Claude flagged it as one (see [Event.ErrorText])
+// or its [Event.ResultSubtype] is set to anything other than
+// [ResultSubtypeSuccess], such as [ResultSubtypeErrorMaxTurns]. The Claude
+// process may still exit with code 0.
+func (e Event) IsError() bool {
+	if e.Type != EventTypeResult {
+		return false
+	}
+	return e.ErrorText != "" || (e.ResultSubtype != "" && e.ResultSubtype != ResultSubtypeSuccess)
+}
+
 // IsText returns true if this event contains text content from Claude.
 //
 // Use this method to filter for events where Claude is outputting text
@@ -22,6 +22,16 @@ func TestNewEventFromStream_SystemInit(t *testing.T) {
 	assert.False(t, event.SessionComplete)
 }
 
+func TestNewEventFromStream_SessionID(t *testing.T) {
+	var raw StreamEvent
+	require.NoError(t, json.Unmarshal([]byte(`{"type":"system","subtype":"init","session_id":"0f6c-42"}`), &raw))
+
+	event := NewEventFromStream(&raw)
+
+	assert.True(t, event.SessionStarted)
+	assert.Equal(t, "0f6c-42", event.SessionID)
+}
+
 func TestNewEventFromStream_AssistantText(t *testing.T) {
 	raw := &StreamEvent{
 		Type: "assistant",
@@ -97,6 +107,58 @@ func TestNewEventFromStream_Result(t *testing.T) {
 	assert.True(t, event.SessionComplete)
 }
 
+func TestNewEventFromStream_ErrorResult(t *testing.T) {
+	raw := &StreamEvent{
+		Type:    "result",
+		IsError: true,
+		Result:  "Claude AI usage limit reached|1760000000",
+	}
+
+	event := NewEventFromStream(raw)
+
+	assert.True(t, event.SessionComplete)
+	assert.Equal(t, "Claude AI usage limit reached|1760000000", event.ErrorText)
+
+	// A successful result carries no error text
+	event = NewEventFromStream(&StreamEvent{Type: "result", Result: "Done"})
+	assert.Empty(t, event.ErrorText)
+}
+
+func TestNewEventFromStream_ResultSubtype(t *testing.T) {
+	event := NewEventFromStream(&StreamEvent{Type: "result", Subtype: "error_max_turns"})
+	assert.Equal(t, ResultSubtypeErrorMaxTurns, event.ResultSubtype)
+	assert.True(t, event.IsError())
+
+	event = NewEventFromStream(&StreamEvent{Type: "result", Subtype: "success"})
+	assert.Equal(t, ResultSubtypeSuccess, event.ResultSubtype)
+	assert.False(t, event.IsError())
+
+	// Only result events carry a result subtype
+	event = NewEventFromStream(&StreamEvent{Type: "system", Subtype: "init"})
+	assert.Empty(t, event.ResultSubtype)
+}
+
+func TestEvent_IsError(t *testing.T) {
+	tests := []struct {
+		name     string
+		event    Event
+		expected bool
+	}{
+		{"success result", Event{Type: EventTypeResult, ResultSubtype: ResultSubtypeSuccess}, false},
+		{"result without subtype", Event{Type: EventTypeResult}, false},
+		{"max turns", Event{Type: EventTypeResult, ResultSubtype: ResultSubtypeErrorMaxTurns}, true},
+		{"execution error", Event{Type: EventTypeResult, ResultSubtype: ResultSubtypeErrorDuringExecution}, true},
+		{"flagged error", Event{Type: EventTypeResult, ResultSubtype: ResultSubtypeSuccess, ErrorText: "usage limit"}, true},
+		{"not a result", Event{Type: EventTypeSystem, Subtype: "error"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.event.IsError())
+		})
+	}
+}
+
 func TestEvent_IsText(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -0,0 +1,33 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"bmaduum/internal/claude"
+)
+
+// ExtraArgsExecutor is implemented by executors that accept additional
+// Claude CLI arguments, such as [claude.DefaultExecutor].
+type ExtraArgsExecutor interface {
+	AddExtraArgs(args ...string)
+}
+
+// setupClaudeArgs applies the global --claude-arg flags to app's executor,
+// after claude.extra_args from the config.
+func setupClaudeArgs(app *App, args []string) error {
+	if len(args) == 0 {
+		return nil
+	}
+	if err := claude.ValidateExtraArgs(args); err != nil {
+		return fmt.Errorf("invalid --claude-arg: %w", err)
+	}
+
+	e, ok := app.Executor.(ExtraArgsExecutor)
+	if !ok {
+		fmt.Fprintln(os.Stderr, "Warning: --claude-arg ignored: executor does not accept extra arguments")
+		return nil
+	}
+	e.AddExtraArgs(args...)
+	return nil
+}
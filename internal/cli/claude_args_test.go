@@ -0,0 +1,61 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"bmaduum/internal/claude"
+	"bmaduum/internal/config"
+	"bmaduum/internal/output"
+)
+
+// extraArgsExecutor records the arguments added with AddExtraArgs.
+type extraArgsExecutor struct {
+	claude.MockExecutor
+	extraArgs []string
+}
+
+func (e *extraArgsExecutor) AddExtraArgs(args ...string) {
+	e.extraArgs = append(e.extraArgs, args...)
+}
+
+func TestClaudeArgFlag(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      []string
+		expected  []string
+		expectErr bool
+	}{
+		{name: "none", args: []string{"version"}},
+		{name: "repeated", args: []string{"--claude-arg", "--max-turns", "--claude-arg", "50", "version"}, expected: []string{"--max-turns", "50"}},
+		{name: "output format rejected", args: []string{"--claude-arg=--output-format=json", "version"}, expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			executor := &extraArgsExecutor{}
+			app := &App{
+				Config:   config.DefaultConfig(),
+				Executor: executor,
+				Runner:   &MockWorkflowRunner{},
+				Printer:  output.NewPrinterWithWriter(&bytes.Buffer{}),
+			}
+
+			rootCmd := NewRootCommand(app)
+			rootCmd.SetOut(&bytes.Buffer{})
+			rootCmd.SetErr(&bytes.Buffer{})
+			rootCmd.SetArgs(tt.args)
+
+			err := rootCmd.Execute()
+			if tt.expectErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.expected, executor.extraArgs)
+		})
+	}
+}
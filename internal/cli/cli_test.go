@@ -2,6 +2,8 @@ package cli
 
 import (
 	"bytes"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/spf13/cobra"
@@ -10,7 +12,9 @@ import (
 
 	"bmaduum/internal/claude"
 	"bmaduum/internal/config"
+	"bmaduum/internal/manifest"
 	"bmaduum/internal/output"
+	"bmaduum/internal/router"
 	"bmaduum/internal/status"
 	"bmaduum/internal/workflow"
 )
@@ -69,6 +73,12 @@ func TestNewRootCommand_HasAllSubcommands(t *testing.T) {
 		"epic",
 		"workflow",
 		"raw",
+		"status",
+		"migrate-status",
+		"list-modules",
+		"config",
+		"manifest",
+		"replay",
 	}
 
 	commands := rootCmd.Commands()
@@ -259,6 +269,10 @@ func TestCommandsHaveRunEFunctions(t *testing.T) {
 		"epic",
 		"raw",
 		"workflow",
+		"status",
+		"migrate-status",
+		"list-modules",
+		"replay",
 	}
 
 	for _, cmdName := range commands {
@@ -433,3 +447,179 @@ func TestExecuteResult(t *testing.T) {
 		assert.Error(t, result.Err)
 	})
 }
+
+func TestLoadModules(t *testing.T) {
+	t.Setenv("BMADUUM_MODULE_MANIFEST_PATH", "")
+
+	t.Run("discovers manifest and injects sdet step", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		manifestPath := filepath.Join(tmpDir, manifest.ModuleManifestPath)
+		require.NoError(t, os.MkdirAll(filepath.Dir(manifestPath), 0755))
+		require.NoError(t, os.WriteFile(manifestPath, []byte(`modules:
+  - name: bmm
+    version: "6.0.0"
+  - name: sdet
+    version: "1.0.0"
+`), 0644))
+
+		wfRouter := router.NewRouter()
+		modules := loadModules(tmpDir, wfRouter)
+
+		require.NotNil(t, modules)
+		assert.Equal(t, []string{"bmm", "sdet"}, modules.Names())
+
+		steps, err := wfRouter.GetLifecycle(status.StatusReview)
+		require.NoError(t, err)
+		require.Len(t, steps, 3)
+		assert.Equal(t, "test-automation", steps[1].Workflow)
+	})
+
+	t.Run("environment override", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		manifestPath := filepath.Join(tmpDir, "modules.yaml")
+		require.NoError(t, os.WriteFile(manifestPath, []byte(`modules:
+  - name: tea
+`), 0644))
+		t.Setenv("BMADUUM_MODULE_MANIFEST_PATH", manifestPath)
+
+		modules := loadModules(t.TempDir(), router.NewRouter())
+		require.NotNil(t, modules)
+		assert.True(t, modules.HasModule("tea"))
+	})
+
+	t.Run("missing manifest", func(t *testing.T) {
+		wfRouter := router.NewRouter()
+		assert.Nil(t, loadModules(t.TempDir(), wfRouter))
+
+		steps, err := wfRouter.GetLifecycle(status.StatusReview)
+		require.NoError(t, err)
+		assert.Len(t, steps, 2)
+	})
+}
+
+func TestWarnLegacyStatusPath(t *testing.T) {
+	t.Setenv("BMADUUM_SPRINT_STATUS_PATH", "")
+
+	t.Run("warns for legacy location", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, status.LegacyStatusPath), []byte("{}"), 0644))
+
+		buf := &bytes.Buffer{}
+		warnLegacyStatusPath(buf, status.NewReader(tmpDir))
+
+		assert.Equal(t, "Warning: using legacy sprint-status.yaml location; consider migrating to _bmad-output/implementation-artifacts/sprint-status.yaml (bmaduum migrate-status)\n", buf.String())
+	})
+
+	t.Run("silent for v6 location", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		createSprintStatusFile(t, tmpDir, "{}")
+
+		buf := &bytes.Buffer{}
+		warnLegacyStatusPath(buf, status.NewReader(tmpDir))
+
+		assert.Empty(t, buf.String())
+	})
+}
+
+func TestReplayCommand(t *testing.T) {
+	session := filepath.Join(t.TempDir(), "session.jsonl")
+	require.NoError(t, os.WriteFile(session, []byte(`{"type":"system","subtype":"init"}
+{"type":"assistant","message":{"content":[{"type":"text","text":"Replayed text"}]}}
+{"type":"result"}
+`), 0644))
+
+	replay := func() string {
+		buf := &bytes.Buffer{}
+		mockExecutor := &claude.MockExecutor{}
+		app := &App{
+			Config:   config.DefaultConfig(),
+			Executor: mockExecutor,
+			Printer:  output.NewPrinterWithWriter(buf),
+		}
+
+		rootCmd := NewRootCommand(app)
+		rootCmd.SetArgs([]string{"replay", session})
+		require.NoError(t, rootCmd.Execute())
+		assert.Empty(t, mockExecutor.RecordedPrompts)
+		return buf.String()
+	}
+
+	out := replay()
+	assert.Contains(t, out, "Replayed text")
+	assert.Equal(t, out, replay())
+}
+
+func TestReplayCommand_MissingFile(t *testing.T) {
+	app := setupTestApp()
+	rootCmd := NewRootCommand(app)
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{"replay", filepath.Join(t.TempDir(), "missing.jsonl")})
+
+	err := rootCmd.Execute()
+
+	code, ok := IsExitError(err)
+	assert.True(t, ok)
+	assert.Equal(t, 1, code)
+}
+
+func TestWorkflowCommand_ResumeSession(t *testing.T) {
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, `development_status:
+  6-4-resume: in-progress`)
+
+	cfg := config.DefaultConfig()
+	mockExecutor := &claude.MockExecutor{
+		Events: []claude.Event{
+			{Type: claude.EventTypeSystem, SessionStarted: true, SessionID: "session-1"},
+			{Type: claude.EventTypeResult, SessionComplete: true},
+		},
+		ExitCode: 1,
+	}
+	runner := workflow.NewRunner(mockExecutor, output.NewPrinterWithWriter(&bytes.Buffer{}), cfg)
+	runner.SetProgressWriter(&bytes.Buffer{})
+	app := &App{
+		Config:       cfg,
+		Runner:       runner,
+		Printer:      output.NewPrinterWithWriter(&bytes.Buffer{}),
+		StatusReader: status.NewReader(tmpDir),
+	}
+
+	run := func(args ...string) error {
+		rootCmd := NewRootCommand(app)
+		rootCmd.SetOut(&bytes.Buffer{})
+		rootCmd.SetErr(&bytes.Buffer{})
+		rootCmd.SetArgs(args)
+		return rootCmd.Execute()
+	}
+
+	// The failed run's session is recorded, then resumed
+	require.Error(t, run("workflow", "dev-story", "6-4-resume"))
+	mockExecutor.ExitCode = 0
+	require.NoError(t, run("workflow", "dev-story", "6-4-resume", "--resume"))
+
+	// A session from another workflow is not resumed
+	require.NoError(t, run("workflow", "code-review", "6-4-resume", "--resume"))
+
+	assert.Equal(t, []string{"", "session-1", ""}, mockExecutor.RecordedSessions)
+}
+
+func TestProfileFromArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		expected string
+	}{
+		{name: "none", args: []string{"story", "6-1"}},
+		{name: "separate value", args: []string{"--profile", "work", "story", "6-1"}, expected: "work"},
+		{name: "equals value", args: []string{"story", "--profile=home", "6-1"}, expected: "home"},
+		{name: "last wins", args: []string{"--profile", "work", "--profile=home"}, expected: "home"},
+		{name: "after terminator", args: []string{"raw", "--", "--profile", "work"}},
+		{name: "missing value", args: []string{"story", "--profile"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, profileFromArgs(tt.args))
+		})
+	}
+}
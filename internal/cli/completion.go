@@ -0,0 +1,25 @@
+package cli
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"bmaduum/internal/status"
+)
+
+// completeStatuses is a [cobra.CompletionFunc] suggesting the valid story
+// statuses (see [status.ValidStatuses]) that start with toComplete.
+//
+// Register it for any flag or argument that takes a status, e.g.
+//
+//	cmd.RegisterFlagCompletionFunc("to", completeStatuses)
+func completeStatuses(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	var completions []cobra.Completion
+	for _, s := range status.ValidStatuses() {
+		if strings.HasPrefix(string(s), toComplete) {
+			completions = append(completions, string(s))
+		}
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,42 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"bmaduum/internal/config"
+)
+
+func TestCompleteStatuses(t *testing.T) {
+	completions, directive := completeStatuses(&cobra.Command{}, nil, "")
+
+	assert.Equal(t, []string{"backlog", "ready-for-dev", "in-progress", "review", "done"}, completions)
+	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+
+	completions, _ = completeStatuses(&cobra.Command{}, nil, "re")
+	assert.Equal(t, []string{"ready-for-dev", "review"}, completions)
+
+	completions, _ = completeStatuses(&cobra.Command{}, nil, "x")
+	assert.Empty(t, completions)
+}
+
+func TestStatusCommand_CompleteKeys(t *testing.T) {
+	app := &App{Config: config.DefaultConfig()}
+
+	rootCmd := NewRootCommand(app)
+	out := &bytes.Buffer{}
+	rootCmd.SetOut(out)
+	rootCmd.SetErr(out)
+	rootCmd.SetArgs([]string{"status", "--complete-keys"})
+
+	require.NoError(t, rootCmd.Execute())
+	assert.Equal(t, "backlog\nready-for-dev\nin-progress\nreview\ndone\n", out.String())
+
+	statusCmd := findCommand(rootCmd, "status")
+	require.NotNil(t, statusCmd)
+	assert.True(t, statusCmd.Flags().Lookup("complete-keys").Hidden)
+}
@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"bmaduum/internal/config"
+)
+
+func newConfigInitCommand() *cobra.Command {
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:   "init",
+		Short: "Write a commented default workflows.yaml to the user config directory",
+		Long: `Write the built-in default configuration, with a comment explaining each
+setting, to workflows.yaml in the user config directory:
+  - Linux: ~/.config/bmaduum/workflows.yaml
+  - macOS: ~/Library/Application Support/bmaduum/workflows.yaml
+  - Windows: %APPDATA%\bmaduum\workflows.yaml
+
+The file is a starting point for customizing prompts, models, and output.
+An existing file is not overwritten unless --force is given.
+
+Examples:
+  bmaduum config init
+  bmaduum config init --force`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, err := writeDefaultConfig(force)
+			if err != nil {
+				cmd.SilenceUsage = true
+				fmt.Printf("Error: %v\n", err)
+				return NewExitError(1)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Wrote default configuration to %s\n", path)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&force, "force", false, "Overwrite an existing config file")
+	return cmd
+}
+
+// writeDefaultConfig writes [config.DefaultYAML] to [config.DefaultConfigPath]
+// and returns the path. An existing file is only replaced with force.
+func writeDefaultConfig(force bool) (string, error) {
+	data, err := config.DefaultYAML()
+	if err != nil {
+		return "", err
+	}
+	path, err := config.DefaultConfigPath()
+	if err != nil {
+		return "", err
+	}
+	if err := config.EnsureConfigDir(); err != nil {
+		return "", fmt.Errorf("create config directory: %w", err)
+	}
+
+	flags := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	if !force {
+		flags |= os.O_EXCL
+	}
+	f, err := os.OpenFile(path, flags, 0644)
+	if errors.Is(err, fs.ErrExist) {
+		return "", fmt.Errorf("%s already exists; use --force to overwrite it", path)
+	}
+	if err != nil {
+		return "", fmt.Errorf("write config: %w", err)
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return "", fmt.Errorf("write config: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return "", fmt.Errorf("write config: %w", err)
+	}
+	return path, nil
+}
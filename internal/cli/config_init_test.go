@@ -0,0 +1,59 @@
+package cli
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"bmaduum/internal/config"
+)
+
+// useTempConfigDir points the user config directory at a temporary
+// directory for the rest of the test.
+func useTempConfigDir(t *testing.T) {
+	t.Helper()
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("HOME", dir)
+	t.Setenv("AppData", dir)
+}
+
+func TestConfigInitCommand(t *testing.T) {
+	useTempConfigDir(t)
+	app := &App{Config: config.DefaultConfig()}
+	path, err := config.DefaultConfigPath()
+	require.NoError(t, err)
+
+	out, err := runValidateCommand(t, app, "config", "init")
+	require.NoError(t, err)
+	assert.Equal(t, "Wrote default configuration to "+path+"\n", out)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	expected, err := config.DefaultYAML()
+	require.NoError(t, err)
+	assert.Equal(t, expected, data)
+}
+
+func TestConfigInitCommand_RefusesOverwrite(t *testing.T) {
+	useTempConfigDir(t)
+	app := &App{Config: config.DefaultConfig()}
+	require.NoError(t, config.EnsureConfigDir())
+	path, err := config.DefaultConfigPath()
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, []byte("use_slash_commands: false\n"), 0644))
+
+	_, err = runValidateCommand(t, app, "config", "init")
+	require.Error(t, err)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "use_slash_commands: false\n", string(data))
+
+	_, err = runValidateCommand(t, app, "config", "init", "--force")
+	require.NoError(t, err)
+	data, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "use_slash_commands: true")
+}
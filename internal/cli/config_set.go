@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"bmaduum/internal/config"
+)
+
+func newConfigSetCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "set <key> <value>",
+		Short: "Set one setting in the config file",
+		Long: `Set one setting in the config file and save it.
+
+The key is a dotted path of config file keys, such as claude.binary_path,
+output.truncate_lines, use_slash_commands, or workflows.dev-story.model.
+Unknown keys and values of the wrong type are rejected and nothing is
+written. Setting a field of a workflow that is not configured adds it.
+
+The file edited is the one bmaduum reads: BMADUUM_CONFIG_PATH if set, else
+the first workflows.yaml found in the user config directory, ./config, and
+the current directory. If none exists, workflows.yaml is created in the user
+config directory. Environment variable overrides are not written to the
+file, and comments in it are not kept.
+
+Examples:
+  bmaduum config set claude.binary_path /usr/local/bin/claude
+  bmaduum config set output.truncate_lines 50
+  bmaduum config set use_slash_commands false
+  bmaduum config set workflows.dev-story.model opus
+  bmaduum config set claude.extra_args '[--max-turns, "50"]'`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, err := setConfigValue(args[0], args[1])
+			if err != nil {
+				cmd.SilenceUsage = true
+				fmt.Printf("Error: %v\n", err)
+				return NewExitError(1)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Set %s in %s\n", args[0], path)
+			return nil
+		},
+	}
+}
+
+// setConfigValue sets key to value in the file returned by
+// [config.FindConfigFile], starting from the defaults if the file does not
+// exist, and returns the file's path.
+func setConfigValue(key, value string) (string, error) {
+	path, err := config.FindConfigFile()
+	if err != nil {
+		return "", err
+	}
+
+	loader := config.NewLoader()
+	cfg := config.DefaultConfig()
+	if _, err := os.Stat(path); err == nil {
+		if cfg, err = loader.LoadFromFile(path); err != nil {
+			return "", err
+		}
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return "", err
+	}
+
+	if err := cfg.Set(key, value); err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", fmt.Errorf("create config directory: %w", err)
+	}
+	if err := loader.Save(cfg, path); err != nil {
+		return "", err
+	}
+	return path, nil
+}
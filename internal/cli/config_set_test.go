@@ -0,0 +1,64 @@
+package cli
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"bmaduum/internal/config"
+)
+
+func TestConfigSetCommand_CreatesFile(t *testing.T) {
+	useTempConfigDir(t)
+	t.Setenv("BMADUUM_CONFIG_PATH", "")
+	t.Chdir(t.TempDir())
+	app := &App{Config: config.DefaultConfig()}
+	path, err := config.DefaultConfigPath()
+	require.NoError(t, err)
+
+	out, err := runValidateCommand(t, app, "config", "set", "output.truncate_lines", "50")
+	require.NoError(t, err)
+	assert.Equal(t, "Set output.truncate_lines in "+path+"\n", out)
+
+	_, err = runValidateCommand(t, app, "config", "set", "workflows.dev-story.model", "opus")
+	require.NoError(t, err)
+
+	cfg, err := config.NewLoader().LoadFromFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, 50, cfg.Output.TruncateLines)
+	assert.Equal(t, "opus", cfg.Workflows["dev-story"].Model)
+}
+
+func TestConfigSetCommand_EditsConfigPath(t *testing.T) {
+	path := t.TempDir() + "/custom.yaml"
+	require.NoError(t, os.WriteFile(path, []byte("claude:\n  binary_path: /old/claude\n"), 0644))
+	t.Setenv("BMADUUM_CONFIG_PATH", path)
+	app := &App{Config: config.DefaultConfig()}
+
+	_, err := runValidateCommand(t, app, "config", "set", "use_slash_commands", "false")
+	require.NoError(t, err)
+
+	cfg, err := config.NewLoader().LoadFromFile(path)
+	require.NoError(t, err)
+	assert.False(t, cfg.UseSlashCommands)
+	assert.Equal(t, "/old/claude", cfg.Claude.BinaryPath)
+}
+
+func TestConfigSetCommand_UnknownKey(t *testing.T) {
+	path := t.TempDir() + "/custom.yaml"
+	original := []byte("claude:\n  binary_path: /old/claude\n")
+	require.NoError(t, os.WriteFile(path, original, 0644))
+	t.Setenv("BMADUUM_CONFIG_PATH", path)
+	app := &App{Config: config.DefaultConfig()}
+
+	_, err := runValidateCommand(t, app, "config", "set", "claude.binray_path", "/x")
+	code, ok := IsExitError(err)
+	require.True(t, ok)
+	assert.Equal(t, 1, code)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, original, data, "file must not be rewritten")
+}
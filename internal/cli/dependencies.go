@@ -0,0 +1,45 @@
+package cli
+
+import (
+	"fmt"
+
+	"bmaduum/internal/router"
+	"bmaduum/internal/status"
+)
+
+// orderStories sorts storyKeys so each story runs after the stories it
+// depends on, as declared by depends_on in the story overrides sidecar.
+//
+// A dependency outside storyKeys must already be done, or be a key in
+// earlier (stories that run before storyKeys in the same command).
+func orderStories(app *App, storyKeys []string, earlier map[string]bool) ([]string, error) {
+	satisfied := func(storyKey string) bool {
+		if earlier[storyKey] {
+			return true
+		}
+		s, err := app.StatusReader.GetStoryStatus(storyKey)
+		return err == nil && s == status.StatusDone
+	}
+	return router.OrderStories(storyKeys, app.StoryOverrides.DependsOn, satisfied)
+}
+
+// failedDependency returns the first story storyKey depends on that is in
+// failed, so --continue-on-error does not run a story on top of a
+// dependency that failed or was cancelled.
+func failedDependency(dependsOn func(storyKey string) []string, storyKey string, failed map[string]bool) (string, bool) {
+	if dependsOn == nil {
+		return "", false
+	}
+	for _, dep := range dependsOn(storyKey) {
+		if failed[dep] {
+			return dep, true
+		}
+	}
+	return "", false
+}
+
+// dependencyFailedError is the error of a story not run because its
+// dependency dep failed.
+func dependencyFailedError(dep string) error {
+	return fmt.Errorf("dependency %s failed", dep)
+}
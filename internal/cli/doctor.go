@@ -0,0 +1,241 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"bmaduum/internal/git"
+	"bmaduum/internal/manifest"
+	"bmaduum/internal/router"
+)
+
+// claudeVersionTimeout bounds the `claude --version` probe run by doctor.
+const claudeVersionTimeout = 10 * time.Second
+
+// doctorCheck is the outcome of one doctor check.
+type doctorCheck struct {
+	name string
+	// detail describes what was found, shown when the check passes.
+	detail string
+	err    error
+	// hint tells the user how to fix a failed check.
+	hint string
+	// critical checks fail the doctor command; the others only warn.
+	critical bool
+}
+
+func newDoctorCommand(app *App) *cobra.Command {
+	return &cobra.Command{
+		Use:   "doctor",
+		Short: "Diagnose the environment bmaduum runs in",
+		Long: `Check that everything bmaduum needs is in place and print a checklist.
+
+Checks the Claude binary (and its version), that sprint-status.yaml can be
+found and parsed, the workflow and module manifests, the configuration, and
+that the working directory is inside a git repository. Each failed check is
+followed by a hint on how to fix it.
+
+Problems with the Claude binary, sprint-status.yaml, or the configuration
+stop runs and make doctor exit 1. A missing or broken manifest or a missing
+git repository is reported as a warning.
+
+Example:
+  bmaduum doctor`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			checks := runDoctorChecks(cmd.Context(), app)
+			if !reportDoctorChecks(cmd.OutOrStdout(), checks) {
+				cmd.SilenceUsage = true
+				return NewExitError(1)
+			}
+			return nil
+		},
+	}
+}
+
+// runDoctorChecks runs every doctor check against app, in the order they
+// are reported.
+func runDoctorChecks(ctx context.Context, app *App) []doctorCheck {
+	return []doctorCheck{
+		doctorClaude(ctx, app),
+		doctorSprintStatus(app),
+		doctorWorkflowManifest(app),
+		doctorModuleManifest(),
+		doctorConfig(app),
+		doctorGit(ctx, app),
+	}
+}
+
+// doctorClaude checks that the Claude binary is on PATH and reports the
+// version it prints.
+func doctorClaude(ctx context.Context, app *App) doctorCheck {
+	check := doctorCheck{
+		name:     "claude binary",
+		hint:     "install Claude Code, or set BMADUUM_CLAUDE_PATH or claude.binary_path to the claude binary",
+		critical: true,
+	}
+	binary := app.Config.Claude.BinaryPath
+	if binary == "" {
+		binary = "claude"
+	}
+	path, err := exec.LookPath(binary)
+	if err != nil {
+		check.err = fmt.Errorf("%s not found: %w", binary, err)
+		return check
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, claudeVersionTimeout)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, path, "--version").Output()
+	if err != nil {
+		check.err = fmt.Errorf("%s --version failed: %w", path, err)
+		return check
+	}
+	check.detail = fmt.Sprintf("%s (%s)", path, strings.TrimSpace(string(out)))
+	return check
+}
+
+// doctorSprintStatus checks that sprint-status.yaml exists at its resolved
+// path and parses.
+func doctorSprintStatus(app *App) doctorCheck {
+	check := doctorCheck{
+		name:     "sprint status",
+		hint:     "run the BMAD sprint-planning workflow, or set status_path or BMADUUM_SPRINT_STATUS_PATH",
+		critical: true,
+	}
+	path := app.StatusReader.Path()
+	sprint, err := app.StatusReader.Read()
+	if err != nil {
+		check.err = err
+		return check
+	}
+	check.detail = fmt.Sprintf("%s (%d entries)", path, len(sprint.DevelopmentStatus))
+	return check
+}
+
+// doctorWorkflowManifest checks that the workflow manifest exists and
+// routes a coherent lifecycle.
+func doctorWorkflowManifest(app *App) doctorCheck {
+	check := doctorCheck{name: "workflow manifest"}
+	path := app.workflowManifestPath()
+	if _, err := os.Stat(path); err != nil {
+		check.err = fmt.Errorf("%s not found; using default routing", path)
+		check.hint = "install BMAD v6, or pass --manifest or set BMADUUM_MANIFEST_PATH"
+		return check
+	}
+	if problems := validateManifestFile(path); len(problems) > 0 {
+		check.err = fmt.Errorf("%s: %w", path, errors.Join(problems...))
+		check.hint = "fix the manifest (see bmaduum manifest validate); runs use it as is"
+		return check
+	}
+	check.detail = path
+	return check
+}
+
+// doctorModuleManifest checks that the module manifest exists and parses,
+// and lists the installed modules.
+func doctorModuleManifest() doctorCheck {
+	check := doctorCheck{name: "module manifest"}
+	path := manifest.ResolveModulePath("")
+	if _, err := os.Stat(path); err != nil {
+		check.err = fmt.Errorf("%s not found; no module steps are added to the lifecycle", path)
+		check.hint = "install BMAD v6 modules, or set BMADUUM_MODULE_MANIFEST_PATH"
+		return check
+	}
+	modules, err := manifest.ReadModulesFromFile(path)
+	if err != nil {
+		check.err = err
+		check.hint = "fix the module manifest (see bmaduum manifest validate " + path + ")"
+		return check
+	}
+	names := make([]string, len(modules.Modules))
+	for i, module := range modules.Modules {
+		names[i] = module.Name
+	}
+	check.detail = fmt.Sprintf("%s (%s)", path, strings.Join(names, ", "))
+	return check
+}
+
+// doctorConfig checks the configuration the way config validate does,
+// leaving the Claude binary to [doctorClaude].
+func doctorConfig(app *App) doctorCheck {
+	check := doctorCheck{
+		name:     "config",
+		hint:     "run bmaduum config validate for details",
+		critical: true,
+	}
+	wfRouter := app.Router
+	if wfRouter == nil {
+		wfRouter = router.NewRouter()
+	}
+	if problems := validateConfigSettings(app.Config, wfRouter); len(problems) > 0 {
+		check.err = errors.Join(problems...)
+		return check
+	}
+	check.detail = "valid"
+	return check
+}
+
+// doctorGit checks that the working directory is inside a git repository.
+func doctorGit(ctx context.Context, app *App) doctorCheck {
+	check := doctorCheck{
+		name: "git repository",
+		hint: "run bmaduum inside a git repository; git-commit and the uncommitted-changes check need one",
+	}
+	var gitHelper GitHelper = app.Git
+	if gitHelper == nil {
+		gitHelper = git.NewClient("")
+	}
+	root, err := gitHelper.RepoRoot(ctx)
+	if err != nil {
+		check.err = err
+		return check
+	}
+	check.detail = root
+	return check
+}
+
+// reportDoctorChecks prints checks as a checklist and returns false if a
+// critical check failed. Failed checks are marked ✗ if critical and ! if
+// not, and followed by their hint.
+func reportDoctorChecks(w io.Writer, checks []doctorCheck) bool {
+	failed, warnings := 0, 0
+	for _, check := range checks {
+		switch {
+		case check.err == nil:
+			fmt.Fprintf(w, "✓ %s: %s\n", check.name, check.detail)
+			continue
+		case check.critical:
+			failed++
+			fmt.Fprintf(w, "✗ %s: %s\n", check.name, oneLine(check.err))
+		default:
+			warnings++
+			fmt.Fprintf(w, "! %s: %s\n", check.name, oneLine(check.err))
+		}
+		if check.hint != "" {
+			fmt.Fprintf(w, "    hint: %s\n", check.hint)
+		}
+	}
+
+	fmt.Fprintln(w)
+	if failed > 0 {
+		fmt.Fprintf(w, "%d problem(s), %d warning(s): fix the problems before running\n", failed, warnings)
+		return false
+	}
+	fmt.Fprintf(w, "Ready, %d warning(s)\n", warnings)
+	return true
+}
+
+// oneLine returns the message of err, which may join several errors, on a
+// single line.
+func oneLine(err error) string {
+	return strings.ReplaceAll(err.Error(), "\n", "; ")
+}
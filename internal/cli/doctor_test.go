@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"bmaduum/internal/config"
+	"bmaduum/internal/output"
+	"bmaduum/internal/status"
+)
+
+// fakeClaude writes an executable script that prints a version and returns
+// its path.
+func fakeClaude(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "claude")
+	require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\necho '2.0.1 (Claude Code)'\n"), 0755))
+	return path
+}
+
+func TestDoctorCommand(t *testing.T) {
+	tests := []struct {
+		name       string
+		binaryPath string
+		statusYAML string
+		gitErr     error
+		wantCode   int
+		want       []string
+	}{
+		{
+			name:       "ready",
+			statusYAML: "development_status:\n  6-1-setup: backlog\n",
+			want: []string{
+				"✓ claude binary: ",
+				"(2.0.1 (Claude Code))",
+				"(1 entries)",
+				"! workflow manifest: ",
+				"✓ config: valid",
+				"✓ git repository: /repo",
+				"Ready, 2 warning(s)",
+			},
+		},
+		{
+			name:       "claude and status missing",
+			binaryPath: "/nonexistent/claude",
+			gitErr:     errors.New("not a git repository"),
+			wantCode:   1,
+			want: []string{
+				"✗ claude binary: /nonexistent/claude not found",
+				"hint: install Claude Code",
+				"✗ sprint status: ",
+				"hint: run the BMAD sprint-planning workflow",
+				"! git repository: not a git repository",
+				"2 problem(s), 3 warning(s)",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			if tt.statusYAML != "" {
+				createSprintStatusFile(t, tmpDir, tt.statusYAML)
+			}
+			t.Setenv("BMADUUM_MODULE_MANIFEST_PATH", filepath.Join(tmpDir, "missing.yaml"))
+
+			cfg := config.DefaultConfig()
+			cfg.Claude.BinaryPath = tt.binaryPath
+			if cfg.Claude.BinaryPath == "" {
+				cfg.Claude.BinaryPath = fakeClaude(t)
+			}
+
+			app := &App{
+				Config:       cfg,
+				StatusReader: status.NewReader(tmpDir),
+				StatusWriter: &MockStatusWriter{},
+				Runner:       &MockWorkflowRunner{},
+				Printer:      output.NewPrinterWithWriter(&bytes.Buffer{}),
+				Git:          &MockGitHelper{Root: "/repo", RootErr: tt.gitErr},
+			}
+
+			rootCmd := NewRootCommand(app)
+			outBuf := &bytes.Buffer{}
+			rootCmd.SetOut(outBuf)
+			rootCmd.SetErr(&bytes.Buffer{})
+			rootCmd.SetArgs([]string{"doctor"})
+
+			err := rootCmd.Execute()
+			if tt.wantCode == 0 {
+				require.NoError(t, err)
+			} else {
+				code, ok := IsExitError(err)
+				require.True(t, ok)
+				assert.Equal(t, tt.wantCode, code)
+			}
+			for _, want := range tt.want {
+				assert.Contains(t, outBuf.String(), want)
+			}
+		})
+	}
+}
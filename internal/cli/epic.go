@@ -1,12 +1,15 @@
 package cli
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"bmaduum/internal/lifecycle"
+	"bmaduum/internal/output/core"
 	"bmaduum/internal/router"
 )
 
@@ -14,14 +17,30 @@ func newEpicCommand(app *App) *cobra.Command {
 	var dryRun bool
 	var autoRetry bool
 	var noBmadHelp bool
+	var forceCreate bool
+	var force bool
+	var abortOnUncommitted bool
+	var continueOnError bool
+	var runManifest string
+	var resume bool
+	var printTransitions bool
+	var assumeYes bool
+	var retries int
+	var parallel int
+	var model string
+	var fromStatus string
+	var report string
 
 	cmd := &cobra.Command{
 		Use:   "epic <epic-id>|all [epic-id...]",
 		Short: "Run full lifecycle for all stories in one or more epics",
 		Long: `Run the complete lifecycle for all stories in one or more epics to completion.
 
-Finds all stories matching the pattern {epic-id}-{N}-* where N is numeric,
-sorts them by story number, and runs each to completion before moving to the next.
+Finds all stories matching the pattern {epic-id}-{N}-* where N is a story
+number in the configured story_numbering scheme (numeric by default), sorts
+them by story number, and runs each to completion before moving to the next.
+A story listed with depends_on in story-overrides.yaml runs after the stories
+it depends on, which must be done or belong to this or an earlier epic.
 
 For each story, executes all remaining workflows based on its current status:
   - backlog       → create-story → dev-story → code-review → git-commit → done
@@ -30,16 +49,37 @@ For each story, executes all remaining workflows based on its current status:
   - review        → code-review → git-commit → done
   - done          → skipped (story already complete)
 
-The epic command stops on the first failure. Done stories are skipped and do not cause failure.
-Status is updated in sprint-status.yaml after each successful workflow.
+The epic command stops on the first failure unless --continue-on-error is set.
+Done stories are skipped and do not cause failure.
+Status is updated in sprint-status.yaml after each successful workflow. A summary
+box with completed, skipped, and failed counts is printed at the end of the run.
 
 Use --dry-run to preview workflows without executing them.
 Use --auto-retry to automatically retry on rate limit errors.
+Use --retries N to re-run each failed workflow step up to N times (overrides --auto-retry).
 Use --no-bmad-help to disable the bmad-help fallback for unknown statuses.
+Use --force-create to run create-story even if the story file already exists.
+Use --abort-on-uncommitted-after to fail a story that leaves uncommitted changes.
+Use --continue-on-error to record a failed story and go on with the next one;
+the run exits 1 at the end if any story failed.
+Use --run-manifest to record each step's changed files and commits to a JSON file.
+Use --report to write a JSON report of each story's final status, steps, retries,
+token usage, and cost to a file when the run ends.
+Use --resume to continue each story from its last checkpoint after a crash.
+Use --print-transitions to print the status transitions that actually occurred.
+Use --assume-yes to proceed when the run exceeds max_stories_per_run.
+Use --parallel N to run up to N independent stories at once. Claude's output is
+hidden in parallel runs; each story reports when it starts and finishes.
+Use --model to run steps whose workflow has no configured model with the given
+Claude model.
+Use --from-status to skip stories whose status comes before the given one in
+the lifecycle (e.g. --from-status review runs only stories in review).
 
 Examples:
   bmaduum epic 6
+  bmaduum epic --from-status review 6
   bmaduum epic 2 4 6
+  bmaduum epic --parallel 3 6
   bmaduum epic all`,
 		Args: cobra.MinimumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -51,11 +91,11 @@ Examples:
 				allEpics, err := app.StatusReader.GetAllEpics()
 				if err != nil {
 					cmd.SilenceUsage = true
-					fmt.Printf("Error reading epics: %v\n", err)
+					app.printf("Error reading epics: %v\n", err)
 					return NewExitError(1)
 				}
 				if len(allEpics) == 0 {
-					fmt.Println("No active epics found")
+					app.println("No active epics found")
 					return nil
 				}
 				epicIDs = allEpics
@@ -63,20 +103,147 @@ Examples:
 				epicIDs = args
 			}
 
-			// Create lifecycle executor with app dependencies
-			executor := lifecycle.NewExecutor(app.Runner, app.StatusReader, app.StatusWriter)
-			executor.SetRouter(app.Router)
+			if err := checkParallelFlags(app, parallel, runManifest, report, abortOnUncommitted); err != nil {
+				cmd.SilenceUsage = true
+				app.printf("Error: %v\n", err)
+				return NewExitError(1)
+			}
+			if report != "" && dryRun {
+				cmd.SilenceUsage = true
+				app.println("Error: --report cannot be combined with --dry-run")
+				return NewExitError(1)
+			}
+			applyDefaultModel(app, model)
 
-			// Enable bmad-help fallback unless disabled
-			if !noBmadHelp && app.BmadHelp != nil {
-				executor.SetBmadHelp(app.BmadHelp)
+			// Create lifecycle executor with app dependencies
+			executor := newLifecycleExecutor(app, noBmadHelp, forceCreate, force)
+			var observers stepObservers
+			if runManifest != "" {
+				observers = append(observers, newManifestRecorder(app, runManifest))
+			}
+			notifier := newRunNotifier(app)
+			if notifier != nil {
+				observers = append(observers, notifier)
+			}
+			var summary *summaryRecorder
+			if report != "" {
+				summary = newSummaryRecorder(app)
+				observers = append(observers, summary)
+			}
+			if len(observers) > 0 {
+				executor.SetStepObserver(observers)
 			}
+			autoRetry, maxRetries := resolveRetries(cmd, app.Config, executor, autoRetry, retries)
 
 			// Handle dry-run mode
 			if dryRun {
-				return runEpicDryRun(cmd, app, executor, epicIDs)
+				return runEpicDryRun(cmd, app, executor, epicIDs, fromStatus)
+			}
+			if err := requireClaude(cmd, app); err != nil {
+				return err
+			}
+
+			// Expand all epics up front so the story limit guard can run before execution
+			epicStories := make([][]string, len(epicIDs))
+			totalStories := 0
+			earlier := make(map[string]bool)
+			for epicIdx, epicID := range epicIDs {
+				storyKeys, err := app.StatusReader.GetEpicStories(epicID)
+				if err != nil {
+					cmd.SilenceUsage = true
+					app.printf("Error reading stories for epic %s: %v\n", epicID, err)
+					return NewExitError(1)
+				}
+
+				// Stories may depend on stories of epics that run earlier
+				storyKeys, err = orderStories(app, storyKeys, earlier)
+				if err != nil {
+					cmd.SilenceUsage = true
+					app.printf("Error ordering stories for epic %s: %v\n", epicID, err)
+					return NewExitError(1)
+				}
+				for _, storyKey := range storyKeys {
+					earlier[storyKey] = true
+				}
+
+				storyKeys, err = filterFromStatus(app, storyKeys, fromStatus)
+				if err != nil {
+					cmd.SilenceUsage = true
+					app.printf("Error: %v\n", err)
+					return NewExitError(1)
+				}
+				epicStories[epicIdx] = storyKeys
+				totalStories += len(storyKeys)
 			}
 
+			if err := checkStoryLimit(app.Config, totalStories, assumeYes); err != nil {
+				cmd.SilenceUsage = true
+				app.printf("Error: %v\n", err)
+				return NewExitError(1)
+			}
+
+			// Collect per-story results for the epic-level summary
+			runStart := time.Now()
+			allKeys := make([]string, 0, totalStories)
+			for _, storyKeys := range epicStories {
+				allKeys = append(allKeys, storyKeys...)
+			}
+			queueSummary := func(results []core.StoryResult) {
+				app.Printer.QueueSummary(results, allKeys, time.Since(runStart))
+				outcomes := storyOutcomes(results)
+				notifier.runFinished(ctx, outcomes, remainingStories(allKeys, outcomes))
+			}
+
+			if parallel > 1 {
+				run := parallelRun{
+					workers: parallel,
+					newExecutor: func(workerApp *App) *lifecycle.Executor {
+						e := newLifecycleExecutor(workerApp, noBmadHelp, forceCreate, force)
+						resolveRetries(cmd, workerApp.Config, e, autoRetry, retries)
+						if notifier != nil {
+							e.SetStepObserver(notifier)
+						}
+						return e
+					},
+					dependsOn:        app.StoryOverrides.DependsOn,
+					resume:           resume,
+					autoRetry:        autoRetry,
+					maxRetries:       maxRetries,
+					printTransitions: printTransitions,
+					continueOnError:  continueOnError,
+					storyFailed: func(storyKey string, err error) {
+						notifier.storyFailed(ctx, storyKey, err)
+					},
+				}
+				results, usage, err := runStoriesParallel(ctx, app, run, allKeys)
+				queueSummary(results)
+				printUsage(app, usage)
+				if err != nil {
+					cmd.SilenceUsage = true
+					if failed := failedStories(results); failed > 0 {
+						app.printf("✗ %d of %d stories failed\n", failed, len(results))
+					}
+					return NewExitError(1)
+				}
+				app.printf("✓ All %d epic(s) completed successfully!\n", len(epicIDs))
+				return nil
+			}
+
+			// Report what the run cost, including runs that fail part way
+			defer printRunUsage(app)
+			finishStory := func(storyKey, outcome string, err error) {}
+			if summary != nil {
+				finishStory = summary.finishStory
+				defer func() {
+					if err := summary.writeFile(report); err != nil {
+						app.printf("Error: %v\n", err)
+					}
+				}()
+			}
+
+			results := make([]core.StoryResult, 0, totalStories)
+			failedKeys := make(map[string]bool)
+
 			// Process each epic
 			for epicIdx, epicID := range epicIDs {
 				// Set operation context for progress display
@@ -88,13 +255,7 @@ Examples:
 					app.Runner.SetOperation(fmt.Sprintf("Epic %s", epicID))
 				}
 
-				// Get all stories for this epic
-				storyKeys, err := app.StatusReader.GetEpicStories(epicID)
-				if err != nil {
-					cmd.SilenceUsage = true
-					fmt.Printf("Error reading stories for epic %s: %v\n", epicID, err)
-					return NewExitError(1)
-				}
+				storyKeys := epicStories[epicIdx]
 
 				// Execute full lifecycle for each story in order
 				for storyIdx, storyKey := range storyKeys {
@@ -103,25 +264,89 @@ Examples:
 						app.Runner.SetOperation(fmt.Sprintf("Epic %s: Story %d of %d", epicID, storyIdx+1, len(storyKeys)))
 					}
 
-					err := executeWithRetry(ctx, executor, storyKey, autoRetry, 10, func(stepIndex, totalSteps int, workflow string) {
+					// Do not build on a story that failed
+					if dep, ok := failedDependency(app.StoryOverrides.DependsOn, storyKey, failedKeys); ok {
+						err := dependencyFailedError(dep)
+						app.printf("Story %s not run: %v\n", storyKey, err)
+						finishStory(storyKey, outcomeFailed, err)
+						notifier.storyFailed(ctx, storyKey, err)
+						results = append(results, core.StoryResult{Key: storyKey, BlockedBy: dep})
+						failedKeys[storyKey] = true
+						continue
+					}
+
+					storyStart := time.Now()
+					costBefore := runCost(app)
+					err := executeWithRetry(ctx, executor, storyKey, resume, autoRetry, maxRetries, func(stepIndex, totalSteps int, workflow string) {
 						app.Printer.StepStart(stepIndex, totalSteps, workflow)
 					})
+					if printTransitions {
+						printStoryTransitions(executor, storyKey)
+					}
+					result := core.StoryResult{
+						Key:      storyKey,
+						Duration: time.Since(storyStart),
+						CostUSD:  runCost(app) - costBefore,
+					}
 					if err != nil {
 						cmd.SilenceUsage = true
 						if errors.Is(err, router.ErrStoryComplete) {
-							fmt.Printf("Story %s is already complete, skipping\n", storyKey)
+							app.printf("Story %s is already complete, skipping\n", storyKey)
+							result.Skipped = true
+							finishStory(storyKey, outcomeSkipped, nil)
+							results = append(results, result)
 							continue
 						}
-						fmt.Printf("Error running lifecycle for story %s: %v\n", storyKey, err)
-						return NewExitError(1)
+						if errors.Is(err, context.Canceled) {
+							app.printf("Story %s cancelled\n", storyKey)
+							result.Cancelled = true
+							finishStory(storyKey, outcomeCancelled, err)
+							queueSummary(append(results, result))
+							return NewExitError(1)
+						}
+						app.printf("Error running lifecycle for story %s: %v\n", storyKey, err)
+						finishStory(storyKey, outcomeFailed, err)
+						notifier.storyFailed(ctx, storyKey, err)
+						if !continueOnError {
+							queueSummary(append(results, result))
+							return NewExitError(1)
+						}
+						results = append(results, result)
+						failedKeys[storyKey] = true
+						continue
 					}
-					fmt.Printf("Story %s completed successfully\n", storyKey)
+
+					// Fail the story if it left the working tree dirty
+					if abortOnUncommitted {
+						if err := checkCleanTree(ctx, app.Git, storyKey); err != nil {
+							cmd.SilenceUsage = true
+							app.printf("Error: %v\n", err)
+							finishStory(storyKey, outcomeFailed, err)
+							notifier.storyFailed(ctx, storyKey, err)
+							if !continueOnError {
+								queueSummary(append(results, result))
+								return NewExitError(1)
+							}
+							results = append(results, result)
+							failedKeys[storyKey] = true
+							continue
+						}
+					}
+					result.Success = true
+					finishStory(storyKey, outcomeSuccess, nil)
+					results = append(results, result)
+					app.printf("Story %s completed successfully\n", storyKey)
 				}
 
-				fmt.Printf("Epic %s completed (%d stories processed)\n\n", epicID, len(storyKeys))
+				app.printf("Epic %s completed (%d stories processed)\n\n", epicID, len(storyKeys))
 			}
 
-			fmt.Printf("✓ All %d epic(s) completed successfully!\n", len(epicIDs))
+			queueSummary(results)
+			if failed := failedStories(results); failed > 0 {
+				app.printf("✗ %d of %d stories failed\n", failed, len(results))
+				return NewExitError(1)
+			}
+			app.printf("✓ All %d epic(s) completed successfully!\n", len(epicIDs))
 
 			return nil
 		},
@@ -129,12 +354,46 @@ Examples:
 
 	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Preview workflows without executing them")
 	cmd.Flags().BoolVar(&autoRetry, "auto-retry", false, "Automatically retry on rate limit errors")
+	addRetriesFlag(cmd, &retries)
 	cmd.Flags().BoolVar(&noBmadHelp, "no-bmad-help", false, "Disable bmad-help fallback for unknown statuses")
+	cmd.Flags().BoolVar(&forceCreate, "force-create", false, "Run create-story even if the story file already exists")
+	cmd.Flags().BoolVar(&force, "force", false, "Allow status transitions that validate_transitions would reject")
+	cmd.Flags().BoolVar(&abortOnUncommitted, "abort-on-uncommitted-after", false, "Fail a story if uncommitted changes remain after it completes")
+	cmd.Flags().BoolVar(&continueOnError, "continue-on-error", false, "Record a failed story and continue with the next one, exiting 1 at the end")
+	cmd.Flags().BoolVar(&resume, "resume", false, "Continue each story from its checkpoint, skipping steps completed before a crash")
+	cmd.Flags().StringVar(&runManifest, runManifestFlag, "", "Record each step and the files and commits it produced to `path` (JSON)")
+	cmd.Flags().StringVar(&report, "report", "", "Write a JSON report of each story's status, steps, retries, usage, and cost to `path`")
+	cmd.Flags().BoolVar(&assumeYes, "assume-yes", false, "Proceed even if the run exceeds max_stories_per_run")
+	cmd.Flags().BoolVar(&printTransitions, "print-transitions", false, "Print the status transitions performed for each story")
+	cmd.Flags().IntVar(&parallel, "parallel", 1, "Run up to `N` stories at once")
+	addDefaultModelFlag(cmd, &model)
+	addFromStatusFlag(cmd, &fromStatus)
 
 	return cmd
 }
 
-func runEpicDryRun(cmd *cobra.Command, app *App, executor *lifecycle.Executor, epicIDs []string) error {
+// checkParallelFlags validates --parallel against the other epic flags.
+//
+// Parallel stories share one working tree, so the post-story clean-tree
+// check cannot attribute changes, and the run manifest and report are
+// written by single recorders; all three require sequential runs.
+func checkParallelFlags(app *App, parallel int, runManifest, report string, abortOnUncommitted bool) error {
+	if parallel < 1 {
+		return fmt.Errorf("--parallel must be at least 1, got %d", parallel)
+	}
+	if parallel == 1 {
+		return nil
+	}
+	if runManifest != "" || report != "" || abortOnUncommitted {
+		return fmt.Errorf("--parallel cannot be combined with --%s, --report, or --abort-on-uncommitted-after", runManifestFlag)
+	}
+	if app.NewRunner == nil {
+		return fmt.Errorf("--parallel is not supported by this runner")
+	}
+	return nil
+}
+
+func runEpicDryRun(cmd *cobra.Command, app *App, executor *lifecycle.Executor, epicIDs []string, fromStatus string) error {
 	printModuleInfo(app)
 
 	totalWorkflows := 0
@@ -146,46 +405,64 @@ func runEpicDryRun(cmd *cobra.Command, app *App, executor *lifecycle.Executor, e
 		storyKeys, err := app.StatusReader.GetEpicStories(epicID)
 		if err != nil {
 			cmd.SilenceUsage = true
-			fmt.Printf("Error reading stories for epic %s: %v\n", epicID, err)
+			app.printf("Error reading stories for epic %s: %v\n", epicID, err)
+			return NewExitError(1)
+		}
+		storyKeys, err = filterFromStatus(app, storyKeys, fromStatus)
+		if err != nil {
+			cmd.SilenceUsage = true
+			app.printf("Error: %v\n", err)
 			return NewExitError(1)
 		}
 
-		fmt.Printf("Epic %s:\n", epicID)
+		app.printf("Epic %s:\n", epicID)
 
 		for _, storyKey := range storyKeys {
-			fmt.Printf("  Story %s:\n", storyKey)
+			app.printf("  Story %s:\n", storyKey)
 
 			steps, err := executor.GetSteps(storyKey)
 			if err != nil {
 				if errors.Is(err, router.ErrStoryComplete) {
-					fmt.Printf("    (already complete)\n")
+					app.printf("    (already complete)\n")
 					storiesComplete++
 					continue
 				}
 				cmd.SilenceUsage = true
-				fmt.Printf("    Error: %v\n", err)
+				app.printf("    Error: %v\n", err)
 				return NewExitError(1)
 			}
 
 			for i, step := range steps {
 				modelInfo := ""
-				model := app.Config.GetModel(step.Workflow)
+				model := stepModel(app.Config, step)
 				if model != "" {
 					modelInfo = fmt.Sprintf(" (%s)", model)
 				}
-				fmt.Printf("    %d. %s%s → %s\n", i+1, step.Workflow, modelInfo, step.NextStatus)
+				app.printf("    %d. %s%s → %s\n", i+1, step.Workflow, modelInfo, step.NextStatus)
 			}
 			totalWorkflows += len(steps)
 			storiesWithWork++
 		}
-		fmt.Println()
+		app.println()
 	}
 
 	if storiesComplete > 0 {
-		fmt.Printf("Total: %d workflows across %d stories (%d already complete)\n", totalWorkflows, storiesWithWork, storiesComplete)
+		app.printf("Total: %d workflows across %d stories (%d already complete)\n", totalWorkflows, storiesWithWork, storiesComplete)
 	} else {
-		fmt.Printf("Total: %d workflows across %d stories\n", totalWorkflows, storiesWithWork)
+		app.printf("Total: %d workflows across %d stories\n", totalWorkflows, storiesWithWork)
 	}
 
 	return nil
 }
+
+// failedStories returns the number of results that failed, not counting
+// skipped and cancelled stories.
+func failedStories(results []core.StoryResult) int {
+	failed := 0
+	for _, result := range results {
+		if !result.Success && !result.Skipped && !result.Cancelled {
+			failed++
+		}
+	}
+	return failed
+}
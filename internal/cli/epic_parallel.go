@@ -0,0 +1,213 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"bmaduum/internal/lifecycle"
+	"bmaduum/internal/output/core"
+	"bmaduum/internal/router"
+	"bmaduum/internal/state"
+	"bmaduum/internal/status"
+)
+
+// parallelRun configures a run of story lifecycles on concurrent workers.
+type parallelRun struct {
+	// workers is the maximum number of stories run at once.
+	workers int
+
+	// newExecutor creates a configured lifecycle executor for a worker's app.
+	newExecutor func(app *App) *lifecycle.Executor
+
+	// dependsOn returns the stories a story must wait for.
+	dependsOn func(storyKey string) []string
+
+	// storyFailed, if set, is called when a story fails. It may be called
+	// from several workers at once.
+	storyFailed func(storyKey string, err error)
+
+	resume           bool
+	autoRetry        bool
+	maxRetries       int
+	printTransitions bool
+
+	// continueOnError keeps starting stories after a story fails.
+	continueOnError bool
+}
+
+// lockedStatusWriter serializes status updates from concurrent workers, so
+// any [StatusWriter] implementation can be shared between them.
+type lockedStatusWriter struct {
+	mu     sync.Mutex
+	writer StatusWriter
+}
+
+// UpdateStatus writes the status while holding the lock.
+func (w *lockedStatusWriter) UpdateStatus(storyKey string, newStatus status.Status) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.writer.UpdateStatus(storyKey, newStatus)
+}
+
+// runStoriesParallel runs the lifecycles of storyKeys on up to run.workers
+// concurrent workers.
+//
+// Each worker gets its own runner from [App.NewRunner], with streaming output
+// suppressed, and its own executor; status updates are serialized and all
+// workers share one checkpoint store and one session store. storyKeys must already be ordered by
+// dependency; a story is not started until the stories it depends on have
+// finished. Once a story fails no further stories are started, unless
+// run.continueOnError is set, but stories already running are allowed to
+// finish; with run.continueOnError, a story whose dependency failed is not
+// run and has a result with [core.StoryResult.BlockedBy] set. Cancellation
+// always stops the run.
+//
+// Returns the results of the stories that ran, in story order, the usage
+// accumulated across all workers, and an error describing the first failed
+// story in story order, if any.
+func runStoriesParallel(ctx context.Context, app *App, run parallelRun, storyKeys []string) ([]core.StoryResult, core.Usage, error) {
+	writer := &lockedStatusWriter{writer: app.StatusWriter}
+	checkpoints := state.NewCheckpointStore(filepath.Dir(app.StatusReader.Path()))
+	sessions := state.NewSessionStore(filepath.Dir(app.StatusReader.Path()))
+
+	var outputMu sync.Mutex
+	printf := func(format string, args ...any) {
+		outputMu.Lock()
+		defer outputMu.Unlock()
+		app.printf(format, args...)
+	}
+
+	position := make(map[string]int, len(storyKeys))
+	finished := make([]chan struct{}, len(storyKeys))
+	for i, storyKey := range storyKeys {
+		position[storyKey] = i
+		finished[i] = make(chan struct{})
+	}
+
+	results := make([]*core.StoryResult, len(storyKeys))
+	errs := make([]error, len(storyKeys))
+	var stop atomic.Bool
+
+	jobs := make(chan int)
+	workerApps := make([]*App, run.workers)
+	var wg sync.WaitGroup
+	for w := range workerApps {
+		workerApp := *app
+		workerApp.Runner = app.NewRunner()
+		workerApp.StatusWriter = writer
+		if runner, ok := workerApp.Runner.(QuietRunner); ok {
+			runner.SetQuiet(true)
+		}
+		workerApps[w] = &workerApp
+
+		executor := run.newExecutor(&workerApp)
+		executor.SetCheckpointer(checkpoints)
+		executor.SetSessionRecorder(sessions)
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				storyKey := storyKeys[i]
+				printf("Story %s started\n", storyKey)
+
+				storyStart := time.Now()
+				costBefore := runCost(&workerApp)
+				err := executeWithRetry(ctx, executor, storyKey, run.resume, run.autoRetry, run.maxRetries, nil)
+				result := core.StoryResult{
+					Key:      storyKey,
+					Duration: time.Since(storyStart),
+					CostUSD:  runCost(&workerApp) - costBefore,
+				}
+				if run.printTransitions {
+					if trace := lifecycle.FormatTransitions(executor.Transitions()); trace != "" {
+						printf("Transitions for %s: %s\n", storyKey, trace)
+					}
+				}
+
+				switch {
+				case errors.Is(err, router.ErrStoryComplete):
+					result.Skipped = true
+					printf("Story %s is already complete, skipping\n", storyKey)
+				case errors.Is(err, context.Canceled):
+					result.Cancelled = true
+					errs[i] = err
+					stop.Store(true)
+					printf("Story %s cancelled\n", storyKey)
+				case err != nil:
+					errs[i] = err
+					if !run.continueOnError {
+						stop.Store(true)
+					}
+					printf("Error running lifecycle for story %s: %v\n", storyKey, err)
+					if run.storyFailed != nil {
+						run.storyFailed(storyKey, err)
+					}
+				default:
+					result.Success = true
+					printf("Story %s completed successfully\n", storyKey)
+				}
+				results[i] = &result
+				close(finished[i])
+			}
+		}()
+	}
+
+	// Hold each story until its dependencies finish, and stop handing out
+	// stories after the first failure or cancellation. Under
+	// run.continueOnError, a story whose dependency failed is not run.
+	failed := make(map[string]bool)
+	for i, storyKey := range storyKeys {
+		if run.dependsOn != nil {
+			for _, dep := range run.dependsOn(storyKey) {
+				if j, ok := position[dep]; ok && j < i {
+					<-finished[j]
+					if result := results[j]; !result.Success && !result.Skipped {
+						failed[dep] = true
+					}
+				}
+			}
+		}
+		if stop.Load() {
+			break
+		}
+		if dep, ok := failedDependency(run.dependsOn, storyKey, failed); ok {
+			errs[i] = dependencyFailedError(dep)
+			results[i] = &core.StoryResult{Key: storyKey, BlockedBy: dep}
+			close(finished[i])
+			printf("Story %s not run: %v\n", storyKey, errs[i])
+			if run.storyFailed != nil {
+				run.storyFailed(storyKey, errs[i])
+			}
+			continue
+		}
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	var usage core.Usage
+	for _, workerApp := range workerApps {
+		if reporter, ok := workerApp.Runner.(UsageReporter); ok {
+			usage = usage.Add(reporter.TotalUsage())
+		}
+	}
+
+	ordered := make([]core.StoryResult, 0, len(storyKeys))
+	var firstErr error
+	for i, result := range results {
+		if result == nil {
+			continue
+		}
+		ordered = append(ordered, *result)
+		if errs[i] != nil && firstErr == nil {
+			firstErr = fmt.Errorf("story %s: %w", storyKeys[i], errs[i])
+		}
+	}
+	return ordered, usage, firstErr
+}
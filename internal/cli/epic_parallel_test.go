@@ -0,0 +1,220 @@
+package cli
+
+import (
+	"bytes"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"bmaduum/internal/config"
+	"bmaduum/internal/output"
+	"bmaduum/internal/status"
+)
+
+func TestEpicCommand_Parallel(t *testing.T) {
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, `development_status:
+  6-1-first: done
+  6-2-second: review
+  6-3-third: ready-for-dev
+  6-4-fourth: review`)
+
+	var mu sync.Mutex
+	var runners []*MockWorkflowRunner
+	printerOut := &bytes.Buffer{}
+	writer := &MockStatusWriter{}
+	app := &App{
+		Config:       config.DefaultConfig(),
+		StatusReader: status.NewReader(tmpDir),
+		StatusWriter: writer,
+		Runner:       &MockWorkflowRunner{},
+		Printer:      output.NewPrinterWithWriter(printerOut),
+		NewRunner: func() WorkflowRunner {
+			mu.Lock()
+			defer mu.Unlock()
+			runner := &MockWorkflowRunner{}
+			runners = append(runners, runner)
+			return runner
+		},
+	}
+
+	rootCmd := NewRootCommand(app)
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetErr(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{"epic", "--parallel", "2", "6"})
+
+	require.NoError(t, rootCmd.Execute())
+
+	assert.Len(t, runners, 2)
+	workflows := 0
+	for _, runner := range runners {
+		workflows += len(runner.ExecutedWorkflows)
+	}
+	assert.Equal(t, 7, workflows)
+
+	// Every story reaches done, whichever worker ran it
+	final := make(map[string]status.Status)
+	for _, update := range writer.Updates {
+		final[update.StoryKey] = update.NewStatus
+	}
+	assert.Equal(t, map[string]status.Status{
+		"6-2-second": status.StatusDone,
+		"6-3-third":  status.StatusDone,
+		"6-4-fourth": status.StatusDone,
+	}, final)
+
+	assert.Contains(t, printerOut.String(), "Completed: 3 | Skipped: 1 | Failed: 0 | Remaining: 0")
+}
+
+func TestEpicCommand_ParallelContinueOnError(t *testing.T) {
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, `development_status:
+  6-1-first: ready-for-dev
+  6-2-second: review
+  6-3-third: review`)
+
+	printerOut := &bytes.Buffer{}
+	app := &App{
+		Config:       config.DefaultConfig(),
+		StatusReader: status.NewReader(tmpDir),
+		StatusWriter: &MockStatusWriter{},
+		Runner:       &MockWorkflowRunner{},
+		Printer:      output.NewPrinterWithWriter(printerOut),
+		NewRunner: func() WorkflowRunner {
+			return &MockWorkflowRunner{FailOnWorkflow: "dev-story"}
+		},
+	}
+
+	rootCmd := NewRootCommand(app)
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetErr(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{"epic", "--parallel", "2", "--continue-on-error", "6"})
+
+	err := rootCmd.Execute()
+	code, ok := IsExitError(err)
+	require.True(t, ok, "expected an exit error, got %v", err)
+	assert.Equal(t, 1, code)
+	assert.Contains(t, printerOut.String(), "Completed: 2 | Skipped: 0 | Failed: 1 | Remaining: 0")
+}
+
+func TestEpicCommand_ParallelFailedDependency(t *testing.T) {
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, `development_status:
+  6-1-first: ready-for-dev
+  6-2-second: review
+  6-3-third: review`)
+
+	var mu sync.Mutex
+	var ran []string
+	printerOut := &bytes.Buffer{}
+	app := &App{
+		Config:       config.DefaultConfig(),
+		StatusReader: status.NewReader(tmpDir),
+		StatusWriter: &MockStatusWriter{},
+		Runner:       &MockWorkflowRunner{},
+		Printer:      output.NewPrinterWithWriter(printerOut),
+		StoryOverrides: &config.StoryOverrides{Stories: map[string]config.StoryOverride{
+			"6-2-second": {DependsOn: []string{"6-1-first"}},
+		}},
+		NewRunner: func() WorkflowRunner {
+			return &MockWorkflowRunner{FailOnWorkflow: "dev-story", OnRun: func(workflowName, storyKey string) {
+				mu.Lock()
+				defer mu.Unlock()
+				ran = append(ran, storyKey)
+			}}
+		},
+	}
+
+	rootCmd := NewRootCommand(app)
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetErr(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{"epic", "--parallel", "2", "--continue-on-error", "6"})
+
+	err := rootCmd.Execute()
+	code, ok := IsExitError(err)
+	require.True(t, ok, "expected an exit error, got %v", err)
+	assert.Equal(t, 1, code)
+	assert.NotContains(t, ran, "6-2-second")
+	assert.Contains(t, ran, "6-3-third")
+	assert.Contains(t, printerOut.String(), "Completed: 1 | Skipped: 0 | Failed: 2 | Remaining: 0")
+	assert.Contains(t, printerOut.String(), "(blocked by 6-1-first)")
+}
+
+func TestCheckParallelFlags(t *testing.T) {
+	newRunner := func() WorkflowRunner { return &MockWorkflowRunner{} }
+
+	tests := []struct {
+		name               string
+		parallel           int
+		runManifest        string
+		report             string
+		abortOnUncommitted bool
+		newRunner          func() WorkflowRunner
+		wantErr            string
+	}{
+		{name: "sequential", parallel: 1},
+		{name: "sequential without runner factory", parallel: 1, runManifest: "run.json", abortOnUncommitted: true},
+		{name: "parallel", parallel: 4, newRunner: newRunner},
+		{name: "zero", parallel: 0, wantErr: "--parallel must be at least 1"},
+		{name: "with run manifest", parallel: 2, runManifest: "run.json", newRunner: newRunner, wantErr: "cannot be combined"},
+		{name: "with report", parallel: 2, report: "report.json", newRunner: newRunner, wantErr: "cannot be combined"},
+		{name: "with clean tree check", parallel: 2, abortOnUncommitted: true, newRunner: newRunner, wantErr: "cannot be combined"},
+		{name: "without runner factory", parallel: 2, wantErr: "not supported"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := &App{NewRunner: tt.newRunner}
+			err := checkParallelFlags(app, tt.parallel, tt.runManifest, tt.report, tt.abortOnUncommitted)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestEpicCommand_ParallelWaitsForDependencies(t *testing.T) {
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, `development_status:
+  6-1-first: review
+  6-2-second: review
+  6-3-third: review`)
+
+	var mu sync.Mutex
+	var finished []string
+	app := &App{
+		Config:       config.DefaultConfig(),
+		StatusReader: status.NewReader(tmpDir),
+		StatusWriter: &MockStatusWriter{},
+		Runner:       &MockWorkflowRunner{},
+		Printer:      output.NewPrinterWithWriter(&bytes.Buffer{}),
+		StoryOverrides: &config.StoryOverrides{Stories: map[string]config.StoryOverride{
+			"6-1-first": {DependsOn: []string{"6-3-third"}},
+		}},
+		NewRunner: func() WorkflowRunner {
+			return &MockWorkflowRunner{OnRun: func(workflowName, storyKey string) {
+				if workflowName == "git-commit" {
+					mu.Lock()
+					defer mu.Unlock()
+					finished = append(finished, storyKey)
+				}
+			}}
+		},
+	}
+
+	rootCmd := NewRootCommand(app)
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetErr(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{"epic", "--parallel", "3", "6"})
+
+	require.NoError(t, rootCmd.Execute())
+
+	require.Len(t, finished, 3)
+	assert.Less(t, slices.Index(finished, "6-3-third"), slices.Index(finished, "6-1-first"))
+}
@@ -2,6 +2,10 @@ package cli
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -456,3 +460,375 @@ func TestEpicCommand_All(t *testing.T) {
 // Note: Legacy tests removed - obsolete after lifecycle executor change.
 // The epic command now executes full lifecycle (multiple workflows per story), not single workflow routing.
 // See TestEpicCommand_FullLifecycleExecution for comprehensive lifecycle testing.
+
+// TestEpicCommand_MaxStoriesPerRun tests the max_stories_per_run safety guard
+func TestEpicCommand_MaxStoriesPerRun(t *testing.T) {
+	statusYAML := `development_status:
+  6-1-first: review
+  6-2-second: review
+  6-3-third: review`
+
+	tests := []struct {
+		name              string
+		limit             int
+		extraArgs         []string
+		expectError       bool
+		expectedWorkflows []string
+	}{
+		{
+			name:        "expansion over limit aborts without assume-yes",
+			limit:       2,
+			expectError: true,
+		},
+		{
+			name:              "expansion over limit proceeds with assume-yes",
+			limit:             2,
+			extraArgs:         []string{"--assume-yes"},
+			expectedWorkflows: []string{"code-review", "git-commit", "code-review", "git-commit", "code-review", "git-commit"},
+		},
+		{
+			name:              "expansion under limit proceeds",
+			limit:             3,
+			expectedWorkflows: []string{"code-review", "git-commit", "code-review", "git-commit", "code-review", "git-commit"},
+		},
+		{
+			name:              "zero limit disables the guard",
+			limit:             0,
+			expectedWorkflows: []string{"code-review", "git-commit", "code-review", "git-commit", "code-review", "git-commit"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			createSprintStatusFile(t, tmpDir, statusYAML)
+
+			cfg := config.DefaultConfig()
+			cfg.MaxStoriesPerRun = tt.limit
+			mockRunner := &MockWorkflowRunner{}
+
+			app := &App{
+				Config:       cfg,
+				StatusReader: status.NewReader(tmpDir),
+				StatusWriter: &MockStatusWriter{},
+				Runner:       mockRunner,
+				Printer:      output.NewPrinterWithWriter(&bytes.Buffer{}),
+			}
+
+			rootCmd := NewRootCommand(app)
+			outBuf := &bytes.Buffer{}
+			rootCmd.SetOut(outBuf)
+			rootCmd.SetErr(outBuf)
+			rootCmd.SetArgs(append([]string{"epic", "6"}, tt.extraArgs...))
+
+			err := rootCmd.Execute()
+
+			if tt.expectError {
+				require.Error(t, err)
+				code, ok := IsExitError(err)
+				assert.True(t, ok)
+				assert.Equal(t, 1, code)
+				assert.Empty(t, mockRunner.ExecutedWorkflows, "no workflows should run when the guard aborts")
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedWorkflows, mockRunner.ExecutedWorkflows)
+			}
+		})
+	}
+}
+
+// TestEpicCommand_Summary tests that the epic-level summary counts completed, skipped, and failed stories
+func TestEpicCommand_Summary(t *testing.T) {
+	tests := []struct {
+		name           string
+		failOnWorkflow string
+		expectError    bool
+		expected       []string
+	}{
+		{
+			name:     "all stories complete",
+			expected: []string{"QUEUE COMPLETE", "Completed: 2 | Skipped: 1 | Failed: 0 | Remaining: 0"},
+		},
+		{
+			name:           "failure stops the epic",
+			failOnWorkflow: "dev-story",
+			expectError:    true,
+			expected:       []string{"QUEUE FINISHED WITH FAILURES", "Completed: 1 | Skipped: 1 | Failed: 1 | Remaining: 0"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			createSprintStatusFile(t, tmpDir, `development_status:
+  6-1-first: done
+  6-2-second: review
+  6-3-third: ready-for-dev`)
+
+			printerOut := &bytes.Buffer{}
+			app := &App{
+				Config:       config.DefaultConfig(),
+				StatusReader: status.NewReader(tmpDir),
+				StatusWriter: &MockStatusWriter{},
+				Runner:       &MockWorkflowRunner{FailOnWorkflow: tt.failOnWorkflow},
+				Printer:      output.NewPrinterWithWriter(printerOut),
+			}
+
+			rootCmd := NewRootCommand(app)
+			rootCmd.SetOut(&bytes.Buffer{})
+			rootCmd.SetErr(&bytes.Buffer{})
+			rootCmd.SetArgs([]string{"epic", "6"})
+
+			err := rootCmd.Execute()
+			if tt.expectError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			for _, want := range tt.expected {
+				assert.Contains(t, printerOut.String(), want)
+			}
+		})
+	}
+}
+
+func TestEpicCommand_ContinueOnError(t *testing.T) {
+	tests := []struct {
+		name              string
+		args              []string
+		expectedWorkflows []string
+		expected          []string
+	}{
+		{
+			name:              "failure stops the epic",
+			args:              []string{"epic", "6"},
+			expectedWorkflows: []string{"dev-story"},
+			expected:          []string{"QUEUE STOPPED", "Completed: 0 | Skipped: 0 | Failed: 1 | Remaining: 2"},
+		},
+		{
+			name:              "continue on error runs every story",
+			args:              []string{"epic", "--continue-on-error", "6"},
+			expectedWorkflows: []string{"dev-story", "code-review", "git-commit"},
+			expected:          []string{"QUEUE FINISHED WITH FAILURES", "Completed: 1 | Skipped: 1 | Failed: 1 | Remaining: 0", "(failed)"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			createSprintStatusFile(t, tmpDir, `development_status:
+  6-1-first: ready-for-dev
+  6-2-second: review
+  6-3-third: done`)
+
+			printerOut := &bytes.Buffer{}
+			mockRunner := &MockWorkflowRunner{FailOnWorkflow: "dev-story"}
+			app := &App{
+				Config:       config.DefaultConfig(),
+				StatusReader: status.NewReader(tmpDir),
+				StatusWriter: &MockStatusWriter{},
+				Runner:       mockRunner,
+				Printer:      output.NewPrinterWithWriter(printerOut),
+			}
+
+			rootCmd := NewRootCommand(app)
+			rootCmd.SetOut(&bytes.Buffer{})
+			rootCmd.SetErr(&bytes.Buffer{})
+			rootCmd.SetArgs(tt.args)
+
+			err := rootCmd.Execute()
+			code, ok := IsExitError(err)
+			require.True(t, ok, "expected an exit error, got %v", err)
+			assert.Equal(t, 1, code)
+			assert.Equal(t, tt.expectedWorkflows, mockRunner.ExecutedWorkflows)
+			for _, want := range tt.expected {
+				assert.Contains(t, printerOut.String(), want)
+			}
+		})
+	}
+}
+
+func TestEpicCommand_FromStatus(t *testing.T) {
+	tests := []struct {
+		name              string
+		fromStatus        string
+		expectError       bool
+		expectedWorkflows []string
+	}{
+		{
+			name:              "skips stories before review and done stories",
+			fromStatus:        "review",
+			expectedWorkflows: []string{"code-review", "git-commit"},
+		},
+		{
+			name:              "in-progress includes ready-for-dev",
+			fromStatus:        "in-progress",
+			expectedWorkflows: []string{"code-review", "git-commit", "dev-story", "code-review", "git-commit"},
+		},
+		{
+			name:        "unknown status is rejected",
+			fromStatus:  "reviewed",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			createSprintStatusFile(t, tmpDir, `development_status:
+  6-1-first: done
+  6-2-second: review
+  6-3-third: ready-for-dev
+  6-4-fourth: backlog`)
+
+			mockRunner := &MockWorkflowRunner{}
+			app := &App{
+				Config:       config.DefaultConfig(),
+				StatusReader: status.NewReader(tmpDir),
+				StatusWriter: &MockStatusWriter{},
+				Runner:       mockRunner,
+				Printer:      output.NewPrinterWithWriter(&bytes.Buffer{}),
+			}
+
+			rootCmd := NewRootCommand(app)
+			rootCmd.SetOut(&bytes.Buffer{})
+			rootCmd.SetErr(&bytes.Buffer{})
+			rootCmd.SetArgs([]string{"epic", "--from-status", tt.fromStatus, "6"})
+
+			err := rootCmd.Execute()
+
+			if tt.expectError {
+				require.Error(t, err)
+				assert.Empty(t, mockRunner.ExecutedWorkflows)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedWorkflows, mockRunner.ExecutedWorkflows)
+		})
+	}
+}
+
+// TestEpicCommand_Cancelled tests that an interrupted story is reported as cancelled
+func TestEpicCommand_Cancelled(t *testing.T) {
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, `development_status:
+  6-1-first: review
+  6-2-second: review
+  6-3-third: review`)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	mockWriter := &MockStatusWriter{}
+	printerOut := &bytes.Buffer{}
+	app := &App{
+		Config:       config.DefaultConfig(),
+		StatusReader: status.NewReader(tmpDir),
+		StatusWriter: mockWriter,
+		Runner: &MockWorkflowRunner{OnRun: func(workflowName, storyKey string) {
+			// Ctrl-C while the second story is in code review
+			if storyKey == "6-2-second" {
+				cancel()
+			}
+		}},
+		Printer: output.NewPrinterWithWriter(printerOut),
+	}
+
+	rootCmd := NewRootCommand(app)
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetErr(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{"epic", "6"})
+
+	err := rootCmd.ExecuteContext(ctx)
+
+	require.Error(t, err)
+	for _, update := range mockWriter.Updates {
+		assert.NotEqual(t, "6-2-second", update.StoryKey, "cancelled story must not advance")
+	}
+	assert.Contains(t, printerOut.String(), "QUEUE CANCELLED")
+	assert.Contains(t, printerOut.String(), "Completed: 1 | Skipped: 0 | Cancelled: 1 | Remaining: 1")
+}
+
+// TestEpicCommand_ContinueOnErrorFailedDependency tests that a story whose
+// dependency failed is not run, and is reported as blocked, under
+// --continue-on-error
+func TestEpicCommand_ContinueOnErrorFailedDependency(t *testing.T) {
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, `development_status:
+  6-1-first: ready-for-dev
+  6-2-second: review
+  6-3-third: review
+  6-4-fourth: review`)
+
+	printerOut := &bytes.Buffer{}
+	mockRunner := &MockWorkflowRunner{FailOnWorkflow: "dev-story"}
+	app := &App{
+		Config:       config.DefaultConfig(),
+		StatusReader: status.NewReader(tmpDir),
+		StatusWriter: &MockStatusWriter{},
+		Runner:       mockRunner,
+		Printer:      output.NewPrinterWithWriter(printerOut),
+		StoryOverrides: &config.StoryOverrides{Stories: map[string]config.StoryOverride{
+			"6-2-second": {DependsOn: []string{"6-1-first"}},
+			"6-3-third":  {DependsOn: []string{"6-2-second"}},
+		}},
+	}
+
+	rootCmd := NewRootCommand(app)
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetErr(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{"epic", "--continue-on-error", "6"})
+
+	err := rootCmd.Execute()
+	code, ok := IsExitError(err)
+	require.True(t, ok, "expected an exit error, got %v", err)
+	assert.Equal(t, 1, code)
+	assert.Equal(t, []string{"dev-story", "code-review", "git-commit"}, mockRunner.ExecutedWorkflows,
+		"only the independent story runs after the failure")
+	assert.Contains(t, printerOut.String(), "Completed: 1 | Skipped: 0 | Failed: 3 | Remaining: 0")
+	assert.Contains(t, printerOut.String(), "(blocked by 6-1-first)")
+	assert.Contains(t, printerOut.String(), "(blocked by 6-2-second)", "blocking is transitive")
+}
+
+// TestEpicCommand_Report tests that --report writes each story's status, steps, and retries to a file
+func TestEpicCommand_Report(t *testing.T) {
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, `development_status:
+  6-1-first: review
+  6-2-second: review`)
+
+	app := &App{
+		Config:       config.DefaultConfig(),
+		StatusReader: status.NewReader(tmpDir),
+		StatusWriter: status.NewWriter(tmpDir),
+		Runner:       &MockWorkflowRunner{FailOnWorkflow: "git-commit"},
+		Printer:      output.NewPrinterWithWriter(&bytes.Buffer{}),
+	}
+
+	reportPath := filepath.Join(tmpDir, "report.json")
+	rootCmd := NewRootCommand(app)
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetErr(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{"epic", "--retries", "1", "--report", reportPath, "6"})
+	require.Error(t, rootCmd.Execute())
+
+	data, err := os.ReadFile(reportPath)
+	require.NoError(t, err)
+	var report runSummary
+	require.NoError(t, json.Unmarshal(data, &report))
+
+	assert.Equal(t, outcomeFailed, report.Outcome)
+	assert.False(t, report.StartedAt.IsZero())
+	require.Len(t, report.Stories, 1)
+	story := report.Stories[0]
+	assert.Equal(t, "6-1-first", story.StoryKey)
+	assert.Equal(t, outcomeFailed, story.Outcome)
+	assert.Equal(t, "done", story.FinalStatus, "code-review moved the story to done")
+	assert.Equal(t, 1, story.Retries)
+	require.Len(t, story.Steps, 3)
+	assert.Equal(t, "code-review", story.Steps[0].Workflow)
+	assert.Equal(t, 1, story.Steps[0].Attempt)
+	for i, step := range story.Steps[1:] {
+		assert.Equal(t, "git-commit", step.Workflow)
+		assert.Equal(t, i+1, step.Attempt)
+		assert.Equal(t, 1, step.ExitCode)
+	}
+}
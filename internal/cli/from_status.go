@@ -0,0 +1,89 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"bmaduum/internal/lifecycle"
+	"bmaduum/internal/router"
+	"bmaduum/internal/status"
+)
+
+// addFromStatusFlag registers the --from-status flag on a lifecycle command,
+// which skips stories whose status comes before the given one.
+func addFromStatusFlag(cmd *cobra.Command, fromStatus *string) {
+	cmd.Flags().StringVar(fromStatus, "from-status", "", "Skip stories whose status comes before `status` in the lifecycle, and done stories")
+	_ = cmd.RegisterFlagCompletionFunc("from-status", completeStatuses)
+}
+
+// filterFromStatus returns the stories of storyKeys that have reached
+// fromStatus, in order. An empty fromStatus keeps every story.
+//
+// Statuses are ordered by their position in the router's lifecycle chain, so
+// custom manifests order them too. Done stories are always dropped, and
+// stories with a status the router does not recognize are kept so the run
+// reports them. A line is printed for each dropped story.
+func filterFromStatus(app *App, storyKeys []string, fromStatus string) ([]string, error) {
+	if fromStatus == "" {
+		return storyKeys, nil
+	}
+
+	wfRouter := lifecycleRouter(app)
+	from := status.Status(fromStatus)
+	fromIdx, ok := wfRouter.ChainIndex(from)
+	if !ok {
+		return nil, fmt.Errorf("--from-status %q is not a status in the lifecycle", fromStatus)
+	}
+
+	kept := make([]string, 0, len(storyKeys))
+	for _, storyKey := range storyKeys {
+		current, err := app.StatusReader.GetStoryStatus(storyKey)
+		if err != nil {
+			return nil, err
+		}
+		if current == status.StatusDone {
+			app.printf("Story %s is already complete, skipping\n", storyKey)
+			continue
+		}
+		if idx, ok := wfRouter.ChainIndex(current); ok && idx < fromIdx {
+			app.printf("Story %s is %s, before %s, skipping\n", storyKey, current, from)
+			continue
+		}
+		kept = append(kept, storyKey)
+	}
+	return kept, nil
+}
+
+// lifecycleRouter returns the app's router, or the default router if none is
+// configured.
+func lifecycleRouter(app *App) *router.Router {
+	if app.Router != nil {
+		return app.Router
+	}
+	return router.NewRouter()
+}
+
+// addStartStatusFlag registers the --start-status flag on a lifecycle
+// command, which starts each story's lifecycle from the given status.
+func addStartStatusFlag(cmd *cobra.Command, startStatus *string) {
+	cmd.Flags().StringVar(startStatus, "start-status", "", "Start each story's lifecycle from `status` instead of its status in sprint-status.yaml")
+	_ = cmd.RegisterFlagCompletionFunc("start-status", completeStatuses)
+}
+
+// applyStartStatus sets the --start-status override on executor. An empty
+// startStatus leaves the executor reading each story's status.
+//
+// Returns an error if startStatus is not a status in the router's lifecycle
+// chain.
+func applyStartStatus(app *App, executor *lifecycle.Executor, startStatus string) error {
+	if startStatus == "" {
+		return nil
+	}
+	start := status.Status(startStatus)
+	if _, ok := lifecycleRouter(app).ChainIndex(start); !ok {
+		return fmt.Errorf("--start-status %q is not a status in the lifecycle", startStatus)
+	}
+	executor.SetStartStatus(start)
+	return nil
+}
@@ -0,0 +1,45 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"bmaduum/internal/config"
+)
+
+// checkStoryLimit enforces the max_stories_per_run safety guard.
+//
+// Returns an error if count exceeds the configured limit and assumeYes is
+// false. A limit of zero (or a nil config) disables the guard.
+func checkStoryLimit(cfg *config.Config, count int, assumeYes bool) error {
+	if cfg == nil || cfg.MaxStoriesPerRun <= 0 || assumeYes {
+		return nil
+	}
+	if count > cfg.MaxStoriesPerRun {
+		return fmt.Errorf("run expands to %d stories, exceeding max_stories_per_run (%d); pass --assume-yes to confirm",
+			count, cfg.MaxStoriesPerRun)
+	}
+	return nil
+}
+
+// checkCleanTree fails a completed story whose run left uncommitted changes.
+//
+// Returns an error listing the uncommitted files if the working tree is
+// dirty, or if the check itself cannot run.
+func checkCleanTree(ctx context.Context, gitHelper GitHelper, storyKey string) error {
+	if gitHelper == nil {
+		return fmt.Errorf("cannot check for uncommitted changes after story %s: no git helper configured", storyKey)
+	}
+
+	files, err := gitHelper.UncommittedFiles(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot check for uncommitted changes after story %s: %w", storyKey, err)
+	}
+	if len(files) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("story %s left %d uncommitted file(s):\n  %s",
+		storyKey, len(files), strings.Join(files, "\n  "))
+}
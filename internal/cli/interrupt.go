@@ -0,0 +1,23 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// interruptContext returns a context that is canceled on the first
+// interrupt (Ctrl-C) or SIGTERM. Cancellation kills the running Claude
+// process and stops the lifecycle before its next status update.
+//
+// Later signals get their default behavior again, so a second Ctrl-C
+// terminates bmaduum at once. Call stop to release the signal handler.
+func interruptContext() (ctx context.Context, stop context.CancelFunc) {
+	ctx, stop = signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+	return ctx, stop
+}
@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"bmaduum/internal/router"
+	"bmaduum/internal/status"
+)
+
+func newLintCommand(app *App) *cobra.Command {
+	return &cobra.Command{
+		Use:   "lint",
+		Short: "Check sprint-status.yaml for statuses the lifecycle cannot route",
+		Long: `Read the whole sprint-status.yaml and report every story whose status the
+lifecycle router does not know, such as a typo like "in-progres" or a custom
+status like "pending-qa". Running such a story fails with an unknown status
+error, so lint before a long epic run to catch them all at once.
+
+The statuses are checked against the router in use, so a workflow manifest
+(see --manifest) can add or remove known statuses. Epic and retrospective
+entries are not checked.
+
+Exits non-zero if any unknown status is found.
+
+Examples:
+  bmaduum lint
+  bmaduum --manifest custom-manifest.csv lint`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := cmd.OutOrStdout()
+
+			sprintStatus, err := app.StatusReader.Read()
+			if err != nil {
+				cmd.SilenceUsage = true
+				fmt.Fprintf(out, "Error: %v\n", err)
+				return NewExitError(1)
+			}
+
+			wfRouter := app.Router
+			if wfRouter == nil {
+				wfRouter = router.NewRouter()
+			}
+			return reportProblems(cmd, out, app.StatusReader.Path(), lintStatuses(sprintStatus, wfRouter))
+		},
+	}
+}
+
+// lintStatuses returns a problem for every story in sprintStatus whose
+// status wfRouter cannot route, sorted by story key. Epic and retrospective
+// entries (keys starting with "epic-") are skipped.
+func lintStatuses(sprintStatus *status.SprintStatus, wfRouter *router.Router) []error {
+	keys := make([]string, 0, len(sprintStatus.DevelopmentStatus))
+	for key := range sprintStatus.DevelopmentStatus {
+		if !strings.HasPrefix(key, "epic-") {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var problems []error
+	for _, key := range keys {
+		s := sprintStatus.DevelopmentStatus[key]
+		if _, err := wfRouter.GetWorkflow(s); errors.Is(err, router.ErrUnknownStatus) {
+			if parsed, err := status.ParseStatus(string(s)); err == nil && parsed != s {
+				problems = append(problems, fmt.Errorf("%s: unknown status %q (did you mean %s? set normalize_statuses to accept it)", key, s, parsed))
+				continue
+			}
+			problems = append(problems, fmt.Errorf("%s: unknown status %q", key, s))
+		}
+	}
+	return problems
+}
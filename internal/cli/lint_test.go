@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"bmaduum/internal/config"
+	"bmaduum/internal/manifest"
+	"bmaduum/internal/router"
+	"bmaduum/internal/status"
+)
+
+func runLintCommand(t *testing.T, app *App) (string, error) {
+	t.Helper()
+
+	rootCmd := NewRootCommand(app)
+	outBuf := &bytes.Buffer{}
+	rootCmd.SetOut(outBuf)
+	rootCmd.SetErr(outBuf)
+	rootCmd.SetArgs([]string{"lint"})
+
+	err := rootCmd.Execute()
+	return outBuf.String(), err
+}
+
+func TestLintCommand_ReportsUnknownStatuses(t *testing.T) {
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, `development_status:
+  epic-7: contexted
+  7-1-define-schema: done
+  7-2-create-api: pending-qa
+  7-3-build-ui: backlog
+  7-4-add-docs: in-progres
+  epic-7-retrospective: optional`)
+	app := &App{Config: config.DefaultConfig(), StatusReader: status.NewReader(tmpDir)}
+
+	out, err := runLintCommand(t, app)
+
+	code, ok := IsExitError(err)
+	require.True(t, ok)
+	assert.Equal(t, 1, code)
+	path := filepath.Join(tmpDir, status.V6StatusPath)
+	assert.Contains(t, out, path+` has 2 problem(s):
+  - 7-2-create-api: unknown status "pending-qa"
+  - 7-4-add-docs: unknown status "in-progres"
+`)
+}
+
+func TestLintCommand_Valid(t *testing.T) {
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, `development_status:
+  7-1-define-schema: done
+  7-2-create-api: review`)
+	app := &App{Config: config.DefaultConfig(), StatusReader: status.NewReader(tmpDir)}
+
+	out, err := runLintCommand(t, app)
+
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(tmpDir, status.V6StatusPath)+" is valid\n", out)
+}
+
+func TestLintStatuses_UsesRouter(t *testing.T) {
+	m, err := manifest.ReadFromString(`workflow,trigger_status,next_status
+dev-story,ready-for-dev,done
+`)
+	require.NoError(t, err)
+	sprintStatus := &status.SprintStatus{DevelopmentStatus: map[string]status.Status{
+		"7-1-a": "ready-for-dev",
+		"7-2-b": "review",
+		"7-3-c": "done",
+	}}
+
+	problems := lintStatuses(sprintStatus, router.NewRouterFromManifest(m))
+	require.Len(t, problems, 1)
+	assert.EqualError(t, problems[0], `7-2-b: unknown status "review"`)
+
+	assert.Empty(t, lintStatuses(sprintStatus, router.NewRouter()))
+}
+
+func TestLintStatuses_SuggestsNormalizedStatus(t *testing.T) {
+	sprintStatus := &status.SprintStatus{DevelopmentStatus: map[string]status.Status{
+		"7-1-a": "Ready-For-Dev",
+		"7-2-b": "pending-qa",
+	}}
+
+	problems := lintStatuses(sprintStatus, router.NewRouter())
+	require.Len(t, problems, 2)
+	assert.EqualError(t, problems[0], `7-1-a: unknown status "Ready-For-Dev" (did you mean ready-for-dev? set normalize_statuses to accept it)`)
+	assert.EqualError(t, problems[1], `7-2-b: unknown status "pending-qa"`)
+}
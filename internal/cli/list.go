@@ -0,0 +1,50 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"bmaduum/internal/status"
+)
+
+func newListCommand(app *App) *cobra.Command {
+	var statusFilter string
+
+	cmd := &cobra.Command{
+		Use:   "list --status <status>",
+		Short: "List the story keys with a given status",
+		Long: `List the keys of every story in sprint-status.yaml with the given status,
+one per line, for scripting.
+
+Stories are sorted by epic and then by story number, the order epic runs
+them in. Epic and retrospective entries are never listed. Nothing is
+printed if no story has the status.
+
+Examples:
+  bmaduum list --status review
+  bmaduum story $(bmaduum list --status review)`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := cmd.OutOrStdout()
+
+			storyKeys, err := app.StatusReader.GetStoriesByStatus(status.Status(statusFilter))
+			if err != nil {
+				cmd.SilenceUsage = true
+				fmt.Fprintf(out, "Error: %v\n", err)
+				return NewExitError(1)
+			}
+
+			for _, key := range storyKeys {
+				fmt.Fprintln(out, key)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&statusFilter, "status", "", "List stories with this `status`")
+	_ = cmd.MarkFlagRequired("status")
+	_ = cmd.RegisterFlagCompletionFunc("status", completeStatuses)
+
+	return cmd
+}
@@ -0,0 +1,56 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"bmaduum/internal/manifest"
+	"bmaduum/internal/router"
+)
+
+func newListModulesCommand(app *App) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list-modules",
+		Short: "Show detected BMAD modules and the steps they inject",
+		Long: `Show the BMAD modules read from _bmad/_config/manifest.yaml (or the
+path in BMADUUM_MODULE_MANIFEST_PATH).
+
+Each module is listed with its version and path. Modules that add steps to
+the story lifecycle, either declared under "injects" in the manifest or
+built in (sdet and tea inject test-automation after code-review), are
+annotated with the injected step.
+
+Examples:
+  bmaduum list-modules`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := cmd.OutOrStdout()
+			path := manifest.ResolveModulePath("")
+
+			if app.Modules == nil {
+				fmt.Fprintf(out, "No module manifest found at %s\n", path)
+				return nil
+			}
+
+			injected := make(map[string][]router.ModuleStep)
+			for _, step := range router.ModuleStepsFor(app.Modules) {
+				injected[step.Module] = append(injected[step.Module], step)
+			}
+
+			fmt.Fprintf(out, "Modules (%s):\n", path)
+			for _, m := range app.Modules.Modules {
+				fmt.Fprintf(out, "  %-12s %-10s %s\n", m.Name, m.Version, m.Path)
+				for _, step := range injected[m.Name] {
+					if step.Before != "" {
+						fmt.Fprintf(out, "    ↳ injects %s before %s\n", step.Workflow, step.Before)
+					} else {
+						fmt.Fprintf(out, "    ↳ injects %s after %s\n", step.Workflow, step.After)
+					}
+				}
+			}
+
+			return nil
+		},
+	}
+}
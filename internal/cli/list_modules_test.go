@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"bmaduum/internal/config"
+	"bmaduum/internal/manifest"
+)
+
+func TestListModulesCommand(t *testing.T) {
+	modules, err := manifest.ReadModulesFromBytes([]byte(`modules:
+  - name: bmm
+    version: "6.0.0"
+    path: bmm
+  - name: sdet
+    version: "1.0.0"
+    path: modules/sdet
+`))
+	require.NoError(t, err)
+
+	app := &App{Config: config.DefaultConfig(), Modules: modules}
+
+	rootCmd := NewRootCommand(app)
+	out := &bytes.Buffer{}
+	rootCmd.SetOut(out)
+	rootCmd.SetErr(out)
+	rootCmd.SetArgs([]string{"list-modules"})
+
+	require.NoError(t, rootCmd.Execute())
+	assert.Contains(t, out.String(), "Modules (_bmad/_config/manifest.yaml):")
+	assert.Contains(t, out.String(), "bmm          6.0.0      bmm\n")
+	assert.Contains(t, out.String(), "sdet         1.0.0      modules/sdet\n    ↳ injects test-automation after code-review\n")
+	assert.Equal(t, 1, bytes.Count(out.Bytes(), []byte("injects")), "only sdet injects a step")
+}
+
+func TestListModulesCommand_NoManifest(t *testing.T) {
+	app := &App{Config: config.DefaultConfig()}
+
+	rootCmd := NewRootCommand(app)
+	out := &bytes.Buffer{}
+	rootCmd.SetOut(out)
+	rootCmd.SetErr(out)
+	rootCmd.SetArgs([]string{"list-modules"})
+
+	require.NoError(t, rootCmd.Execute())
+	assert.Equal(t, "No module manifest found at _bmad/_config/manifest.yaml\n", out.String())
+}
+
+func TestListModulesCommand_DeclaredInjects(t *testing.T) {
+	modules, err := manifest.ReadModulesFromBytes([]byte(`modules:
+  - name: security
+    version: "1.0.0"
+    path: modules/security
+    injects:
+      - workflow: security-review
+        before: git-commit
+`))
+	require.NoError(t, err)
+
+	app := &App{Config: config.DefaultConfig(), Modules: modules}
+
+	rootCmd := NewRootCommand(app)
+	out := &bytes.Buffer{}
+	rootCmd.SetOut(out)
+	rootCmd.SetErr(out)
+	rootCmd.SetArgs([]string{"list-modules"})
+
+	require.NoError(t, rootCmd.Execute())
+	assert.Contains(t, out.String(), "security     1.0.0      modules/security\n    ↳ injects security-review before git-commit\n")
+}
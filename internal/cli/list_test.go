@@ -0,0 +1,59 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"bmaduum/internal/config"
+	"bmaduum/internal/status"
+)
+
+func runListCommand(t *testing.T, tmpDir string, args ...string) (string, error) {
+	t.Helper()
+
+	app := &App{Config: config.DefaultConfig(), StatusReader: status.NewReader(tmpDir)}
+
+	rootCmd := NewRootCommand(app)
+	outBuf := &bytes.Buffer{}
+	rootCmd.SetOut(outBuf)
+	rootCmd.SetErr(outBuf)
+	rootCmd.SetArgs(append([]string{"list"}, args...))
+
+	err := rootCmd.Execute()
+	return outBuf.String(), err
+}
+
+func TestListCommand_ByStatus(t *testing.T) {
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, `development_status:
+  epic-7: in-progress
+  7-10-last: review
+  7-2-create-api: review
+  7-3-build-ui: in-progress
+  8-1-next-epic: review`)
+
+	out, err := runListCommand(t, tmpDir, "--status", "review")
+
+	require.NoError(t, err)
+	assert.Equal(t, "7-2-create-api\n7-10-last\n8-1-next-epic\n", out)
+}
+
+func TestListCommand_NoMatches(t *testing.T) {
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, `development_status:
+  7-1-define-schema: done`)
+
+	out, err := runListCommand(t, tmpDir, "--status", "review")
+
+	require.NoError(t, err)
+	assert.Empty(t, out)
+}
+
+func TestListCommand_RequiresStatus(t *testing.T) {
+	_, err := runListCommand(t, t.TempDir())
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"bmaduum/internal/output"
+	"bmaduum/internal/runlog"
+)
+
+// TranscriptRunner is implemented by runners that can record workflow
+// headers in a run transcript, such as [workflow.Runner].
+type TranscriptRunner interface {
+	SetTranscript(t *runlog.Transcript)
+}
+
+// StreamRecorder is implemented by executors that can copy Claude's raw
+// output stream to a writer, such as [claude.DefaultExecutor].
+type StreamRecorder interface {
+	AddRecorder(w io.Writer)
+}
+
+// transcriptStreamPrefix marks the lines of Claude's raw stream-json output
+// in the transcript.
+const transcriptStreamPrefix = "[claude] "
+
+// setupLogFile applies the global --log-file flag to app.
+//
+// When path is set, the transcript file is opened for appending and app's
+// printer, and the printers of its runners, are replaced with one that
+// writes to both the command's stdout and the transcript. Notices printed
+// with [App.printf] and Claude's raw stream, if app's executor is a
+// [StreamRecorder], are recorded as well. The transcript is closed by
+// [App.finishOutput].
+func setupLogFile(cmd *cobra.Command, app *App, path string) error {
+	if path == "" {
+		return nil
+	}
+
+	transcript, err := runlog.OpenTranscript(path)
+	if err != nil {
+		return fmt.Errorf("--log-file: %w", err)
+	}
+	app.transcript = transcript
+	if recorder, ok := app.Executor.(StreamRecorder); ok {
+		recorder.AddRecorder(transcript.Stream(transcriptStreamPrefix))
+	}
+
+	encoding := output.ResolveEncoding(app.Config.Output.Encoding)
+	printer := output.NewPrinterWithEncoding(io.MultiWriter(cmd.OutOrStdout(), transcript), encoding, markdownConfig(app.Config.Output.Markdown))
+	app.Printer = printer
+	transcriptRunner(app.Runner, printer, transcript)
+	if newRunner := app.NewRunner; newRunner != nil {
+		app.NewRunner = func() WorkflowRunner {
+			return transcriptRunner(newRunner(), printer, transcript)
+		}
+	}
+	return nil
+}
+
+// transcriptRunner points runner's output at printer and its workflow
+// headers at transcript, if it supports them.
+func transcriptRunner(runner WorkflowRunner, printer *output.DefaultPrinter, transcript *runlog.Transcript) WorkflowRunner {
+	if r, ok := runner.(PrinterRunner); ok {
+		r.SetPrinter(printer)
+	}
+	if r, ok := runner.(TranscriptRunner); ok {
+		r.SetTranscript(transcript)
+	}
+	return runner
+}
+
+// stdout returns where the commands print their plain-text notices, such as
+// skipped stories and retries: os.Stdout, teed into the --log-file
+// transcript if one is open.
+func (app *App) stdout() io.Writer {
+	if app.transcript == nil {
+		return os.Stdout
+	}
+	return io.MultiWriter(os.Stdout, app.transcript)
+}
+
+// printf prints a plain-text notice to [App.stdout].
+func (app *App) printf(format string, args ...any) {
+	fmt.Fprintf(app.stdout(), format, args...)
+}
+
+// println prints a plain-text notice line to [App.stdout].
+func (app *App) println(args ...any) {
+	fmt.Fprintln(app.stdout(), args...)
+}
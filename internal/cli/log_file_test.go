@@ -0,0 +1,183 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"bmaduum/internal/claude"
+	"bmaduum/internal/config"
+	"bmaduum/internal/output"
+	"bmaduum/internal/status"
+	"bmaduum/internal/workflow"
+)
+
+func TestLogFile_WritesTranscript(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "logs", "run.log")
+	cfg := config.DefaultConfig()
+	executor := &claude.MockExecutor{
+		Events: []claude.Event{
+			{Type: claude.EventTypeAssistant, Text: "Implementing the story"},
+			{Type: claude.EventTypeResult, SessionComplete: true},
+		},
+	}
+	var terminal bytes.Buffer
+	runner := workflow.NewRunner(executor, output.NewPrinterWithWriter(&terminal), cfg)
+	runner.SetProgressWriter(&bytes.Buffer{})
+
+	app := &App{
+		Config:  cfg,
+		Runner:  runner,
+		Printer: output.NewPrinterWithWriter(&terminal),
+	}
+
+	var stdout bytes.Buffer
+	rootCmd := NewRootCommand(app)
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetErr(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{"--log-file=" + logPath, "workflow", "dev-story", "7-1-log"})
+
+	result := executeRoot(rootCmd)
+	app.finishOutput(result.ExitCode)
+	require.NoError(t, result.Err)
+
+	assert.Contains(t, stdout.String(), "Implementing the story", "output is still shown")
+
+	data, err := os.ReadFile(logPath)
+	require.NoError(t, err)
+	log := string(data)
+	assert.Regexp(t, `(?m)^\S+ === workflow=dev-story story=7-1-log started=\S+ ===$`, log)
+	assert.Regexp(t, `(?m)^\S+ .*Implementing the story`, log)
+	assert.Regexp(t, `(?m)^\S+ === exit_code=0 ===$`, log)
+	assert.NotContains(t, log, "\x1b[")
+}
+
+func TestLogFile_RecordsNotices(t *testing.T) {
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, `development_status:
+  7-1-first: ready-for-dev
+  7-2-second: review`)
+	logPath := filepath.Join(tmpDir, "run.log")
+
+	app := &App{
+		Config:       config.DefaultConfig(),
+		StatusReader: status.NewReader(tmpDir),
+		StatusWriter: &MockStatusWriter{},
+		Runner:       &MockWorkflowRunner{FailOnWorkflow: "dev-story"},
+		Printer:      output.NewPrinterWithWriter(&bytes.Buffer{}),
+		StoryOverrides: &config.StoryOverrides{Stories: map[string]config.StoryOverride{
+			"7-2-second": {SkipSteps: []string{"code-review"}},
+		}},
+	}
+
+	// Notices still go to stdout as well
+	stdout := os.Stdout
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	require.NoError(t, err)
+	os.Stdout = devNull
+	t.Cleanup(func() {
+		os.Stdout = stdout
+		devNull.Close()
+	})
+
+	rootCmd := NewRootCommand(app)
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetErr(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{"--log-file=" + logPath, "story", "--continue-on-error", "7-1-first", "7-2-second"})
+
+	result := executeRoot(rootCmd)
+	app.finishOutput(result.ExitCode)
+
+	data, err := os.ReadFile(logPath)
+	require.NoError(t, err)
+	log := string(data)
+	assert.Regexp(t, `(?m)^\S+ Error running lifecycle for story 7-1-first: `, log)
+	assert.Regexp(t, `(?m)^\S+ Skipping code-review for story 7-2-second \(story override\)$`, log, "lifecycle notices are recorded")
+	assert.Regexp(t, `(?m)^\S+ Story 7-2-second completed successfully$`, log)
+}
+
+// streamRecordingExecutor is a MockExecutor that accepts stream recorders.
+type streamRecordingExecutor struct {
+	claude.MockExecutor
+	recorders []io.Writer
+}
+
+func (e *streamRecordingExecutor) AddRecorder(w io.Writer) {
+	e.recorders = append(e.recorders, w)
+}
+
+func TestLogFile_RecordsClaudeStream(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "run.log")
+	executor := &streamRecordingExecutor{}
+	app := &App{
+		Config:   config.DefaultConfig(),
+		Executor: executor,
+		Runner:   &MockWorkflowRunner{},
+		Printer:  output.NewPrinterWithWriter(&bytes.Buffer{}),
+	}
+
+	rootCmd := NewRootCommand(app)
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetErr(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{"--log-file=" + logPath, "version"})
+	require.NoError(t, rootCmd.Execute())
+
+	require.Len(t, executor.recorders, 1)
+	fmt.Fprint(executor.recorders[0], "{\"type\":\"system\",\"subtype\":\"init\"}\n")
+	app.finishOutput(0)
+
+	data, err := os.ReadFile(logPath)
+	require.NoError(t, err)
+	assert.Regexp(t, `(?m)^\S+ \[claude\] \{"type":"system","subtype":"init"\}$`, string(data))
+}
+
+func TestLogFile_DefaultPath(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+
+	app := &App{
+		Config:  config.DefaultConfig(),
+		Runner:  &MockWorkflowRunner{},
+		Printer: output.NewPrinterWithWriter(&bytes.Buffer{}),
+	}
+
+	rootCmd := NewRootCommand(app)
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetErr(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{"--log-file", "version"})
+
+	result := executeRoot(rootCmd)
+	app.finishOutput(result.ExitCode)
+	require.NoError(t, result.Err)
+
+	data, err := os.ReadFile(filepath.Join(dir, "_bmad-output", "bmaduum-run.log"))
+	require.NoError(t, err)
+	assert.Regexp(t, `(?m)^\S+ === exit_code=0 ===$`, string(data))
+}
+
+func TestLogFile_Unwritable(t *testing.T) {
+	dir := t.TempDir()
+	blocker := filepath.Join(dir, "file")
+	require.NoError(t, os.WriteFile(blocker, nil, 0644))
+
+	app := &App{
+		Config:  config.DefaultConfig(),
+		Runner:  &MockWorkflowRunner{},
+		Printer: output.NewPrinterWithWriter(&bytes.Buffer{}),
+	}
+
+	rootCmd := NewRootCommand(app)
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetErr(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{"--log-file=" + filepath.Join(blocker, "run.log"), "version"})
+
+	err := rootCmd.Execute()
+	require.Error(t, err)
+	assert.Nil(t, app.transcript)
+}
@@ -0,0 +1,50 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"bmaduum/internal/status"
+)
+
+func newMigrateStatusCommand() *cobra.Command {
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "migrate-status",
+		Short: "Move a legacy sprint-status.yaml to the v6 location",
+		Long: `Move the legacy root-level sprint-status.yaml into the BMAD v6 location
+(_bmad-output/implementation-artifacts/sprint-status.yaml), creating the
+directory structure as needed.
+
+The command refuses to overwrite an existing v6 status file.
+Use --dry-run to preview the move without changing anything.
+
+Examples:
+  bmaduum migrate-status --dry-run
+  bmaduum migrate-status`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := cmd.OutOrStdout()
+
+			from, to, err := status.MigrateLegacyStatus("", dryRun)
+			if err != nil {
+				cmd.SilenceUsage = true
+				fmt.Fprintf(out, "Error: %v\n", err)
+				return NewExitError(1)
+			}
+
+			if dryRun {
+				fmt.Fprintf(out, "Would move %s → %s\n", from, to)
+				return nil
+			}
+			fmt.Fprintf(out, "Moved %s → %s\n", from, to)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Preview the move without changing anything")
+
+	return cmd
+}
@@ -0,0 +1,37 @@
+package cli
+
+import (
+	"github.com/spf13/cobra"
+
+	"bmaduum/internal/claude"
+)
+
+// addModelFlag registers the --model flag on a workflow subcommand.
+func addModelFlag(cmd *cobra.Command, model *string) {
+	cmd.Flags().StringVar(model, "model", "", "Claude model for this run (e.g. opus), overriding the workflow's configured model")
+}
+
+// addDefaultModelFlag registers the --model flag on a lifecycle command,
+// which applies to every step whose workflow has no model configured.
+func addDefaultModelFlag(cmd *cobra.Command, model *string) {
+	cmd.Flags().StringVar(model, "model", "", "Claude model for steps whose workflow has no model configured (overrides claude.model)")
+}
+
+// applyDefaultModel makes model the default for workflows without a model
+// of their own for the rest of the invocation. An empty model changes
+// nothing.
+func applyDefaultModel(app *App, model string) {
+	if model == "" {
+		return
+	}
+	warnUnknownModel(app, model)
+	app.Config.Claude.Model = model
+}
+
+// warnUnknownModel prints a warning if model is not a model Claude CLI is
+// known to accept. The model is still passed to Claude CLI as is.
+func warnUnknownModel(app *App, model string) {
+	if !claude.IsKnownModel(model) {
+		app.printf("Warning: unknown model %q; passing it to Claude CLI as is\n", model)
+	}
+}
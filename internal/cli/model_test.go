@@ -0,0 +1,68 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"bmaduum/internal/claude"
+	"bmaduum/internal/config"
+	"bmaduum/internal/output"
+	"bmaduum/internal/status"
+	"bmaduum/internal/workflow"
+)
+
+// setupModelTestApp returns an app whose runner records the model of each
+// Claude run, with git-commit configured to use haiku.
+func setupModelTestApp(t *testing.T, statusYAML string) (*App, *claude.MockExecutor) {
+	t.Helper()
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, statusYAML)
+
+	cfg := config.DefaultConfig()
+	wf := cfg.Workflows["git-commit"]
+	wf.Model = "haiku"
+	cfg.Workflows["git-commit"] = wf
+
+	executor := &claude.MockExecutor{}
+	printer := output.NewPrinterWithWriter(&bytes.Buffer{})
+	return &App{
+		Config:       cfg,
+		Executor:     executor,
+		Printer:      printer,
+		Runner:       workflow.NewRunner(executor, printer, cfg),
+		StatusReader: status.NewReader(tmpDir),
+		StatusWriter: &MockStatusWriter{},
+	}, executor
+}
+
+func runModelTestCommand(t *testing.T, app *App, args ...string) {
+	t.Helper()
+	rootCmd := NewRootCommand(app)
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetErr(&bytes.Buffer{})
+	rootCmd.SetArgs(args)
+	require.NoError(t, rootCmd.Execute())
+}
+
+func TestWorkflowCommand_ModelFlag(t *testing.T) {
+	app, executor := setupModelTestApp(t, `development_status:
+  7-1-model: review`)
+
+	runModelTestCommand(t, app, "workflow", "git-commit", "7-1-model", "--model", "sonnet")
+	runModelTestCommand(t, app, "workflow", "git-commit", "7-1-model")
+
+	assert.Equal(t, []string{"sonnet", "haiku"}, executor.RecordedModels)
+}
+
+func TestStoryCommand_ModelFlag(t *testing.T) {
+	app, executor := setupModelTestApp(t, `development_status:
+  7-2-model: review`)
+
+	runModelTestCommand(t, app, "story", "7-2-model", "--model", "opus")
+
+	// git-commit keeps its configured model
+	assert.Equal(t, []string{"opus", "haiku"}, executor.RecordedModels)
+}
@@ -0,0 +1,205 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"bmaduum/internal/notify"
+	"bmaduum/internal/output/core"
+)
+
+// runNotifier implements [lifecycle.StepObserver], sending lifecycle events
+// to the notifiers configured under notifications: a step_finished event
+// after every step, a story_failed event with [runNotifier.storyFailed],
+// and a run_finished event with [runNotifier.runFinished].
+//
+// Delivery failures are printed as warnings and never fail the run. Steps
+// of different stories may run concurrently under epic --parallel, so step
+// state is kept per story.
+type runNotifier struct {
+	notifiers []notify.Notifier
+	reader    StatusReader
+	start     time.Time
+
+	mu    sync.Mutex
+	steps map[string]notifyStep
+	// failedSteps holds the step_finished event of each story's last step,
+	// if it failed, so story_failed can say where the story failed.
+	failedSteps map[string]notify.Event
+}
+
+// notifyStep is the state of a story's running step.
+type notifyStep struct {
+	start      time.Time
+	fromStatus string
+}
+
+// newRunNotifier creates a notifier for a run starting now, or returns nil
+// if no notifications are configured.
+func newRunNotifier(app *App) *runNotifier {
+	if app.Config == nil {
+		return nil
+	}
+	var notifiers []notify.Notifier
+	if url := app.Config.Notifications.WebhookURL; url != "" {
+		notifiers = append(notifiers, notify.NewWebhook(url))
+	}
+	if url := app.Config.Notifications.SlackWebhook; url != "" {
+		notifiers = append(notifiers, notify.NewSlack(url))
+	}
+	if url := app.Config.Notifications.DiscordWebhook; url != "" {
+		notifiers = append(notifiers, notify.NewDiscord(url))
+	}
+	if len(notifiers) == 0 {
+		return nil
+	}
+	return &runNotifier{
+		notifiers:   notifiers,
+		reader:      app.StatusReader,
+		start:       time.Now(),
+		steps:       make(map[string]notifyStep),
+		failedSteps: make(map[string]notify.Event),
+	}
+}
+
+// StepStarted notes the start time and the story's status before the step.
+func (n *runNotifier) StepStarted(ctx context.Context, storyKey, workflow string) {
+	step := notifyStep{start: time.Now()}
+	if s, err := n.reader.GetStoryStatus(storyKey); err == nil {
+		step.fromStatus = string(s)
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.steps[storyKey] = step
+}
+
+// StepFinished sends a step_finished event with the story's status
+// transition.
+func (n *runNotifier) StepFinished(ctx context.Context, storyKey, workflow string, exitCode int) {
+	n.mu.Lock()
+	step := n.steps[storyKey]
+	delete(n.steps, storyKey)
+	n.mu.Unlock()
+
+	event := notify.Event{
+		Type:       notify.EventStepFinished,
+		StoryKey:   storyKey,
+		Workflow:   workflow,
+		FromStatus: step.fromStatus,
+		ExitCode:   exitCode,
+		Success:    exitCode == 0,
+		DurationMS: time.Since(step.start).Milliseconds(),
+	}
+	if s, err := n.reader.GetStoryStatus(storyKey); err == nil {
+		event.ToStatus = string(s)
+	}
+
+	n.mu.Lock()
+	if exitCode != 0 {
+		n.failedSteps[storyKey] = event
+	} else {
+		delete(n.failedSteps, storyKey)
+	}
+	n.mu.Unlock()
+
+	n.send(ctx, event)
+}
+
+// storyFailed sends a story_failed event naming the step the story failed
+// in, if it failed in one. It does nothing on a nil notifier, so callers
+// need not check whether notifications are configured.
+func (n *runNotifier) storyFailed(ctx context.Context, storyKey string, err error) {
+	if n == nil {
+		return
+	}
+	n.mu.Lock()
+	step, ok := n.failedSteps[storyKey]
+	delete(n.failedSteps, storyKey)
+	n.mu.Unlock()
+
+	event := notify.Event{Type: notify.EventStoryFailed, StoryKey: storyKey}
+	if ok {
+		event.Workflow = step.Workflow
+		event.FromStatus = step.FromStatus
+		event.ToStatus = step.ToStatus
+		event.ExitCode = step.ExitCode
+	}
+	if err != nil {
+		event.Error = err.Error()
+	}
+	n.send(ctx, event)
+}
+
+// runFinished sends a run_finished event with the outcome of each story
+// that ran and the stories left unrun. It does nothing on a nil notifier.
+func (n *runNotifier) runFinished(ctx context.Context, stories []notify.StoryOutcome, remaining []string) {
+	if n == nil {
+		return
+	}
+	event := notify.Event{
+		Type:       notify.EventRunFinished,
+		Success:    true,
+		DurationMS: time.Since(n.start).Milliseconds(),
+		Stories:    stories,
+		Remaining:  remaining,
+	}
+	for _, story := range stories {
+		if story.Outcome != outcomeSuccess && story.Outcome != outcomeSkipped {
+			event.Success = false
+		}
+	}
+	n.send(ctx, event)
+}
+
+// send delivers event to every notifier, printing a warning for each that
+// fails.
+func (n *runNotifier) send(ctx context.Context, event notify.Event) {
+	for _, notifier := range n.notifiers {
+		if err := notifier.Send(ctx, event); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: notification: %v\n", err)
+		}
+	}
+}
+
+// storyOutcomes converts the results of a queue run to run_finished story
+// outcomes.
+func storyOutcomes(results []core.StoryResult) []notify.StoryOutcome {
+	outcomes := make([]notify.StoryOutcome, len(results))
+	for i, result := range results {
+		outcome := notify.StoryOutcome{StoryKey: result.Key, Outcome: outcomeSuccess}
+		switch {
+		case result.Skipped:
+			outcome.Outcome = outcomeSkipped
+		case result.Cancelled:
+			outcome.Outcome = outcomeCancelled
+		case !result.Success:
+			outcome.Outcome = outcomeFailed
+			if result.BlockedBy != "" {
+				outcome.Error = dependencyFailedError(result.BlockedBy).Error()
+			} else if result.FailedAt != "" {
+				outcome.Error = "failed at " + result.FailedAt
+			}
+		}
+		outcomes[i] = outcome
+	}
+	return outcomes
+}
+
+// remainingStories returns the keys of storyKeys, in order, that have no
+// outcome.
+func remainingStories(storyKeys []string, outcomes []notify.StoryOutcome) []string {
+	ran := make(map[string]bool, len(outcomes))
+	for _, outcome := range outcomes {
+		ran[outcome.StoryKey] = true
+	}
+	var remaining []string
+	for _, storyKey := range storyKeys {
+		if !ran[storyKey] {
+			remaining = append(remaining, storyKey)
+		}
+	}
+	return remaining
+}
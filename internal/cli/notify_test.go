@@ -0,0 +1,201 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"bmaduum/internal/config"
+	"bmaduum/internal/notify"
+	"bmaduum/internal/output"
+	"bmaduum/internal/status"
+)
+
+// webhookServer starts a server that records the events posted to it.
+func webhookServer(t *testing.T) (*httptest.Server, func() []notify.Event) {
+	t.Helper()
+	var mu sync.Mutex
+	var events []notify.Event
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event notify.Event
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&event))
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, event)
+	}))
+	t.Cleanup(server.Close)
+	return server, func() []notify.Event {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]notify.Event(nil), events...)
+	}
+}
+
+func TestEpicCommand_Webhook(t *testing.T) {
+	server, events := webhookServer(t)
+
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, `development_status:
+  6-1-first: done
+  6-2-second: review`)
+
+	cfg := config.DefaultConfig()
+	cfg.Notifications.WebhookURL = server.URL
+	app := &App{
+		Config:       cfg,
+		StatusReader: status.NewReader(tmpDir),
+		StatusWriter: &MockStatusWriter{},
+		Runner:       &MockWorkflowRunner{},
+		Printer:      output.NewPrinterWithWriter(&bytes.Buffer{}),
+	}
+
+	rootCmd := NewRootCommand(app)
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetErr(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{"epic", "6"})
+	require.NoError(t, rootCmd.Execute())
+
+	got := events()
+	require.Len(t, got, 3)
+	for i, workflow := range []string{"code-review", "git-commit"} {
+		assert.Equal(t, notify.SchemaVersion, got[i].SchemaVersion)
+		assert.Equal(t, notify.EventStepFinished, got[i].Type)
+		assert.Equal(t, "6-2-second", got[i].StoryKey)
+		assert.Equal(t, workflow, got[i].Workflow)
+		assert.Equal(t, "review", got[i].FromStatus)
+		assert.True(t, got[i].Success)
+	}
+
+	run := got[2]
+	assert.Equal(t, notify.EventRunFinished, run.Type)
+	assert.True(t, run.Success)
+	assert.Equal(t, []notify.StoryOutcome{
+		{StoryKey: "6-1-first", Outcome: "skipped"},
+		{StoryKey: "6-2-second", Outcome: "success"},
+	}, run.Stories)
+}
+
+func TestStoryCommand_Webhook(t *testing.T) {
+	server, events := webhookServer(t)
+
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, `development_status:
+  6-1-first: review`)
+
+	cfg := config.DefaultConfig()
+	cfg.Notifications.WebhookURL = server.URL
+	app := &App{
+		Config:       cfg,
+		StatusReader: status.NewReader(tmpDir),
+		StatusWriter: &MockStatusWriter{},
+		Runner:       &MockWorkflowRunner{FailOnWorkflow: "git-commit"},
+		Printer:      output.NewPrinterWithWriter(&bytes.Buffer{}),
+	}
+
+	rootCmd := NewRootCommand(app)
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetErr(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{"story", "6-1-first"})
+	err := rootCmd.Execute()
+	code, ok := IsExitError(err)
+	require.True(t, ok)
+	assert.Equal(t, 1, code)
+
+	got := events()
+	require.Len(t, got, 4)
+	assert.True(t, got[0].Success)
+	assert.Equal(t, "git-commit", got[1].Workflow)
+	assert.False(t, got[1].Success)
+	assert.Equal(t, 1, got[1].ExitCode)
+
+	failed := got[2]
+	assert.Equal(t, notify.EventStoryFailed, failed.Type)
+	assert.Equal(t, "6-1-first", failed.StoryKey)
+	assert.Equal(t, "git-commit", failed.Workflow)
+	assert.Equal(t, 1, failed.ExitCode)
+	assert.NotEmpty(t, failed.Error)
+
+	run := got[3]
+	assert.Equal(t, notify.EventRunFinished, run.Type)
+	assert.False(t, run.Success)
+	require.Len(t, run.Stories, 1)
+	assert.Equal(t, "failed", run.Stories[0].Outcome)
+	assert.NotEmpty(t, run.Stories[0].Error)
+}
+
+func TestStoryCommand_NotificationFailureDoesNotFailRun(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, `development_status:
+  6-1-first: review`)
+
+	cfg := config.DefaultConfig()
+	cfg.Notifications.WebhookURL = server.URL
+	runner := &MockWorkflowRunner{}
+	app := &App{
+		Config:       cfg,
+		StatusReader: status.NewReader(tmpDir),
+		StatusWriter: &MockStatusWriter{},
+		Runner:       runner,
+		Printer:      output.NewPrinterWithWriter(&bytes.Buffer{}),
+	}
+
+	rootCmd := NewRootCommand(app)
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetErr(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{"story", "6-1-first"})
+	require.NoError(t, rootCmd.Execute())
+	assert.Equal(t, []string{"code-review", "git-commit"}, runner.ExecutedWorkflows)
+}
+
+func TestEpicCommand_Slack(t *testing.T) {
+	var mu sync.Mutex
+	var messages []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		mu.Lock()
+		defer mu.Unlock()
+		messages = append(messages, body["text"])
+	}))
+	defer server.Close()
+
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, `development_status:
+  6-1-first: review
+  6-2-second: in-progress
+  6-3-third: review`)
+
+	cfg := config.DefaultConfig()
+	cfg.Notifications.SlackWebhook = server.URL
+	app := &App{
+		Config:       cfg,
+		StatusReader: status.NewReader(tmpDir),
+		StatusWriter: &MockStatusWriter{},
+		Runner:       &MockWorkflowRunner{FailOnWorkflow: "dev-story"},
+		Printer:      output.NewPrinterWithWriter(&bytes.Buffer{}),
+	}
+
+	rootCmd := NewRootCommand(app)
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetErr(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{"epic", "6"})
+	_, ok := IsExitError(rootCmd.Execute())
+	require.True(t, ok)
+
+	require.Len(t, messages, 2)
+	assert.Contains(t, messages[0], "story 6-2-second failed at dev-story (exit code 1)")
+	assert.Contains(t, messages[1], "bmaduum run failed after")
+	assert.Contains(t, messages[1], "1 completed, 1 failed, 1 remaining")
+	assert.Contains(t, messages[1], "• 6-2-second failed")
+}
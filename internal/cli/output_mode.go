@@ -0,0 +1,146 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"bmaduum/internal/config"
+	"bmaduum/internal/output"
+	"bmaduum/internal/output/core"
+	"bmaduum/internal/status"
+)
+
+// Output modes accepted by the global --output flag.
+const (
+	outputModeText = "text"
+	outputModeJSON = "json"
+)
+
+// PrinterRunner is implemented by runners whose output can be redirected,
+// such as [workflow.Runner]. --output json uses it to send the runner's
+// events to a [output.JSONPrinter] and hide the progress line.
+type PrinterRunner interface {
+	SetPrinter(printer core.Printer)
+	SetProgressWriter(w io.Writer)
+}
+
+// transitionStatusWriter emits a transition event for every successful
+// status update, recording the status the story moved from.
+type transitionStatusWriter struct {
+	writer  StatusWriter
+	reader  StatusReader
+	printer *output.JSONPrinter
+}
+
+// UpdateStatus reads the current status, writes the new one, and emits the
+// transition.
+func (w *transitionStatusWriter) UpdateStatus(storyKey string, newStatus status.Status) error {
+	var from status.Status
+	if w.reader != nil {
+		from, _ = w.reader.GetStoryStatus(storyKey)
+	}
+	if err := w.writer.UpdateStatus(storyKey, newStatus); err != nil {
+		return err
+	}
+	w.printer.Transition(storyKey, string(from), string(newStatus))
+	return nil
+}
+
+// markdownConfig converts the output.markdown settings to the printer's
+// markdown configuration.
+func markdownConfig(cfg config.MarkdownConfig) output.MarkdownConfig {
+	return output.MarkdownConfig{
+		Enabled:  cfg.Enabled,
+		Style:    cfg.Style,
+		WordWrap: cfg.WordWrap,
+		Emoji:    cfg.Emoji,
+	}
+}
+
+// setupNoMarkdown applies the global --no-markdown flag to app: Claude's
+// text is printed as plain text by app's printer, which its runners share,
+// and by any printer later built from app's config.
+func setupNoMarkdown(app *App) {
+	if app.Config != nil {
+		app.Config.Output.Markdown.Enabled = false
+	}
+	if p, ok := app.Printer.(*output.DefaultPrinter); ok {
+		p.DisableMarkdown()
+	}
+}
+
+// setupNoColor applies the global --no-color flag: output is printed
+// without color escape codes for the rest of the process, and markdown,
+// which is rendered with colors, is turned off as with --no-markdown.
+func setupNoColor(app *App) {
+	output.DisableColor()
+	setupNoMarkdown(app)
+}
+
+// setupOutputMode applies the global --output flag to app.
+//
+// In json mode, all printer output from app and its runners becomes JSON
+// Lines on the command's stdout, status updates emit transition events, and
+// plain-text output printed to os.Stdout is moved to stderr until
+// [App.finishOutput] is called.
+func setupOutputMode(cmd *cobra.Command, app *App, mode string) error {
+	switch mode {
+	case outputModeText:
+		return nil
+	case outputModeJSON:
+	default:
+		return fmt.Errorf("invalid --output %q: must be %s or %s", mode, outputModeText, outputModeJSON)
+	}
+
+	var out io.Writer = cmd.OutOrStdout()
+	if app.transcript != nil {
+		out = io.MultiWriter(out, app.transcript)
+	}
+	printer := output.NewJSONPrinter(out)
+	app.Printer = printer
+	app.jsonPrinter = printer
+	jsonRunner(app.Runner, printer)
+	if newRunner := app.NewRunner; newRunner != nil {
+		app.NewRunner = func() WorkflowRunner {
+			return jsonRunner(newRunner(), printer)
+		}
+	}
+	if app.StatusWriter != nil {
+		app.StatusWriter = &transitionStatusWriter{writer: app.StatusWriter, reader: app.StatusReader, printer: printer}
+	}
+	app.restoreStdout = redirectStdout()
+	return nil
+}
+
+// jsonRunner points runner's output at printer, if it supports it.
+func jsonRunner(runner WorkflowRunner, printer core.Printer) WorkflowRunner {
+	if r, ok := runner.(PrinterRunner); ok {
+		r.SetPrinter(printer)
+		r.SetProgressWriter(io.Discard)
+	}
+	return runner
+}
+
+// finishOutput ends the run's output: in json mode it writes the final exit
+// event and restores stdout, and with --log-file it records the exit code
+// and closes the transcript. It is safe to call more than once.
+func (app *App) finishOutput(exitCode int) {
+	if app.restoreStdout != nil {
+		app.restoreStdout()
+		app.restoreStdout = nil
+	}
+	if app.jsonPrinter != nil {
+		app.jsonPrinter.Exit(exitCode)
+		app.jsonPrinter = nil
+	}
+	if app.transcript != nil {
+		fmt.Fprintf(app.transcript, "=== exit_code=%d ===\n", exitCode)
+		if err := app.transcript.Close(); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to close log file: %v\n", err)
+		}
+		app.transcript = nil
+	}
+}
@@ -0,0 +1,215 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"bmaduum/internal/config"
+	"bmaduum/internal/output"
+	"bmaduum/internal/status"
+)
+
+// readJSONEvents parses JSON Lines output into events.
+func readJSONEvents(t *testing.T, data []byte) []output.JSONEvent {
+	t.Helper()
+	var events []output.JSONEvent
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		var event output.JSONEvent
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &event), "line: %s", scanner.Text())
+		events = append(events, event)
+	}
+	require.NoError(t, scanner.Err())
+	return events
+}
+
+func TestOutputJSON_Story(t *testing.T) {
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, `development_status:
+  7-1-json: review`)
+
+	app := &App{
+		Config:       config.DefaultConfig(),
+		StatusReader: status.NewReader(tmpDir),
+		StatusWriter: status.NewWriter(tmpDir),
+		Runner:       &MockWorkflowRunner{},
+		Printer:      output.NewPrinterWithWriter(&bytes.Buffer{}),
+	}
+
+	var stdout bytes.Buffer
+	rootCmd := NewRootCommand(app)
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetErr(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{"--output", "json", "story", "7-1-json"})
+
+	result := executeRoot(rootCmd)
+	app.finishOutput(result.ExitCode)
+	require.NoError(t, result.Err)
+
+	events := readJSONEvents(t, stdout.Bytes())
+	require.NotEmpty(t, events)
+
+	var transitions []output.JSONEvent
+	for _, event := range events {
+		if event.Type == output.EventTransition {
+			transitions = append(transitions, event)
+		}
+	}
+	require.NotEmpty(t, transitions)
+	assert.Equal(t, "7-1-json", transitions[0].StoryKey)
+	assert.Equal(t, "review", transitions[0].From)
+	assert.Equal(t, "done", transitions[len(transitions)-1].To)
+
+	last := events[len(events)-1]
+	assert.Equal(t, output.EventExit, last.Type)
+	require.NotNil(t, last.ExitCode)
+	assert.Equal(t, 0, *last.ExitCode)
+}
+
+func TestOutputJSON_ExitCodeOnFailure(t *testing.T) {
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, `development_status:
+  7-2-fails: review`)
+
+	app := &App{
+		Config:       config.DefaultConfig(),
+		StatusReader: status.NewReader(tmpDir),
+		StatusWriter: status.NewWriter(tmpDir),
+		Runner:       &MockWorkflowRunner{FailOnWorkflow: "code-review"},
+		Printer:      output.NewPrinterWithWriter(&bytes.Buffer{}),
+	}
+
+	var stdout bytes.Buffer
+	rootCmd := NewRootCommand(app)
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetErr(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{"--output", "json", "story", "7-2-fails"})
+
+	result := executeRoot(rootCmd)
+	app.finishOutput(result.ExitCode)
+	require.Error(t, result.Err)
+
+	events := readJSONEvents(t, stdout.Bytes())
+	require.NotEmpty(t, events)
+	last := events[len(events)-1]
+	assert.Equal(t, output.EventExit, last.Type)
+	require.NotNil(t, last.ExitCode)
+	assert.Equal(t, result.ExitCode, *last.ExitCode)
+	assert.NotZero(t, *last.ExitCode)
+}
+
+func TestOutputMode_Invalid(t *testing.T) {
+	app := &App{
+		Config:  config.DefaultConfig(),
+		Runner:  &MockWorkflowRunner{},
+		Printer: output.NewPrinterWithWriter(&bytes.Buffer{}),
+	}
+
+	rootCmd := NewRootCommand(app)
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetErr(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{"--output", "yaml", "version"})
+
+	err := rootCmd.Execute()
+	require.Error(t, err)
+	code, ok := IsExitError(err)
+	require.True(t, ok)
+	assert.Equal(t, 1, code)
+	assert.Nil(t, app.jsonPrinter)
+}
+
+func TestOutputJSON_RejectsStoryJSON(t *testing.T) {
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, `development_status:
+  7-3-both: review`)
+
+	mockWriter := &MockStatusWriter{}
+	app := &App{
+		Config:       config.DefaultConfig(),
+		StatusReader: status.NewReader(tmpDir),
+		StatusWriter: mockWriter,
+		Runner:       &MockWorkflowRunner{},
+		Printer:      output.NewPrinterWithWriter(&bytes.Buffer{}),
+	}
+
+	rootCmd := NewRootCommand(app)
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetErr(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{"--output", "json", "story", "--quiet", "--json", "7-3-both"})
+
+	result := executeRoot(rootCmd)
+	app.finishOutput(result.ExitCode)
+	require.Error(t, result.Err)
+	assert.Empty(t, mockWriter.Updates)
+}
+
+func TestVerbosityFlag(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      []string
+		expected  string
+		expectErr bool
+	}{
+		{name: "config default", args: []string{"version"}, expected: config.VerbosityNormal},
+		{name: "flag overrides config", args: []string{"--verbosity", "quiet", "version"}, expected: config.VerbosityQuiet},
+		{name: "invalid", args: []string{"--verbosity", "loud", "version"}, expected: config.VerbosityNormal, expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := &App{
+				Config:  config.DefaultConfig(),
+				Runner:  &MockWorkflowRunner{},
+				Printer: output.NewPrinterWithWriter(&bytes.Buffer{}),
+			}
+
+			rootCmd := NewRootCommand(app)
+			rootCmd.SetOut(&bytes.Buffer{})
+			rootCmd.SetErr(&bytes.Buffer{})
+			rootCmd.SetArgs(tt.args)
+
+			err := rootCmd.Execute()
+			if tt.expectErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.expected, app.Config.Output.Verbosity)
+		})
+	}
+}
+
+func TestNoMarkdownFlag(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		expected bool
+	}{
+		{name: "config default", args: []string{"version"}, expected: true},
+		{name: "flag overrides config", args: []string{"--no-markdown", "version"}, expected: false},
+		{name: "no-color turns markdown off", args: []string{"--no-color", "version"}, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := &App{
+				Config:  config.DefaultConfig(),
+				Runner:  &MockWorkflowRunner{},
+				Printer: output.NewPrinterWithWriter(&bytes.Buffer{}),
+			}
+
+			rootCmd := NewRootCommand(app)
+			rootCmd.SetOut(&bytes.Buffer{})
+			rootCmd.SetErr(&bytes.Buffer{})
+			rootCmd.SetArgs(tt.args)
+
+			require.NoError(t, rootCmd.Execute())
+			assert.Equal(t, tt.expected, app.Config.Output.Markdown.Enabled)
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"bmaduum/internal/claude"
+	"bmaduum/internal/config"
+	"bmaduum/internal/lifecycle"
+	"bmaduum/internal/manifest"
+)
+
+// envCheck is the outcome of a single preflight check.
+type envCheck struct {
+	name string
+	err  error
+}
+
+// runEnvChecks verifies that a real run of the given workflows would start
+// cleanly: the Claude binary is on PATH, the workflow manifest (if present)
+// parses, every workflow has a prompt, and the status file is writable.
+func runEnvChecks(app *App, workflows []string) []envCheck {
+	checks := []envCheck{
+		{name: "claude binary", err: checkClaudeBinary(app.Config)},
+		{name: "workflow manifest", err: checkWorkflowManifest(app.workflowManifestPath())},
+	}
+
+	seen := make(map[string]bool)
+	for _, wf := range workflows {
+		if seen[wf] {
+			continue
+		}
+		seen[wf] = true
+		_, err := app.Config.GetPrompt(wf, "preflight")
+		checks = append(checks, envCheck{name: "prompt for " + wf, err: err})
+	}
+
+	checks = append(checks, envCheck{name: "status file writable", err: checkStatusWritable(app.StatusReader.Path())})
+	return checks
+}
+
+// checkClaudeBinary verifies the configured Claude binary can be found.
+func checkClaudeBinary(cfg *config.Config) error {
+	binary := cfg.Claude.BinaryPath
+	if binary == "" {
+		binary = "claude"
+	}
+	if _, err := exec.LookPath(binary); err != nil {
+		return fmt.Errorf("%s not found: %w", binary, err)
+	}
+	return nil
+}
+
+// requireClaude fails fast when the app's executor reports that the Claude
+// CLI cannot be run, so a missing binary is reported once before any
+// workflow starts rather than by every step. Executors that cannot check,
+// such as test mocks, always pass.
+func requireClaude(cmd *cobra.Command, app *App) error {
+	checker, ok := app.Executor.(claude.BinaryChecker)
+	if !ok {
+		return nil
+	}
+	if err := checker.CheckBinary(); err != nil {
+		cmd.SilenceUsage = true
+		app.printf("Error: %v\n", err)
+		return NewExitError(1)
+	}
+	return nil
+}
+
+// checkWorkflowManifest verifies the workflow manifest parses. A missing
+// manifest is fine since the default routing is used instead.
+func checkWorkflowManifest(path string) error {
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	_, err := manifest.ReadFromFile(path)
+	return err
+}
+
+// checkStatusWritable verifies the status file can be opened for writing
+// and that its directory accepts the temp file used for atomic updates.
+func checkStatusWritable(path string) error {
+	f, err := os.OpenFile(path, os.O_WRONLY, 0)
+	if err != nil {
+		return err
+	}
+	f.Close()
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".bmaduum-preflight-*")
+	if err != nil {
+		return err
+	}
+	tmp.Close()
+	return os.Remove(tmp.Name())
+}
+
+// reportEnvReadiness runs the preflight checks for the workflows planned for
+// storyKeys and prints the results. Returns true if every check passed.
+func reportEnvReadiness(app *App, executor *lifecycle.Executor, storyKeys []string) bool {
+	var workflows []string
+	for _, storyKey := range storyKeys {
+		steps, err := executor.GetSteps(storyKey)
+		if err != nil {
+			continue
+		}
+		for _, step := range steps {
+			workflows = append(workflows, step.Workflow)
+		}
+	}
+
+	ready := true
+	app.println()
+	app.println("Environment:")
+	for _, check := range runEnvChecks(app, workflows) {
+		if check.err != nil {
+			ready = false
+			app.printf("  ✗ %s: %v\n", check.name, check.err)
+		} else {
+			app.printf("  ✓ %s\n", check.name)
+		}
+	}
+
+	if ready {
+		app.println("Ready: a real run should start cleanly")
+	} else {
+		app.println("Not ready: fix the failed checks before running")
+	}
+	return ready
+}
@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"bmaduum/internal/config"
+	"bmaduum/internal/manifest"
+	"bmaduum/internal/status"
+)
+
+// legacyInstallDir is where pre-v6 BMAD (v4) installs its core files.
+const legacyInstallDir = ".bmad-core"
+
+// detectPromptMode reports whether the project under basePath should use
+// slash commands, and why. Evidence, in order: the BMAD version in the module
+// manifest, a v6 _bmad-output directory, and a v4 .bmad-core directory. ok is
+// false when none is found.
+func detectPromptMode(basePath string, modules *manifest.ModuleManifest) (useSlash bool, reason string, ok bool) {
+	if modules != nil {
+		version := modules.BMADVersion()
+		if major, err := majorVersion(version); err == nil {
+			return major >= 6, "module manifest reports BMAD " + version, true
+		}
+	}
+	if isDir(filepath.Join(basePath, status.OutputDir)) {
+		return true, "found " + status.OutputDir + "/ (BMAD v6)", true
+	}
+	if isDir(filepath.Join(basePath, legacyInstallDir)) {
+		return false, "found " + legacyInstallDir + "/ (BMAD v4)", true
+	}
+	return false, "", false
+}
+
+// applyPromptMode sets cfg.UseSlashCommands from [detectPromptMode] unless
+// the config sets use_slash_commands explicitly. The choice is reported to w
+// when it selects legacy prompts, which is not the default, or when the
+// output is verbose.
+func applyPromptMode(w io.Writer, cfg *config.Config, basePath string, modules *manifest.ModuleManifest) {
+	if cfg.UseSlashCommandsSet {
+		return
+	}
+	useSlash, reason, ok := detectPromptMode(basePath, modules)
+	if !ok {
+		return
+	}
+	cfg.UseSlashCommands = useSlash
+
+	mode := "slash commands"
+	if !useSlash {
+		mode = "legacy prompt templates"
+	} else if cfg.Output.Verbosity != config.VerbosityVerbose {
+		return
+	}
+	fmt.Fprintf(w, "Using %s: %s (set use_slash_commands to override)\n", mode, reason)
+}
+
+// majorVersion returns the major number of a version such as "6.0.0-alpha"
+// or "v4.44.1".
+func majorVersion(version string) (int, error) {
+	major, _, _ := strings.Cut(strings.TrimPrefix(version, "v"), ".")
+	return strconv.Atoi(major)
+}
+
+// isDir reports whether path is an existing directory.
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
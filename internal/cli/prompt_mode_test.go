@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"bmaduum/internal/config"
+	"bmaduum/internal/manifest"
+)
+
+func TestDetectPromptMode(t *testing.T) {
+	v4 := &manifest.ModuleManifest{Version: "4.44.1"}
+	v6 := &manifest.ModuleManifest{Modules: []manifest.Module{{Name: "bmm", Version: "v6.0.0-alpha"}}}
+
+	tests := []struct {
+		name     string
+		dirs     []string
+		modules  *manifest.ModuleManifest
+		useSlash bool
+		reason   string
+		ok       bool
+	}{
+		{name: "nothing found"},
+		{name: "v4 manifest", modules: v4, useSlash: false, reason: "module manifest reports BMAD 4.44.1", ok: true},
+		{name: "v6 manifest", modules: v6, useSlash: true, reason: "module manifest reports BMAD v6.0.0-alpha", ok: true},
+		{name: "manifest wins over directories", dirs: []string{"_bmad-output"}, modules: v4, useSlash: false, reason: "module manifest reports BMAD 4.44.1", ok: true},
+		{name: "v6 output directory", dirs: []string{"_bmad-output"}, useSlash: true, reason: "found _bmad-output/ (BMAD v6)", ok: true},
+		{name: "v4 install directory", dirs: []string{".bmad-core"}, useSlash: false, reason: "found .bmad-core/ (BMAD v4)", ok: true},
+		{name: "unversioned manifest", dirs: []string{".bmad-core"}, modules: &manifest.ModuleManifest{}, useSlash: false, reason: "found .bmad-core/ (BMAD v4)", ok: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			basePath := t.TempDir()
+			for _, dir := range tt.dirs {
+				require.NoError(t, os.Mkdir(filepath.Join(basePath, dir), 0755))
+			}
+
+			useSlash, reason, ok := detectPromptMode(basePath, tt.modules)
+			assert.Equal(t, tt.useSlash, useSlash)
+			assert.Equal(t, tt.reason, reason)
+			assert.Equal(t, tt.ok, ok)
+		})
+	}
+}
+
+func TestApplyPromptMode(t *testing.T) {
+	basePath := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(basePath, ".bmad-core"), 0755))
+
+	var out bytes.Buffer
+	cfg := config.DefaultConfig()
+	applyPromptMode(&out, cfg, basePath, nil)
+	assert.False(t, cfg.UseSlashCommands)
+	assert.Equal(t, "Using legacy prompt templates: found .bmad-core/ (BMAD v4) (set use_slash_commands to override)\n", out.String())
+
+	// An explicit setting always wins
+	out.Reset()
+	cfg = config.DefaultConfig()
+	require.NoError(t, cfg.Set("use_slash_commands", "true"))
+	applyPromptMode(&out, cfg, basePath, nil)
+	assert.True(t, cfg.UseSlashCommands)
+	assert.Empty(t, out.String())
+}
+
+func TestApplyPromptMode_SlashCommandsReportedWhenVerbose(t *testing.T) {
+	basePath := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(basePath, "_bmad-output"), 0755))
+
+	var out bytes.Buffer
+	cfg := config.DefaultConfig()
+	applyPromptMode(&out, cfg, basePath, nil)
+	assert.True(t, cfg.UseSlashCommands)
+	assert.Empty(t, out.String())
+
+	cfg.Output.Verbosity = config.VerbosityVerbose
+	applyPromptMode(&out, cfg, basePath, nil)
+	assert.Equal(t, "Using slash commands: found _bmad-output/ (BMAD v6) (set use_slash_commands to override)\n", out.String())
+}
@@ -0,0 +1,40 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"bmaduum/internal/workflow"
+)
+
+func newReplayCommand(app *App) *cobra.Command {
+	return &cobra.Command{
+		Use:   "replay <session.jsonl>",
+		Short: "Replay a recorded Claude session",
+		Long: `Replay a recorded Claude session through the output pipeline without calling Claude.
+
+The file contains Claude's raw stream-json output, one event per line, as
+written when claude.record_path is set. Events are parsed and printed exactly
+as during a live run, which is useful for debugging output rendering and for
+demos.
+
+Examples:
+  bmaduum replay session.jsonl`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			f, err := os.Open(args[0])
+			if err != nil {
+				cmd.SilenceUsage = true
+				fmt.Fprintf(cmd.OutOrStdout(), "Error: %v\n", err)
+				return NewExitError(1)
+			}
+			defer f.Close()
+
+			runner := workflow.NewRunner(app.Executor, app.Printer, app.Config)
+			runner.Replay(f)
+			return nil
+		},
+	}
+}
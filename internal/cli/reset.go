@@ -0,0 +1,170 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"bmaduum/internal/status"
+)
+
+// BatchStatusWriter is implemented by status writers that can update several
+// stories in one write, such as [status.Writer].
+type BatchStatusWriter interface {
+	UpdateStatusBatch(updates map[string]status.Status) error
+}
+
+// storyReset is a story whose status the reset command changes.
+type storyReset struct {
+	key  string
+	from status.Status
+}
+
+func newResetCommand(app *App) *cobra.Command {
+	var to, epicID string
+	var yes bool
+
+	cmd := &cobra.Command{
+		Use:   "reset [story-key...] --to <status>",
+		Short: "Set the status of several stories at once",
+		Long: `Set the status of the given stories, or of every story in an epic, in
+sprint-status.yaml, for example to send stories back to ready-for-dev after a
+failed run.
+
+The target status must be a status in the lifecycle (an alias is written as
+its canonical status), and every story must exist in sprint-status.yaml;
+otherwise nothing is changed. Stories already at the target status are left
+alone. The stories to change are listed and
+must be confirmed unless --yes is given, and each changed story is reported.
+
+Examples:
+  bmaduum reset 6-1-setup 6-2-api --to ready-for-dev
+  bmaduum reset --epic 6 --to backlog --yes`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := cmd.OutOrStdout()
+			fail := func(err error) error {
+				cmd.SilenceUsage = true
+				fmt.Fprintf(out, "Error: %v\n", err)
+				return NewExitError(1)
+			}
+
+			wfRouter := lifecycleRouter(app)
+			target := wfRouter.ResolveAlias(status.Status(to))
+			if _, ok := wfRouter.ChainIndex(target); !ok {
+				return fail(fmt.Errorf("--to %q is not a status in the lifecycle", to))
+			}
+
+			storyKeys, err := resetStoryKeys(app, args, epicID)
+			if err != nil {
+				return fail(err)
+			}
+
+			var resets []storyReset
+			for _, key := range storyKeys {
+				current, err := app.StatusReader.GetStoryStatus(key)
+				if err != nil {
+					return fail(err)
+				}
+				if current == target {
+					fmt.Fprintf(out, "Story %s is already %s, skipping\n", key, target)
+					continue
+				}
+				resets = append(resets, storyReset{key: key, from: current})
+			}
+			if len(resets) == 0 {
+				fmt.Fprintln(out, "No stories to reset")
+				return nil
+			}
+
+			if !yes {
+				fmt.Fprintf(out, "Reset %d stories to %s:\n", len(resets), target)
+				for _, r := range resets {
+					fmt.Fprintf(out, "  %s (%s)\n", r.key, r.from)
+				}
+				if !confirm(cmd.InOrStdin(), out, "Continue?") {
+					fmt.Fprintln(out, "Aborted, no stories changed")
+					return nil
+				}
+			}
+
+			if err := resetStories(app, resets, target); err != nil {
+				return fail(err)
+			}
+			for _, r := range resets {
+				fmt.Fprintf(out, "%s: %s → %s\n", r.key, r.from, target)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&to, "to", "", "Set the stories to this `status`")
+	cmd.Flags().StringVar(&epicID, "epic", "", "Also reset every story in this `epic`")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Change the stories without asking for confirmation")
+	_ = cmd.MarkFlagRequired("to")
+	_ = cmd.RegisterFlagCompletionFunc("to", completeStatuses)
+
+	return cmd
+}
+
+// resetStoryKeys returns storyKeys followed by the stories of epicID, if
+// set, without duplicates.
+//
+// Returns an error if neither is given or the epic's stories cannot be read.
+func resetStoryKeys(app *App, storyKeys []string, epicID string) ([]string, error) {
+	if len(storyKeys) == 0 && epicID == "" {
+		return nil, fmt.Errorf("no stories given; pass story keys or --epic")
+	}
+
+	keys := append([]string(nil), storyKeys...)
+	if epicID != "" {
+		epicStories, err := app.StatusReader.GetEpicStories(epicID)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, epicStories...)
+	}
+
+	seen := make(map[string]bool, len(keys))
+	unique := keys[:0]
+	for _, key := range keys {
+		if !seen[key] {
+			seen[key] = true
+			unique = append(unique, key)
+		}
+	}
+	return unique, nil
+}
+
+// resetStories sets every story of resets to target, in a single write if
+// the status writer is a [BatchStatusWriter].
+func resetStories(app *App, resets []storyReset, target status.Status) error {
+	if batch, ok := app.StatusWriter.(BatchStatusWriter); ok {
+		updates := make(map[string]status.Status, len(resets))
+		for _, r := range resets {
+			updates[r.key] = target
+		}
+		return batch.UpdateStatusBatch(updates)
+	}
+
+	for _, r := range resets {
+		if err := app.StatusWriter.UpdateStatus(r.key, target); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// confirm prints question to out and reports whether the answer read from
+// in is yes. Anything else, including end of input, is no.
+func confirm(in io.Reader, out io.Writer, question string) bool {
+	fmt.Fprintf(out, "%s [y/N] ", question)
+	answer, _ := bufio.NewReader(in).ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true
+	}
+	return false
+}
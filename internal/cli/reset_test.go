@@ -0,0 +1,141 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"bmaduum/internal/config"
+	"bmaduum/internal/status"
+)
+
+const resetStatusYAML = `development_status:
+  epic-6: in-progress
+  6-1-setup: review
+  6-2-api: in-progress
+  6-3-ui: ready-for-dev
+  7-1-next: done`
+
+func runResetCommand(t *testing.T, app *App, stdin string, args ...string) (string, error) {
+	t.Helper()
+
+	rootCmd := NewRootCommand(app)
+	outBuf := &bytes.Buffer{}
+	rootCmd.SetOut(outBuf)
+	rootCmd.SetErr(outBuf)
+	rootCmd.SetIn(strings.NewReader(stdin))
+	rootCmd.SetArgs(append([]string{"reset"}, args...))
+
+	err := rootCmd.Execute()
+	return outBuf.String(), err
+}
+
+func newResetApp(tmpDir string) *App {
+	return &App{
+		Config:       config.DefaultConfig(),
+		StatusReader: status.NewReader(tmpDir),
+		StatusWriter: status.NewWriter(tmpDir),
+	}
+}
+
+func TestResetCommand_Stories(t *testing.T) {
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, resetStatusYAML)
+	app := newResetApp(tmpDir)
+
+	out, err := runResetCommand(t, app, "", "6-1-setup", "7-1-next", "--to", "ready-for-dev", "--yes")
+
+	require.NoError(t, err)
+	assert.Contains(t, out, "6-1-setup: review → ready-for-dev")
+	assert.Contains(t, out, "7-1-next: done → ready-for-dev")
+	for key, want := range map[string]status.Status{"6-1-setup": status.StatusReadyForDev, "7-1-next": status.StatusReadyForDev, "6-2-api": status.StatusInProgress} {
+		got, err := app.StatusReader.GetStoryStatus(key)
+		require.NoError(t, err)
+		assert.Equal(t, want, got, key)
+	}
+}
+
+func TestResetCommand_Epic(t *testing.T) {
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, resetStatusYAML)
+	writer := &MockStatusWriter{}
+	app := &App{Config: config.DefaultConfig(), StatusReader: status.NewReader(tmpDir), StatusWriter: writer}
+
+	out, err := runResetCommand(t, app, "", "6-2-api", "--epic", "6", "--to", "ready-for-dev", "-y")
+
+	require.NoError(t, err)
+	assert.Contains(t, out, "Story 6-3-ui is already ready-for-dev, skipping")
+	assert.Equal(t, []StatusUpdate{
+		{StoryKey: "6-2-api", NewStatus: status.StatusReadyForDev},
+		{StoryKey: "6-1-setup", NewStatus: status.StatusReadyForDev},
+	}, writer.Updates)
+}
+
+func TestResetCommand_Confirmation(t *testing.T) {
+	tests := []struct {
+		name    string
+		stdin   string
+		changed bool
+	}{
+		{name: "yes", stdin: "y\n", changed: true},
+		{name: "no", stdin: "n\n", changed: false},
+		{name: "end of input", stdin: "", changed: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			createSprintStatusFile(t, tmpDir, resetStatusYAML)
+			app := newResetApp(tmpDir)
+
+			out, err := runResetCommand(t, app, tt.stdin, "6-1-setup", "--to", "backlog")
+
+			require.NoError(t, err)
+			assert.Contains(t, out, "Reset 1 stories to backlog:\n  6-1-setup (review)\n")
+			got, err := app.StatusReader.GetStoryStatus("6-1-setup")
+			require.NoError(t, err)
+			if tt.changed {
+				assert.Equal(t, status.StatusBacklog, got)
+			} else {
+				assert.Contains(t, out, "Aborted, no stories changed")
+				assert.Equal(t, status.StatusReview, got)
+			}
+		})
+	}
+}
+
+func TestResetCommand_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantOut string
+	}{
+		{name: "unknown status", args: []string{"6-1-setup", "--to", "started"}, wantOut: `--to "started" is not a status in the lifecycle`},
+		{name: "missing story", args: []string{"6-1-setup", "6-9-missing", "--to", "backlog"}, wantOut: "story not found: 6-9-missing"},
+		{name: "no stories", args: []string{"--to", "backlog"}, wantOut: "no stories given"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			createSprintStatusFile(t, tmpDir, resetStatusYAML)
+			app := newResetApp(tmpDir)
+			before, err := os.ReadFile(app.StatusReader.Path())
+			require.NoError(t, err)
+
+			out, err := runResetCommand(t, app, "", append(tt.args, "--yes")...)
+
+			code, ok := IsExitError(err)
+			require.True(t, ok)
+			assert.Equal(t, 1, code)
+			assert.Contains(t, out, tt.wantOut)
+			after, err := os.ReadFile(app.StatusReader.Path())
+			require.NoError(t, err)
+			assert.Equal(t, string(before), string(after), "status file should be unchanged")
+		})
+	}
+}
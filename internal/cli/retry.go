@@ -2,32 +2,95 @@ package cli
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
+	"github.com/spf13/cobra"
+
+	"bmaduum/internal/config"
 	"bmaduum/internal/lifecycle"
 	"bmaduum/internal/ratelimit"
 )
 
+// retrySleep waits d between retry attempts, or until ctx is done, whichever
+// comes first. Returns ctx.Err() if the wait was cut short. Tests replace it
+// to avoid real delays.
+var retrySleep = func(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// retriesFlag is the name of the per-invocation retry count flag.
+const retriesFlag = "retries"
+
+// addRetriesFlag registers the --retries flag on cmd.
+func addRetriesFlag(cmd *cobra.Command, retries *int) {
+	cmd.Flags().IntVar(retries, retriesFlag, 0, "Re-run each failed workflow step up to N times (overrides --auto-retry)")
+}
+
+// resolveRetries applies the retry settings and returns the effective
+// auto-retry setting and retry limit for [executeWithRetry].
+//
+// Precedence is flag > config: an explicit --retries value is applied to
+// the executor as per-step retries with the claude.retry_* backoff settings
+// (see [lifecycle.Executor.SetRetryPolicy]) and replaces the lifecycle-level
+// auto-retry loop. Otherwise claude.max_retries
+// applies to --auto-retry and autoRetry is returned unchanged.
+func resolveRetries(cmd *cobra.Command, cfg *config.Config, executor *lifecycle.Executor, autoRetry bool, retries int) (bool, int) {
+	if cfg == nil {
+		cfg = config.DefaultConfig()
+	}
+
+	if cmd.Flags().Changed(retriesFlag) {
+		executor.SetRetryPolicy(lifecycle.RetryPolicy{
+			MaxRetries: retries,
+			BaseDelay:  cfg.Claude.RetryBaseDelay,
+			Multiplier: cfg.Claude.RetryMultiplier,
+			MaxDelay:   cfg.Claude.RetryMaxDelay,
+		})
+		return false, 0
+	}
+
+	return autoRetry, cfg.Claude.MaxRetries
+}
+
 // executeWithRetry executes a story lifecycle with automatic retry on rate limit errors.
 //
-// If autoRetry is true, rate limit errors will trigger a wait until the reset time,
-// then retry up to maxRetries times. The progress callback is invoked before each
-// workflow execution.
+// If resume is true, each attempt uses [lifecycle.Executor.Resume] instead of
+// [lifecycle.Executor.Execute]. If autoRetry is true, failed attempts are
+// retried up to maxRetries times; a rate limit with a known reset time (see
+// [ratelimit.Error]) waits until the reset. A wait ends early with ctx.Err()
+// when ctx is canceled, e.g. by Ctrl-C. Expired authentication is not
+// retried, since no attempt can succeed until the user logs in again. The
+// progress callback is invoked before each workflow execution.
 func executeWithRetry(
 	ctx context.Context,
 	executor *lifecycle.Executor,
 	storyKey string,
+	resume bool,
 	autoRetry bool,
 	maxRetries int,
 	progressCallback func(stepIndex, totalSteps int, workflow string),
 ) error {
+	execute := executor.Execute
+	if resume {
+		execute = executor.Resume
+	}
+
 	if !autoRetry {
 		// No retry - just execute once
 		if progressCallback != nil {
 			executor.SetProgressCallback(progressCallback)
 		}
-		return executor.Execute(ctx, storyKey)
+		return execute(ctx, storyKey)
 	}
 
 	// With auto-retry
@@ -37,35 +100,33 @@ func executeWithRetry(
 			executor.SetProgressCallback(progressCallback)
 		}
 
-		rateLimitState := ratelimit.NewState()
-
-		// Set up stderr handler to detect rate limits
-		// This would need to be integrated with the executor's stderr handling
-		// For now, we execute and check for rate limit errors
-
-		err := executor.Execute(ctx, storyKey)
-
-		// Check if this is a rate limit error
-		// In a full implementation, we would need to capture stderr and check
-		// For now, we just retry on any error if autoRetry is enabled
+		err := execute(ctx, storyKey)
 		if err == nil {
 			return nil
 		}
 
+		// Ctrl-C ends the run, and a retry cannot log in; neither is worth retrying
+		if ctx.Err() != nil || errors.Is(err, ratelimit.ErrAuthExpired) {
+			return err
+		}
+
 		// Check if we've exceeded max retries
 		if retryCount >= maxRetries {
 			return fmt.Errorf("max retries (%d) exceeded: %w", maxRetries, err)
 		}
 
-		// Wait before retrying
+		// Wait before retrying, until the reset of a rate limit if known
 		waitTime := time.Duration(retryCount+1) * 30 * time.Second
-		if rateLimitState.WaitTime() > 0 {
-			waitTime = rateLimitState.WaitTime()
+		var limitErr *ratelimit.Error
+		if errors.As(err, &limitErr) && limitErr.WaitTime() > 0 {
+			waitTime = limitErr.WaitTime()
 		}
 
-		fmt.Printf("\n⚠️  Error encountered, waiting %v before retry %d/%d...\n",
+		fmt.Fprintf(executor.Output(), "\n⚠️  Error encountered, waiting %v before retry %d/%d...\n",
 			waitTime.Round(time.Second), retryCount+1, maxRetries)
-		time.Sleep(waitTime)
+		if err := retrySleep(ctx, waitTime); err != nil {
+			return err
+		}
 
 		retryCount++
 	}
@@ -14,26 +14,39 @@
 //
 // Commands provided:
 //   - story - Execute full story lifecycle from current status to done (one or more stories)
+//   - step - Run only the next lifecycle step of a story
 //   - epic - Run all stories in an epic (or all epics with "all")
 //   - raw - Execute a raw prompt directly
+//   - status - Show the sprint status board and report orphaned story files
+//   - watch - Run stories as they move to an actionable status
+//   - doctor - Diagnose the Claude binary, status file, manifests, config, and git
+//   - migrate-status - Move a legacy sprint-status.yaml to the v6 location
+//   - reset - Set the status of several stories at once
+//   - list-modules - Show detected BMAD modules and the steps they inject
+//   - replay - Replay a recorded Claude session
+//   - tail-log - Follow the run log of an active run
 //   - create-story, dev-story, code-review, git-commit - Individual workflow commands
 package cli
 
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/spf13/cobra"
 
-	"bmaduum/internal/bmadhelp"
 	"bmaduum/internal/claude"
 	"bmaduum/internal/config"
+	"bmaduum/internal/git"
 	"bmaduum/internal/lifecycle"
 	"bmaduum/internal/manifest"
 	"bmaduum/internal/output"
 	"bmaduum/internal/output/core"
 	"bmaduum/internal/router"
+	"bmaduum/internal/runlog"
 	"bmaduum/internal/status"
 	"bmaduum/internal/workflow"
 )
@@ -78,6 +91,16 @@ type StatusReader interface {
 
 	// GetAllEpics returns all epic IDs with active status, sorted numerically.
 	GetAllEpics() ([]string, error)
+
+	// GetStoriesByStatus returns the keys of all stories with the given
+	// status, sorted by epic and then by story number.
+	GetStoriesByStatus(s status.Status) ([]string, error)
+
+	// Read returns the complete parsed sprint status board.
+	Read() (*status.SprintStatus, error)
+
+	// Path returns the resolved path of the sprint-status.yaml file.
+	Path() string
 }
 
 // StatusWriter is the interface for updating story status in sprint-status.yaml.
@@ -90,6 +113,24 @@ type StatusWriter interface {
 	UpdateStatus(storyKey string, newStatus status.Status) error
 }
 
+// GitHelper is the interface for inspecting the git working tree.
+//
+// The production implementation is [git.Client], which shells out to git.
+type GitHelper interface {
+	// UncommittedFiles returns paths with uncommitted changes, including
+	// untracked files. An empty result means the working tree is clean.
+	UncommittedFiles(ctx context.Context) ([]string, error)
+
+	// HeadSHA returns the SHA of the commit currently checked out.
+	HeadSHA(ctx context.Context) (string, error)
+
+	// CommitsSince returns the SHAs of commits made after base, oldest first.
+	CommitsSince(ctx context.Context, base string) ([]string, error)
+
+	// RepoRoot returns the top-level directory of the repository.
+	RepoRoot(ctx context.Context) (string, error)
+}
+
 // App is the main application container with dependency injection.
 //
 // All dependencies are injected via struct fields, enabling comprehensive
@@ -104,6 +145,7 @@ type StatusWriter interface {
 //   - Runner: Workflow execution engine
 //   - StatusReader: Sprint status file reader
 //   - StatusWriter: Sprint status file writer
+//   - Git: Working tree inspection for post-run checks and the run manifest
 type App struct {
 	// Config holds application configuration including workflow definitions.
 	Config *config.Config
@@ -117,6 +159,10 @@ type App struct {
 	// Runner executes named workflows or raw prompts.
 	Runner WorkflowRunner
 
+	// NewRunner creates an independent runner for each concurrent worker of
+	// epic --parallel. If nil, parallel runs are unavailable.
+	NewRunner func() WorkflowRunner
+
 	// StatusReader reads story status from sprint-status.yaml.
 	StatusReader StatusReader
 
@@ -134,6 +180,26 @@ type App struct {
 	// If nil, unknown statuses produce an immediate error. Set via NewApp or
 	// directly in tests.
 	BmadHelp lifecycle.BmadHelpFallback
+
+	// StoryOverrides holds per-story config overrides loaded from the
+	// story-overrides.yaml sidecar next to sprint-status.yaml, or nil if none.
+	StoryOverrides *config.StoryOverrides
+
+	// Git inspects the working tree for post-run safety checks.
+	Git GitHelper
+
+	// jsonPrinter is the --output json event printer, or nil in text mode.
+	jsonPrinter *output.JSONPrinter
+
+	// restoreStdout undoes the stdout redirect made for --output json.
+	restoreStdout func()
+
+	// transcript is the --log-file run transcript, or nil if not logging.
+	transcript *runlog.Transcript
+
+	// manifestPath is the workflow manifest Router was built from, or the
+	// location it was looked for. Empty means the default location.
+	manifestPath string
 }
 
 // NewApp creates a new [App] with all production dependencies wired up.
@@ -146,7 +212,18 @@ type App struct {
 //
 // For testing, construct [App] directly with mock dependencies instead.
 func NewApp(cfg *config.Config) *App {
-	printer := output.NewPrinter()
+	printer := output.NewPrinterWithEncoding(os.Stdout, output.ResolveEncoding(cfg.Output.Encoding), markdownConfig(cfg.Output.Markdown))
+
+	// Record the raw Claude stream for later replay if configured
+	var recorder io.Writer
+	if cfg.Claude.RecordPath != "" {
+		f, err := os.OpenFile(cfg.Claude.RecordPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			os.Stderr.WriteString("Warning: failed to open session recording: " + err.Error() + "\n")
+		} else {
+			recorder = f
+		}
+	}
 
 	executor := claude.NewExecutor(claude.ExecutorConfig{
 		BinaryPath:   cfg.Claude.BinaryPath,
@@ -155,42 +232,105 @@ func NewApp(cfg *config.Config) *App {
 			// Print stderr to stderr
 			os.Stderr.WriteString("[stderr] " + line + "\n")
 		},
+		Recorder: recorder,
 	})
+	if err := claude.ValidateExtraArgs(cfg.Claude.ExtraArgs); err != nil {
+		os.Stderr.WriteString("Warning: claude.extra_args ignored: " + err.Error() + "\n")
+	} else {
+		executor.AddExtraArgs(cfg.Claude.ExtraArgs...)
+	}
 
 	runner := workflow.NewRunner(executor, printer, cfg)
+
+	// Load per-story overrides from the sidecar next to sprint-status.yaml
+	overridesPath := filepath.Join(filepath.Dir(status.ResolvePath("", cfg.StatusPath)), config.StoryOverridesFileName)
+	storyOverrides, err := config.LoadStoryOverrides(overridesPath)
+	if err != nil {
+		os.Stderr.WriteString("Warning: " + err.Error() + "\n")
+		storyOverrides = nil
+	}
+
 	statusReader := status.NewReaderWithPath("", cfg.StatusPath)
-	statusWriter := status.NewWriterWithPath("", cfg.StatusPath)
+	gitClient := git.NewClient("")
+	runner.SetStoryOverrides(storyOverrides)
+	runner.SetPromptSources(statusReader, gitClient)
+	newRunner := func() WorkflowRunner {
+		r := workflow.NewRunner(executor, printer, cfg)
+		r.SetStoryOverrides(storyOverrides)
+		r.SetPromptSources(statusReader, gitClient)
+		return r
+	}
 
-	// Try to load workflow manifest for dynamic routing
-	var wfRouter *router.Router
-	if m, err := manifest.ReadFromFile("_bmad/_cfg/workflow-manifest.csv"); err == nil {
-		wfRouter = router.NewRouterFromManifest(m)
+	warnLegacyStatusPath(os.Stderr, statusReader)
+	statusReader.SetNormalizeStatuses(cfg.NormalizeStatuses)
+	if scheme, err := status.ParseNumberScheme(cfg.StoryNumbering); err != nil {
+		os.Stderr.WriteString("Warning: " + err.Error() + "; using numeric\n")
 	} else {
-		wfRouter = router.NewRouter()
+		statusReader.SetNumberScheme(scheme)
 	}
+	statusWriter := status.NewWriterWithPath("", cfg.StatusPath)
+	statusWriter.SetLockTimeout(cfg.StatusLockTimeout)
+	statusWriter.SetTrackUpdatedAt(cfg.TrackStatusUpdatedAt)
+
+	// Try to load workflow manifest for dynamic routing
+	manifestPath := manifest.ResolvePath("", "")
+	wfRouter := loadWorkflowRouter(os.Stderr, manifestPath)
+	applyStatusAliases(wfRouter, cfg)
 
 	// Try to load module manifest for module-aware lifecycle
-	var modules *manifest.ModuleManifest
-	if mm, err := manifest.ReadModulesFromFile("_bmad/_config/manifest.yaml"); err == nil {
-		modules = mm
+	modules := loadModules("", wfRouter)
 
-		// If SDET or TEA module is installed, add test-automation step after code-review
-		if modules.HasModule("sdet") || modules.HasModule("tea") {
-			wfRouter.InsertStepAfter("code-review", "test-automation", status.StatusDone)
-		}
-	}
+	// Pick slash commands or legacy prompts from the project's BMAD version
+	applyPromptMode(os.Stderr, cfg, "", modules)
 
 	return &App{
-		Config:       cfg,
-		Executor:     executor,
-		Printer:      printer,
-		Runner:       runner,
-		StatusReader: statusReader,
-		StatusWriter: statusWriter,
-		Router:       wfRouter,
-		Modules:      modules,
-		BmadHelp:     bmadhelp.NewClaudeFallback(executor),
+		Config:         cfg,
+		Executor:       executor,
+		Printer:        printer,
+		Runner:         runner,
+		NewRunner:      newRunner,
+		StatusReader:   statusReader,
+		StatusWriter:   statusWriter,
+		Router:         wfRouter,
+		Modules:        modules,
+		BmadHelp:       newBmadHelp(cfg, executor, wfRouter),
+		StoryOverrides: storyOverrides,
+		Git:            gitClient,
+		manifestPath:   manifestPath,
+	}
+}
+
+// loadModules reads the auto-discovered module manifest under basePath and
+// injects the lifecycle steps of the installed modules into wfRouter.
+//
+// Returns nil if no module manifest exists. A manifest that exists but
+// cannot be parsed is reported as a warning and treated as absent.
+func loadModules(basePath string, wfRouter *router.Router) *manifest.ModuleManifest {
+	path := manifest.ResolveModulePath(basePath)
+	if _, err := os.Stat(path); err != nil {
+		return nil
 	}
+
+	modules, err := manifest.ReadModulesFromFile(path)
+	if err != nil {
+		os.Stderr.WriteString("Warning: " + err.Error() + "\n")
+		return nil
+	}
+
+	// Inject module-specific steps (e.g. test-automation for SDET or TEA)
+	wfRouter.ApplyModules(modules)
+	return modules
+}
+
+// warnLegacyStatusPath nudges v6 migration when the status file was
+// auto-discovered at the pre-v6 root-level location. NewApp calls it once
+// per invocation.
+func warnLegacyStatusPath(w io.Writer, reader *status.Reader) {
+	if !reader.UsesLegacyPath() {
+		return
+	}
+	fmt.Fprintf(w, "Warning: using legacy %s location; consider migrating to %s (bmaduum migrate-status)\n",
+		status.LegacyStatusPath, status.V6StatusPath)
 }
 
 // NewRootCommand creates the root Cobra command with all subcommands attached.
@@ -200,7 +340,28 @@ func NewApp(cfg *config.Config) *App {
 //   - epic: Run all stories in an epic (or all epics)
 //   - raw: Execute a raw prompt directly
 //   - workflow: Run individual BMAD workflow steps (advanced)
+//   - status: Show the sprint status board and report orphaned story files
+//   - list: List the story keys with a given status
+//   - lint: Report stories whose status the lifecycle cannot route
+//   - migrate-status: Move a legacy sprint-status.yaml to the v6 location
+//   - reset: Set the status of several stories at once
+//   - list-modules: Show detected BMAD modules and the steps they inject
+//   - config validate: Validate the configuration
+//   - manifest validate: Validate a workflow or module manifest
+//   - replay: Replay a recorded Claude session
+//   - tail-log: Follow the run log of an active run
+//
+// The persistent --output flag selects text (default) or json output; see
+// [setupOutputMode]. The persistent --verbosity flag overrides
+// output.verbosity, --no-markdown prints Claude's text without markdown
+// rendering, --no-color turns off color escape codes, and --log-file tees the output to a transcript; see
+// [setupLogFile].
 func NewRootCommand(app *App) *cobra.Command {
+	var outputMode, verbosity, logFile string
+	var noMarkdown, noColor bool
+	var claudeArgs []string
+	var manifestOpts manifestFlags
+
 	rootCmd := &cobra.Command{
 		Use:   "bmaduum",
 		Short: "BMAD Automation CLI",
@@ -208,14 +369,78 @@ func NewRootCommand(app *App) *cobra.Command {
 
 This tool orchestrates Claude to run development workflows including
 story creation, development, code review, and git operations.`,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if cmd.Flags().Changed("verbosity") {
+				if err := config.ValidateVerbosity(verbosity); err != nil {
+					cmd.SilenceUsage = true
+					fmt.Printf("Error: invalid --verbosity: %v\n", err)
+					return NewExitError(1)
+				}
+				app.Config.Output.Verbosity = verbosity
+			}
+			if manifestOpts.set() {
+				if err := setupManifest(app, manifestOpts); err != nil {
+					cmd.SilenceUsage = true
+					fmt.Printf("Error: %v\n", err)
+					return NewExitError(1)
+				}
+			}
+			if err := setupClaudeArgs(app, claudeArgs); err != nil {
+				cmd.SilenceUsage = true
+				fmt.Printf("Error: %v\n", err)
+				return NewExitError(1)
+			}
+			if noMarkdown {
+				setupNoMarkdown(app)
+			}
+			if noColor {
+				setupNoColor(app)
+			}
+			if err := setupLogFile(cmd, app, logFile); err != nil {
+				cmd.SilenceUsage = true
+				fmt.Printf("Error: %v\n", err)
+				return NewExitError(1)
+			}
+			if err := setupOutputMode(cmd, app, outputMode); err != nil {
+				cmd.SilenceUsage = true
+				fmt.Printf("Error: %v\n", err)
+				return NewExitError(1)
+			}
+			return nil
+		},
 	}
+	rootCmd.PersistentFlags().StringVar(&verbosity, "verbosity", "", "Output detail: quiet (step results only), normal, or verbose (full prompts and untruncated tool output); overrides output.verbosity")
+	rootCmd.PersistentFlags().BoolVar(&noMarkdown, "no-markdown", false, "Print Claude's text as plain text instead of rendering markdown; overrides output.markdown.enabled")
+	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "Print without colors, as when NO_COLOR is set; also turns off markdown rendering")
+	rootCmd.PersistentFlags().StringVar(&logFile, "log-file", "", "Also write a timestamped transcript of the run to this file (appended); without a value, to "+runlog.DefaultPath("")+" (pass a path as --log-file=path)")
+	rootCmd.PersistentFlags().Lookup("log-file").NoOptDefVal = runlog.DefaultPath("")
+	rootCmd.PersistentFlags().StringVar(&outputMode, "output", outputModeText, "Output format: text, or json for one JSON event per line")
+	// Applied while loading the config (see Run); declared so it parses
+	rootCmd.PersistentFlags().String("profile", "", "Apply this profile from the config file's profiles map (overrides "+config.ProfileEnvVar+")")
+	rootCmd.PersistentFlags().StringVar(&manifestOpts.path, "manifest", "", "Route the lifecycle with this workflow manifest CSV (overrides "+manifest.ManifestPathEnvVar+")")
+	rootCmd.PersistentFlags().BoolVar(&manifestOpts.strict, "strict-manifest", false, "Fail if the workflow manifest has a broken status chain instead of routing with it")
+	rootCmd.PersistentFlags().StringVar(&manifestOpts.phase, "phase", "", "Route only the workflow manifest entries of this BMAD phase (e.g. 3 for implementation)")
+	rootCmd.PersistentFlags().StringArrayVar(&claudeArgs, "claude-arg", nil, "Extra argument to pass to Claude CLI (repeatable), after claude.extra_args")
 
 	// Add subcommands
 	rootCmd.AddCommand(
 		newStoryCommand(app),
+		newStepCommand(app),
 		newEpicCommand(app),
 		newRawCommand(app),
 		newWorkflowCommand(app),
+		newStatusCommand(app),
+		newListCommand(app),
+		newLintCommand(app),
+		newDoctorCommand(app),
+		newWatchCommand(app),
+		newMigrateStatusCommand(),
+		newResetCommand(app),
+		newListModulesCommand(app),
+		newConfigCommand(app),
+		newManifestCommand(app),
+		newReplayCommand(app),
+		newTailLogCommand(),
 		newVersionCommand(),
 	)
 
@@ -240,6 +465,7 @@ type ExecuteResult struct {
 // This is the testable core of [Execute], accepting an already-loaded [config.Config]
 // so tests can provide custom configurations. It creates an [App] via [NewApp],
 // builds the command tree via [NewRootCommand], and executes the command.
+// Ctrl-C or SIGTERM cancels the command's context (see [interruptContext]).
 //
 // Exit codes:
 //   - 0: Success
@@ -249,6 +475,17 @@ func RunWithConfig(cfg *config.Config) ExecuteResult {
 	app := NewApp(cfg)
 	rootCmd := NewRootCommand(app)
 
+	ctx, stop := interruptContext()
+	defer stop()
+	rootCmd.SetContext(ctx)
+
+	result := executeRoot(rootCmd)
+	app.finishOutput(result.ExitCode)
+	return result
+}
+
+// executeRoot executes rootCmd and converts its error into an [ExecuteResult].
+func executeRoot(rootCmd *cobra.Command) ExecuteResult {
 	if err := rootCmd.Execute(); err != nil {
 		// Check if it's an ExitError from a command
 		if code, ok := IsExitError(err); ok {
@@ -263,13 +500,16 @@ func RunWithConfig(cfg *config.Config) ExecuteResult {
 // Run loads configuration and executes the CLI, returning the result.
 //
 // This is the fully testable entry point that:
-//  1. Loads configuration via [config.NewLoader]
+//  1. Loads configuration via [config.NewLoader], applying the profile
+//     named by the global --profile flag
 //  2. Calls [RunWithConfig] with the loaded config
 //
 // Use this for integration tests that need to test config loading.
 // For unit tests with custom configs, use [RunWithConfig] directly.
 func Run() ExecuteResult {
-	cfg, err := config.NewLoader().Load()
+	loader := config.NewLoader()
+	loader.SetProfile(profileFromArgs(os.Args[1:]))
+	cfg, err := loader.Load()
 	if err != nil {
 		return ExecuteResult{
 			ExitCode: 1,
@@ -279,6 +519,27 @@ func Run() ExecuteResult {
 	return RunWithConfig(cfg)
 }
 
+// profileFromArgs returns the value of the global --profile flag in args.
+//
+// The config is loaded before the command line is parsed, so the flag is
+// looked up directly. Arguments after "--" are not flags.
+func profileFromArgs(args []string) string {
+	profile := ""
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--":
+			return profile
+		case arg == "--profile" && i+1 < len(args):
+			profile = args[i+1]
+			i++
+		case strings.HasPrefix(arg, "--profile="):
+			profile = strings.TrimPrefix(arg, "--profile=")
+		}
+	}
+	return profile
+}
+
 // Execute runs the CLI application and exits the process.
 //
 // This is the entry point called by main(). It calls [Run] and translates
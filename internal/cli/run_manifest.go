@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"bmaduum/internal/runlog"
+)
+
+// runManifestFlag is the flag that enables the run manifest.
+const runManifestFlag = "run-manifest"
+
+// ArtifactReporter is implemented by runners that track the files touched
+// by their most recent workflow run. [workflow.Runner] implements it.
+type ArtifactReporter interface {
+	LastArtifacts() (changed, created []string)
+}
+
+// manifestRecorder implements [lifecycle.StepObserver], appending an entry
+// with the step's artifacts to the run manifest after every step.
+//
+// The manifest is rewritten after each step so it stays complete even if
+// the run is interrupted.
+type manifestRecorder struct {
+	path     string
+	manifest *runlog.Manifest
+	runner   WorkflowRunner
+	git      GitHelper
+
+	stepStart time.Time
+	baseSHA   string
+}
+
+// newManifestRecorder creates a recorder writing the run manifest to path.
+func newManifestRecorder(app *App, path string) *manifestRecorder {
+	return &manifestRecorder{
+		path:     path,
+		manifest: runlog.NewManifest(time.Now()),
+		runner:   app.Runner,
+		git:      app.Git,
+	}
+}
+
+// StepStarted notes the start time and current HEAD so commits made by the
+// step can be identified afterwards.
+func (r *manifestRecorder) StepStarted(ctx context.Context, storyKey, workflow string) {
+	r.stepStart = time.Now()
+	r.baseSHA = ""
+	if r.git != nil {
+		if sha, err := r.git.HeadSHA(ctx); err == nil {
+			r.baseSHA = sha
+		}
+	}
+}
+
+// StepFinished collects the step's artifacts and saves the manifest.
+func (r *manifestRecorder) StepFinished(ctx context.Context, storyKey, workflow string, exitCode int) {
+	entry := runlog.StepEntry{
+		StoryKey:  storyKey,
+		Workflow:  workflow,
+		ExitCode:  exitCode,
+		StartedAt: r.stepStart,
+		Duration:  time.Since(r.stepStart),
+	}
+
+	if reporter, ok := r.runner.(ArtifactReporter); ok {
+		entry.Artifacts.ChangedFiles, entry.Artifacts.CreatedFiles = reporter.LastArtifacts()
+	}
+	if r.git != nil && r.baseSHA != "" {
+		if commits, err := r.git.CommitsSince(ctx, r.baseSHA); err == nil {
+			entry.Artifacts.Commits = commits
+		}
+	}
+
+	r.manifest.Steps = append(r.manifest.Steps, entry)
+	if err := runlog.SaveManifest(r.path, r.manifest); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+}
@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"bmaduum/internal/config"
+	"bmaduum/internal/output"
+	"bmaduum/internal/runlog"
+	"bmaduum/internal/status"
+)
+
+func TestStoryCommand_RunManifest(t *testing.T) {
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, `development_status:
+  6-1-first: ready-for-dev`)
+	manifestPath := filepath.Join(tmpDir, "run-manifest.json")
+
+	gitHelper := &MockGitHelper{Head: "base000"}
+	mockRunner := &MockWorkflowRunner{
+		ChangedFiles: map[string][]string{"dev-story": {"internal/app.go", "internal/app_test.go"}},
+		CreatedFiles: map[string][]string{"code-review": {"_bmad-output/6-1-first-review.md"}},
+		OnRun: func(workflowName, storyKey string) {
+			// Only git-commit makes a commit
+			gitHelper.NewCommits = nil
+			if workflowName == "git-commit" {
+				gitHelper.NewCommits = []string{"abc1234"}
+			}
+		},
+	}
+	app := &App{
+		Config:       config.DefaultConfig(),
+		StatusReader: status.NewReader(tmpDir),
+		StatusWriter: &MockStatusWriter{},
+		Runner:       mockRunner,
+		Printer:      output.NewPrinterWithWriter(&bytes.Buffer{}),
+		Git:          gitHelper,
+	}
+
+	rootCmd := NewRootCommand(app)
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetErr(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{"story", "--run-manifest", manifestPath, "6-1-first"})
+	require.NoError(t, rootCmd.Execute())
+
+	m, err := runlog.LoadManifest(manifestPath)
+	require.NoError(t, err)
+	require.Len(t, m.Steps, 3)
+
+	dev := m.Steps[0]
+	assert.Equal(t, "6-1-first", dev.StoryKey)
+	assert.Equal(t, "dev-story", dev.Workflow)
+	assert.Equal(t, []string{"internal/app.go", "internal/app_test.go"}, dev.Artifacts.ChangedFiles)
+	assert.Empty(t, dev.Artifacts.Commits)
+
+	review := m.Steps[1]
+	assert.Equal(t, "code-review", review.Workflow)
+	assert.Equal(t, []string{"_bmad-output/6-1-first-review.md"}, review.Artifacts.CreatedFiles)
+
+	commit := m.Steps[2]
+	assert.Equal(t, "git-commit", commit.Workflow)
+	assert.Equal(t, 0, commit.ExitCode)
+	assert.Equal(t, []string{"abc1234"}, commit.Artifacts.Commits)
+}
+
+func TestStoryCommand_RunManifestRecordsFailedStep(t *testing.T) {
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, `development_status:
+  6-1-first: ready-for-dev`)
+	manifestPath := filepath.Join(tmpDir, "run-manifest.json")
+
+	app := &App{
+		Config:       config.DefaultConfig(),
+		StatusReader: status.NewReader(tmpDir),
+		StatusWriter: &MockStatusWriter{},
+		Runner:       &MockWorkflowRunner{FailOnWorkflow: "dev-story"},
+		Printer:      output.NewPrinterWithWriter(&bytes.Buffer{}),
+	}
+
+	rootCmd := NewRootCommand(app)
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetErr(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{"story", "--run-manifest", manifestPath, "6-1-first"})
+	require.Error(t, rootCmd.Execute())
+
+	m, err := runlog.LoadManifest(manifestPath)
+	require.NoError(t, err)
+	require.Len(t, m.Steps, 1)
+	assert.Equal(t, "dev-story", m.Steps[0].Workflow)
+	assert.Equal(t, 1, m.Steps[0].ExitCode)
+}
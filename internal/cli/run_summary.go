@@ -0,0 +1,244 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"bmaduum/internal/lifecycle"
+	"bmaduum/internal/output/core"
+)
+
+// Outcomes reported for stories and runs in the JSON run summary.
+const (
+	outcomeSuccess   = "success"
+	outcomeSkipped   = "skipped"
+	outcomeFailed    = "failed"
+	outcomeCancelled = "cancelled"
+)
+
+// QuietRunner is implemented by runners that can suppress their streaming
+// output. [workflow.Runner] implements it.
+type QuietRunner interface {
+	SetQuiet(quiet bool)
+}
+
+// runSummary is the single JSON document printed by story --quiet --json
+// and written by story and epic --report.
+type runSummary struct {
+	Outcome   string         `json:"outcome"`
+	StartedAt time.Time      `json:"started_at"`
+	Stories   []storySummary `json:"stories"`
+	Totals    runTotals      `json:"totals"`
+}
+
+// storySummary is the result of one story in a [runSummary].
+type storySummary struct {
+	StoryKey string `json:"story_key"`
+	Outcome  string `json:"outcome"`
+	// FinalStatus is the story's status when it finished, if it could be
+	// read.
+	FinalStatus string `json:"final_status,omitempty"`
+	Error       string `json:"error,omitempty"`
+	// Retries counts the steps that re-ran a workflow right after it failed.
+	Retries int           `json:"retries"`
+	Steps   []stepSummary `json:"steps"`
+}
+
+// stepSummary is the result of one workflow step. Retry attempts are
+// reported as separate steps, numbered by Attempt from 1.
+type stepSummary struct {
+	Workflow     string  `json:"workflow"`
+	Attempt      int     `json:"attempt"`
+	ExitCode     int     `json:"exit_code"`
+	DurationMS   int64   `json:"duration_ms"`
+	InputTokens  int     `json:"input_tokens"`
+	OutputTokens int     `json:"output_tokens"`
+	CostUSD      float64 `json:"cost_usd"`
+}
+
+// runTotals aggregates the stories and steps of a [runSummary].
+type runTotals struct {
+	Stories      int     `json:"stories"`
+	Succeeded    int     `json:"succeeded"`
+	Skipped      int     `json:"skipped"`
+	Failed       int     `json:"failed"`
+	Cancelled    int     `json:"cancelled"`
+	DurationMS   int64   `json:"duration_ms"`
+	InputTokens  int     `json:"input_tokens"`
+	OutputTokens int     `json:"output_tokens"`
+	CostUSD      float64 `json:"cost_usd"`
+}
+
+// summaryRecorder implements [lifecycle.StepObserver], collecting the
+// per-step results for the JSON run summary.
+//
+// Step usage is the change in the runner's total usage across the step, so
+// it is zero for runners that do not implement [UsageReporter].
+type summaryRecorder struct {
+	runner     WorkflowRunner
+	reader     StatusReader
+	start      time.Time
+	stepStart  time.Time
+	stepUsage  core.Usage
+	stories    []storySummary
+	storyIndex map[string]int
+}
+
+// newSummaryRecorder creates a recorder for a run starting now.
+func newSummaryRecorder(app *App) *summaryRecorder {
+	return &summaryRecorder{
+		runner:     app.Runner,
+		reader:     app.StatusReader,
+		start:      time.Now(),
+		storyIndex: make(map[string]int),
+	}
+}
+
+// StepStarted notes the start time and usage so far.
+func (r *summaryRecorder) StepStarted(ctx context.Context, storyKey, workflow string) {
+	r.stepStart = time.Now()
+	r.stepUsage = r.totalUsage()
+}
+
+// StepFinished records the step's exit code, duration, and usage. A step
+// that re-runs the story's previous step after it failed counts as a retry.
+func (r *summaryRecorder) StepFinished(ctx context.Context, storyKey, workflow string, exitCode int) {
+	total := r.totalUsage()
+	story := r.story(storyKey)
+	attempt := 1
+	if n := len(story.Steps); n > 0 && story.Steps[n-1].Workflow == workflow && story.Steps[n-1].ExitCode != 0 {
+		attempt = story.Steps[n-1].Attempt + 1
+		story.Retries++
+	}
+	story.Steps = append(story.Steps, stepSummary{
+		Workflow:     workflow,
+		Attempt:      attempt,
+		ExitCode:     exitCode,
+		DurationMS:   time.Since(r.stepStart).Milliseconds(),
+		InputTokens:  total.InputTokens - r.stepUsage.InputTokens,
+		OutputTokens: total.OutputTokens - r.stepUsage.OutputTokens,
+		CostUSD:      total.CostUSD - r.stepUsage.CostUSD,
+	})
+}
+
+// finishStory records a story's outcome, its current status, and, for
+// failures, its error.
+func (r *summaryRecorder) finishStory(storyKey, outcome string, err error) {
+	story := r.story(storyKey)
+	story.Outcome = outcome
+	if r.reader != nil {
+		if s, readErr := r.reader.GetStoryStatus(storyKey); readErr == nil {
+			story.FinalStatus = string(s)
+		}
+	}
+	if err != nil {
+		story.Error = err.Error()
+	}
+}
+
+// write encodes the summary of the run so far to w.
+func (r *summaryRecorder) write(w io.Writer) error {
+	summary := runSummary{Outcome: outcomeSuccess, StartedAt: r.start.UTC(), Stories: r.stories}
+	if summary.Stories == nil {
+		summary.Stories = []storySummary{}
+	}
+
+	for i := range summary.Stories {
+		story := &summary.Stories[i]
+		if story.Steps == nil {
+			story.Steps = []stepSummary{}
+		}
+		switch story.Outcome {
+		case outcomeSuccess:
+			summary.Totals.Succeeded++
+		case outcomeSkipped:
+			summary.Totals.Skipped++
+		case outcomeCancelled:
+			summary.Totals.Cancelled++
+			summary.Outcome = outcomeCancelled
+		default:
+			// A story interrupted before its outcome was recorded failed
+			story.Outcome = outcomeFailed
+			summary.Totals.Failed++
+			summary.Outcome = outcomeFailed
+		}
+		for _, step := range story.Steps {
+			summary.Totals.InputTokens += step.InputTokens
+			summary.Totals.OutputTokens += step.OutputTokens
+			summary.Totals.CostUSD += step.CostUSD
+		}
+	}
+	summary.Totals.Stories = len(summary.Stories)
+	summary.Totals.DurationMS = time.Since(r.start).Milliseconds()
+
+	return writeJSON(w, summary)
+}
+
+// writeFile writes the summary of the run so far to the file at path,
+// replacing it.
+func (r *summaryRecorder) writeFile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to write run report: %w", err)
+	}
+	if err := r.write(f); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write run report: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write run report: %w", err)
+	}
+	return nil
+}
+
+// story returns the summary entry for storyKey, adding it if needed.
+func (r *summaryRecorder) story(storyKey string) *storySummary {
+	i, ok := r.storyIndex[storyKey]
+	if !ok {
+		i = len(r.stories)
+		r.storyIndex[storyKey] = i
+		r.stories = append(r.stories, storySummary{StoryKey: storyKey})
+	}
+	return &r.stories[i]
+}
+
+// totalUsage returns the runner's cumulative usage, or zero if it does not
+// report usage.
+func (r *summaryRecorder) totalUsage() core.Usage {
+	if reporter, ok := r.runner.(UsageReporter); ok {
+		return reporter.TotalUsage()
+	}
+	return core.Usage{}
+}
+
+// stepObservers fans [lifecycle.StepObserver] notifications out to several
+// observers, in order.
+type stepObservers []lifecycle.StepObserver
+
+// StepStarted notifies each observer.
+func (o stepObservers) StepStarted(ctx context.Context, storyKey, workflow string) {
+	for _, observer := range o {
+		observer.StepStarted(ctx, storyKey, workflow)
+	}
+}
+
+// StepFinished notifies each observer.
+func (o stepObservers) StepFinished(ctx context.Context, storyKey, workflow string, exitCode int) {
+	for _, observer := range o {
+		observer.StepFinished(ctx, storyKey, workflow, exitCode)
+	}
+}
+
+// redirectStdout points os.Stdout at os.Stderr so output printed with
+// fmt.Printf stays off the machine-readable stream. It returns a function
+// that restores os.Stdout.
+func redirectStdout() func() {
+	stdout := os.Stdout
+	os.Stdout = os.Stderr
+	return func() {
+		os.Stdout = stdout
+	}
+}
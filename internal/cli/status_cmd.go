@@ -0,0 +1,262 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"bmaduum/internal/router"
+	"bmaduum/internal/status"
+)
+
+func newStatusCommand(app *App) *cobra.Command {
+	var orphans bool
+	var epicID string
+	var jsonOutput bool
+	var completeKeys bool
+
+	cmd := &cobra.Command{
+		Use:   "status [story-key]",
+		Short: "Show the sprint status board, a story, or an epic",
+		Long: `Show the development status of stories in sprint-status.yaml.
+
+Without arguments, every story on the board is listed with its status.
+With a story key, the story's status and its remaining lifecycle steps are
+shown. With --epic, every story in the epic is listed in story order.
+The command exits non-zero if the story or epic is not found.
+
+Use --json to print the result as JSON for other tooling.
+
+Use --orphans to cross-reference story files with the board instead. Story
+files are markdown files named after their story key (e.g. 7-1-define-schema.md)
+anywhere under the directory containing sprint-status.yaml. Two kinds of
+drift are reported:
+  - files without entries: a story file exists but the board has no entry
+  - entries without files: the board has an entry but no story file exists
+    (backlog entries are ignored since create-story has not written them yet)
+
+With --orphans the command exits non-zero if any drift is found.
+
+Examples:
+  bmaduum status
+  bmaduum status 7-1-define-schema
+  bmaduum status --epic 7 --json
+  bmaduum status --orphans`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := cmd.OutOrStdout()
+
+			// Print the valid status values, one per line, for scripts
+			if completeKeys {
+				completions, _ := completeStatuses(cmd, args, "")
+				for _, s := range completions {
+					fmt.Fprintln(out, s)
+				}
+				return nil
+			}
+
+			if err := checkStatusFlags(args, epicID, orphans, jsonOutput); err != nil {
+				cmd.SilenceUsage = true
+				fmt.Fprintf(out, "Error: %v\n", err)
+				return NewExitError(1)
+			}
+
+			switch {
+			case len(args) == 1:
+				return runStoryStatus(cmd, app, args[0], jsonOutput)
+			case epicID != "":
+				return runEpicStatus(cmd, app, epicID, jsonOutput)
+			}
+
+			sprintStatus, err := app.StatusReader.Read()
+			if err != nil {
+				cmd.SilenceUsage = true
+				fmt.Fprintf(out, "Error: %v\n", err)
+				return NewExitError(1)
+			}
+
+			if !orphans {
+				if jsonOutput {
+					return writeJSON(out, storyStatusesJSON{Stories: boardEntries(sprintStatus)})
+				}
+				printStatusBoard(out, sprintStatus)
+				return nil
+			}
+
+			artifactsDir := filepath.Dir(app.StatusReader.Path())
+			storyFiles, err := status.DiscoverStoryFiles(artifactsDir)
+			if err != nil {
+				cmd.SilenceUsage = true
+				fmt.Fprintf(out, "Error discovering story files: %v\n", err)
+				return NewExitError(1)
+			}
+
+			report := status.FindOrphans(sprintStatus, storyFiles)
+			printOrphanReport(out, report)
+			if report.HasOrphans() {
+				cmd.SilenceUsage = true
+				return NewExitError(1)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&orphans, "orphans", false, "Report story files and board entries that do not match")
+	cmd.Flags().StringVar(&epicID, "epic", "", "List the stories of `epic-id` with their status")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Print the status as JSON")
+	cmd.Flags().BoolVar(&completeKeys, "complete-keys", false, "Print the valid status values, one per line")
+	_ = cmd.Flags().MarkHidden("complete-keys")
+
+	return cmd
+}
+
+// storyStatusJSON is the JSON form of a single story's status.
+type storyStatusJSON struct {
+	StoryKey       string                 `json:"story_key"`
+	Status         status.Status          `json:"status"`
+	RemainingSteps []router.LifecycleStep `json:"remaining_steps,omitempty"`
+}
+
+// storyStatusesJSON is the JSON form of the board or an epic.
+type storyStatusesJSON struct {
+	Epic    string            `json:"epic,omitempty"`
+	Stories []storyStatusJSON `json:"stories"`
+}
+
+// checkStatusFlags rejects combinations of the status command's modes.
+func checkStatusFlags(args []string, epicID string, orphans, jsonOutput bool) error {
+	if len(args) == 1 && epicID != "" {
+		return fmt.Errorf("--epic cannot be combined with a story key")
+	}
+	if orphans && (len(args) == 1 || epicID != "") {
+		return fmt.Errorf("--orphans cannot be combined with a story key or --epic")
+	}
+	if orphans && jsonOutput {
+		return fmt.Errorf("--json cannot be combined with --orphans")
+	}
+	return nil
+}
+
+// runStoryStatus prints a story's status and its remaining lifecycle steps.
+func runStoryStatus(cmd *cobra.Command, app *App, storyKey string, jsonOutput bool) error {
+	out := cmd.OutOrStdout()
+
+	current, err := app.StatusReader.GetStoryStatus(storyKey)
+	if err != nil {
+		cmd.SilenceUsage = true
+		fmt.Fprintf(out, "Error: %v\n", err)
+		return NewExitError(1)
+	}
+
+	// bmad-help is disabled so that unknown statuses never invoke Claude
+	steps, err := newLifecycleExecutor(app, true, false, false).GetSteps(storyKey)
+	if err != nil && !errors.Is(err, router.ErrStoryComplete) {
+		cmd.SilenceUsage = true
+		fmt.Fprintf(out, "Error: %v\n", err)
+		return NewExitError(1)
+	}
+
+	if jsonOutput {
+		return writeJSON(out, storyStatusJSON{StoryKey: storyKey, Status: current, RemainingSteps: steps})
+	}
+
+	fmt.Fprintf(out, "%s: %s\n", storyKey, current)
+	if len(steps) == 0 {
+		fmt.Fprintln(out, "No remaining steps")
+		return nil
+	}
+	fmt.Fprintln(out, "Remaining steps:")
+	for i, step := range steps {
+		fmt.Fprintf(out, "  %d. %s → %s\n", i+1, step.Workflow, step.NextStatus)
+	}
+	return nil
+}
+
+// runEpicStatus prints every story in an epic with its status, in story order.
+func runEpicStatus(cmd *cobra.Command, app *App, epicID string, jsonOutput bool) error {
+	out := cmd.OutOrStdout()
+
+	storyKeys, err := app.StatusReader.GetEpicStories(epicID)
+	if err != nil {
+		cmd.SilenceUsage = true
+		fmt.Fprintf(out, "Error: %v\n", err)
+		return NewExitError(1)
+	}
+	sprintStatus, err := app.StatusReader.Read()
+	if err != nil {
+		cmd.SilenceUsage = true
+		fmt.Fprintf(out, "Error: %v\n", err)
+		return NewExitError(1)
+	}
+
+	result := storyStatusesJSON{Epic: epicID}
+	for _, key := range storyKeys {
+		result.Stories = append(result.Stories, storyStatusJSON{StoryKey: key, Status: sprintStatus.DevelopmentStatus[key]})
+	}
+
+	if jsonOutput {
+		return writeJSON(out, result)
+	}
+
+	fmt.Fprintf(out, "Epic %s (%d stories):\n", epicID, len(result.Stories))
+	for _, story := range result.Stories {
+		fmt.Fprintf(out, "  %-40s %s\n", story.StoryKey, story.Status)
+	}
+	return nil
+}
+
+// boardEntries returns every story on the board with its status, sorted by key.
+func boardEntries(sprintStatus *status.SprintStatus) []storyStatusJSON {
+	keys := make([]string, 0, len(sprintStatus.DevelopmentStatus))
+	for key := range sprintStatus.DevelopmentStatus {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	entries := make([]storyStatusJSON, 0, len(keys))
+	for _, key := range keys {
+		entries = append(entries, storyStatusJSON{StoryKey: key, Status: sprintStatus.DevelopmentStatus[key]})
+	}
+	return entries
+}
+
+// writeJSON writes v to out as indented JSON.
+func writeJSON(out io.Writer, v any) error {
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+// printStatusBoard prints each story key with its status, sorted by key.
+func printStatusBoard(out io.Writer, sprintStatus *status.SprintStatus) {
+	for _, entry := range boardEntries(sprintStatus) {
+		fmt.Fprintf(out, "%-40s %s\n", entry.StoryKey, entry.Status)
+	}
+}
+
+// printOrphanReport prints both orphan categories of report.
+func printOrphanReport(out io.Writer, report *status.OrphanReport) {
+	if !report.HasOrphans() {
+		fmt.Fprintln(out, "No orphaned stories found")
+		return
+	}
+
+	if len(report.FilesWithoutEntries) > 0 {
+		fmt.Fprintf(out, "Story files without sprint-status entries (%d):\n", len(report.FilesWithoutEntries))
+		for _, key := range report.FilesWithoutEntries {
+			fmt.Fprintf(out, "  %s\n", key)
+		}
+	}
+
+	if len(report.EntriesWithoutFiles) > 0 {
+		fmt.Fprintf(out, "Sprint-status entries without story files (%d):\n", len(report.EntriesWithoutFiles))
+		for _, key := range report.EntriesWithoutFiles {
+			fmt.Fprintf(out, "  %s\n", key)
+		}
+	}
+}
@@ -0,0 +1,199 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"bmaduum/internal/config"
+	"bmaduum/internal/output"
+	"bmaduum/internal/status"
+)
+
+func runStatusCommand(t *testing.T, tmpDir string, args ...string) (string, error) {
+	t.Helper()
+
+	app := &App{
+		Config:       config.DefaultConfig(),
+		StatusReader: status.NewReader(tmpDir),
+		StatusWriter: &MockStatusWriter{},
+		Runner:       &MockWorkflowRunner{},
+		Printer:      output.NewPrinterWithWriter(&bytes.Buffer{}),
+	}
+
+	rootCmd := NewRootCommand(app)
+	outBuf := &bytes.Buffer{}
+	rootCmd.SetOut(outBuf)
+	rootCmd.SetErr(outBuf)
+	rootCmd.SetArgs(append([]string{"status"}, args...))
+
+	err := rootCmd.Execute()
+	return outBuf.String(), err
+}
+
+func TestStatusCommand_PrintsBoard(t *testing.T) {
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, `development_status:
+  7-2-create-api: review
+  7-1-define-schema: done`)
+
+	out, err := runStatusCommand(t, tmpDir)
+
+	require.NoError(t, err)
+	assert.Regexp(t, `(?s)7-1-define-schema\s+done.*7-2-create-api\s+review`, out)
+}
+
+func TestStatusCommand_Orphans(t *testing.T) {
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, `development_status:
+  epic-7: in-progress
+  7-1-define-schema: done
+  7-2-create-api: review
+  7-3-build-ui: backlog`)
+
+	artifactsDir := filepath.Join(tmpDir, "_bmad-output", "implementation-artifacts")
+	for _, name := range []string{"7-1-define-schema.md", "8-1-untracked-story.md"} {
+		require.NoError(t, os.WriteFile(filepath.Join(artifactsDir, name), []byte("# story\n"), 0644))
+	}
+
+	out, err := runStatusCommand(t, tmpDir, "--orphans")
+
+	require.Error(t, err)
+	code, ok := IsExitError(err)
+	assert.True(t, ok)
+	assert.Equal(t, 1, code)
+	assert.Contains(t, out, "Story files without sprint-status entries (1):\n  8-1-untracked-story\n")
+	assert.Contains(t, out, "Sprint-status entries without story files (1):\n  7-2-create-api\n")
+	assert.NotContains(t, out, "7-3-build-ui")
+	assert.NotContains(t, out, "epic-7")
+}
+
+func TestStatusCommand_NoOrphans(t *testing.T) {
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, `development_status:
+  7-1-define-schema: done`)
+
+	artifactsDir := filepath.Join(tmpDir, "_bmad-output", "implementation-artifacts")
+	require.NoError(t, os.WriteFile(filepath.Join(artifactsDir, "7-1-define-schema.md"), []byte("# story\n"), 0644))
+
+	out, err := runStatusCommand(t, tmpDir, "--orphans")
+
+	require.NoError(t, err)
+	assert.Contains(t, out, "No orphaned stories found")
+}
+
+func TestStatusCommand_Story(t *testing.T) {
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, `development_status:
+  7-1-define-schema: review
+  7-2-create-api: done`)
+
+	out, err := runStatusCommand(t, tmpDir, "7-1-define-schema")
+
+	require.NoError(t, err)
+	assert.Equal(t, "7-1-define-schema: review\nRemaining steps:\n  1. code-review → done\n  2. git-commit → done\n", out)
+
+	out, err = runStatusCommand(t, tmpDir, "7-2-create-api")
+
+	require.NoError(t, err)
+	assert.Equal(t, "7-2-create-api: done\nNo remaining steps\n", out)
+}
+
+func TestStatusCommand_StoryJSON(t *testing.T) {
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, `development_status:
+  7-1-define-schema: review`)
+
+	out, err := runStatusCommand(t, tmpDir, "7-1-define-schema", "--json")
+
+	require.NoError(t, err)
+	var result storyStatusJSON
+	require.NoError(t, json.Unmarshal([]byte(out), &result))
+	assert.Equal(t, "7-1-define-schema", result.StoryKey)
+	assert.Equal(t, status.StatusReview, result.Status)
+	require.Len(t, result.RemainingSteps, 2)
+	assert.Equal(t, "code-review", result.RemainingSteps[0].Workflow)
+}
+
+func TestStatusCommand_StoryNotFound(t *testing.T) {
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, `development_status:
+  7-1-define-schema: review`)
+
+	out, err := runStatusCommand(t, tmpDir, "9-9-missing")
+
+	require.Error(t, err)
+	assert.Equal(t, 1, err.(*ExitError).Code)
+	assert.Contains(t, out, "Error: story not found: 9-9-missing")
+}
+
+func TestStatusCommand_Epic(t *testing.T) {
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, `development_status:
+  7-10-polish: backlog
+  7-2-create-api: review
+  7-1-define-schema: done
+  8-1-other-epic: backlog`)
+
+	out, err := runStatusCommand(t, tmpDir, "--epic", "7")
+
+	require.NoError(t, err)
+	assert.Regexp(t, `(?s)^Epic 7 \(3 stories\):\n  7-1-define-schema\s+done\n  7-2-create-api\s+review\n  7-10-polish\s+backlog\n$`, out)
+
+	out, err = runStatusCommand(t, tmpDir, "--epic", "7", "--json")
+
+	require.NoError(t, err)
+	var result storyStatusesJSON
+	require.NoError(t, json.Unmarshal([]byte(out), &result))
+	assert.Equal(t, "7", result.Epic)
+	require.Len(t, result.Stories, 3)
+	assert.Equal(t, storyStatusJSON{StoryKey: "7-10-polish", Status: status.StatusBacklog}, result.Stories[2])
+}
+
+func TestStatusCommand_EpicNotFound(t *testing.T) {
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, `development_status:
+  7-1-define-schema: done`)
+
+	_, err := runStatusCommand(t, tmpDir, "--epic", "9")
+
+	require.Error(t, err)
+	assert.Equal(t, 1, err.(*ExitError).Code)
+}
+
+func TestStatusCommand_BoardJSON(t *testing.T) {
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, `development_status:
+  7-2-create-api: review
+  7-1-define-schema: done`)
+
+	out, err := runStatusCommand(t, tmpDir, "--json")
+
+	require.NoError(t, err)
+	var result storyStatusesJSON
+	require.NoError(t, json.Unmarshal([]byte(out), &result))
+	assert.Equal(t, []storyStatusJSON{
+		{StoryKey: "7-1-define-schema", Status: status.StatusDone},
+		{StoryKey: "7-2-create-api", Status: status.StatusReview},
+	}, result.Stories)
+}
+
+func TestStatusCommand_ConflictingFlags(t *testing.T) {
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, `development_status:
+  7-1-define-schema: done`)
+
+	for _, args := range [][]string{
+		{"7-1-define-schema", "--epic", "7"},
+		{"--orphans", "--epic", "7"},
+		{"--orphans", "--json"},
+	} {
+		_, err := runStatusCommand(t, tmpDir, args...)
+		assert.Error(t, err, "args %v", args)
+	}
+}
@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"bmaduum/internal/router"
+)
+
+func newStepCommand(app *App) *cobra.Command {
+	var noBmadHelp bool
+	var forceCreate bool
+	var force bool
+	var retries int
+	var model string
+
+	cmd := &cobra.Command{
+		Use:   "step <story-key>",
+		Short: "Run the next lifecycle step of a story",
+		Long: `Run only the next step of a story's lifecycle and advance its status once.
+
+The workflow is chosen from the story's current status, as 'story' would for
+its first step:
+  - backlog       → create-story → ready-for-dev
+  - ready-for-dev → dev-story → review
+  - in-progress   → dev-story → review
+  - review        → code-review → done
+
+The command fails if the workflow fails or the story is already done. When
+review loops are enabled and code-review sends the story back, the status it
+set is kept and the next 'step' runs dev-story again.
+
+Use --retries N to re-run the workflow up to N times if it fails.
+Use --no-bmad-help to disable the bmad-help fallback for unknown statuses.
+Use --force-create to run create-story even if the story file already exists.
+Use --model to run the step with the given Claude model if its workflow has
+no configured model.
+
+Example:
+  bmaduum step 6-1`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			storyKey := args[0]
+
+			applyDefaultModel(app, model)
+			if err := requireClaude(cmd, app); err != nil {
+				return err
+			}
+
+			executor := newLifecycleExecutor(app, noBmadHelp, forceCreate, force)
+			if notifier := newRunNotifier(app); notifier != nil {
+				executor.SetStepObserver(notifier)
+			}
+			resolveRetries(cmd, app.Config, executor, false, retries)
+			executor.SetProgressCallback(func(stepIndex, totalSteps int, workflow string) {
+				app.Printer.StepStart(stepIndex, totalSteps, workflow)
+			})
+			app.Runner.SetOperation(fmt.Sprintf("Story %s", storyKey))
+			defer printRunUsage(app)
+
+			err := executor.ExecuteStep(ctx, storyKey)
+			if errors.Is(err, router.ErrStoryComplete) {
+				cmd.SilenceUsage = true
+				app.printf("Story %s is already done\n", storyKey)
+				return NewExitError(1)
+			}
+			if err != nil {
+				cmd.SilenceUsage = true
+				app.printf("Error running step for story %s: %v\n", storyKey, err)
+				return NewExitError(1)
+			}
+
+			printStoryTransitions(executor, storyKey)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&noBmadHelp, "no-bmad-help", false, "Disable bmad-help fallback for unknown statuses")
+	cmd.Flags().BoolVar(&forceCreate, "force-create", false, "Run create-story even if the story file already exists")
+	cmd.Flags().BoolVar(&force, "force", false, "Allow status transitions that validate_transitions would reject")
+	addRetriesFlag(cmd, &retries)
+	addModelFlag(cmd, &model)
+	return cmd
+}
@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"bmaduum/internal/config"
+	"bmaduum/internal/output"
+	"bmaduum/internal/status"
+)
+
+func TestStepCommand(t *testing.T) {
+	tests := []struct {
+		name             string
+		statusYAML       string
+		failOnWorkflow   string
+		expectError      bool
+		expectedWorkflow []string
+		expectedUpdates  []status.Status
+	}{
+		{
+			name: "backlog story runs create-story",
+			statusYAML: `development_status:
+  STORY-1: backlog`,
+			expectedWorkflow: []string{"create-story"},
+			expectedUpdates:  []status.Status{status.StatusReadyForDev},
+		},
+		{
+			name: "in-progress story runs dev-story",
+			statusYAML: `development_status:
+  STORY-1: in-progress`,
+			expectedWorkflow: []string{"dev-story"},
+			expectedUpdates:  []status.Status{status.StatusReview},
+		},
+		{
+			name: "failed workflow leaves the status",
+			statusYAML: `development_status:
+  STORY-1: review`,
+			failOnWorkflow:   "code-review",
+			expectError:      true,
+			expectedWorkflow: []string{"code-review"},
+		},
+		{
+			name: "done story fails",
+			statusYAML: `development_status:
+  STORY-1: done`,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			createSprintStatusFile(t, tmpDir, tt.statusYAML)
+
+			mockRunner := &MockWorkflowRunner{FailOnWorkflow: tt.failOnWorkflow}
+			mockWriter := &MockStatusWriter{}
+			app := &App{
+				Config:       config.DefaultConfig(),
+				StatusReader: status.NewReader(tmpDir),
+				StatusWriter: mockWriter,
+				Runner:       mockRunner,
+				Printer:      output.NewPrinterWithWriter(&bytes.Buffer{}),
+			}
+
+			rootCmd := NewRootCommand(app)
+			rootCmd.SetOut(&bytes.Buffer{})
+			rootCmd.SetErr(&bytes.Buffer{})
+			rootCmd.SetArgs([]string{"step", "STORY-1"})
+
+			err := rootCmd.Execute()
+			if tt.expectError {
+				code, ok := IsExitError(err)
+				require.True(t, ok, "expected an exit error, got %v", err)
+				assert.Equal(t, 1, code)
+			} else {
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.expectedWorkflow, mockRunner.ExecutedWorkflows)
+			var updates []status.Status
+			for _, update := range mockWriter.Updates {
+				updates = append(updates, update.NewStatus)
+			}
+			assert.Equal(t, tt.expectedUpdates, updates)
+		})
+	}
+}
@@ -1,14 +1,20 @@
 package cli
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"path/filepath"
 	"strings"
 
 	"github.com/spf13/cobra"
 
+	"bmaduum/internal/config"
 	"bmaduum/internal/lifecycle"
+	"bmaduum/internal/notify"
 	"bmaduum/internal/router"
+	"bmaduum/internal/state"
+	"bmaduum/internal/status"
 )
 
 // printModuleInfo prints discovered BMAD modules for dry-run output.
@@ -18,20 +24,93 @@ func printModuleInfo(app *App) {
 		return
 	}
 	names := app.Modules.Names()
-	fmt.Printf("Modules: %s\n", strings.Join(names, ", "))
+	app.printf("Modules: %s\n", strings.Join(names, ", "))
+}
+
+// printStoryTransitions prints the status transitions the executor performed
+// for a story. Does nothing if no transitions occurred.
+func printStoryTransitions(executor *lifecycle.Executor, storyKey string) {
+	trace := lifecycle.FormatTransitions(executor.Transitions())
+	if trace == "" {
+		return
+	}
+	fmt.Fprintf(executor.Output(), "Transitions for %s: %s\n", storyKey, trace)
+}
+
+// newLifecycleExecutor creates a lifecycle executor wired to the app's
+// dependencies. The bmad-help fallback is enabled unless noBmadHelp is set,
+// and create-story is skipped for backlog stories with an existing story
+// file unless forceCreate is set.
+func newLifecycleExecutor(app *App, noBmadHelp, forceCreate, force bool) *lifecycle.Executor {
+	// Reject illegal status transitions when configured, unless forced
+	var writer StatusWriter = app.StatusWriter
+	if app.Config.ValidateTransitions && !force {
+		writer = newTransitionCheckingWriter(app)
+	}
+
+	executor := lifecycle.NewExecutor(app.Runner, app.StatusReader, writer)
+	executor.SetOutput(app.stdout())
+	executor.SetRouter(app.Router)
+	executor.SetMaxReviewLoops(app.Config.MaxReviewLoops)
+	executor.SetMaxIterations(app.Config.MaxIterations)
+
+	// Enable bmad-help fallback unless disabled
+	if !noBmadHelp && app.BmadHelp != nil {
+		executor.SetBmadHelp(app.BmadHelp)
+	}
+
+	// Apply per-story step skipping from the overrides sidecar
+	if app.StoryOverrides != nil {
+		executor.SetStepSkipper(app.StoryOverrides)
+	}
+
+	// Story files, checkpoints, and sessions live next to sprint-status.yaml
+	artifactsDir := filepath.Dir(app.StatusReader.Path())
+	if !forceCreate {
+		executor.SetStoryFileChecker(status.NewStoryFiles(artifactsDir))
+	}
+	executor.SetCheckpointer(state.NewCheckpointStore(artifactsDir))
+	executor.SetSessionRecorder(state.NewSessionStore(artifactsDir))
+	executor.SetResumeSessions(app.Config.Claude.ResumeSessions)
+
+	return executor
 }
 
 func newStoryCommand(app *App) *cobra.Command {
 	var dryRun bool
 	var autoRetry bool
 	var noBmadHelp bool
+	var forceCreate bool
+	var abortOnUncommitted bool
+	var continueOnError bool
+	var checkEnv bool
+	var runManifest string
+	var resume bool
+	var printTransitions bool
+	var assumeYes bool
+	var retries int
+	var savePlan string
+	var planFile string
+	var stepList string
+	var onDone string
+	var quiet bool
+	var force bool
+	var jsonOutput bool
+	var model string
+	var fromStatus string
+	var until string
+	var startStatus string
+	var showPrompts bool
+	var report string
 
 	cmd := &cobra.Command{
 		Use:   "story <story-key> [story-key...]",
 		Short: "Run the full story lifecycle to completion",
 		Long: `Run the complete lifecycle for one or more stories from their current status to done.
 
-Each story is run to completion before moving to the next.
+Each story is run to completion before moving to the next. Stories are run
+in the given order, except that a story listed with depends_on in
+story-overrides.yaml runs after the stories it depends on.
 
 For each story, executes all remaining workflows based on its current status:
   - backlog       → create-story → dev-story → code-review → git-commit → done
@@ -40,67 +119,336 @@ For each story, executes all remaining workflows based on its current status:
   - review        → code-review → git-commit → done
   - done          → skipped (story already complete)
 
-The command stops on the first failure. Done stories are skipped and do not cause failure.
+The command stops on the first failure unless --continue-on-error is set.
+Done stories are skipped and do not cause failure.
 Status is updated in sprint-status.yaml after each successful workflow.
 
 Use --dry-run to preview workflows without executing them.
+Use --dry-run --check-env to also verify the environment is ready to run.
+Use --dry-run --show-prompts to also print the prompt each step sends to Claude.
 Use --auto-retry to automatically retry on rate limit errors.
+Use --retries N to re-run each failed workflow step up to N times (overrides --auto-retry).
 Use --no-bmad-help to disable the bmad-help fallback for unknown statuses.
+Use --force-create to run create-story even if the story file already exists.
+Use --abort-on-uncommitted-after to fail a story that leaves uncommitted changes.
+Use --continue-on-error to record a failed story and go on with the next one;
+the command exits 1 at the end if any story failed.
+Use --run-manifest to record each step's changed files and commits to a JSON file.
+Use --report to write a JSON report of each story's final status, steps, retries,
+token usage, and cost to a file when the run ends.
+Use --resume to continue each story from its last checkpoint after a crash.
+Use --print-transitions to print the status transitions that actually occurred.
+Use --assume-yes to proceed when the run exceeds max_stories_per_run.
+Use --save-plan to write the resolved lifecycle plan to a file before execution.
+Use --plan-file to execute a previously saved plan exactly as recorded, without
+recomputing steps from sprint-status.yaml (story keys are taken from the plan).
+Use --on-done to choose what happens to a story that is already done: skip it
+(default), fail with error, or rerun its lifecycle from dev-story.
+Use --steps to run only the named workflows, in the given order, regardless of
+each story's status; each step still applies its status transition.
+Use --model to run steps whose workflow has no configured model with the given
+Claude model.
+Use --from-status to skip stories whose status comes before the given one in
+the lifecycle, and done stories.
+Use --until to stop each story once it reaches the given status, e.g. --until
+review to leave stories for a human to look at before code-review; the
+preview of --dry-run stops there too.
+Use --start-status to run each story's lifecycle as if it had the given status,
+when sprint-status.yaml is out of sync with the story's actual state. Only the
+initial status lookup is bypassed; status updates are still written.
+Use --quiet to hide Claude's streaming output. Add --json to print nothing on
+stdout but a single JSON summary of the run; other output goes to stderr.
 
 Examples:
   bmaduum story 6-1
-  bmaduum story 6-1 6-2 6-3`,
-		Args: cobra.MinimumNArgs(1),
+  bmaduum story 6-1 6-2 6-3
+  bmaduum story --dry-run --save-plan plan.json 6-1 6-2
+  bmaduum story --plan-file plan.json
+  bmaduum story --steps create-story,dev-story 6-1
+  bmaduum story --until review 6-1`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if planFile != "" {
+				return cobra.NoArgs(cmd, args)
+			}
+			return cobra.MinimumNArgs(1)(cmd, args)
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
 			storyKeys := args
 
+			// Keep stdout for the JSON summary; everything else goes to stderr
+			out := cmd.OutOrStdout()
+			if jsonOutput {
+				defer redirectStdout()()
+			}
+
+			onDone, err := resolveOnDone(app.Config, onDone)
+			if err != nil {
+				cmd.SilenceUsage = true
+				app.printf("Error: %v\n", err)
+				return NewExitError(1)
+			}
+
+			if err := checkQuietJSONFlags(quiet, jsonOutput, dryRun, stepList, planFile, savePlan); err != nil {
+				cmd.SilenceUsage = true
+				app.printf("Error: %v\n", err)
+				return NewExitError(1)
+			}
+			if report != "" && (dryRun || stepList != "" || planFile != "") {
+				cmd.SilenceUsage = true
+				app.println("Error: --report cannot be combined with --dry-run, --steps, or --plan-file")
+				return NewExitError(1)
+			}
+			if showPrompts && (!dryRun || stepList != "") {
+				cmd.SilenceUsage = true
+				app.println("Error: --show-prompts requires --dry-run and cannot be combined with --steps")
+				return NewExitError(1)
+			}
+			if jsonOutput && app.jsonPrinter != nil {
+				cmd.SilenceUsage = true
+				app.println("Error: --json cannot be combined with --output json")
+				return NewExitError(1)
+			}
+			if checkEnv && !dryRun {
+				cmd.SilenceUsage = true
+				app.println("Error: --check-env requires --dry-run")
+				return NewExitError(1)
+			}
+			if (until != "" || startStatus != "") && (stepList != "" || planFile != "") {
+				cmd.SilenceUsage = true
+				app.println("Error: --until and --start-status cannot be combined with --steps or --plan-file")
+				return NewExitError(1)
+			}
+			applyDefaultModel(app, model)
+			// Run dependencies first; a saved plan keeps its recorded order
+			if planFile == "" {
+				storyKeys, err = orderStories(app, storyKeys, nil)
+				if err == nil {
+					storyKeys, err = filterFromStatus(app, storyKeys, fromStatus)
+				}
+				if err != nil {
+					cmd.SilenceUsage = true
+					app.printf("Error: %v\n", err)
+					return NewExitError(1)
+				}
+			} else if fromStatus != "" {
+				cmd.SilenceUsage = true
+				app.println("Error: --from-status cannot be combined with --plan-file")
+				return NewExitError(1)
+			}
+
+			if quiet {
+				if runner, ok := app.Runner.(QuietRunner); ok {
+					runner.SetQuiet(true)
+				}
+			}
+
+			// Fail fast if Claude cannot run, except when only previewing
+			if !dryRun {
+				if err := requireClaude(cmd, app); err != nil {
+					return err
+				}
+			}
+
 			// Create lifecycle executor with app dependencies
-			executor := lifecycle.NewExecutor(app.Runner, app.StatusReader, app.StatusWriter)
-			executor.SetRouter(app.Router)
+			executor := newLifecycleExecutor(app, noBmadHelp, forceCreate, force)
+			var observers stepObservers
+			if runManifest != "" {
+				observers = append(observers, newManifestRecorder(app, runManifest))
+			}
+			var summary *summaryRecorder
+			if jsonOutput || report != "" {
+				summary = newSummaryRecorder(app)
+				observers = append(observers, summary)
+			}
+			notifier := newRunNotifier(app)
+			if notifier != nil {
+				observers = append(observers, notifier)
+			}
+			if len(observers) > 0 {
+				executor.SetStepObserver(observers)
+			}
+			autoRetry, maxRetries := resolveRetries(cmd, app.Config, executor, autoRetry, retries)
+			err = applyUntil(app, executor, until)
+			if err == nil {
+				err = applyStartStatus(app, executor, startStatus)
+			}
+			if err != nil {
+				cmd.SilenceUsage = true
+				app.printf("Error: %v\n", err)
+				return NewExitError(1)
+			}
+
+			// Run an explicit subset of lifecycle steps
+			if stepList != "" {
+				if planFile != "" || savePlan != "" || resume {
+					cmd.SilenceUsage = true
+					app.println("Error: --steps cannot be combined with --plan-file, --save-plan, or --resume")
+					return NewExitError(1)
+				}
+				return runStorySteps(cmd, app, executor, storyKeys, stepList, dryRun, assumeYes, printTransitions)
+			}
 
-			// Enable bmad-help fallback unless disabled
-			if !noBmadHelp && app.BmadHelp != nil {
-				executor.SetBmadHelp(app.BmadHelp)
+			// Execute a saved plan instead of resolving steps from status
+			if planFile != "" {
+				return runStoryPlanFile(cmd, app, executor, planFile, assumeYes, printTransitions)
+			}
+
+			if savePlan != "" {
+				plan, err := executor.BuildPlan(storyKeys)
+				if err == nil {
+					err = lifecycle.SavePlan(savePlan, plan)
+				}
+				if err != nil {
+					cmd.SilenceUsage = true
+					app.printf("Error saving plan: %v\n", err)
+					return NewExitError(1)
+				}
+				app.printf("Plan written to %s\n", savePlan)
 			}
 
 			// Handle dry-run mode
 			if dryRun {
-				return runStoryDryRun(cmd, app, executor, storyKeys)
+				err := runStoryDryRun(cmd, app, executor, storyKeys, showPrompts)
+				if checkEnv && !reportEnvReadiness(app, executor, storyKeys) && err == nil {
+					cmd.SilenceUsage = true
+					return NewExitError(1)
+				}
+				return err
+			}
+
+			if err := checkStoryLimit(app.Config, len(storyKeys), assumeYes); err != nil {
+				cmd.SilenceUsage = true
+				app.printf("Error: %v\n", err)
+				return NewExitError(1)
+			}
+
+			// Report what the run cost, including runs that fail part way
+			if jsonOutput {
+				defer func() {
+					if err := summary.write(out); err != nil {
+						app.printf("Error writing run summary: %v\n", err)
+					}
+				}()
+			} else {
+				defer printRunUsage(app)
+			}
+			if report != "" {
+				defer func() {
+					if err := summary.writeFile(report); err != nil {
+						app.printf("Error: %v\n", err)
+					}
+				}()
 			}
 
-			// Execute full lifecycle for each story in order
+			// Record each story's outcome for the JSON summary and notifications
+			var outcomes []notify.StoryOutcome
+			finishStory := func(storyKey, outcome string, err error) {
+				if summary != nil {
+					summary.finishStory(storyKey, outcome, err)
+				}
+				if outcome == outcomeFailed {
+					notifier.storyFailed(ctx, storyKey, err)
+				}
+				storyOutcome := notify.StoryOutcome{StoryKey: storyKey, Outcome: outcome}
+				if err != nil {
+					storyOutcome.Error = err.Error()
+				}
+				outcomes = append(outcomes, storyOutcome)
+			}
+			defer func() {
+				notifier.runFinished(ctx, outcomes, remainingStories(storyKeys, outcomes))
+			}()
+
+			// Step headers are printed through the printer, which writes to stdout
+			stepStart := func(stepIndex, totalSteps int, workflow string) {
+				app.Printer.StepStart(stepIndex, totalSteps, workflow)
+			}
+			if jsonOutput {
+				stepStart = func(int, int, string) {}
+			}
+
+			// Execute full lifecycle for each story in order, collecting the
+			// stories that failed under --continue-on-error
+			var failed []string
+			failedKeys := make(map[string]bool)
 			for i, storyKey := range storyKeys {
 				// Set operation context for progress display
 				if len(storyKeys) > 1 {
 					app.Runner.SetOperation(fmt.Sprintf("Story %d of %d: %s", i+1, len(storyKeys), storyKey))
 					// Show story progress for multiple stories
-					fmt.Printf("─── Story %d of %d: %s\n", i+1, len(storyKeys), storyKey)
+					app.printf("─── Story %d of %d: %s\n", i+1, len(storyKeys), storyKey)
 				} else {
 					app.Runner.SetOperation(fmt.Sprintf("Story %s", storyKey))
 				}
 
-				err := executeWithRetry(ctx, executor, storyKey, autoRetry, 10, func(stepIndex, totalSteps int, workflow string) {
-					app.Printer.StepStart(stepIndex, totalSteps, workflow)
-				})
+				// Do not build on a story that failed
+				if dep, ok := failedDependency(app.StoryOverrides.DependsOn, storyKey, failedKeys); ok {
+					err := dependencyFailedError(dep)
+					finishStory(storyKey, outcomeFailed, err)
+					app.printf("Story %s not run: %v\n", storyKey, err)
+					failed = append(failed, storyKey)
+					failedKeys[storyKey] = true
+					continue
+				}
+
+				err := executeWithRetry(ctx, executor, storyKey, resume, autoRetry, maxRetries, stepStart)
+				if errors.Is(err, router.ErrStoryComplete) {
+					err = handleDoneStory(ctx, executor, storyKey, onDone)
+				}
+				if printTransitions {
+					printStoryTransitions(executor, storyKey)
+				}
+				if errors.Is(err, errStorySkipped) {
+					finishStory(storyKey, outcomeSkipped, nil)
+					continue
+				}
+				if errors.Is(err, context.Canceled) {
+					finishStory(storyKey, outcomeCancelled, err)
+					cmd.SilenceUsage = true
+					app.printf("Story %s cancelled\n", storyKey)
+					return NewExitError(1)
+				}
 				if err != nil {
+					finishStory(storyKey, outcomeFailed, err)
 					cmd.SilenceUsage = true
-					if errors.Is(err, router.ErrStoryComplete) {
-						fmt.Printf("Story %s is already complete, skipping\n", storyKey)
+					app.printf("Error running lifecycle for story %s: %v\n", storyKey, err)
+					if !continueOnError {
+						return NewExitError(1)
+					}
+					failed = append(failed, storyKey)
+					failedKeys[storyKey] = true
+					continue
+				}
+
+				// Fail the story if it left the working tree dirty
+				if abortOnUncommitted {
+					if err := checkCleanTree(ctx, app.Git, storyKey); err != nil {
+						finishStory(storyKey, outcomeFailed, err)
+						cmd.SilenceUsage = true
+						app.printf("Error: %v\n", err)
+						if !continueOnError {
+							return NewExitError(1)
+						}
+						failed = append(failed, storyKey)
+						failedKeys[storyKey] = true
 						continue
 					}
-					fmt.Printf("Error running lifecycle for story %s: %v\n", storyKey, err)
-					return NewExitError(1)
 				}
+				finishStory(storyKey, outcomeSuccess, nil)
 
 				// Show completion message
 				if len(storyKeys) > 1 {
-					fmt.Printf("Story %s completed successfully\n\n", storyKey)
+					app.printf("Story %s completed successfully\n\n", storyKey)
 				}
 			}
 
+			if len(failed) > 0 {
+				app.printf("✗ %d of %d stories failed: %s\n", len(failed), len(storyKeys), strings.Join(failed, ", "))
+				return NewExitError(1)
+			}
 			if len(storyKeys) > 1 {
-				fmt.Printf("All %d stories processed\n", len(storyKeys))
+				app.printf("All %d stories processed\n", len(storyKeys))
 			}
 
 			return nil
@@ -108,13 +456,219 @@ Examples:
 	}
 
 	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Preview workflows without executing them")
+	cmd.Flags().BoolVar(&checkEnv, "check-env", false, "With --dry-run, verify the Claude binary, manifest, prompts, and status file")
+	cmd.Flags().BoolVar(&showPrompts, "show-prompts", false, "With --dry-run, print the prompt each step would send to Claude")
 	cmd.Flags().BoolVar(&autoRetry, "auto-retry", false, "Automatically retry on rate limit errors")
+	addRetriesFlag(cmd, &retries)
 	cmd.Flags().BoolVar(&noBmadHelp, "no-bmad-help", false, "Disable bmad-help fallback for unknown statuses")
+	cmd.Flags().BoolVar(&forceCreate, "force-create", false, "Run create-story even if the story file already exists")
+	cmd.Flags().BoolVar(&force, "force", false, "Allow status transitions that validate_transitions would reject")
+	cmd.Flags().BoolVar(&abortOnUncommitted, "abort-on-uncommitted-after", false, "Fail a story if uncommitted changes remain after it completes")
+	cmd.Flags().BoolVar(&continueOnError, "continue-on-error", false, "Record a failed story and continue with the next one, exiting 1 at the end")
+	cmd.Flags().BoolVar(&resume, "resume", false, "Continue each story from its checkpoint, skipping steps completed before a crash")
+	cmd.Flags().StringVar(&runManifest, runManifestFlag, "", "Record each step and the files and commits it produced to `path` (JSON)")
+	cmd.Flags().StringVar(&report, "report", "", "Write a JSON report of each story's status, steps, retries, usage, and cost to `path`")
+	cmd.Flags().BoolVar(&assumeYes, "assume-yes", false, "Proceed even if the run exceeds max_stories_per_run")
+	cmd.Flags().BoolVar(&printTransitions, "print-transitions", false, "Print the status transitions performed for each story")
+	cmd.Flags().StringVar(&savePlan, "save-plan", "", "Write the resolved lifecycle plan to `path` before execution")
+	cmd.Flags().StringVar(&planFile, "plan-file", "", "Execute a previously saved plan from `path` instead of resolving from status")
+	cmd.Flags().StringVar(&onDone, "on-done", "", "What to do with a story that is already done: `skip`, error, or rerun (default from on_done config)")
+	cmd.Flags().BoolVar(&quiet, "quiet", false, "Hide Claude's streaming output")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "With --quiet, print only a JSON summary of the run on stdout")
+	cmd.Flags().StringVar(&stepList, "steps", "", "Run only these comma-separated `workflows`, in order, regardless of status")
+	addDefaultModelFlag(cmd, &model)
+	addFromStatusFlag(cmd, &fromStatus)
+	addUntilFlag(cmd, &until)
+	addStartStatusFlag(cmd, &startStatus)
 
 	return cmd
 }
 
-func runStoryDryRun(cmd *cobra.Command, app *App, executor *lifecycle.Executor, storyKeys []string) error {
+// checkQuietJSONFlags validates the --quiet and --json flags against the
+// other story flags. --json requires --quiet and is only supported for
+// lifecycle runs.
+func checkQuietJSONFlags(quiet, jsonOutput, dryRun bool, stepList, planFile, savePlan string) error {
+	if !jsonOutput {
+		return nil
+	}
+	if !quiet {
+		return fmt.Errorf("--json requires --quiet")
+	}
+	if dryRun || stepList != "" || planFile != "" || savePlan != "" {
+		return fmt.Errorf("--json cannot be combined with --dry-run, --steps, --plan-file, or --save-plan")
+	}
+	return nil
+}
+
+// errStorySkipped reports that a done story was skipped by [handleDoneStory].
+var errStorySkipped = errors.New("story skipped")
+
+// resolveOnDone returns the on-done behavior to use: the flag value if set,
+// otherwise the on_done config value, otherwise skip.
+func resolveOnDone(cfg *config.Config, flag string) (string, error) {
+	onDone := flag
+	if onDone == "" {
+		onDone = cfg.OnDone
+	}
+	switch onDone {
+	case "":
+		return config.OnDoneSkip, nil
+	case config.OnDoneSkip, config.OnDoneError, config.OnDoneRerun:
+		return onDone, nil
+	default:
+		return "", fmt.Errorf("invalid on-done behavior %q: must be skip, error, or rerun", onDone)
+	}
+}
+
+// handleDoneStory applies the on-done behavior to a story that is already done.
+//
+// Returns [errStorySkipped] if the story was skipped, an error if the
+// behavior is error or the rerun failed, and nil after a successful rerun.
+func handleDoneStory(ctx context.Context, executor *lifecycle.Executor, storyKey, onDone string) error {
+	switch onDone {
+	case config.OnDoneError:
+		return fmt.Errorf("story is already done (on_done: error)")
+	case config.OnDoneRerun:
+		fmt.Fprintf(executor.Output(), "Story %s is already done, re-running from dev-story\n", storyKey)
+		return executor.Rerun(ctx, storyKey)
+	default:
+		fmt.Fprintf(executor.Output(), "Story %s is already complete, skipping\n", storyKey)
+		return errStorySkipped
+	}
+}
+
+// parseStepList splits a comma-separated --steps value into workflow names.
+//
+// Returns an error if the list is empty, names a workflow that is not
+// configured, or repeats a workflow.
+func parseStepList(raw string, cfg *config.Config) ([]string, error) {
+	var workflows []string
+	seen := make(map[string]bool)
+	for _, name := range strings.Split(raw, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if _, ok := cfg.Workflows[name]; !ok {
+			return nil, fmt.Errorf("unknown workflow in --steps: %s", name)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("workflow %s listed more than once in --steps", name)
+		}
+		seen[name] = true
+		workflows = append(workflows, name)
+	}
+	if len(workflows) == 0 {
+		return nil, fmt.Errorf("--steps requires at least one workflow")
+	}
+	return workflows, nil
+}
+
+// runStorySteps runs the workflows named in stepList for each story, in order.
+//
+// Each story's steps are resolved with [lifecycle.Executor.PlanSteps] and
+// run with [lifecycle.Executor.ExecutePlan]. With dryRun, the resolved steps
+// are printed instead.
+func runStorySteps(cmd *cobra.Command, app *App, executor *lifecycle.Executor, storyKeys []string, stepList string, dryRun, assumeYes, printTransitions bool) error {
+	workflows, err := parseStepList(stepList, app.Config)
+	if err != nil {
+		cmd.SilenceUsage = true
+		app.printf("Error: %v\n", err)
+		return NewExitError(1)
+	}
+
+	storyPlans := make([]lifecycle.StoryPlan, 0, len(storyKeys))
+	for _, storyKey := range storyKeys {
+		storyPlan, err := executor.PlanSteps(storyKey, workflows)
+		if err != nil {
+			cmd.SilenceUsage = true
+			app.printf("Error: %v\n", err)
+			return NewExitError(1)
+		}
+		storyPlans = append(storyPlans, storyPlan)
+	}
+
+	if dryRun {
+		for _, storyPlan := range storyPlans {
+			app.printf("Dry run for story %s (%s):\n", storyPlan.StoryKey, storyPlan.StartStatus)
+			for i, step := range storyPlan.Steps {
+				app.printf("  %d. %s → %s\n", i+1, step.Workflow, step.NextStatus)
+			}
+		}
+		return nil
+	}
+
+	if err := checkStoryLimit(app.Config, len(storyPlans), assumeYes); err != nil {
+		cmd.SilenceUsage = true
+		app.printf("Error: %v\n", err)
+		return NewExitError(1)
+	}
+	defer printRunUsage(app)
+
+	executor.SetProgressCallback(func(stepIndex, totalSteps int, workflow string) {
+		app.Printer.StepStart(stepIndex, totalSteps, workflow)
+	})
+
+	for i, storyPlan := range storyPlans {
+		app.Runner.SetOperation(fmt.Sprintf("Story %d of %d: %s", i+1, len(storyPlans), storyPlan.StoryKey))
+
+		err := executor.ExecutePlan(cmd.Context(), storyPlan)
+		if printTransitions {
+			printStoryTransitions(executor, storyPlan.StoryKey)
+		}
+		if err != nil {
+			cmd.SilenceUsage = true
+			app.printf("Error running steps for story %s: %v\n", storyPlan.StoryKey, err)
+			return NewExitError(1)
+		}
+	}
+
+	app.printf("Ran %s for %d stories\n", strings.Join(workflows, ", "), len(storyPlans))
+	return nil
+}
+
+// runStoryPlanFile executes every story in a saved plan file, in order.
+//
+// Steps run exactly as recorded in the plan; sprint-status.yaml is only
+// written to, never consulted for routing.
+func runStoryPlanFile(cmd *cobra.Command, app *App, executor *lifecycle.Executor, path string, assumeYes, printTransitions bool) error {
+	plan, err := lifecycle.LoadPlan(path)
+	if err != nil {
+		cmd.SilenceUsage = true
+		app.printf("Error: %v\n", err)
+		return NewExitError(1)
+	}
+
+	if err := checkStoryLimit(app.Config, len(plan.Stories), assumeYes); err != nil {
+		cmd.SilenceUsage = true
+		app.printf("Error: %v\n", err)
+		return NewExitError(1)
+	}
+
+	executor.SetProgressCallback(func(stepIndex, totalSteps int, workflow string) {
+		app.Printer.StepStart(stepIndex, totalSteps, workflow)
+	})
+
+	for i, storyPlan := range plan.Stories {
+		app.Runner.SetOperation(fmt.Sprintf("Story %d of %d: %s", i+1, len(plan.Stories), storyPlan.StoryKey))
+		app.printf("─── Story %d of %d: %s (from plan)\n", i+1, len(plan.Stories), storyPlan.StoryKey)
+
+		err := executor.ExecutePlan(cmd.Context(), storyPlan)
+		if printTransitions {
+			printStoryTransitions(executor, storyPlan.StoryKey)
+		}
+		if err != nil {
+			cmd.SilenceUsage = true
+			app.printf("Error running plan for story %s: %v\n", storyPlan.StoryKey, err)
+			return NewExitError(1)
+		}
+	}
+
+	app.printf("Plan %s completed: %d stories processed\n", path, len(plan.Stories))
+	return nil
+}
+
+func runStoryDryRun(cmd *cobra.Command, app *App, executor *lifecycle.Executor, storyKeys []string, showPrompts bool) error {
 	// Single story dry-run - simpler output
 	if len(storyKeys) == 1 {
 		storyKey := storyKeys[0]
@@ -122,68 +676,114 @@ func runStoryDryRun(cmd *cobra.Command, app *App, executor *lifecycle.Executor,
 		if err != nil {
 			cmd.SilenceUsage = true
 			if errors.Is(err, router.ErrStoryComplete) {
-				fmt.Printf("Story is already complete, no workflows to run\n")
+				app.printf("Story is already complete, no workflows to run\n")
 				return nil
 			}
-			fmt.Printf("Error: %v\n", err)
+			app.printf("Error: %v\n", err)
 			return NewExitError(1)
 		}
 
 		printModuleInfo(app)
-		fmt.Printf("Dry run for story %s:\n", storyKey)
-		for i, step := range steps {
-			modelInfo := ""
-			model := app.Config.GetModel(step.Workflow)
-			if model != "" {
-				modelInfo = fmt.Sprintf(" (%s)", model)
-			}
-			fmt.Printf("  %d. %s%s → %s\n", i+1, step.Workflow, modelInfo, step.NextStatus)
+		app.printf("Dry run for story %s:\n", storyKey)
+		if err := printDryRunSteps(cmd.Context(), app, storyKey, steps, showPrompts); err != nil {
+			cmd.SilenceUsage = true
+			app.printf("Error: %v\n", err)
+			return NewExitError(1)
 		}
 		return nil
 	}
 
 	// Multiple stories dry-run - detailed output
 	printModuleInfo(app)
-	fmt.Printf("Dry run for %d stories:\n", len(storyKeys))
+	app.printf("Dry run for %d stories:\n", len(storyKeys))
 
 	totalWorkflows := 0
 	storiesWithWork := 0
 	storiesComplete := 0
 
 	for _, storyKey := range storyKeys {
-		fmt.Println()
-		fmt.Printf("Story %s:\n", storyKey)
+		app.println()
+		app.printf("Story %s:\n", storyKey)
 
 		steps, err := executor.GetSteps(storyKey)
 		if err != nil {
 			if errors.Is(err, router.ErrStoryComplete) {
-				fmt.Printf("  (already complete)\n")
+				app.printf("  (already complete)\n")
 				storiesComplete++
 				continue
 			}
 			cmd.SilenceUsage = true
-			fmt.Printf("  Error: %v\n", err)
+			app.printf("  Error: %v\n", err)
 			return NewExitError(1)
 		}
 
-		for i, step := range steps {
-			modelInfo := ""
-			model := app.Config.GetModel(step.Workflow)
-			if model != "" {
-				modelInfo = fmt.Sprintf(" (%s)", model)
-			}
-			fmt.Printf("  %d. %s%s → %s\n", i+1, step.Workflow, modelInfo, step.NextStatus)
+		if err := printDryRunSteps(cmd.Context(), app, storyKey, steps, showPrompts); err != nil {
+			cmd.SilenceUsage = true
+			app.printf("  Error: %v\n", err)
+			return NewExitError(1)
 		}
 		totalWorkflows += len(steps)
 		storiesWithWork++
 	}
 
-	fmt.Println()
+	app.println()
 	if storiesComplete > 0 {
-		fmt.Printf("Total: %d workflows across %d stories (%d already complete)\n", totalWorkflows, storiesWithWork, storiesComplete)
+		app.printf("Total: %d workflows across %d stories (%d already complete)\n", totalWorkflows, storiesWithWork, storiesComplete)
 	} else {
-		fmt.Printf("Total: %d workflows across %d stories\n", totalWorkflows, storiesWithWork)
+		app.printf("Total: %d workflows across %d stories\n", totalWorkflows, storiesWithWork)
 	}
 
 	return nil
 }
+
+// printDryRunSteps prints a story's numbered steps with their models. With
+// showPrompts, each step is followed by the prompt it would send to Claude,
+// indented below it.
+func printDryRunSteps(ctx context.Context, app *App, storyKey string, steps []router.LifecycleStep, showPrompts bool) error {
+	for i, step := range steps {
+		modelInfo := ""
+		model := stepModel(app.Config, step)
+		if model != "" {
+			modelInfo = fmt.Sprintf(" (%s)", model)
+		}
+		app.printf("  %d. %s%s → %s\n", i+1, step.Workflow, modelInfo, step.NextStatus)
+
+		if !showPrompts {
+			continue
+		}
+		prompt, err := stepPrompt(ctx, app, storyKey, step.Workflow)
+		if err != nil {
+			return err
+		}
+		for _, line := range strings.Split(strings.TrimRight(prompt, "\n"), "\n") {
+			app.printf("     %s\n", line)
+		}
+	}
+	return nil
+}
+
+// PromptRunner is implemented by runners that can resolve the prompt a
+// workflow run would send to Claude without running it. [workflow.Runner]
+// implements it; story --dry-run --show-prompts uses it.
+type PromptRunner interface {
+	Prompt(ctx context.Context, workflowName, storyKey string) (string, error)
+}
+
+// stepPrompt returns the prompt workflow would send to Claude for storyKey.
+// Runners that do not implement [PromptRunner] fall back to the config's
+// template, with the story's overrides applied.
+func stepPrompt(ctx context.Context, app *App, storyKey, workflow string) (string, error) {
+	if runner, ok := app.Runner.(PromptRunner); ok {
+		return runner.Prompt(ctx, workflow, storyKey)
+	}
+	return app.Config.ForStory(storyKey, app.StoryOverrides).GetPrompt(workflow, storyKey)
+}
+
+// stepModel returns the Claude model a lifecycle step runs with: the step's
+// own model if set, otherwise the workflow's configured model.
+func stepModel(cfg *config.Config, step router.LifecycleStep) string {
+	if step.Model != "" {
+		return step.Model
+	}
+	return cfg.GetModel(step.Workflow)
+}
@@ -2,16 +2,26 @@ package cli
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"bmaduum/internal/claude"
 	"bmaduum/internal/config"
+	"bmaduum/internal/lifecycle"
 	"bmaduum/internal/manifest"
 	"bmaduum/internal/output"
+	"bmaduum/internal/output/core"
+	"bmaduum/internal/ratelimit"
 	"bmaduum/internal/router"
+	"bmaduum/internal/state"
 	"bmaduum/internal/status"
 )
 
@@ -133,6 +143,80 @@ func TestStoryCommand_SingleStory(t *testing.T) {
 	}
 }
 
+func TestStoryCommand_ContinueOnError(t *testing.T) {
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, `development_status:
+  STORY-1: ready-for-dev
+  STORY-2: review`)
+
+	mockRunner := &MockWorkflowRunner{FailOnWorkflow: "dev-story"}
+	app := &App{
+		Config:       config.DefaultConfig(),
+		StatusReader: status.NewReader(tmpDir),
+		StatusWriter: &MockStatusWriter{},
+		Runner:       mockRunner,
+		Printer:      output.NewPrinterWithWriter(&bytes.Buffer{}),
+	}
+
+	rootCmd := NewRootCommand(app)
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetErr(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{"story", "--continue-on-error", "STORY-1", "STORY-2"})
+
+	err := rootCmd.Execute()
+	code, ok := IsExitError(err)
+	require.True(t, ok, "expected an exit error, got %v", err)
+	assert.Equal(t, 1, code)
+	assert.Equal(t, []string{"dev-story", "code-review", "git-commit"}, mockRunner.ExecutedWorkflows)
+}
+
+// TestStoryCommand_ContinueOnErrorFailedDependency tests that a story whose
+// dependency failed is not run under --continue-on-error
+func TestStoryCommand_ContinueOnErrorFailedDependency(t *testing.T) {
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, `development_status:
+  6-1-first: ready-for-dev
+  6-2-second: review
+  6-3-third: review`)
+
+	mockRunner := &MockWorkflowRunner{FailOnWorkflow: "dev-story"}
+	app := &App{
+		Config:       config.DefaultConfig(),
+		StatusReader: status.NewReader(tmpDir),
+		StatusWriter: &MockStatusWriter{},
+		Runner:       mockRunner,
+		Printer:      output.NewPrinterWithWriter(&bytes.Buffer{}),
+		StoryOverrides: &config.StoryOverrides{Stories: map[string]config.StoryOverride{
+			"6-2-second": {DependsOn: []string{"6-1-first"}},
+		}},
+	}
+
+	reportPath := filepath.Join(tmpDir, "report.json")
+	rootCmd := NewRootCommand(app)
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetErr(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{"story", "--continue-on-error", "--report", reportPath, "6-1-first", "6-2-second", "6-3-third"})
+
+	err := rootCmd.Execute()
+	code, ok := IsExitError(err)
+	require.True(t, ok, "expected an exit error, got %v", err)
+	assert.Equal(t, 1, code)
+	assert.Equal(t, []string{"dev-story", "code-review", "git-commit"}, mockRunner.ExecutedWorkflows,
+		"only the independent story runs after the failure")
+
+	data, err := os.ReadFile(reportPath)
+	require.NoError(t, err)
+	var report runSummary
+	require.NoError(t, json.Unmarshal(data, &report))
+	require.Len(t, report.Stories, 3)
+	blocked := report.Stories[1]
+	assert.Equal(t, "6-2-second", blocked.StoryKey)
+	assert.Equal(t, outcomeFailed, blocked.Outcome)
+	assert.Equal(t, "dependency 6-1-first failed", blocked.Error)
+	assert.Empty(t, blocked.Steps)
+	assert.Equal(t, outcomeSuccess, report.Stories[2].Outcome)
+}
+
 // TestStoryCommand_MultipleStories tests that story command processes multiple stories
 func TestStoryCommand_MultipleStories(t *testing.T) {
 	tests := []struct {
@@ -559,3 +643,1344 @@ func TestStoryCommand_DryRunWithoutModules(t *testing.T) {
 	// Should not contain modules line
 	assert.NotContains(t, stdout, "Modules:")
 }
+
+// TestStoryCommand_RetriesFlag tests that --retries re-runs the failed step and takes precedence over claude.max_retries
+func TestStoryCommand_RetriesFlag(t *testing.T) {
+	originalSleep := retrySleep
+	retrySleep = func(context.Context, time.Duration) error { return nil }
+	t.Cleanup(func() { retrySleep = originalSleep })
+
+	tests := []struct {
+		name             string
+		args             []string
+		expectedAttempts int
+		expectedOutput   string
+	}{
+		{
+			name:             "flag retries the failed step and overrides config",
+			args:             []string{"story", "--retries", "2", "6-1-test"},
+			expectedAttempts: 3,
+			expectedOutput:   "Retrying code-review for story 6-1-test (retry 2/2)",
+		},
+		{
+			name:             "flag overrides auto-retry",
+			args:             []string{"story", "--auto-retry", "--retries", "1", "6-1-test"},
+			expectedAttempts: 2,
+			expectedOutput:   "Retrying code-review for story 6-1-test (retry 1/1)",
+		},
+		{
+			name:             "config governs when flag is absent",
+			args:             []string{"story", "--auto-retry", "6-1-test"},
+			expectedAttempts: 5,
+		},
+		{
+			name:             "zero retries runs once",
+			args:             []string{"story", "--auto-retry", "--retries", "0", "6-1-test"},
+			expectedAttempts: 1,
+		},
+		{
+			name:             "no retry flags runs once",
+			args:             []string{"story", "6-1-test"},
+			expectedAttempts: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			createSprintStatusFile(t, tmpDir, `development_status:
+  6-1-test: review`)
+
+			cfg := config.DefaultConfig()
+			cfg.Claude.MaxRetries = 4
+			mockRunner := &MockWorkflowRunner{FailOnWorkflow: "code-review"}
+
+			app := &App{
+				Config:       cfg,
+				StatusReader: status.NewReader(tmpDir),
+				StatusWriter: &MockStatusWriter{},
+				Runner:       mockRunner,
+				Printer:      output.NewPrinterWithWriter(&bytes.Buffer{}),
+			}
+
+			// Capture stdout
+			oldStdout := os.Stdout
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			rootCmd := NewRootCommand(app)
+			outBuf := &bytes.Buffer{}
+			rootCmd.SetOut(outBuf)
+			rootCmd.SetErr(outBuf)
+			rootCmd.SetArgs(tt.args)
+
+			err := rootCmd.Execute()
+			w.Close()
+			os.Stdout = oldStdout
+
+			var stdoutBuf bytes.Buffer
+			stdoutBuf.ReadFrom(r)
+
+			require.Error(t, err)
+			assert.Len(t, mockRunner.ExecutedWorkflows, tt.expectedAttempts)
+			if tt.expectedOutput != "" {
+				assert.Contains(t, stdoutBuf.String(), tt.expectedOutput)
+			}
+		})
+	}
+}
+
+// TestStoryCommand_AutoRetryStopsOnAuthFailure tests that --auto-retry does not retry a failed login
+func TestStoryCommand_AutoRetryStopsOnAuthFailure(t *testing.T) {
+	originalSleep := retrySleep
+	retrySleep = func(context.Context, time.Duration) error { return nil }
+	t.Cleanup(func() { retrySleep = originalSleep })
+
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, `development_status:
+  6-1-test: review`)
+
+	mockRunner := &MockWorkflowRunner{
+		FailOnWorkflow: "code-review",
+		FailureError:   &ratelimit.Error{Kind: ratelimit.ErrAuthExpired, Message: "OAuth token has expired"},
+	}
+	app := &App{
+		Config:       config.DefaultConfig(),
+		StatusReader: status.NewReader(tmpDir),
+		StatusWriter: &MockStatusWriter{},
+		Runner:       mockRunner,
+		Printer:      output.NewPrinterWithWriter(&bytes.Buffer{}),
+	}
+
+	// Capture stdout
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	rootCmd := NewRootCommand(app)
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetErr(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{"story", "--auto-retry", "6-1-test"})
+
+	err := rootCmd.Execute()
+	w.Close()
+	os.Stdout = oldStdout
+
+	var stdoutBuf bytes.Buffer
+	stdoutBuf.ReadFrom(r)
+
+	require.Error(t, err)
+	assert.Equal(t, []string{"code-review"}, mockRunner.ExecutedWorkflows)
+	assert.Contains(t, stdoutBuf.String(), "claude authentication failed: OAuth token has expired")
+}
+
+// TestExecuteWithRetry_CanceledDuringBackoff tests that Ctrl-C during the
+// --auto-retry wait ends the run without waiting out the backoff
+func TestExecuteWithRetry_CanceledDuringBackoff(t *testing.T) {
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, `development_status:
+  6-1-test: review`)
+
+	mockRunner := &MockWorkflowRunner{FailOnWorkflow: "code-review"}
+	executor := lifecycle.NewExecutor(mockRunner, status.NewReader(tmpDir), &MockStatusWriter{})
+	executor.SetOutput(&bytes.Buffer{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	begin := time.Now()
+	err := executeWithRetry(ctx, executor, "6-1-test", false, true, 3, nil)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Less(t, time.Since(begin), 5*time.Second, "the 30s backoff was cut short")
+	assert.Equal(t, []string{"code-review"}, mockRunner.ExecutedWorkflows)
+}
+
+// TestExecuteWithRetry_CanceledDuringRateLimitWait tests that Ctrl-C while
+// waiting for a rate limit to reset ends the run promptly
+func TestExecuteWithRetry_CanceledDuringRateLimitWait(t *testing.T) {
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, `development_status:
+  6-1-test: review`)
+
+	mockRunner := &MockWorkflowRunner{
+		FailOnWorkflow: "code-review",
+		FailureError:   &ratelimit.Error{Kind: ratelimit.ErrRateLimited, Message: "usage limit reached", ResetTime: time.Now().Add(3 * time.Hour)},
+	}
+	var out bytes.Buffer
+	executor := lifecycle.NewExecutor(mockRunner, status.NewReader(tmpDir), &MockStatusWriter{})
+	executor.SetOutput(&out)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	begin := time.Now()
+	err := executeWithRetry(ctx, executor, "6-1-test", false, true, 3, nil)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Less(t, time.Since(begin), 5*time.Second, "the wait for the reset was cut short")
+	assert.Contains(t, out.String(), "waiting 3h0m")
+	assert.Equal(t, []string{"code-review"}, mockRunner.ExecutedWorkflows)
+}
+
+// TestStoryCommand_RetriesRecover tests that a step succeeding on retry lets the lifecycle continue
+func TestStoryCommand_RetriesRecover(t *testing.T) {
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, `development_status:
+  6-1-test: ready-for-dev`)
+
+	failures := 1
+	mockRunner := &MockWorkflowRunner{}
+	mockRunner.OnRun = func(workflowName, storyKey string) {
+		// Fail dev-story once, then let it succeed
+		mockRunner.FailOnWorkflow = ""
+		if workflowName == "dev-story" && failures > 0 {
+			failures--
+			mockRunner.FailOnWorkflow = "dev-story"
+		}
+	}
+	mockWriter := &MockStatusWriter{}
+
+	app := &App{
+		Config:       config.DefaultConfig(),
+		StatusReader: status.NewReader(tmpDir),
+		StatusWriter: mockWriter,
+		Runner:       mockRunner,
+		Printer:      output.NewPrinterWithWriter(&bytes.Buffer{}),
+	}
+
+	rootCmd := NewRootCommand(app)
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetErr(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{"story", "--retries", "3", "6-1-test"})
+
+	require.NoError(t, rootCmd.Execute())
+	assert.Equal(t, []string{"dev-story", "dev-story", "code-review", "git-commit"}, mockRunner.ExecutedWorkflows)
+	assert.Len(t, mockWriter.Updates, 3)
+}
+
+// TestStoryCommand_SaveAndExecutePlan tests that a saved plan runs unchanged after the status file shifts
+func TestStoryCommand_SaveAndExecutePlan(t *testing.T) {
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, `development_status:
+  6-1-first: review
+  6-2-second: done`)
+	planPath := filepath.Join(tmpDir, "plan.json")
+
+	newApp := func(runner *MockWorkflowRunner, writer *MockStatusWriter) *App {
+		return &App{
+			Config:       config.DefaultConfig(),
+			StatusReader: status.NewReader(tmpDir),
+			StatusWriter: writer,
+			Runner:       runner,
+			Printer:      output.NewPrinterWithWriter(&bytes.Buffer{}),
+		}
+	}
+
+	// Save the plan during a dry run: nothing executes
+	dryRunner := &MockWorkflowRunner{}
+	rootCmd := NewRootCommand(newApp(dryRunner, &MockStatusWriter{}))
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetErr(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{"story", "--dry-run", "--save-plan", planPath, "6-1-first", "6-2-second"})
+	require.NoError(t, rootCmd.Execute())
+	assert.Empty(t, dryRunner.ExecutedWorkflows)
+	require.FileExists(t, planPath)
+
+	// The status file shifts after the plan was reviewed
+	createSprintStatusFile(t, tmpDir, `development_status:
+  6-1-first: backlog
+  6-2-second: backlog`)
+
+	runner := &MockWorkflowRunner{}
+	writer := &MockStatusWriter{}
+	rootCmd = NewRootCommand(newApp(runner, writer))
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetErr(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{"story", "--plan-file", planPath})
+	require.NoError(t, rootCmd.Execute())
+
+	assert.Equal(t, []string{"code-review", "git-commit"}, runner.ExecutedWorkflows)
+	assert.Equal(t, []StatusUpdate{
+		{StoryKey: "6-1-first", NewStatus: status.StatusDone},
+		{StoryKey: "6-1-first", NewStatus: status.StatusDone},
+	}, writer.Updates)
+}
+
+// TestStoryCommand_PlanFileRejectsStoryKeys tests that story keys cannot be combined with --plan-file
+func TestStoryCommand_PlanFileRejectsStoryKeys(t *testing.T) {
+	app := &App{
+		Config:       config.DefaultConfig(),
+		StatusReader: status.NewReader(t.TempDir()),
+		StatusWriter: &MockStatusWriter{},
+		Runner:       &MockWorkflowRunner{},
+		Printer:      output.NewPrinterWithWriter(&bytes.Buffer{}),
+	}
+
+	rootCmd := NewRootCommand(app)
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetErr(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{"story", "--plan-file", "plan.json", "6-1-first"})
+
+	assert.Error(t, rootCmd.Execute())
+}
+
+// TestStoryCommand_SkipsCreateStoryWhenFileExists tests story file detection and --force-create
+func TestStoryCommand_SkipsCreateStoryWhenFileExists(t *testing.T) {
+	tests := []struct {
+		name              string
+		args              []string
+		expectedWorkflows []string
+	}{
+		{
+			name:              "existing story file skips create-story",
+			args:              []string{"story", "6-1-test"},
+			expectedWorkflows: []string{"dev-story", "code-review", "git-commit"},
+		},
+		{
+			name:              "force-create runs create-story",
+			args:              []string{"story", "--force-create", "6-1-test"},
+			expectedWorkflows: []string{"create-story", "dev-story", "code-review", "git-commit"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			createSprintStatusFile(t, tmpDir, `development_status:
+  6-1-test: backlog`)
+			storyFile := filepath.Join(tmpDir, "_bmad-output", "implementation-artifacts", "6-1-test.md")
+			require.NoError(t, os.WriteFile(storyFile, []byte("# Story 6.1\n"), 0644))
+
+			mockRunner := &MockWorkflowRunner{}
+			app := &App{
+				Config:       config.DefaultConfig(),
+				StatusReader: status.NewReader(tmpDir),
+				StatusWriter: &MockStatusWriter{},
+				Runner:       mockRunner,
+				Printer:      output.NewPrinterWithWriter(&bytes.Buffer{}),
+			}
+
+			rootCmd := NewRootCommand(app)
+			rootCmd.SetOut(&bytes.Buffer{})
+			rootCmd.SetErr(&bytes.Buffer{})
+			rootCmd.SetArgs(tt.args)
+
+			require.NoError(t, rootCmd.Execute())
+			assert.Equal(t, tt.expectedWorkflows, mockRunner.ExecutedWorkflows)
+		})
+	}
+}
+
+// TestStoryCommand_AbortOnUncommittedAfter tests the post-run dirty tree check
+func TestStoryCommand_AbortOnUncommittedAfter(t *testing.T) {
+	tests := []struct {
+		name          string
+		args          []string
+		gitHelper     *MockGitHelper
+		expectError   bool
+		expectedCalls int
+	}{
+		{
+			name:          "dirty tree fails the story",
+			args:          []string{"story", "--abort-on-uncommitted-after", "6-1-first", "6-2-second"},
+			gitHelper:     &MockGitHelper{Files: []string{"internal/app.go", "coverage.out"}},
+			expectError:   true,
+			expectedCalls: 1,
+		},
+		{
+			name:          "clean tree passes",
+			args:          []string{"story", "--abort-on-uncommitted-after", "6-1-first", "6-2-second"},
+			gitHelper:     &MockGitHelper{},
+			expectedCalls: 2,
+		},
+		{
+			name:          "git failure fails the story",
+			args:          []string{"story", "--abort-on-uncommitted-after", "6-1-first"},
+			gitHelper:     &MockGitHelper{Err: errors.New("not a git repository")},
+			expectError:   true,
+			expectedCalls: 1,
+		},
+		{
+			name:          "check disabled by default",
+			args:          []string{"story", "6-1-first"},
+			gitHelper:     &MockGitHelper{Files: []string{"internal/app.go"}},
+			expectedCalls: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			createSprintStatusFile(t, tmpDir, `development_status:
+  6-1-first: review
+  6-2-second: review`)
+
+			mockRunner := &MockWorkflowRunner{}
+			app := &App{
+				Config:       config.DefaultConfig(),
+				StatusReader: status.NewReader(tmpDir),
+				StatusWriter: &MockStatusWriter{},
+				Runner:       mockRunner,
+				Printer:      output.NewPrinterWithWriter(&bytes.Buffer{}),
+				Git:          tt.gitHelper,
+			}
+
+			rootCmd := NewRootCommand(app)
+			rootCmd.SetOut(&bytes.Buffer{})
+			rootCmd.SetErr(&bytes.Buffer{})
+			rootCmd.SetArgs(tt.args)
+
+			err := rootCmd.Execute()
+
+			if tt.expectError {
+				require.Error(t, err)
+				code, ok := IsExitError(err)
+				assert.True(t, ok)
+				assert.Equal(t, 1, code)
+				// The first story ran, but the dirty tree stopped the second
+				assert.Equal(t, []string{"code-review", "git-commit"}, mockRunner.ExecutedWorkflows)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedCalls, tt.gitHelper.Calls)
+		})
+	}
+}
+
+func TestCheckCleanTree(t *testing.T) {
+	ctx := context.Background()
+
+	assert.NoError(t, checkCleanTree(ctx, &MockGitHelper{}, "6-1"))
+
+	err := checkCleanTree(ctx, &MockGitHelper{Files: []string{"a.go", "b.go"}}, "6-1")
+	assert.EqualError(t, err, "story 6-1 left 2 uncommitted file(s):\n  a.go\n  b.go")
+
+	assert.Error(t, checkCleanTree(ctx, nil, "6-1"))
+}
+
+// TestStoryCommand_DryRunCheckEnv tests that --check-env reports preflight results after the plan
+func TestStoryCommand_DryRunCheckEnv(t *testing.T) {
+	tests := []struct {
+		name        string
+		binaryPath  string
+		expectReady bool
+	}{
+		{
+			name:        "binary found",
+			binaryPath:  "sh",
+			expectReady: true,
+		},
+		{
+			name:        "binary missing",
+			binaryPath:  "/nonexistent/claude",
+			expectReady: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			createSprintStatusFile(t, tmpDir, `development_status:
+  STORY-1: backlog`)
+
+			cfg := config.DefaultConfig()
+			cfg.Claude.BinaryPath = tt.binaryPath
+
+			mockRunner := &MockWorkflowRunner{}
+			app := &App{
+				Config:       cfg,
+				StatusReader: status.NewReader(tmpDir),
+				StatusWriter: &MockStatusWriter{},
+				Runner:       mockRunner,
+				Printer:      output.NewPrinterWithWriter(&bytes.Buffer{}),
+			}
+
+			// Capture stdout
+			oldStdout := os.Stdout
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			rootCmd := NewRootCommand(app)
+			outBuf := &bytes.Buffer{}
+			rootCmd.SetOut(outBuf)
+			rootCmd.SetErr(outBuf)
+			rootCmd.SetArgs([]string{"story", "--dry-run", "--check-env", "STORY-1"})
+
+			err := rootCmd.Execute()
+			w.Close()
+			os.Stdout = oldStdout
+
+			var stdoutBuf bytes.Buffer
+			stdoutBuf.ReadFrom(r)
+			stdout := stdoutBuf.String()
+
+			assert.Empty(t, mockRunner.ExecutedWorkflows, "dry-run should not execute workflows")
+			assert.Contains(t, stdout, "create-story", "plan should still be printed")
+			assert.Contains(t, stdout, "✓ prompt for create-story")
+			assert.Contains(t, stdout, "✓ status file writable")
+
+			if tt.expectReady {
+				assert.NoError(t, err)
+				assert.Contains(t, stdout, "✓ claude binary")
+				assert.Contains(t, stdout, "Ready:")
+			} else {
+				code, ok := IsExitError(err)
+				require.True(t, ok)
+				assert.Equal(t, 1, code)
+				assert.Contains(t, stdout, "✗ claude binary")
+				assert.Contains(t, stdout, "Not ready:")
+			}
+		})
+	}
+}
+
+// TestStoryCommand_CheckEnvRequiresDryRun tests that --check-env alone is rejected
+func TestStoryCommand_CheckEnvRequiresDryRun(t *testing.T) {
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, `development_status:
+  STORY-1: backlog`)
+
+	mockRunner := &MockWorkflowRunner{}
+	app := &App{
+		Config:       config.DefaultConfig(),
+		StatusReader: status.NewReader(tmpDir),
+		StatusWriter: &MockStatusWriter{},
+		Runner:       mockRunner,
+		Printer:      output.NewPrinterWithWriter(&bytes.Buffer{}),
+	}
+
+	rootCmd := NewRootCommand(app)
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetErr(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{"story", "--check-env", "STORY-1"})
+
+	err := rootCmd.Execute()
+	code, ok := IsExitError(err)
+	require.True(t, ok)
+	assert.Equal(t, 1, code)
+	assert.Empty(t, mockRunner.ExecutedWorkflows)
+}
+
+// TestStoryCommand_CheckEnvRequiresDryRun_NoPlanWritten tests that the
+// --check-env check runs before --save-plan writes anything
+func TestStoryCommand_CheckEnvRequiresDryRun_NoPlanWritten(t *testing.T) {
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, `development_status:
+  STORY-1: backlog`)
+	planPath := filepath.Join(tmpDir, "plan.json")
+
+	app := &App{
+		Config:       config.DefaultConfig(),
+		StatusReader: status.NewReader(tmpDir),
+		StatusWriter: &MockStatusWriter{},
+		Runner:       &MockWorkflowRunner{},
+		Printer:      output.NewPrinterWithWriter(&bytes.Buffer{}),
+	}
+
+	rootCmd := NewRootCommand(app)
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetErr(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{"story", "--check-env", "--save-plan", planPath, "STORY-1"})
+
+	err := rootCmd.Execute()
+	code, ok := IsExitError(err)
+	require.True(t, ok)
+	assert.Equal(t, 1, code)
+	assert.NoFileExists(t, planPath)
+}
+
+// TestStoryCommand_DryRunShowPrompts tests that --show-prompts prints each step's prompt
+func TestStoryCommand_DryRunShowPrompts(t *testing.T) {
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, `development_status:
+  STORY-1: review`)
+
+	mockRunner := &MockWorkflowRunner{}
+	app := &App{
+		Config:       config.DefaultConfig(),
+		StatusReader: status.NewReader(tmpDir),
+		StatusWriter: &MockStatusWriter{},
+		Runner:       mockRunner,
+		Printer:      output.NewPrinterWithWriter(&bytes.Buffer{}),
+	}
+
+	// Capture stdout
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	rootCmd := NewRootCommand(app)
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetErr(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{"story", "--dry-run", "--show-prompts", "STORY-1"})
+
+	err := rootCmd.Execute()
+	w.Close()
+	os.Stdout = oldStdout
+
+	var stdoutBuf bytes.Buffer
+	stdoutBuf.ReadFrom(r)
+	stdout := stdoutBuf.String()
+
+	require.NoError(t, err)
+	assert.Empty(t, mockRunner.ExecutedWorkflows, "dry-run should not execute workflows")
+	assert.Contains(t, stdout, "code-review")
+	assert.Contains(t, stdout, "     /code-review STORY-1")
+}
+
+// TestStoryCommand_Until tests that --until stops each story at the target status
+func TestStoryCommand_Until(t *testing.T) {
+	tests := []struct {
+		name              string
+		args              []string
+		expectError       bool
+		expectedWorkflows []string
+		expectedStatuses  []status.Status
+	}{
+		{
+			name:              "stops at review",
+			args:              []string{"--until", "review", "STORY-1"},
+			expectedWorkflows: []string{"create-story", "dev-story"},
+			expectedStatuses:  []status.Status{status.StatusReadyForDev, status.StatusReview},
+		},
+		{
+			name:        "unknown status",
+			args:        []string{"--until", "reviewed", "STORY-1"},
+			expectError: true,
+		},
+		{
+			name:        "unreachable status",
+			args:        []string{"--until", "ready-for-dev", "STORY-2"},
+			expectError: true,
+		},
+		{
+			name:        "rejected with --steps",
+			args:        []string{"--until", "review", "--steps", "dev-story", "STORY-1"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			createSprintStatusFile(t, tmpDir, `development_status:
+  STORY-1: backlog
+  STORY-2: review`)
+
+			mockRunner := &MockWorkflowRunner{}
+			mockWriter := &MockStatusWriter{}
+			app := &App{
+				Config:       config.DefaultConfig(),
+				StatusReader: status.NewReader(tmpDir),
+				StatusWriter: mockWriter,
+				Runner:       mockRunner,
+				Printer:      output.NewPrinterWithWriter(&bytes.Buffer{}),
+			}
+
+			rootCmd := NewRootCommand(app)
+			rootCmd.SetOut(&bytes.Buffer{})
+			rootCmd.SetErr(&bytes.Buffer{})
+			rootCmd.SetArgs(append([]string{"story"}, tt.args...))
+
+			err := rootCmd.Execute()
+			if tt.expectError {
+				code, ok := IsExitError(err)
+				require.True(t, ok, "expected an exit error, got %v", err)
+				assert.Equal(t, 1, code)
+			} else {
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.expectedWorkflows, mockRunner.ExecutedWorkflows)
+			var statuses []status.Status
+			for _, update := range mockWriter.Updates {
+				statuses = append(statuses, update.NewStatus)
+			}
+			assert.Equal(t, tt.expectedStatuses, statuses)
+		})
+	}
+}
+
+// TestStoryCommand_UntilConflictCheckedFirst tests that --until and
+// --start-status conflicts are rejected before Claude is looked up or the
+// executor is built
+func TestStoryCommand_UntilConflictCheckedFirst(t *testing.T) {
+	app := &App{
+		Config:       config.DefaultConfig(),
+		Executor:     claude.NewExecutor(claude.ExecutorConfig{BinaryPath: "/nonexistent/claude"}),
+		StatusReader: status.NewReader(t.TempDir()),
+		StatusWriter: &MockStatusWriter{},
+		Runner:       &MockWorkflowRunner{},
+		Printer:      output.NewPrinterWithWriter(&bytes.Buffer{}),
+	}
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	rootCmd := NewRootCommand(app)
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetErr(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{"story", "--start-status", "review", "--plan-file", "plan.json"})
+
+	err := rootCmd.Execute()
+	w.Close()
+	os.Stdout = oldStdout
+
+	var stdoutBuf bytes.Buffer
+	stdoutBuf.ReadFrom(r)
+
+	code, ok := IsExitError(err)
+	require.True(t, ok)
+	assert.Equal(t, 1, code)
+	assert.Equal(t, "Error: --until and --start-status cannot be combined with --steps or --plan-file\n", stdoutBuf.String())
+}
+
+// TestStoryCommand_StartStatus tests that --start-status overrides the status read from the file
+func TestStoryCommand_StartStatus(t *testing.T) {
+	tests := []struct {
+		name              string
+		startStatus       string
+		expectError       bool
+		expectedWorkflows []string
+	}{
+		{
+			name:              "starts from the given status",
+			startStatus:       "review",
+			expectedWorkflows: []string{"code-review", "git-commit"},
+		},
+		{
+			name:        "unknown status",
+			startStatus: "reviewed",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			createSprintStatusFile(t, tmpDir, `development_status:
+  STORY-1: backlog`)
+
+			mockRunner := &MockWorkflowRunner{}
+			mockWriter := &MockStatusWriter{}
+			app := &App{
+				Config:       config.DefaultConfig(),
+				StatusReader: status.NewReader(tmpDir),
+				StatusWriter: mockWriter,
+				Runner:       mockRunner,
+				Printer:      output.NewPrinterWithWriter(&bytes.Buffer{}),
+			}
+
+			rootCmd := NewRootCommand(app)
+			rootCmd.SetOut(&bytes.Buffer{})
+			rootCmd.SetErr(&bytes.Buffer{})
+			rootCmd.SetArgs([]string{"story", "--start-status", tt.startStatus, "STORY-1"})
+
+			err := rootCmd.Execute()
+			if tt.expectError {
+				code, ok := IsExitError(err)
+				require.True(t, ok, "expected an exit error, got %v", err)
+				assert.Equal(t, 1, code)
+				assert.Empty(t, mockRunner.ExecutedWorkflows)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedWorkflows, mockRunner.ExecutedWorkflows)
+			require.Len(t, mockWriter.Updates, 2)
+			assert.Equal(t, status.StatusDone, mockWriter.Updates[1].NewStatus)
+		})
+	}
+}
+
+// TestStoryCommand_UntilDryRun tests that the dry-run preview stops at the --until status
+func TestStoryCommand_UntilDryRun(t *testing.T) {
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, `development_status:
+  STORY-1: backlog`)
+
+	mockRunner := &MockWorkflowRunner{}
+	app := &App{
+		Config:       config.DefaultConfig(),
+		StatusReader: status.NewReader(tmpDir),
+		StatusWriter: &MockStatusWriter{},
+		Runner:       mockRunner,
+		Printer:      output.NewPrinterWithWriter(&bytes.Buffer{}),
+	}
+
+	// Capture stdout
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	rootCmd := NewRootCommand(app)
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetErr(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{"story", "--dry-run", "--until", "review", "STORY-1"})
+
+	err := rootCmd.Execute()
+	w.Close()
+	os.Stdout = oldStdout
+
+	var stdoutBuf bytes.Buffer
+	stdoutBuf.ReadFrom(r)
+	stdout := stdoutBuf.String()
+
+	require.NoError(t, err)
+	assert.Empty(t, mockRunner.ExecutedWorkflows, "dry-run should not execute workflows")
+	assert.Contains(t, stdout, "2. dev-story → review")
+	assert.NotContains(t, stdout, "code-review")
+}
+
+// TestStoryCommand_ShowPromptsRequiresDryRun tests that --show-prompts alone is rejected
+func TestStoryCommand_ShowPromptsRequiresDryRun(t *testing.T) {
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, `development_status:
+  STORY-1: backlog`)
+
+	mockRunner := &MockWorkflowRunner{}
+	app := &App{
+		Config:       config.DefaultConfig(),
+		StatusReader: status.NewReader(tmpDir),
+		StatusWriter: &MockStatusWriter{},
+		Runner:       mockRunner,
+		Printer:      output.NewPrinterWithWriter(&bytes.Buffer{}),
+	}
+
+	rootCmd := NewRootCommand(app)
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetErr(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{"story", "--show-prompts", "STORY-1"})
+
+	err := rootCmd.Execute()
+	code, ok := IsExitError(err)
+	require.True(t, ok)
+	assert.Equal(t, 1, code)
+	assert.Empty(t, mockRunner.ExecutedWorkflows)
+}
+
+// TestStoryCommand_ClaudeMissing tests that a missing Claude binary fails the run before any workflow
+func TestStoryCommand_ClaudeMissing(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		wantCode int
+	}{
+		{name: "run fails fast", args: []string{"story", "STORY-1"}, wantCode: 1},
+		{name: "dry run skips the check", args: []string{"story", "--dry-run", "STORY-1"}, wantCode: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			createSprintStatusFile(t, tmpDir, `development_status:
+  STORY-1: ready-for-dev`)
+
+			mockRunner := &MockWorkflowRunner{}
+			app := &App{
+				Config:       config.DefaultConfig(),
+				Executor:     claude.NewExecutor(claude.ExecutorConfig{BinaryPath: "/nonexistent/claude"}),
+				StatusReader: status.NewReader(tmpDir),
+				StatusWriter: &MockStatusWriter{},
+				Runner:       mockRunner,
+				Printer:      output.NewPrinterWithWriter(&bytes.Buffer{}),
+			}
+
+			rootCmd := NewRootCommand(app)
+			rootCmd.SetOut(&bytes.Buffer{})
+			rootCmd.SetErr(&bytes.Buffer{})
+			rootCmd.SetArgs(tt.args)
+
+			err := rootCmd.Execute()
+			if tt.wantCode == 0 {
+				assert.NoError(t, err)
+			} else {
+				code, ok := IsExitError(err)
+				require.True(t, ok)
+				assert.Equal(t, tt.wantCode, code)
+			}
+			assert.Empty(t, mockRunner.ExecutedWorkflows)
+		})
+	}
+}
+
+// TestStoryCommand_Resume tests that --resume skips steps recorded in the checkpoint
+func TestStoryCommand_Resume(t *testing.T) {
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, `development_status:
+  6-1-first: ready-for-dev`)
+
+	statusReader := status.NewReader(tmpDir)
+	store := state.NewCheckpointStore(filepath.Dir(statusReader.Path()))
+	require.NoError(t, store.Save(state.Checkpoint{StoryKey: "6-1-first", Workflow: "dev-story"}))
+
+	mockRunner := &MockWorkflowRunner{}
+	app := &App{
+		Config:       config.DefaultConfig(),
+		StatusReader: statusReader,
+		StatusWriter: &MockStatusWriter{},
+		Runner:       mockRunner,
+		Printer:      output.NewPrinterWithWriter(&bytes.Buffer{}),
+	}
+
+	rootCmd := NewRootCommand(app)
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetErr(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{"story", "--resume", "6-1-first"})
+
+	require.NoError(t, rootCmd.Execute())
+	assert.Equal(t, []string{"code-review", "git-commit"}, mockRunner.ExecutedWorkflows)
+
+	_, err := store.Load("6-1-first")
+	assert.ErrorIs(t, err, state.ErrNoCheckpoint)
+}
+
+// TestStoryCommand_PrintsTotalCost tests that the cumulative cost reported by the runner is printed
+func TestStoryCommand_PrintsTotalCost(t *testing.T) {
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, `development_status:
+  6-1-test: review`)
+
+	mockRunner := &MockWorkflowRunner{Usage: core.Usage{InputTokens: 100, OutputTokens: 20, CostUSD: 0.05}}
+	app := &App{
+		Config:       config.DefaultConfig(),
+		StatusReader: status.NewReader(tmpDir),
+		StatusWriter: &MockStatusWriter{},
+		Runner:       mockRunner,
+		Printer:      output.NewPrinterWithWriter(&bytes.Buffer{}),
+	}
+
+	// Capture stdout
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	rootCmd := NewRootCommand(app)
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetErr(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{"story", "6-1-test"})
+
+	err := rootCmd.Execute()
+	w.Close()
+	os.Stdout = oldStdout
+
+	var stdout bytes.Buffer
+	_, _ = stdout.ReadFrom(r)
+
+	require.NoError(t, err)
+	// code-review and git-commit each report usage
+	assert.Contains(t, stdout.String(), "Total cost: $0.1000 (200 input / 40 output tokens)")
+}
+
+// TestStoryCommand_Steps tests that --steps runs only the listed workflows in order
+func TestStoryCommand_Steps(t *testing.T) {
+	tests := []struct {
+		name              string
+		args              []string
+		expectError       bool
+		expectedWorkflows []string
+		expectedStatuses  []StatusUpdate
+	}{
+		{
+			name:              "runs listed steps in order and advances status",
+			args:              []string{"story", "--steps", "create-story, dev-story", "6-1-test"},
+			expectedWorkflows: []string{"create-story", "dev-story"},
+			expectedStatuses: []StatusUpdate{
+				{StoryKey: "6-1-test", NewStatus: status.StatusReadyForDev},
+				{StoryKey: "6-1-test", NewStatus: status.StatusReview},
+			},
+		},
+		{
+			name:              "ignores the story status",
+			args:              []string{"story", "--steps", "code-review", "6-1-test"},
+			expectedWorkflows: []string{"code-review"},
+			expectedStatuses:  []StatusUpdate{{StoryKey: "6-1-test", NewStatus: status.StatusDone}},
+		},
+		{
+			name:        "unknown workflow",
+			args:        []string{"story", "--steps", "create-story,deploy", "6-1-test"},
+			expectError: true,
+		},
+		{
+			name:        "duplicate workflow",
+			args:        []string{"story", "--steps", "dev-story,dev-story", "6-1-test"},
+			expectError: true,
+		},
+		{
+			name:        "empty list",
+			args:        []string{"story", "--steps", " , ", "6-1-test"},
+			expectError: true,
+		},
+		{
+			name:        "conflicts with resume",
+			args:        []string{"story", "--steps", "dev-story", "--resume", "6-1-test"},
+			expectError: true,
+		},
+		{
+			name: "dry run executes nothing",
+			args: []string{"story", "--dry-run", "--steps", "dev-story", "6-1-test"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			createSprintStatusFile(t, tmpDir, `development_status:
+  6-1-test: backlog`)
+
+			mockRunner := &MockWorkflowRunner{}
+			mockWriter := &MockStatusWriter{}
+			app := &App{
+				Config:       config.DefaultConfig(),
+				StatusReader: status.NewReader(tmpDir),
+				StatusWriter: mockWriter,
+				Runner:       mockRunner,
+				Printer:      output.NewPrinterWithWriter(&bytes.Buffer{}),
+			}
+
+			rootCmd := NewRootCommand(app)
+			rootCmd.SetOut(&bytes.Buffer{})
+			rootCmd.SetErr(&bytes.Buffer{})
+			rootCmd.SetArgs(tt.args)
+
+			err := rootCmd.Execute()
+			if tt.expectError {
+				require.Error(t, err)
+				assert.Empty(t, mockRunner.ExecutedWorkflows)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedWorkflows, mockRunner.ExecutedWorkflows)
+			assert.Equal(t, tt.expectedStatuses, mockWriter.Updates)
+		})
+	}
+}
+
+// TestStoryCommand_OnDone tests the skip, error, and rerun behaviors for done stories
+func TestStoryCommand_OnDone(t *testing.T) {
+	tests := []struct {
+		name              string
+		configOnDone      string
+		args              []string
+		expectError       bool
+		expectedWorkflows []string
+	}{
+		{
+			name: "skips by default",
+			args: []string{"story", "6-1-test"},
+		},
+		{
+			name:        "error from flag",
+			args:        []string{"story", "--on-done", "error", "6-1-test"},
+			expectError: true,
+		},
+		{
+			name:              "rerun from config",
+			configOnDone:      config.OnDoneRerun,
+			args:              []string{"story", "6-1-test"},
+			expectedWorkflows: []string{"dev-story", "code-review", "git-commit"},
+		},
+		{
+			name:         "flag overrides config",
+			configOnDone: config.OnDoneError,
+			args:         []string{"story", "--on-done", "skip", "6-1-test"},
+		},
+		{
+			name:        "invalid value",
+			args:        []string{"story", "--on-done", "ignore", "6-1-test"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			createSprintStatusFile(t, tmpDir, `development_status:
+  6-1-test: done`)
+
+			cfg := config.DefaultConfig()
+			if tt.configOnDone != "" {
+				cfg.OnDone = tt.configOnDone
+			}
+			mockRunner := &MockWorkflowRunner{}
+			app := &App{
+				Config:       cfg,
+				StatusReader: status.NewReader(tmpDir),
+				StatusWriter: &MockStatusWriter{},
+				Runner:       mockRunner,
+				Printer:      output.NewPrinterWithWriter(&bytes.Buffer{}),
+			}
+
+			rootCmd := NewRootCommand(app)
+			rootCmd.SetOut(&bytes.Buffer{})
+			rootCmd.SetErr(&bytes.Buffer{})
+			rootCmd.SetArgs(tt.args)
+
+			err := rootCmd.Execute()
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedWorkflows, mockRunner.ExecutedWorkflows)
+		})
+	}
+}
+
+// TestStoryCommand_DependsOn tests that stories run after the stories they depend on
+func TestStoryCommand_DependsOn(t *testing.T) {
+	tests := []struct {
+		name          string
+		args          []string
+		expectError   bool
+		expectedOrder []string
+	}{
+		{
+			name:          "dependencies run first",
+			args:          []string{"story", "6-6-last", "6-5-middle", "6-4-first"},
+			expectedOrder: []string{"6-4-first", "6-5-middle", "6-6-last"},
+		},
+		{
+			name:          "done dependency outside the run",
+			args:          []string{"story", "6-7-after-done"},
+			expectedOrder: []string{"6-7-after-done"},
+		},
+		{
+			name:        "unmet dependency outside the run",
+			args:        []string{"story", "6-6-last"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			createSprintStatusFile(t, tmpDir, `development_status:
+  6-3-done: done
+  6-4-first: review
+  6-5-middle: review
+  6-6-last: review
+  6-7-after-done: review`)
+
+			mockWriter := &MockStatusWriter{}
+			app := &App{
+				Config:       config.DefaultConfig(),
+				StatusReader: status.NewReader(tmpDir),
+				StatusWriter: mockWriter,
+				Runner:       &MockWorkflowRunner{},
+				Printer:      output.NewPrinterWithWriter(&bytes.Buffer{}),
+				StoryOverrides: &config.StoryOverrides{Stories: map[string]config.StoryOverride{
+					"6-5-middle":     {DependsOn: []string{"6-4-first"}},
+					"6-6-last":       {DependsOn: []string{"6-5-middle"}},
+					"6-7-after-done": {DependsOn: []string{"6-3-done"}},
+				}},
+			}
+
+			rootCmd := NewRootCommand(app)
+			rootCmd.SetOut(&bytes.Buffer{})
+			rootCmd.SetErr(&bytes.Buffer{})
+			rootCmd.SetArgs(tt.args)
+
+			err := rootCmd.Execute()
+			if tt.expectError {
+				require.Error(t, err)
+				assert.Empty(t, mockWriter.Updates)
+				return
+			}
+			require.NoError(t, err)
+
+			var order []string
+			for _, update := range mockWriter.Updates {
+				if len(order) == 0 || order[len(order)-1] != update.StoryKey {
+					order = append(order, update.StoryKey)
+				}
+			}
+			assert.Equal(t, tt.expectedOrder, order)
+		})
+	}
+}
+
+// TestStoryCommand_FromStatus tests that --from-status drops stories before the status
+func TestStoryCommand_FromStatus(t *testing.T) {
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, `development_status:
+  6-1-first: done
+  6-2-second: backlog
+  6-3-third: review`)
+
+	mockWriter := &MockStatusWriter{}
+	app := &App{
+		Config:       config.DefaultConfig(),
+		StatusReader: status.NewReader(tmpDir),
+		StatusWriter: mockWriter,
+		Runner:       &MockWorkflowRunner{},
+		Printer:      output.NewPrinterWithWriter(&bytes.Buffer{}),
+	}
+
+	rootCmd := NewRootCommand(app)
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetErr(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{"story", "--from-status", "ready-for-dev", "6-1-first", "6-2-second", "6-3-third"})
+
+	require.NoError(t, rootCmd.Execute())
+	require.NotEmpty(t, mockWriter.Updates)
+	for _, update := range mockWriter.Updates {
+		assert.Equal(t, "6-3-third", update.StoryKey)
+	}
+}
+
+// TestStoryCommand_QuietJSON tests that --quiet --json writes exactly one JSON document to stdout
+func TestStoryCommand_QuietJSON(t *testing.T) {
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, `development_status:
+  6-1-first: review
+  6-2-second: done`)
+
+	// Capture stdout; the command must leave it to the summary alone
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	mockRunner := &MockWorkflowRunner{Usage: core.Usage{InputTokens: 100, OutputTokens: 20, CostUSD: 0.05}}
+	app := &App{
+		Config:       config.DefaultConfig(),
+		StatusReader: status.NewReader(tmpDir),
+		StatusWriter: &MockStatusWriter{},
+		Runner:       mockRunner,
+		Printer:      output.NewPrinterWithWriter(w),
+	}
+
+	rootCmd := NewRootCommand(app)
+	rootCmd.SetErr(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{"story", "--quiet", "--json", "--print-transitions", "6-1-first", "6-2-second"})
+
+	err := rootCmd.Execute()
+	w.Close()
+	os.Stdout = oldStdout
+
+	var stdout bytes.Buffer
+	_, _ = stdout.ReadFrom(r)
+
+	require.NoError(t, err)
+	assert.Equal(t, oldStdout, os.Stdout, "stdout restored")
+
+	decoder := json.NewDecoder(&stdout)
+	var summary runSummary
+	require.NoError(t, decoder.Decode(&summary), "stdout: %s", stdout.String())
+	assert.False(t, decoder.More(), "stdout holds exactly one JSON document")
+
+	assert.Equal(t, outcomeSuccess, summary.Outcome)
+	require.Len(t, summary.Stories, 2)
+	assert.Equal(t, "6-1-first", summary.Stories[0].StoryKey)
+	assert.Equal(t, outcomeSuccess, summary.Stories[0].Outcome)
+	require.Len(t, summary.Stories[0].Steps, 2)
+	assert.Equal(t, "code-review", summary.Stories[0].Steps[0].Workflow)
+	assert.InDelta(t, 0.05, summary.Stories[0].Steps[0].CostUSD, 1e-9)
+	assert.Equal(t, storySummary{StoryKey: "6-2-second", Outcome: outcomeSkipped, FinalStatus: "done", Steps: []stepSummary{}}, summary.Stories[1])
+
+	assert.Equal(t, 2, summary.Totals.Stories)
+	assert.Equal(t, 1, summary.Totals.Succeeded)
+	assert.Equal(t, 1, summary.Totals.Skipped)
+	assert.Equal(t, 200, summary.Totals.InputTokens)
+	assert.InDelta(t, 0.10, summary.Totals.CostUSD, 1e-9)
+}
+
+// TestStoryCommand_QuietJSONFailure tests that a failed run still produces the summary
+func TestStoryCommand_QuietJSONFailure(t *testing.T) {
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, `development_status:
+  6-1-first: review`)
+
+	app := &App{
+		Config:       config.DefaultConfig(),
+		StatusReader: status.NewReader(tmpDir),
+		StatusWriter: &MockStatusWriter{},
+		Runner:       &MockWorkflowRunner{FailOnWorkflow: "git-commit"},
+		Printer:      output.NewPrinterWithWriter(&bytes.Buffer{}),
+	}
+
+	var stdout bytes.Buffer
+	rootCmd := NewRootCommand(app)
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetErr(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{"story", "--quiet", "--json", "6-1-first"})
+
+	require.Error(t, rootCmd.Execute())
+
+	var summary runSummary
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &summary))
+	assert.Equal(t, outcomeFailed, summary.Outcome)
+	require.Len(t, summary.Stories, 1)
+	assert.Equal(t, outcomeFailed, summary.Stories[0].Outcome)
+	assert.NotEmpty(t, summary.Stories[0].Error)
+	require.Len(t, summary.Stories[0].Steps, 2)
+	assert.Equal(t, 1, summary.Stories[0].Steps[1].ExitCode)
+	assert.Equal(t, 1, summary.Totals.Failed)
+}
+
+// TestStoryCommand_Report tests that --report writes the run summary to a file
+func TestStoryCommand_Report(t *testing.T) {
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, `development_status:
+  6-1-first: review`)
+
+	app := &App{
+		Config:       config.DefaultConfig(),
+		StatusReader: status.NewReader(tmpDir),
+		StatusWriter: status.NewWriter(tmpDir),
+		Runner:       &MockWorkflowRunner{Usage: core.Usage{InputTokens: 100, OutputTokens: 20, CostUSD: 0.05}},
+		Printer:      output.NewPrinterWithWriter(&bytes.Buffer{}),
+	}
+
+	reportPath := filepath.Join(tmpDir, "report.json")
+	rootCmd := NewRootCommand(app)
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetErr(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{"story", "--report", reportPath, "6-1-first"})
+	require.NoError(t, rootCmd.Execute())
+
+	data, err := os.ReadFile(reportPath)
+	require.NoError(t, err)
+	var report runSummary
+	require.NoError(t, json.Unmarshal(data, &report))
+	assert.Equal(t, outcomeSuccess, report.Outcome)
+	require.Len(t, report.Stories, 1)
+	assert.Equal(t, "done", report.Stories[0].FinalStatus)
+	assert.Zero(t, report.Stories[0].Retries)
+	require.Len(t, report.Stories[0].Steps, 2)
+	assert.InDelta(t, 0.10, report.Totals.CostUSD, 1e-9)
+}
+
+// TestStoryCommand_ReportRejectsDryRun tests that --report needs a lifecycle run
+func TestStoryCommand_ReportRejectsDryRun(t *testing.T) {
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, `development_status:
+  6-1-first: review`)
+
+	app := &App{
+		Config:       config.DefaultConfig(),
+		StatusReader: status.NewReader(tmpDir),
+		StatusWriter: &MockStatusWriter{},
+		Runner:       &MockWorkflowRunner{},
+		Printer:      output.NewPrinterWithWriter(&bytes.Buffer{}),
+	}
+
+	rootCmd := NewRootCommand(app)
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetErr(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{"story", "--dry-run", "--report", filepath.Join(tmpDir, "report.json"), "6-1-first"})
+
+	code, ok := IsExitError(rootCmd.Execute())
+	require.True(t, ok)
+	assert.Equal(t, 1, code)
+	assert.NoFileExists(t, filepath.Join(tmpDir, "report.json"))
+}
+
+func TestCheckQuietJSONFlags(t *testing.T) {
+	assert.NoError(t, checkQuietJSONFlags(false, false, true, "", "", ""))
+	assert.NoError(t, checkQuietJSONFlags(true, true, false, "", "", ""))
+	assert.EqualError(t, checkQuietJSONFlags(false, true, false, "", "", ""), "--json requires --quiet")
+	assert.Error(t, checkQuietJSONFlags(true, true, true, "", "", ""))
+	assert.Error(t, checkQuietJSONFlags(true, true, false, "dev-story", "", ""))
+}
@@ -0,0 +1,55 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"bmaduum/internal/runlog"
+)
+
+func newTailLogCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tail-log [path]",
+		Short: "Follow the run log of an active run",
+		Long: `Follow the run log of an active or background run, like tail -f.
+
+The path is the file the run was started with --log-file. If no path is
+given, the default run log under the output directory is used
+(_bmad-output/bmaduum-run.log), which is also where --log-file writes when
+given without a path. The log is printed from the beginning and then
+followed as new lines are appended. If the file does not exist yet, a
+notice is printed and the command waits for it. Truncation and log rotation
+are handled automatically.
+
+Press Ctrl-C to stop following.
+
+Examples:
+  bmaduum --log-file epic all &
+  bmaduum tail-log
+  bmaduum tail-log /tmp/overnight.log`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := runlog.DefaultPath("")
+			if len(args) == 1 {
+				path = args[0]
+			}
+
+			out := cmd.OutOrStdout()
+			follower := runlog.NewFollower(path)
+			follower.SetWaitingHandler(func() {
+				fmt.Fprintf(out, "Waiting for %s...\n", path)
+			})
+			if err := follower.Follow(cmd.Context(), func(line string) {
+				fmt.Fprintln(out, line)
+			}); err != nil {
+				cmd.SilenceUsage = true
+				fmt.Fprintf(out, "Error following log %s: %v\n", path, err)
+				return NewExitError(1)
+			}
+			return nil
+		},
+	}
+
+	return cmd
+}
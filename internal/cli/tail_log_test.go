@@ -0,0 +1,46 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"bmaduum/internal/config"
+)
+
+func TestTailLogCommand_DefaultPath(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	rootCmd := NewRootCommand(&App{Config: config.DefaultConfig()})
+	outBuf := &bytes.Buffer{}
+	rootCmd.SetOut(outBuf)
+	rootCmd.SetErr(outBuf)
+	rootCmd.SetArgs([]string{"tail-log"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	require.NoError(t, rootCmd.ExecuteContext(ctx))
+
+	assert.Equal(t, "Waiting for "+filepath.Join("_bmad-output", "bmaduum-run.log")+"...\n", outBuf.String())
+}
+
+func TestTailLogCommand_WaitsForMissingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run.log")
+
+	rootCmd := NewRootCommand(&App{Config: config.DefaultConfig()})
+	outBuf := &bytes.Buffer{}
+	rootCmd.SetOut(outBuf)
+	rootCmd.SetErr(outBuf)
+	rootCmd.SetArgs([]string{"tail-log", path})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	require.NoError(t, rootCmd.ExecuteContext(ctx))
+
+	assert.Equal(t, "Waiting for "+path+"...\n", outBuf.String())
+}
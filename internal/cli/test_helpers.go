@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"bmaduum/internal/output/core"
 	"bmaduum/internal/status"
 )
 
@@ -21,16 +22,39 @@ type MockWorkflowRunner struct {
 	ExecutedWorkflows []string
 	// FailOnWorkflow specifies which workflow should fail (returns exit code 1).
 	FailOnWorkflow string
+	// ChangedFiles and CreatedFiles map a workflow name to the files
+	// LastArtifacts reports after that workflow runs.
+	ChangedFiles map[string][]string
+	CreatedFiles map[string][]string
+	// OnRun, if set, is called for each workflow before it returns.
+	OnRun func(workflowName, storyKey string)
+	// Usage is added to the total TotalUsage reports after each workflow.
+	Usage core.Usage
+	// FailureError is what LastError reports after FailOnWorkflow fails.
+	FailureError error
+
+	lastWorkflow string
+	totalUsage   core.Usage
 }
 
 func (m *MockWorkflowRunner) RunSingle(ctx context.Context, workflowName, storyKey string) int {
 	m.ExecutedWorkflows = append(m.ExecutedWorkflows, workflowName)
+	m.lastWorkflow = workflowName
+	m.totalUsage = m.totalUsage.Add(m.Usage)
+	if m.OnRun != nil {
+		m.OnRun(workflowName, storyKey)
+	}
 	if m.FailOnWorkflow == workflowName {
 		return 1
 	}
 	return 0
 }
 
+// TotalUsage returns the usage accumulated across the workflows run so far.
+func (m *MockWorkflowRunner) TotalUsage() core.Usage {
+	return m.totalUsage
+}
+
 func (m *MockWorkflowRunner) RunRaw(ctx context.Context, prompt string) int {
 	return 0
 }
@@ -39,6 +63,17 @@ func (m *MockWorkflowRunner) SetOperation(operation string) {
 	// No-op for mock
 }
 
+func (m *MockWorkflowRunner) LastArtifacts() (changed, created []string) {
+	return m.ChangedFiles[m.lastWorkflow], m.CreatedFiles[m.lastWorkflow]
+}
+
+func (m *MockWorkflowRunner) LastError() error {
+	if m.lastWorkflow != m.FailOnWorkflow {
+		return nil
+	}
+	return m.FailureError
+}
+
 // MockStatusWriter is a mock for testing.
 type MockStatusWriter struct {
 	// Updates records all status updates.
@@ -50,6 +85,46 @@ func (m *MockStatusWriter) UpdateStatus(storyKey string, newStatus status.Status
 	return nil
 }
 
+// MockGitHelper implements GitHelper for testing.
+type MockGitHelper struct {
+	// Files is returned as the list of uncommitted files.
+	Files []string
+	// Err is returned instead of Files if set.
+	Err error
+	// Calls counts UncommittedFiles invocations.
+	Calls int
+	// Head is returned by HeadSHA.
+	Head string
+	// NewCommits is returned by CommitsSince.
+	NewCommits []string
+	// Root is returned by RepoRoot, or RootErr if set.
+	Root    string
+	RootErr error
+}
+
+func (m *MockGitHelper) UncommittedFiles(ctx context.Context) ([]string, error) {
+	m.Calls++
+	if m.Err != nil {
+		return nil, m.Err
+	}
+	return m.Files, nil
+}
+
+func (m *MockGitHelper) HeadSHA(ctx context.Context) (string, error) {
+	return m.Head, nil
+}
+
+func (m *MockGitHelper) CommitsSince(ctx context.Context, base string) ([]string, error) {
+	return m.NewCommits, nil
+}
+
+func (m *MockGitHelper) RepoRoot(ctx context.Context) (string, error) {
+	if m.RootErr != nil {
+		return "", m.RootErr
+	}
+	return m.Root, nil
+}
+
 // MockBmadHelpFallback implements lifecycle.BmadHelpFallback for testing.
 type MockBmadHelpFallback struct {
 	Workflow   string
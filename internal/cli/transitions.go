@@ -0,0 +1,45 @@
+package cli
+
+import (
+	"fmt"
+
+	"bmaduum/internal/router"
+	"bmaduum/internal/status"
+)
+
+// transitionCheckingWriter wraps a [StatusWriter], rejecting status changes
+// the lifecycle chain never makes (see [router.Router.ValidateTransition]).
+//
+// It is used when validate_transitions is enabled and --force is not given.
+// If the story's current status cannot be read, the update is passed through
+// so the underlying writer reports the problem.
+type transitionCheckingWriter struct {
+	writer StatusWriter
+	reader StatusReader
+	router *router.Router
+}
+
+// newTransitionCheckingWriter wraps the app's status writer, validating
+// against the app's router or the default router if none is set.
+func newTransitionCheckingWriter(app *App) *transitionCheckingWriter {
+	r := app.Router
+	if r == nil {
+		r = router.NewRouter()
+	}
+	return &transitionCheckingWriter{
+		writer: app.StatusWriter,
+		reader: app.StatusReader,
+		router: r,
+	}
+}
+
+// UpdateStatus validates the transition from the story's current status to
+// newStatus, then writes it.
+func (w *transitionCheckingWriter) UpdateStatus(storyKey string, newStatus status.Status) error {
+	if current, err := w.reader.GetStoryStatus(storyKey); err == nil {
+		if err := w.router.ValidateTransition(current, newStatus); err != nil {
+			return fmt.Errorf("story %s: %w (use --force to allow it)", storyKey, err)
+		}
+	}
+	return w.writer.UpdateStatus(storyKey, newStatus)
+}
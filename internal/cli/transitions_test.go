@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"bmaduum/internal/config"
+	"bmaduum/internal/output"
+	"bmaduum/internal/router"
+	"bmaduum/internal/status"
+)
+
+func TestTransitionCheckingWriter(t *testing.T) {
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, `development_status:
+  7-1: done
+  7-2: review`)
+
+	mockWriter := &MockStatusWriter{}
+	writer := newTransitionCheckingWriter(&App{
+		StatusReader: status.NewReader(tmpDir),
+		StatusWriter: mockWriter,
+	})
+
+	err := writer.UpdateStatus("7-1", status.StatusBacklog)
+	require.ErrorIs(t, err, router.ErrIllegalTransition)
+	assert.Equal(t, "story 7-1: illegal status transition: done → backlog (use --force to allow it)", err.Error())
+
+	require.NoError(t, writer.UpdateStatus("7-2", status.StatusDone))
+	// Unknown stories are left to the underlying writer
+	require.NoError(t, writer.UpdateStatus("9-9", status.StatusDone))
+	assert.Equal(t, []StatusUpdate{
+		{StoryKey: "7-2", NewStatus: status.StatusDone},
+		{StoryKey: "9-9", NewStatus: status.StatusDone},
+	}, mockWriter.Updates)
+}
+
+// TestStoryCommand_ValidateTransitions tests that validate_transitions rejects a rerun of a done story unless forced
+func TestStoryCommand_ValidateTransitions(t *testing.T) {
+	tests := []struct {
+		name        string
+		validate    bool
+		args        []string
+		expectError bool
+	}{
+		{"validation off", false, []string{"story", "--on-done", "rerun", "6-1-test"}, false},
+		{"illegal transition rejected", true, []string{"story", "--on-done", "rerun", "6-1-test"}, true},
+		{"force allows it", true, []string{"story", "--on-done", "rerun", "--force", "6-1-test"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			createSprintStatusFile(t, tmpDir, `development_status:
+  6-1-test: done`)
+
+			cfg := config.DefaultConfig()
+			cfg.ValidateTransitions = tt.validate
+			mockWriter := &MockStatusWriter{}
+			app := &App{
+				Config:       cfg,
+				StatusReader: status.NewReader(tmpDir),
+				StatusWriter: mockWriter,
+				Runner:       &MockWorkflowRunner{},
+				Printer:      output.NewPrinterWithWriter(&bytes.Buffer{}),
+			}
+
+			rootCmd := NewRootCommand(app)
+			rootCmd.SetOut(&bytes.Buffer{})
+			rootCmd.SetErr(&bytes.Buffer{})
+			rootCmd.SetArgs(tt.args)
+
+			err := rootCmd.Execute()
+			if tt.expectError {
+				assert.Error(t, err)
+				assert.Empty(t, mockWriter.Updates, "done → review not written")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, StatusUpdate{StoryKey: "6-1-test", NewStatus: status.StatusReview}, mockWriter.Updates[0])
+		})
+	}
+}
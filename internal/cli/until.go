@@ -0,0 +1,35 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"bmaduum/internal/lifecycle"
+	"bmaduum/internal/status"
+)
+
+// addUntilFlag registers the --until flag on a lifecycle command, which stops
+// each story once it reaches the given status.
+func addUntilFlag(cmd *cobra.Command, until *string) {
+	cmd.Flags().StringVar(until, "until", "", "Stop each story once it reaches `status`, even if more steps remain")
+	_ = cmd.RegisterFlagCompletionFunc("until", completeStatuses)
+}
+
+// applyUntil sets the --until target on executor. An empty until leaves the
+// lifecycle running to done.
+//
+// Returns an error if until is not a status in the router's lifecycle chain.
+// Whether a story can reach it is checked when the story runs.
+func applyUntil(app *App, executor *lifecycle.Executor, until string) error {
+	if until == "" {
+		return nil
+	}
+
+	target := status.Status(until)
+	if _, ok := lifecycleRouter(app).ChainIndex(target); !ok {
+		return fmt.Errorf("--until %q is not a status in the lifecycle", until)
+	}
+	executor.SetUntil(target)
+	return nil
+}
@@ -0,0 +1,39 @@
+package cli
+
+import (
+	"bmaduum/internal/output/core"
+)
+
+// UsageReporter is implemented by runners that accumulate the token usage
+// and cost Claude reports for each workflow run. [workflow.Runner]
+// implements it.
+type UsageReporter interface {
+	TotalUsage() core.Usage
+}
+
+// runCost returns the cost accumulated by the runner so far, or zero if the
+// runner does not report usage.
+func runCost(app *App) float64 {
+	if reporter, ok := app.Runner.(UsageReporter); ok {
+		return reporter.TotalUsage().CostUSD
+	}
+	return 0
+}
+
+// printRunUsage prints the cumulative token usage and cost of the run, if
+// the runner reports any.
+func printRunUsage(app *App) {
+	reporter, ok := app.Runner.(UsageReporter)
+	if !ok {
+		return
+	}
+	printUsage(app, reporter.TotalUsage())
+}
+
+// printUsage prints cumulative token usage and cost, unless it is zero.
+func printUsage(app *App, usage core.Usage) {
+	if usage.IsZero() {
+		return
+	}
+	app.printf("Total cost: $%.4f (%d input / %d output tokens)\n", usage.CostUSD, usage.InputTokens, usage.OutputTokens)
+}
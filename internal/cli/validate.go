@@ -0,0 +1,175 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"bmaduum/internal/claude"
+	"bmaduum/internal/config"
+	"bmaduum/internal/manifest"
+	"bmaduum/internal/router"
+	"bmaduum/internal/status"
+)
+
+func newConfigCommand(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Inspect and edit the bmaduum configuration",
+	}
+	cmd.AddCommand(newConfigInitCommand())
+	cmd.AddCommand(newConfigSetCommand())
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "validate [path]",
+		Short: "Validate the configuration without running anything",
+		Long: `Load and validate the configuration, then report every problem found.
+
+Without a path, the configuration bmaduum would use for a run is checked.
+With a path, that config file is loaded over the defaults and checked instead.
+
+Checks that every workflow has a prompt for the active prompt mode and that
+its templates parse, that every workflow in the story lifecycle is configured,
+that story_numbering names a known scheme, that the Claude binary is on PATH,
+and that timeouts and retry settings are not negative.
+
+Exits non-zero if any problem is found, which makes it suitable for CI.
+
+Examples:
+  bmaduum config validate
+  bmaduum config validate config/workflows.yaml`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := cmd.OutOrStdout()
+
+			cfg := app.Config
+			label := "Config"
+			if len(args) == 1 {
+				label = args[0]
+				loaded, err := config.NewLoader().LoadFromFile(args[0])
+				if err != nil {
+					return reportProblems(cmd, out, label, []error{err})
+				}
+				cfg = loaded
+			}
+
+			wfRouter := app.Router
+			if wfRouter == nil {
+				wfRouter = router.NewRouter()
+			}
+			return reportProblems(cmd, out, label, validateConfig(cfg, wfRouter))
+		},
+	})
+
+	return cmd
+}
+
+func newManifestCommand(app *App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "manifest",
+		Short: "Inspect BMAD manifests",
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "validate [path]",
+		Short: "Validate a workflow or module manifest without running anything",
+		Long: `Load and validate a BMAD manifest, then report every problem found.
+
+The path defaults to the --manifest flag, then BMADUUM_MANIFEST_PATH, then
+` + manifest.WorkflowManifestPath + `. CSV files are checked as
+workflow manifests: required columns must be present and the status chain
+must be coherent (known statuses, one workflow per trigger status, every
+next_status advances the story, every trigger status is reachable from the
+first, no status cycles, and the chain ends in done). YAML files are
+checked as module manifests: every module needs a name and every declared
+injection a workflow and exactly one of after or before.
+
+Exits non-zero if any problem is found, which makes it suitable for CI.
+
+Examples:
+  bmaduum manifest validate
+  bmaduum manifest validate _bmad/_config/manifest.yaml`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := app.workflowManifestPath()
+			if len(args) == 1 {
+				path = args[0]
+			}
+			return reportProblems(cmd, cmd.OutOrStdout(), path, validateManifestFile(path))
+		},
+	})
+
+	return cmd
+}
+
+// validateConfig checks cfg on its own (see [config.Config.Validate]) and
+// against the statuses, lifecycle, and Claude binary it will be used with.
+func validateConfig(cfg *config.Config, wfRouter *router.Router) []error {
+	problems := validateConfigSettings(cfg, wfRouter)
+	if err := checkClaudeBinary(cfg); err != nil {
+		problems = append(problems, fmt.Errorf("claude.binary_path: %w", err))
+	}
+	return problems
+}
+
+// validateConfigSettings is [validateConfig] without the Claude binary
+// check, for callers that check the binary themselves.
+func validateConfigSettings(cfg *config.Config, wfRouter *router.Router) []error {
+	problems := cfg.Validate()
+
+	if _, err := status.ParseNumberScheme(cfg.StoryNumbering); err != nil {
+		problems = append(problems, fmt.Errorf("story_numbering: %w", err))
+	}
+	if err := claude.ValidateExtraArgs(cfg.Claude.ExtraArgs); err != nil {
+		problems = append(problems, fmt.Errorf("claude.extra_args: %w", err))
+	}
+
+	steps, err := wfRouter.GetLifecycle(status.StatusBacklog)
+	if err != nil {
+		problems = append(problems, fmt.Errorf("lifecycle: %w", err))
+	}
+	for _, step := range steps {
+		if _, ok := cfg.Workflows[step.Workflow]; !ok {
+			problems = append(problems, fmt.Errorf("lifecycle workflow %s has no entry under workflows", step.Workflow))
+		}
+	}
+
+	return problems
+}
+
+// validateManifestFile loads the manifest at path, choosing the module or
+// workflow manifest format by extension, and returns its problems.
+func validateManifestFile(path string) []error {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		if _, err := manifest.ReadModulesFromFile(path); err != nil {
+			return []error{err}
+		}
+		return nil
+	default:
+		m, err := manifest.ReadFromFile(path)
+		if err != nil {
+			return []error{err}
+		}
+		return m.Validate()
+	}
+}
+
+// reportProblems prints the outcome of a validation of label and returns
+// an exit error if problems is non-empty.
+func reportProblems(cmd *cobra.Command, out io.Writer, label string, problems []error) error {
+	if len(problems) == 0 {
+		fmt.Fprintf(out, "%s is valid\n", label)
+		return nil
+	}
+
+	fmt.Fprintf(out, "%s has %d problem(s):\n", label, len(problems))
+	for _, problem := range problems {
+		fmt.Fprintf(out, "  - %v\n", problem)
+	}
+	cmd.SilenceUsage = true
+	return NewExitError(1)
+}
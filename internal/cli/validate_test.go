@@ -0,0 +1,126 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"bmaduum/internal/config"
+	"bmaduum/internal/router"
+)
+
+func runValidateCommand(t *testing.T, app *App, args ...string) (string, error) {
+	t.Helper()
+
+	rootCmd := NewRootCommand(app)
+	outBuf := &bytes.Buffer{}
+	rootCmd.SetOut(outBuf)
+	rootCmd.SetErr(outBuf)
+	rootCmd.SetArgs(args)
+
+	err := rootCmd.Execute()
+	return outBuf.String(), err
+}
+
+// validateTestConfig returns the default config with a Claude binary that
+// is always on PATH.
+func validateTestConfig() *config.Config {
+	cfg := config.DefaultConfig()
+	cfg.Claude.BinaryPath = "sh"
+	return cfg
+}
+
+func TestConfigValidateCommand_Valid(t *testing.T) {
+	app := &App{Config: validateTestConfig(), Router: router.NewRouter()}
+
+	out, err := runValidateCommand(t, app, "config", "validate")
+
+	require.NoError(t, err)
+	assert.Equal(t, "Config is valid\n", out)
+}
+
+func TestConfigValidateCommand_Problems(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.StoryNumbering = "roman"
+	cfg.Claude.ExtraArgs = []string{"--output-format", "json"}
+	cfg.Claude.BinaryPath = "/nonexistent/claude"
+	cfg.Workflows["code-review"] = config.WorkflowConfig{}
+	delete(cfg.Workflows, "git-commit")
+	app := &App{Config: cfg, Router: router.NewRouter()}
+
+	out, err := runValidateCommand(t, app, "config", "validate")
+
+	require.Error(t, err)
+	assert.Equal(t, 1, err.(*ExitError).Code)
+	assert.Contains(t, out, "Config has 5 problem(s):\n")
+	assert.Contains(t, out, "  - workflows.code-review: workflow code-review has no prompt template or slash command configured\n")
+	assert.Contains(t, out, "  - story_numbering: ")
+	assert.Contains(t, out, "  - claude.extra_args: --output-format is set by bmaduum")
+	assert.Contains(t, out, "  - claude.binary_path: /nonexistent/claude not found: ")
+	assert.Contains(t, out, "  - lifecycle workflow git-commit has no entry under workflows\n")
+}
+
+func TestConfigValidateCommand_File(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "workflows.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`workflows:
+  dev-story:
+    slash_command: "/dev-story {{.StoryKey"
+claude:
+  binary_path: sh
+`), 0644))
+	app := &App{Config: config.DefaultConfig()}
+
+	out, err := runValidateCommand(t, app, "config", "validate", path)
+
+	require.Error(t, err)
+	assert.Contains(t, out, path+" has 2 problem(s):\n")
+	assert.Contains(t, out, "  - workflows.dev-story.slash_command: ")
+}
+
+func TestManifestValidateCommand(t *testing.T) {
+	dir := t.TempDir()
+	good := filepath.Join(dir, "good.csv")
+	require.NoError(t, os.WriteFile(good, []byte(`workflow,trigger_status,next_status
+dev-story,ready-for-dev,review
+code-review,review,done
+`), 0644))
+	broken := filepath.Join(dir, "broken.csv")
+	require.NoError(t, os.WriteFile(broken, []byte(`workflow,trigger_status,next_status
+dev-story,ready-for-dev,testing
+code-review,review,
+`), 0644))
+	missingColumn := filepath.Join(dir, "missing.csv")
+	require.NoError(t, os.WriteFile(missingColumn, []byte("workflow,trigger_status\ndev-story,ready-for-dev\n"), 0644))
+	modules := filepath.Join(dir, "manifest.yaml")
+	require.NoError(t, os.WriteFile(modules, []byte(`modules:
+  - name: security
+    injects:
+      - workflow: security-review
+`), 0644))
+
+	app := &App{Config: config.DefaultConfig()}
+
+	out, err := runValidateCommand(t, app, "manifest", "validate", good)
+	require.NoError(t, err)
+	assert.Equal(t, good+" is valid\n", out)
+
+	out, err = runValidateCommand(t, app, "manifest", "validate", broken)
+	require.Error(t, err)
+	assert.Contains(t, out, broken+` has 3 problem(s):
+  - workflow dev-story: unknown next_status "testing"
+  - workflow code-review: next_status is required
+  - workflow code-review: last entry must set next_status to done
+`)
+
+	out, err = runValidateCommand(t, app, "manifest", "validate", missingColumn)
+	require.Error(t, err)
+	assert.Contains(t, out, "  - manifest missing required column: next_status\n")
+
+	out, err = runValidateCommand(t, app, "manifest", "validate", modules)
+	require.Error(t, err)
+	assert.Contains(t, out, "  - module security: injection security-review must set exactly one of after or before\n")
+}
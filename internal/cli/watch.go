@@ -0,0 +1,161 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"bmaduum/internal/bmadhelp"
+	"bmaduum/internal/router"
+	"bmaduum/internal/status"
+)
+
+// recordingStatusWriter remembers the last status it wrote for each story,
+// so the watch command can tell its own status updates from the user's.
+type recordingStatusWriter struct {
+	writer StatusWriter
+
+	mu      sync.Mutex
+	written map[string]status.Status
+}
+
+// UpdateStatus writes the new status and records it.
+func (w *recordingStatusWriter) UpdateStatus(storyKey string, newStatus status.Status) error {
+	if err := w.writer.UpdateStatus(storyKey, newStatus); err != nil {
+		return err
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.written[storyKey] = newStatus
+	return nil
+}
+
+// caused reports whether s is the status last written for storyKey, and
+// forgets the write so a later change by the user is not mistaken for it.
+func (w *recordingStatusWriter) caused(storyKey string, s status.Status) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	written, ok := w.written[storyKey]
+	delete(w.written, storyKey)
+	return ok && written == s
+}
+
+func newWatchCommand(app *App) *cobra.Command {
+	var debounce time.Duration
+	var noBmadHelp bool
+
+	cmd := &cobra.Command{
+		Use:   "watch",
+		Short: "Run stories as they move to an actionable status",
+		Long: `Watch sprint-status.yaml and run a story's lifecycle whenever it moves
+to a status that has a workflow, such as when you bump it to ready-for-dev.
+
+Changes are picked up once writes to the file have been quiet for the
+--debounce interval. Status updates made by the watched runs themselves
+are ignored, and stories run one at a time: changes made during a run are
+handled after it. A failed story is reported and watching continues.
+
+Press Ctrl-C to stop watching.
+
+Examples:
+  bmaduum watch
+  bmaduum watch --debounce 2s`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := requireClaude(cmd, app); err != nil {
+				return err
+			}
+
+			before, err := app.StatusReader.Read()
+			if err != nil {
+				cmd.SilenceUsage = true
+				app.printf("Error: %v\n", err)
+				return NewExitError(1)
+			}
+
+			recorder := &recordingStatusWriter{writer: app.StatusWriter, written: make(map[string]status.Status)}
+			watchApp := *app
+			watchApp.StatusWriter = recorder
+			executor := newLifecycleExecutor(&watchApp, noBmadHelp, false, false)
+
+			ctx := cmd.Context()
+			path := app.StatusReader.Path()
+			app.printf("Watching %s for stories to run (Ctrl-C to stop)\n", path)
+
+			watcher := status.NewWatcher(path)
+			watcher.SetDebounce(debounce)
+			err = watcher.Watch(ctx, func() {
+				// Each batch of changes is a separate run; statuses may mean
+				// something else to bmad-help by now
+				if cache, ok := app.BmadHelp.(*bmadhelp.CachingFallback); ok {
+					cache.Clear()
+				}
+
+				after, err := app.StatusReader.Read()
+				if err != nil {
+					app.printf("Warning: %v\n", err)
+					return
+				}
+				changed := changedStories(before.DevelopmentStatus, after.DevelopmentStatus)
+				before = after
+
+				for _, storyKey := range changed {
+					if ctx.Err() != nil {
+						return
+					}
+					if recorder.caused(storyKey, after.DevelopmentStatus[storyKey]) {
+						continue
+					}
+					if steps, err := executor.GetSteps(storyKey); err != nil || len(steps) == 0 {
+						continue
+					}
+
+					app.printf("Story %s moved to %s, running its lifecycle\n", storyKey, after.DevelopmentStatus[storyKey])
+					app.Runner.SetOperation(fmt.Sprintf("Story %s", storyKey))
+					err := executeWithRetry(ctx, executor, storyKey, false, false, 0, func(stepIndex, totalSteps int, workflow string) {
+						app.Printer.StepStart(stepIndex, totalSteps, workflow)
+					})
+					switch {
+					case errors.Is(err, context.Canceled):
+						app.printf("Story %s cancelled\n", storyKey)
+					case err != nil && !errors.Is(err, router.ErrStoryComplete):
+						app.printf("Error running lifecycle for story %s: %v\n", storyKey, err)
+					default:
+						app.printf("Story %s completed successfully\n", storyKey)
+					}
+				}
+			})
+			if err != nil {
+				cmd.SilenceUsage = true
+				app.printf("Error: %v\n", err)
+				return NewExitError(1)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().DurationVar(&debounce, "debounce", status.DefaultDebounce, "How long writes to sprint-status.yaml must settle before changes are handled")
+	cmd.Flags().BoolVar(&noBmadHelp, "no-bmad-help", false, "Disable bmad-help fallback for unknown statuses")
+	return cmd
+}
+
+// changedStories returns the story keys, sorted, whose status in after
+// differs from before or that are new in after. Epic entries are ignored.
+func changedStories(before, after map[string]status.Status) []string {
+	var changed []string
+	for key, s := range after {
+		if !status.IsStoryKey(key) {
+			continue
+		}
+		if old, ok := before[key]; !ok || old != s {
+			changed = append(changed, key)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"bmaduum/internal/config"
+	"bmaduum/internal/output"
+	"bmaduum/internal/status"
+)
+
+func TestChangedStories(t *testing.T) {
+	before := map[string]status.Status{
+		"epic-7":     status.StatusInProgress,
+		"7-1-schema": status.StatusDone,
+		"7-2-api":    status.StatusBacklog,
+	}
+	after := map[string]status.Status{
+		"epic-7":     status.StatusDone,
+		"7-1-schema": status.StatusDone,
+		"7-2-api":    status.StatusReadyForDev,
+		"7-3-ui":     status.StatusBacklog,
+	}
+
+	assert.Equal(t, []string{"7-2-api", "7-3-ui"}, changedStories(before, after))
+}
+
+func TestRecordingStatusWriter_Caused(t *testing.T) {
+	recorder := &recordingStatusWriter{writer: &MockStatusWriter{}, written: make(map[string]status.Status)}
+	require.NoError(t, recorder.UpdateStatus("7-1-schema", status.StatusInProgress))
+	require.NoError(t, recorder.UpdateStatus("7-1-schema", status.StatusReview))
+
+	assert.False(t, recorder.caused("7-2-api", status.StatusReview))
+	assert.True(t, recorder.caused("7-1-schema", status.StatusReview))
+	assert.False(t, recorder.caused("7-1-schema", status.StatusReview), "a write is only matched once")
+}
+
+func TestWatchCommand_RunsStoryMovedToActionableStatus(t *testing.T) {
+	tmpDir := t.TempDir()
+	createSprintStatusFile(t, tmpDir, `development_status:
+  7-1-schema: backlog`)
+	statusReader := status.NewReader(tmpDir)
+
+	ran := make(chan string, 10)
+	mockRunner := &MockWorkflowRunner{
+		OnRun: func(workflowName, storyKey string) {
+			ran <- storyKey + " " + workflowName
+		},
+	}
+	app := &App{
+		Config:       config.DefaultConfig(),
+		StatusReader: statusReader,
+		StatusWriter: &MockStatusWriter{},
+		Runner:       mockRunner,
+		Printer:      output.NewPrinterWithWriter(&bytes.Buffer{}),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	rootCmd := NewRootCommand(app)
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetErr(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{"watch", "--debounce", "20ms"})
+	done := make(chan error, 1)
+	go func() {
+		done <- rootCmd.ExecuteContext(ctx)
+	}()
+
+	// Keep moving the story between done and ready-for-dev until the watcher
+	// is running and picks it up
+	deadline := time.After(5 * time.Second)
+	for picked, i := false, 0; !picked; i++ {
+		next := "ready-for-dev"
+		if i%2 == 0 {
+			next = "done"
+		}
+		require.NoError(t, os.WriteFile(statusReader.Path(), []byte("development_status:\n  7-1-schema: "+next+"\n"), 0644))
+		select {
+		case run := <-ran:
+			assert.Equal(t, "7-1-schema dev-story", run)
+			picked = true
+		case <-time.After(200 * time.Millisecond):
+		case <-deadline:
+			t.Fatal("story not run")
+		}
+	}
+
+	cancel()
+	assert.NoError(t, <-done)
+}
@@ -2,9 +2,15 @@ package cli
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"path/filepath"
+	"time"
 
 	"github.com/spf13/cobra"
+
+	"bmaduum/internal/lifecycle"
+	"bmaduum/internal/state"
 )
 
 func newWorkflowCommand(app *App) *cobra.Command {
@@ -67,7 +73,8 @@ Example:
 
 // newCreateStoryWorkflowCommand creates the create-story workflow subcommand
 func newCreateStoryWorkflowCommand(app *App) *cobra.Command {
-	var autoRetry bool
+	var autoRetry, resume bool
+	var model string
 
 	cmd := &cobra.Command{
 		Use:   "create-story <story-key>",
@@ -81,17 +88,20 @@ technical requirements, and implementation plan.`,
 			ctx := cmd.Context()
 			storyKey := args[0]
 
-			return executeWorkflowWithRetry(ctx, cmd, app, "create-story", storyKey, autoRetry)
+			return executeWorkflowWithRetry(ctx, cmd, app, "create-story", storyKey, autoRetry, resume, model)
 		},
 	}
 
 	cmd.Flags().BoolVar(&autoRetry, "auto-retry", false, "Automatically retry on rate limit errors")
+	addResumeSessionFlag(cmd, &resume)
+	addModelFlag(cmd, &model)
 	return cmd
 }
 
 // newDevStoryWorkflowCommand creates the dev-story workflow subcommand
 func newDevStoryWorkflowCommand(app *App) *cobra.Command {
-	var autoRetry bool
+	var autoRetry, resume bool
+	var model string
 
 	cmd := &cobra.Command{
 		Use:   "dev-story <story-key>",
@@ -105,17 +115,20 @@ running the actual implementation work.`,
 			ctx := cmd.Context()
 			storyKey := args[0]
 
-			return executeWorkflowWithRetry(ctx, cmd, app, "dev-story", storyKey, autoRetry)
+			return executeWorkflowWithRetry(ctx, cmd, app, "dev-story", storyKey, autoRetry, resume, model)
 		},
 	}
 
 	cmd.Flags().BoolVar(&autoRetry, "auto-retry", false, "Automatically retry on rate limit errors")
+	addResumeSessionFlag(cmd, &resume)
+	addModelFlag(cmd, &model)
 	return cmd
 }
 
 // newCodeReviewWorkflowCommand creates the code-review workflow subcommand
 func newCodeReviewWorkflowCommand(app *App) *cobra.Command {
-	var autoRetry bool
+	var autoRetry, resume bool
+	var model string
 
 	cmd := &cobra.Command{
 		Use:   "code-review <story-key>",
@@ -128,17 +141,20 @@ This workflow reviews the implementation and generates feedback.`,
 			ctx := cmd.Context()
 			storyKey := args[0]
 
-			return executeWorkflowWithRetry(ctx, cmd, app, "code-review", storyKey, autoRetry)
+			return executeWorkflowWithRetry(ctx, cmd, app, "code-review", storyKey, autoRetry, resume, model)
 		},
 	}
 
 	cmd.Flags().BoolVar(&autoRetry, "auto-retry", false, "Automatically retry on rate limit errors")
+	addResumeSessionFlag(cmd, &resume)
+	addModelFlag(cmd, &model)
 	return cmd
 }
 
 // newGitCommitWorkflowCommand creates the git-commit workflow subcommand
 func newGitCommitWorkflowCommand(app *App) *cobra.Command {
-	var autoRetry bool
+	var autoRetry, resume bool
+	var model string
 
 	cmd := &cobra.Command{
 		Use:   "git-commit <story-key>",
@@ -151,20 +167,80 @@ This workflow commits the changes and pushes them to the remote repository.`,
 			ctx := cmd.Context()
 			storyKey := args[0]
 
-			return executeWorkflowWithRetry(ctx, cmd, app, "git-commit", storyKey, autoRetry)
+			return executeWorkflowWithRetry(ctx, cmd, app, "git-commit", storyKey, autoRetry, resume, model)
 		},
 	}
 
 	cmd.Flags().BoolVar(&autoRetry, "auto-retry", false, "Automatically retry on rate limit errors")
+	addResumeSessionFlag(cmd, &resume)
+	addModelFlag(cmd, &model)
 	return cmd
 }
 
-// executeWorkflowWithRetry executes a single workflow with optional retry logic
-func executeWorkflowWithRetry(ctx context.Context, cmd *cobra.Command, app *App, workflowName, storyKey string, autoRetry bool) error {
-	exitCode := app.Runner.RunSingle(ctx, workflowName, storyKey)
+// addResumeSessionFlag registers the --resume flag on a workflow subcommand.
+func addResumeSessionFlag(cmd *cobra.Command, resume *bool) {
+	cmd.Flags().BoolVar(resume, "resume", false, "Continue the story's last Claude session for this workflow instead of starting a new one")
+}
+
+// executeWorkflowWithRetry executes a single workflow with optional retry logic.
+//
+// With resume, the story's last recorded Claude session is continued if it
+// belongs to this workflow. The session of the run is recorded afterwards so
+// a later --resume can pick it up.
+//
+// A non-empty model overrides the workflow's configured model.
+func executeWorkflowWithRetry(ctx context.Context, cmd *cobra.Command, app *App, workflowName, storyKey string, autoRetry, resume bool, model string) error {
+	if err := requireClaude(cmd, app); err != nil {
+		return err
+	}
+	sessions := workflowSessionStore(app)
+	runner, canResume := app.Runner.(lifecycle.SessionRunner)
+	if resume {
+		if sessions == nil || !canResume {
+			app.println("Warning: Claude sessions cannot be resumed here; starting a new session")
+		} else if session, err := sessions.Load(storyKey); err != nil && !errors.Is(err, state.ErrNoSession) {
+			app.printf("Warning: %v; starting a new session\n", err)
+		} else if err != nil || session.Workflow != workflowName {
+			app.printf("No %s session recorded for story %s; starting a new session\n", workflowName, storyKey)
+		} else {
+			runner.SetResumeSession(session.SessionID)
+		}
+	}
+
+	var exitCode int
+	if modelRunner, ok := app.Runner.(lifecycle.ModelRunner); ok && model != "" {
+		warnUnknownModel(app, model)
+		exitCode = modelRunner.RunSingleWithModel(ctx, workflowName, storyKey, model)
+	} else {
+		if model != "" {
+			app.println("Warning: --model is not supported by this runner; using the configured model")
+		}
+		exitCode = app.Runner.RunSingle(ctx, workflowName, storyKey)
+	}
+	if sessions != nil && canResume && runner.LastSessionID() != "" {
+		err := sessions.Save(state.Session{
+			StoryKey:  storyKey,
+			Workflow:  workflowName,
+			SessionID: runner.LastSessionID(),
+			ExitCode:  exitCode,
+			UpdatedAt: time.Now(),
+		})
+		if err != nil {
+			app.printf("Warning: %v\n", err)
+		}
+	}
 	if exitCode != 0 {
 		cmd.SilenceUsage = true
 		return NewExitError(exitCode)
 	}
 	return nil
 }
+
+// workflowSessionStore returns the session store next to sprint-status.yaml,
+// or nil if the app has no status reader.
+func workflowSessionStore(app *App) *state.SessionStore {
+	if app.StatusReader == nil {
+		return nil
+	}
+	return state.NewSessionStore(filepath.Dir(app.StatusReader.Path()))
+}
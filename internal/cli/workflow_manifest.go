@@ -0,0 +1,165 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"time"
+
+	"bmaduum/internal/bmadhelp"
+	"bmaduum/internal/claude"
+	"bmaduum/internal/config"
+	"bmaduum/internal/lifecycle"
+	"bmaduum/internal/manifest"
+	"bmaduum/internal/router"
+	"bmaduum/internal/status"
+)
+
+// bmadHelpHeartbeat is how often a running bmad-help fallback reports that it
+// is still working.
+const bmadHelpHeartbeat = 30 * time.Second
+
+// loadWorkflowRouter builds the lifecycle router from the workflow manifest
+// at path, falling back to the default routing if there is none. A
+// manifest that exists but cannot be parsed is reported to w as a warning
+// and treated as absent.
+func loadWorkflowRouter(w io.Writer, path string) *router.Router {
+	if _, err := os.Stat(path); err != nil {
+		if path != manifest.WorkflowManifestPath {
+			fmt.Fprintf(w, "Warning: workflow manifest %s not found; using default routing\n", path)
+		}
+		return router.NewRouter()
+	}
+	m, err := manifest.ReadFromFile(path)
+	if err != nil {
+		fmt.Fprintf(w, "Warning: %s: %v; using default routing\n", path, err)
+		return router.NewRouter()
+	}
+	return router.NewRouterFromManifest(m)
+}
+
+// manifestFlags holds the global flags that select how the lifecycle is
+// routed by the workflow manifest.
+type manifestFlags struct {
+	// path is the --manifest path, or empty for the one found at startup.
+	path string
+
+	// strict (--strict-manifest) rejects a manifest that fails
+	// [manifest.Manifest.Validate] instead of routing it as is.
+	strict bool
+
+	// phase (--phase) routes only the manifest entries of this phase.
+	phase string
+}
+
+// set reports whether any of the flags was given.
+func (f manifestFlags) set() bool {
+	return f.path != "" || f.strict || f.phase != ""
+}
+
+// setupManifest applies the global --manifest, --strict-manifest, and
+// --phase flags to app: the lifecycle is routed by the workflow manifest at
+// flags.path, or the one found at startup, with the steps of app's modules
+// injected. Having no manifest at the default location is fine unless a
+// phase is selected.
+func setupManifest(app *App, flags manifestFlags) error {
+	path := flags.path
+	if path == "" {
+		path = app.workflowManifestPath()
+		if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) && path == manifest.WorkflowManifestPath {
+			if flags.phase != "" {
+				return fmt.Errorf("--phase %s needs a workflow manifest; %s not found", flags.phase, path)
+			}
+			return nil
+		}
+	}
+	m, err := manifest.ReadFromFile(path)
+	if err != nil {
+		return fmt.Errorf("invalid workflow manifest: %w", err)
+	}
+
+	var opts []router.ManifestOption
+	if flags.phase != "" {
+		if len(m.GetEntriesForPhase(flags.phase)) == 0 {
+			return fmt.Errorf("workflow manifest %s has no phase %s workflows", path, flags.phase)
+		}
+		opts = append(opts, router.WithPhase(flags.phase))
+	}
+
+	var wfRouter *router.Router
+	if flags.strict {
+		if wfRouter, err = router.NewValidatedRouterFromManifest(m, opts...); err != nil {
+			return fmt.Errorf("invalid workflow manifest %s:\n%w", path, err)
+		}
+	} else {
+		wfRouter = router.NewRouterFromManifest(m, opts...)
+	}
+	if app.Modules != nil {
+		wfRouter.ApplyModules(app.Modules)
+	}
+	applyStatusAliases(wfRouter, app.Config)
+	app.Router = wfRouter
+	app.manifestPath = path
+	if isClaudeBmadHelp(app.BmadHelp) && app.Executor != nil {
+		app.BmadHelp = newBmadHelp(app.Config, app.Executor, wfRouter)
+	}
+	return nil
+}
+
+// applyStatusAliases registers the configured status_aliases with
+// wfRouter.
+func applyStatusAliases(wfRouter *router.Router, cfg *config.Config) {
+	if cfg == nil {
+		return
+	}
+	for alias, canonical := range cfg.StatusAliases {
+		wfRouter.SetStatusAlias(status.Status(alias), status.Status(canonical))
+	}
+}
+
+// newBmadHelp creates the bmad-help fallback for wfRouter's workflows,
+// bounded by the configured timeout and reporting every 30 seconds that it
+// is still running. Unless claude.bmad_help_cache is off, resolutions are
+// reused for the rest of the run by every story with the same status.
+func newBmadHelp(cfg *config.Config, executor claude.Executor, wfRouter *router.Router) bmadhelp.Fallback {
+	fallback := bmadhelp.NewClaudeFallback(executor, helpWorkflows(wfRouter))
+	fallback.SetTimeout(cfg.Claude.BmadHelpTimeout)
+	fallback.SetHeartbeat(bmadHelpHeartbeat, func(elapsed time.Duration) {
+		fmt.Printf("Waiting for bmad-help (%s)...\n", elapsed.Round(time.Second))
+	})
+	if !cfg.Claude.BmadHelpCache {
+		return fallback
+	}
+	return bmadhelp.NewCachingFallback(fallback, 0)
+}
+
+// isClaudeBmadHelp reports whether fallback was created by [newBmadHelp],
+// rather than being a test double.
+func isClaudeBmadHelp(fallback lifecycle.BmadHelpFallback) bool {
+	if cache, ok := fallback.(*bmadhelp.CachingFallback); ok {
+		fallback = cache.Fallback()
+	}
+	_, ok := fallback.(*bmadhelp.ClaudeFallback)
+	return ok
+}
+
+// helpWorkflows lets bmad-help recommend any workflow in wfRouter's
+// lifecycle chain.
+func helpWorkflows(wfRouter *router.Router) []bmadhelp.Recommendation {
+	var workflows []bmadhelp.Recommendation
+	for _, step := range wfRouter.Steps() {
+		workflows = append(workflows, bmadhelp.Recommendation{Workflow: step.Workflow, NextStatus: step.NextStatus})
+	}
+	return workflows
+}
+
+// workflowManifestPath returns the workflow manifest app routes with, or
+// the default location resolved by [manifest.ResolvePath].
+func (app *App) workflowManifestPath() string {
+	if app.manifestPath != "" {
+		return app.manifestPath
+	}
+	return manifest.ResolvePath("", "")
+}
@@ -0,0 +1,190 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"bmaduum/internal/bmadhelp"
+	"bmaduum/internal/claude"
+	"bmaduum/internal/config"
+	"bmaduum/internal/manifest"
+	"bmaduum/internal/router"
+)
+
+// customManifest routes review to a security-review step before done.
+const customManifest = `phase,workflow,agent,command,trigger_status,next_status
+3,create-story,SM,/create-story,backlog,ready-for-dev
+3,dev-story,Dev,/dev-story,ready-for-dev,review
+3,dev-story,Dev,/dev-story,in-progress,review
+3,security-review,QA,/security-review,review,done
+`
+
+// writeManifest writes data to a workflow manifest in a temporary
+// directory and returns its path.
+func writeManifest(t *testing.T, data string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "workflow-manifest.csv")
+	require.NoError(t, os.WriteFile(path, []byte(data), 0644))
+	return path
+}
+
+// stepWorkflows returns the workflows of app's lifecycle chain.
+func stepWorkflows(app *App) []string {
+	var workflows []string
+	for _, step := range app.Router.Steps() {
+		workflows = append(workflows, step.Workflow)
+	}
+	return workflows
+}
+
+func TestLoadWorkflowRouter(t *testing.T) {
+	var out bytes.Buffer
+	wfRouter := loadWorkflowRouter(&out, writeManifest(t, customManifest))
+	assert.Equal(t, "security-review", wfRouter.Steps()[2].Workflow)
+	assert.Empty(t, out.String())
+
+	t.Run("missing default manifest is silent", func(t *testing.T) {
+		t.Chdir(t.TempDir())
+		var out bytes.Buffer
+		wfRouter := loadWorkflowRouter(&out, manifest.WorkflowManifestPath)
+		assert.NotEmpty(t, wfRouter.Steps())
+		assert.Empty(t, out.String())
+	})
+
+	t.Run("missing explicit manifest warns", func(t *testing.T) {
+		var out bytes.Buffer
+		loadWorkflowRouter(&out, "/nonexistent/workflows.csv")
+		assert.Equal(t, "Warning: workflow manifest /nonexistent/workflows.csv not found; using default routing\n", out.String())
+	})
+
+	t.Run("invalid manifest warns", func(t *testing.T) {
+		var out bytes.Buffer
+		path := writeManifest(t, "phase,workflow\n3,dev-story\n")
+		wfRouter := loadWorkflowRouter(&out, path)
+		assert.NotEmpty(t, wfRouter.Steps())
+		assert.Contains(t, out.String(), "Warning: "+path+": ")
+		assert.Contains(t, out.String(), "; using default routing\n")
+	})
+}
+
+func TestManifestFlag(t *testing.T) {
+	path := writeManifest(t, customManifest)
+	app := &App{Config: validateTestConfig()}
+
+	out, err := runValidateCommand(t, app, "--manifest", path, "manifest", "validate")
+	require.NoError(t, err)
+	assert.Equal(t, path+" is valid\n", out)
+	assert.Equal(t, []string{"create-story", "dev-story", "security-review"}, stepWorkflows(app))
+	assert.Equal(t, path, app.workflowManifestPath())
+}
+
+func TestManifestFlag_Invalid(t *testing.T) {
+	app := &App{Config: config.DefaultConfig()}
+
+	_, err := runValidateCommand(t, app, "--manifest", "/nonexistent/workflows.csv", "manifest", "validate")
+	code, ok := IsExitError(err)
+	require.True(t, ok)
+	assert.Equal(t, 1, code)
+	assert.Nil(t, app.Router)
+}
+
+func TestWorkflowManifestPath_Env(t *testing.T) {
+	t.Setenv(manifest.ManifestPathEnvVar, "/custom/workflows.csv")
+	app := &App{Config: config.DefaultConfig()}
+	assert.Equal(t, "/custom/workflows.csv", app.workflowManifestPath())
+}
+
+func TestStrictManifestFlag(t *testing.T) {
+	path := writeManifest(t, `workflow,trigger_status,next_status
+dev-story,ready-for-dev,review
+code-review,review,in-progress
+fix,in-progress,review
+git-commit,,done
+`)
+	t.Setenv(manifest.ManifestPathEnvVar, path)
+
+	// Without the flag the broken chain is routed as is
+	app := &App{Config: config.DefaultConfig()}
+	_, err := runValidateCommand(t, app, "version")
+	require.NoError(t, err)
+
+	app = &App{Config: config.DefaultConfig()}
+	_, err = runValidateCommand(t, app, "--strict-manifest", "version")
+	code, ok := IsExitError(err)
+	require.True(t, ok)
+	assert.Equal(t, 1, code)
+	assert.Nil(t, app.Router)
+
+	err = setupManifest(app, manifestFlags{strict: true})
+	assert.EqualError(t, err, "invalid workflow manifest "+path+":\nstatus cycle: review -> in-progress -> review")
+}
+
+func TestStrictManifestFlag_ValidOrMissing(t *testing.T) {
+	t.Setenv(manifest.ManifestPathEnvVar, writeManifest(t, customManifest))
+	app := &App{Config: config.DefaultConfig()}
+	_, err := runValidateCommand(t, app, "--strict-manifest", "version")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"create-story", "dev-story", "security-review"}, stepWorkflows(app))
+
+	t.Setenv(manifest.ManifestPathEnvVar, "")
+	t.Chdir(t.TempDir())
+	app = &App{Config: config.DefaultConfig()}
+	_, err = runValidateCommand(t, app, "--strict-manifest", "version")
+	require.NoError(t, err)
+	assert.Nil(t, app.Router, "no manifest keeps the default routing")
+}
+
+func TestPhaseFlag(t *testing.T) {
+	path := writeManifest(t, `phase,workflow,trigger_status,next_status
+2,create-prd,backlog,backlog
+3,create-story,backlog,ready-for-dev
+3,dev-story,ready-for-dev,review
+3,code-review,review,done
+`)
+	app := &App{Config: config.DefaultConfig()}
+
+	_, err := runValidateCommand(t, app, "--manifest", path, "--phase", "3", "version")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"create-story", "dev-story", "code-review"}, stepWorkflows(app))
+
+	err = setupManifest(app, manifestFlags{path: path, phase: "4"})
+	assert.EqualError(t, err, "workflow manifest "+path+" has no phase 4 workflows")
+
+	t.Setenv(manifest.ManifestPathEnvVar, "")
+	t.Chdir(t.TempDir())
+	err = setupManifest(&App{Config: config.DefaultConfig()}, manifestFlags{phase: "3"})
+	assert.EqualError(t, err, "--phase 3 needs a workflow manifest; "+manifest.WorkflowManifestPath+" not found")
+}
+
+func TestNewBmadHelp_Cache(t *testing.T) {
+	cfg := config.DefaultConfig()
+	executor := &claude.MockExecutor{}
+
+	fallback := newBmadHelp(cfg, executor, router.NewRouter())
+	assert.IsType(t, &bmadhelp.CachingFallback{}, fallback)
+	assert.True(t, isClaudeBmadHelp(fallback))
+
+	cfg.Claude.BmadHelpCache = false
+	fallback = newBmadHelp(cfg, executor, router.NewRouter())
+	assert.IsType(t, &bmadhelp.ClaudeFallback{}, fallback)
+	assert.True(t, isClaudeBmadHelp(fallback))
+
+	assert.False(t, isClaudeBmadHelp(&MockBmadHelpFallback{}))
+}
+
+func TestApplyStatusAliases(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.StatusAliases = map[string]string{"qa": "review"}
+	wfRouter := router.NewRouter()
+
+	applyStatusAliases(wfRouter, cfg)
+
+	workflow, err := wfRouter.GetWorkflow("qa")
+	require.NoError(t, err)
+	assert.Equal(t, "code-review", workflow)
+}
@@ -7,8 +7,10 @@ import (
 	"path/filepath"
 	"strings"
 	"text/template"
+	"time"
 
 	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
 )
 
 // Loader handles configuration loading from files and environment.
@@ -19,8 +21,15 @@ import (
 type Loader struct {
 	// v is the Viper instance used for configuration loading.
 	v *viper.Viper
+
+	// profile is the profile selected with [Loader.SetProfile].
+	profile string
 }
 
+// ProfileEnvVar is the environment variable that selects a config profile
+// when none is set with [Loader.SetProfile].
+const ProfileEnvVar = "BMADUUM_PROFILE"
+
 // NewLoader creates a new configuration loader.
 //
 // Returns a Loader ready to load configuration from files and environment.
@@ -31,6 +40,13 @@ func NewLoader() *Loader {
 	}
 }
 
+// SetProfile selects the named entry of the config file's profiles map to
+// merge over the base configuration. It takes precedence over
+// BMADUUM_PROFILE. An empty name selects no profile.
+func (l *Loader) SetProfile(name string) {
+	l.profile = name
+}
+
 // Load loads configuration from the default locations and environment.
 //
 // Configuration is loaded and merged with the following priority (highest first):
@@ -46,8 +62,14 @@ func NewLoader() *Loader {
 // Environment variable names use underscores for nested keys. For example,
 // claude.binary_path becomes BMADUUM_CLAUDE_BINARY_PATH.
 //
-// Returns an error if a config file exists but cannot be parsed. Missing
-// config files are not an error; the loader falls back to defaults.
+// If a profile is selected with [Loader.SetProfile] or BMADUUM_PROFILE, its
+// settings under profiles.<name> in the config file are deep-merged over the
+// file's base settings, so a profile can change a single workflow's model
+// without repeating its prompts.
+//
+// Returns an error if a config file exists but cannot be parsed, or if the
+// selected profile is not defined. Missing config files are not an error;
+// the loader falls back to defaults.
 func (l *Loader) Load() (*Config, error) {
 	// Start with defaults
 	cfg := DefaultConfig()
@@ -88,11 +110,21 @@ func (l *Loader) Load() (*Config, error) {
 		}
 	}
 
+	profile := l.profile
+	if profile == "" {
+		profile = os.Getenv(ProfileEnvVar)
+	}
+	if err := l.applyProfile(profile); err != nil {
+		return nil, err
+	}
+
 	// Unmarshal into config struct
 	if err := l.v.Unmarshal(cfg); err != nil {
 		return nil, fmt.Errorf("error unmarshaling config: %w", err)
 	}
 
+	cfg.UseSlashCommandsSet = l.v.IsSet("use_slash_commands")
+
 	// Override Claude binary path from env if set
 	if binaryPath := os.Getenv("BMADUUM_CLAUDE_PATH"); binaryPath != "" {
 		cfg.Claude.BinaryPath = binaryPath
@@ -107,6 +139,9 @@ func (l *Loader) Load() (*Config, error) {
 // searching default locations or checking environment variables. The file
 // extension determines the expected format (yaml, json, etc.).
 //
+// The profile selected with [Loader.SetProfile], if any, is applied as in
+// [Loader.Load].
+//
 // Returns an error if the file cannot be read or parsed.
 func (l *Loader) LoadFromFile(path string) (*Config, error) {
 	cfg := DefaultConfig()
@@ -117,14 +152,106 @@ func (l *Loader) LoadFromFile(path string) (*Config, error) {
 	if err := l.v.ReadInConfig(); err != nil {
 		return nil, fmt.Errorf("error reading config file %s: %w", path, err)
 	}
+	if err := l.applyProfile(l.profile); err != nil {
+		return nil, err
+	}
 
 	if err := l.v.Unmarshal(cfg); err != nil {
 		return nil, fmt.Errorf("error unmarshaling config: %w", err)
 	}
+	cfg.UseSlashCommandsSet = l.v.IsSet("use_slash_commands")
 
 	return cfg, nil
 }
 
+// Save writes cfg to path as YAML, using the same keys the loader reads, so
+// that loading the file again yields an equivalent [Config]. The file is
+// written to a temporary file next to path and renamed into place, so
+// readers never see a partial file. Comments in an existing file at path are
+// not preserved, and use_slash_commands is only written if
+// [Config.UseSlashCommandsSet] is true.
+func (l *Loader) Save(cfg *Config, path string) error {
+	node, err := configNode(cfg)
+	if err != nil {
+		return fmt.Errorf("error encoding config: %w", err)
+	}
+	data, err := encodeYAML(node)
+	if err != nil {
+		return fmt.Errorf("error encoding config: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("error writing config file %s: %w", path, err)
+	}
+	tmpPath := tmp.Name()
+
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmpPath, 0644)
+	}
+	if err == nil {
+		err = os.Rename(tmpPath, path)
+	}
+	if err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("error writing config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// configNode encodes cfg as a YAML mapping node. use_slash_commands is left
+// out unless it was set explicitly, so that the prompt mode of a saved
+// config is still detected from the project.
+func configNode(cfg *Config) (*yaml.Node, error) {
+	var node yaml.Node
+	if err := node.Encode(cfg); err != nil {
+		return nil, err
+	}
+	if !cfg.UseSlashCommandsSet {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == "use_slash_commands" {
+				node.Content = append(node.Content[:i], node.Content[i+2:]...)
+				break
+			}
+		}
+	}
+	return &node, nil
+}
+
+// encodeYAML encodes v as YAML indented by two spaces.
+func encodeYAML(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// applyProfile deep-merges the settings of the named profile over the
+// loaded config file. An empty name does nothing.
+func (l *Loader) applyProfile(name string) error {
+	if name == "" {
+		return nil
+	}
+	profile := l.v.Sub("profiles." + name)
+	if profile == nil {
+		return fmt.Errorf("unknown config profile %q", name)
+	}
+	if err := l.v.MergeConfigMap(profile.AllSettings()); err != nil {
+		return fmt.Errorf("error applying config profile %q: %w", name, err)
+	}
+	return nil
+}
+
 // GetPrompt returns the expanded prompt for a workflow and story key.
 //
 // The workflowName must match a key in the Workflows map. The storyKey is
@@ -137,41 +264,75 @@ func (l *Loader) LoadFromFile(path string) (*Config, error) {
 //
 // Returns an error if the workflow is not found or if template expansion fails.
 func (c *Config) GetPrompt(workflowName, storyKey string) (string, error) {
+	return c.GetPromptWithData(workflowName, NewPromptData(storyKey))
+}
+
+// GetPromptWithData is like [Config.GetPrompt], but expands the template
+// with all of data, so it can also reference {{.Date}}, {{.GitBranch}}, and
+// {{.CurrentStatus}}.
+func (c *Config) GetPromptWithData(workflowName string, data PromptData) (string, error) {
 	workflow, ok := c.Workflows[workflowName]
 	if !ok {
 		return "", fmt.Errorf("unknown workflow: %s", workflowName)
 	}
 
-	tmpl := workflow.SlashCommand
-	if !c.UseSlashCommands {
-		tmpl = workflow.PromptTemplate
-	}
-
-	// Fallback: if the selected template is empty, use the other one
+	tmpl, _ := c.promptTemplate(workflow)
 	if tmpl == "" {
-		if c.UseSlashCommands {
-			tmpl = workflow.PromptTemplate
-		} else {
-			tmpl = workflow.SlashCommand
-		}
+		return "", fmt.Errorf("workflow %s has no prompt template or slash command configured", workflowName)
 	}
 
-	if tmpl == "" {
-		return "", fmt.Errorf("workflow %s has no prompt template or slash command configured", workflowName)
+	return expandTemplate(tmpl, data)
+}
+
+// PromptMode describes the template [Config.GetPrompt] uses for a workflow:
+// "slash command" or "legacy prompt". It reports the fallback template when
+// the selected one is empty, and "" for unknown workflows.
+func (c *Config) PromptMode(workflowName string) string {
+	workflow, ok := c.Workflows[workflowName]
+	if !ok {
+		return ""
 	}
+	if _, slash := c.promptTemplate(workflow); slash {
+		return "slash command"
+	}
+	return "legacy prompt"
+}
 
-	return expandTemplate(tmpl, PromptData{StoryKey: storyKey})
+// promptTemplate returns the template selected by UseSlashCommands, falling
+// back to the other one if it is empty, and whether it is the slash command.
+func (c *Config) promptTemplate(workflow WorkflowConfig) (tmpl string, slash bool) {
+	if c.UseSlashCommands {
+		if workflow.SlashCommand != "" {
+			return workflow.SlashCommand, true
+		}
+		return workflow.PromptTemplate, false
+	}
+	if workflow.PromptTemplate != "" {
+		return workflow.PromptTemplate, false
+	}
+	return workflow.SlashCommand, true
 }
 
 // GetModel returns the model configured for a workflow, or empty string if not set.
 //
+// The workflow's own model takes precedence over [ClaudeConfig.Model].
 // When empty, the Claude CLI will use its default model.
 func (c *Config) GetModel(workflowName string) string {
-	workflow, ok := c.Workflows[workflowName]
-	if !ok {
-		return ""
+	if workflow, ok := c.Workflows[workflowName]; ok && workflow.Model != "" {
+		return workflow.Model
+	}
+	return c.Claude.Model
+}
+
+// GetTimeout returns the time limit for a single run of a workflow.
+//
+// The workflow's own timeout takes precedence over [ClaudeConfig.Timeout].
+// Returns zero if neither is set, meaning no timeout.
+func (c *Config) GetTimeout(workflowName string) time.Duration {
+	if workflow, ok := c.Workflows[workflowName]; ok && workflow.Timeout > 0 {
+		return workflow.Timeout
 	}
-	return workflow.Model
+	return c.Claude.Timeout
 }
 
 // expandTemplate expands a Go template string with the given data.
@@ -236,6 +397,29 @@ func DefaultConfigPath() (string, error) {
 	return filepath.Join(configDir, "workflows.yaml"), nil
 }
 
+// FindConfigFile returns the config file [Loader.Load] reads: the path in
+// BMADUUM_CONFIG_PATH, else the first workflows.yaml or workflows.yml found
+// in the user config directory, ./config, and the current directory. When
+// no file exists yet, it returns [DefaultConfigPath].
+func FindConfigFile() (string, error) {
+	if configPath := os.Getenv("BMADUUM_CONFIG_PATH"); configPath != "" {
+		return configPath, nil
+	}
+	defaultPath, err := DefaultConfigPath()
+	if err != nil {
+		return "", err
+	}
+	for _, dir := range []string{filepath.Dir(defaultPath), "config", "."} {
+		for _, name := range []string{"workflows.yaml", "workflows.yml"} {
+			path := filepath.Join(dir, name)
+			if _, err := os.Stat(path); err == nil {
+				return path, nil
+			}
+		}
+	}
+	return defaultPath, nil
+}
+
 // EnsureConfigDir ensures the user configuration directory exists.
 //
 // If the directory does not exist, it will be created with appropriate
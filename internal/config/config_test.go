@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -263,6 +264,69 @@ claude:
 	assert.Equal(t, "/from/env/override/claude", cfg.Claude.BinaryPath)
 }
 
+const profilesConfig = `
+use_slash_commands: true
+workflows:
+  dev-story:
+    slash_command: "/dev-story {{.StoryKey}}"
+    model: sonnet
+claude:
+  binary_path: /base/claude
+profiles:
+  work:
+    claude:
+      binary_path: /work/claude
+    workflows:
+      dev-story:
+        model: opus
+  legacy:
+    use_slash_commands: false
+`
+
+func TestLoader_Load_Profile(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(profilesConfig), 0644))
+	t.Setenv("BMADUUM_CONFIG_PATH", configPath)
+	t.Setenv("BMADUUM_CLAUDE_PATH", "")
+
+	cfg, err := NewLoader().Load()
+	require.NoError(t, err)
+	assert.Equal(t, "/base/claude", cfg.Claude.BinaryPath, "no profile selected")
+	assert.Equal(t, "sonnet", cfg.GetModel("dev-story"))
+
+	t.Setenv(ProfileEnvVar, "work")
+	cfg, err = NewLoader().Load()
+	require.NoError(t, err)
+	assert.Equal(t, "/work/claude", cfg.Claude.BinaryPath)
+	assert.Equal(t, "opus", cfg.GetModel("dev-story"))
+	assert.Equal(t, "/dev-story {{.StoryKey}}", cfg.Workflows["dev-story"].SlashCommand, "workflows are merged deeply")
+	assert.True(t, cfg.UseSlashCommands)
+
+	// SetProfile takes precedence over the environment
+	loader := NewLoader()
+	loader.SetProfile("legacy")
+	cfg, err = loader.Load()
+	require.NoError(t, err)
+	assert.False(t, cfg.UseSlashCommands)
+	assert.Equal(t, "/base/claude", cfg.Claude.BinaryPath)
+}
+
+func TestLoader_LoadFromFile_Profile(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(profilesConfig), 0644))
+
+	loader := NewLoader()
+	loader.SetProfile("work")
+	cfg, err := loader.LoadFromFile(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, "opus", cfg.GetModel("dev-story"))
+
+	loader = NewLoader()
+	loader.SetProfile("home")
+	_, err = loader.LoadFromFile(configPath)
+	assert.EqualError(t, err, `unknown config profile "home"`)
+}
+
 func TestMustLoad_Success(t *testing.T) {
 	// MustLoad should not panic when loading defaults
 	tmpDir := t.TempDir()
@@ -379,6 +443,18 @@ func TestGetPrompt_LegacyMode(t *testing.T) {
 	assert.Contains(t, prompt, "6-1-implement-auth")
 }
 
+func TestPromptMode(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Workflows["slash-only"] = WorkflowConfig{SlashCommand: "/slash-only {{.StoryKey}}"}
+
+	assert.Equal(t, "slash command", cfg.PromptMode("dev-story"))
+	assert.Equal(t, "", cfg.PromptMode("nonexistent"))
+
+	cfg.UseSlashCommands = false
+	assert.Equal(t, "legacy prompt", cfg.PromptMode("dev-story"))
+	assert.Equal(t, "slash command", cfg.PromptMode("slash-only"), "falls back to the slash command")
+}
+
 func TestGetPrompt_SlashCommandMode_AllWorkflows(t *testing.T) {
 	cfg := DefaultConfig()
 
@@ -502,8 +578,337 @@ workflows:
 
 	require.NoError(t, err)
 	assert.False(t, cfg.UseSlashCommands)
+	assert.True(t, cfg.UseSlashCommandsSet)
 
 	prompt, err := cfg.GetPrompt("dev-story", "test-key")
 	require.NoError(t, err)
 	assert.Equal(t, "Legacy dev: test-key", prompt)
 }
+
+func TestLoadStoryOverrides(t *testing.T) {
+	tmpDir := t.TempDir()
+	path := filepath.Join(tmpDir, StoryOverridesFileName)
+	content := `stories:
+  6-1-special:
+    model: opus
+    skip_steps: [code-review]
+    depends_on: [6-0-setup]
+    workflows:
+      dev-story:
+        model: sonnet
+`
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+
+	overrides, err := LoadStoryOverrides(path)
+	require.NoError(t, err)
+
+	override, ok := overrides.Get("6-1-special")
+	require.True(t, ok)
+	assert.Equal(t, "opus", override.Model)
+	assert.Equal(t, []string{"code-review"}, override.SkipSteps)
+	assert.Equal(t, "sonnet", override.Workflows["dev-story"].Model)
+
+	assert.True(t, overrides.ShouldSkip("6-1-special", "code-review"))
+	assert.False(t, overrides.ShouldSkip("6-1-special", "dev-story"))
+	assert.False(t, overrides.ShouldSkip("6-2-other", "code-review"))
+
+	assert.Equal(t, []string{"6-0-setup"}, overrides.DependsOn("6-1-special"))
+	assert.Empty(t, overrides.DependsOn("6-2-other"))
+	assert.Empty(t, (*StoryOverrides)(nil).DependsOn("6-1-special"))
+}
+
+func TestLoadStoryOverrides_MissingFile(t *testing.T) {
+	overrides, err := LoadStoryOverrides(filepath.Join(t.TempDir(), StoryOverridesFileName))
+	require.NoError(t, err)
+
+	_, ok := overrides.Get("any")
+	assert.False(t, ok)
+}
+
+func TestConfig_ForStory(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Workflows["git-commit"] = WorkflowConfig{SlashCommand: "/git-commit {{.StoryKey}}", Model: "haiku"}
+
+	overrides := &StoryOverrides{Stories: map[string]StoryOverride{
+		"6-1-special": {
+			Model: "opus",
+			Workflows: map[string]WorkflowConfig{
+				"dev-story": {Model: "sonnet"},
+			},
+		},
+	}}
+
+	merged := cfg.ForStory("6-1-special", overrides)
+	assert.Equal(t, "sonnet", merged.GetModel("dev-story"), "per-workflow override wins")
+	assert.Equal(t, "opus", merged.GetModel("create-story"), "story-wide model applies")
+	assert.Equal(t, "opus", merged.GetModel("git-commit"), "story-wide model replaces base model")
+
+	// Prompt templates are preserved by the deep merge
+	prompt, err := merged.GetPrompt("dev-story", "6-1-special")
+	require.NoError(t, err)
+	assert.Equal(t, "/dev-story 6-1-special", prompt)
+
+	// Other stories and the base config are unaffected
+	assert.Same(t, cfg, cfg.ForStory("6-2-other", overrides))
+	assert.Equal(t, "", cfg.GetModel("dev-story"))
+	assert.Equal(t, "haiku", cfg.GetModel("git-commit"))
+}
+
+func TestConfig_ForStory_Timeout(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Claude.Timeout = 10 * time.Minute
+	cfg.Workflows["code-review"] = WorkflowConfig{SlashCommand: "/code-review {{.StoryKey}}", Timeout: 5 * time.Minute}
+
+	overrides, err := ParseStoryOverrides([]byte(`stories:
+  6-1-special:
+    timeout: 30m
+    workflows:
+      dev-story:
+        timeout: 1h
+`))
+	require.NoError(t, err)
+
+	merged := cfg.ForStory("6-1-special", overrides)
+	assert.Equal(t, time.Hour, merged.GetTimeout("dev-story"), "per-workflow override wins")
+	assert.Equal(t, 30*time.Minute, merged.GetTimeout("create-story"), "story-wide timeout applies")
+	assert.Equal(t, 30*time.Minute, merged.GetTimeout("code-review"), "story-wide timeout replaces base timeout")
+	assert.Equal(t, 30*time.Minute, merged.GetTimeout("unknown"), "story-wide timeout applies to unconfigured workflows")
+
+	// Other stories and the base config are unaffected
+	other := cfg.ForStory("6-2-other", overrides)
+	assert.Equal(t, 10*time.Minute, other.GetTimeout("dev-story"))
+	assert.Equal(t, 5*time.Minute, other.GetTimeout("code-review"))
+	assert.Equal(t, 10*time.Minute, cfg.GetTimeout("create-story"))
+}
+
+func TestGetModel_ClaudeDefault(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Workflows["git-commit"] = WorkflowConfig{SlashCommand: "/git-commit {{.StoryKey}}", Model: "haiku"}
+	assert.Equal(t, "", cfg.GetModel("dev-story"))
+
+	cfg.Claude.Model = "opus"
+	assert.Equal(t, "opus", cfg.GetModel("dev-story"), "claude.model applies to workflows without a model")
+	assert.Equal(t, "opus", cfg.GetModel("unknown"))
+	assert.Equal(t, "haiku", cfg.GetModel("git-commit"), "workflow model wins")
+}
+
+func TestDefaultConfig_MaxStoriesPerRun(t *testing.T) {
+	assert.Equal(t, 50, DefaultConfig().MaxStoriesPerRun)
+}
+
+func TestDefaultConfig_StoryNumbering(t *testing.T) {
+	assert.Equal(t, "numeric", DefaultConfig().StoryNumbering)
+}
+
+func TestLoader_LoadFromFile_Timeouts(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	configContent := `
+workflows:
+  dev-story:
+    slash_command: "/dev-story {{.StoryKey}}"
+    timeout: 10m
+claude:
+  timeout: 30m
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(configContent), 0644))
+
+	cfg, err := NewLoader().LoadFromFile(configPath)
+
+	require.NoError(t, err)
+	assert.Equal(t, 10*time.Minute, cfg.GetTimeout("dev-story"), "workflow timeout wins")
+	assert.Equal(t, 30*time.Minute, cfg.GetTimeout("code-review"), "claude timeout is the default")
+}
+
+func TestConfig_GetTimeout_Unset(t *testing.T) {
+	assert.Zero(t, DefaultConfig().GetTimeout("dev-story"))
+	assert.Zero(t, DefaultConfig().GetTimeout("unknown"))
+}
+
+func TestConfig_Validate_StatusAliases(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.StatusAliases = map[string]string{"qa": "review", "staging": ""}
+
+	problems := cfg.Validate()
+	require.Len(t, problems, 1)
+	assert.EqualError(t, problems[0], "status_aliases.staging: must name a status")
+}
+
+func TestConfig_Validate(t *testing.T) {
+	assert.Empty(t, DefaultConfig().Validate())
+
+	cfg := DefaultConfig()
+	cfg.Workflows["dev-story"] = WorkflowConfig{SlashCommand: "/dev-story {{.StoryKey", Timeout: -time.Second}
+	cfg.Workflows["empty"] = WorkflowConfig{}
+	cfg.Claude.MaxRetries = -1
+	cfg.OnDone = "ignore"
+	cfg.Output.Verbosity = "loud"
+	cfg.Notifications.WebhookURL = "ftp://dashboard.example.com"
+	cfg.Notifications.DiscordWebhook = "discord.com/api/webhooks/1"
+
+	var messages []string
+	for _, problem := range cfg.Validate() {
+		messages = append(messages, problem.Error())
+	}
+
+	require.Len(t, messages, 9)
+	assert.Contains(t, messages[0], "workflows.dev-story.slash_command:")
+	assert.Contains(t, messages[1], "workflows.dev-story: error parsing template")
+	assert.Equal(t, "workflows.dev-story.timeout: must not be negative", messages[2])
+	assert.Equal(t, "workflows.empty: workflow empty has no prompt template or slash command configured", messages[3])
+	assert.Equal(t, `on_done: must be skip, error, or rerun, got "ignore"`, messages[4])
+	assert.Equal(t, `output.verbosity: must be quiet, normal, or verbose, got "loud"`, messages[5])
+	assert.Equal(t, "claude.max_retries: must not be negative", messages[6])
+	assert.Equal(t, `notifications.webhook_url: must be an http or https URL, got "ftp://dashboard.example.com"`, messages[7])
+	assert.Equal(t, `notifications.discord_webhook: must be an http or https URL, got "discord.com/api/webhooks/1"`, messages[8])
+}
+
+func TestDefaultYAML_RoundTrip(t *testing.T) {
+	data, err := DefaultYAML()
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "# Claude CLI settings.\nclaude:\n")
+	assert.Contains(t, string(data), "# use_slash_commands: true\n")
+
+	path := filepath.Join(t.TempDir(), "workflows.yaml")
+	require.NoError(t, os.WriteFile(path, data, 0644))
+	cfg, err := NewLoader().LoadFromFile(path)
+	require.NoError(t, err)
+
+	assert.Empty(t, cfg.Claude.ExtraArgs)
+	cfg.Claude.ExtraArgs = nil
+	assert.Equal(t, DefaultConfig(), cfg)
+}
+
+func TestLoader_Save_RoundTrip(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Claude.Model = "opus"
+	cfg.Claude.ExtraArgs = []string{"--max-turns", "50"}
+	cfg.Claude.Timeout = 10 * time.Minute
+	require.NoError(t, cfg.Set("use_slash_commands", "false"))
+	cfg.Workflows["custom"] = WorkflowConfig{PromptTemplate: "Do {{.StoryKey}}", Model: "haiku"}
+
+	path := filepath.Join(t.TempDir(), "workflows.yaml")
+	loader := NewLoader()
+	require.NoError(t, loader.Save(cfg, path))
+
+	loaded, err := loader.LoadFromFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, cfg, loaded)
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "temporary file should be renamed into place")
+}
+
+func TestLoader_Save_OmitsUnsetPromptMode(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "workflows.yaml")
+	loader := NewLoader()
+	require.NoError(t, loader.Save(DefaultConfig(), path))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "use_slash_commands")
+
+	cfg, err := loader.LoadFromFile(path)
+	require.NoError(t, err)
+	assert.True(t, cfg.UseSlashCommands)
+	assert.False(t, cfg.UseSlashCommandsSet)
+}
+
+func TestLoader_Save_KeepsProfiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "workflows.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(profilesConfig), 0644))
+	loader := NewLoader()
+	cfg, err := loader.LoadFromFile(path)
+	require.NoError(t, err)
+	require.NoError(t, loader.Save(cfg, path))
+
+	loader = NewLoader()
+	loader.SetProfile("work")
+	cfg, err = loader.LoadFromFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "/work/claude", cfg.Claude.BinaryPath)
+	assert.Equal(t, "opus", cfg.GetModel("dev-story"))
+}
+
+func TestLoader_Save_MissingDirectory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing", "workflows.yaml")
+	err := NewLoader().Save(DefaultConfig(), path)
+	assert.Error(t, err)
+}
+
+func TestNewPromptData(t *testing.T) {
+	assert.Equal(t, PromptData{StoryKey: "6-1-setup", EpicID: "6"}, NewPromptData("6-1-setup"))
+	assert.Equal(t, PromptData{StoryKey: "PROJ"}, NewPromptData("PROJ"))
+}
+
+func TestGetPromptWithData(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Workflows["dev-story"] = WorkflowConfig{SlashCommand: "/dev-story {{.StoryKey}} on branch {{.GitBranch}} ({{.CurrentStatus}}, {{.Date}})"}
+
+	data := NewPromptData("6-1-setup")
+	data.GitBranch = "main"
+	data.CurrentStatus = "in-progress"
+	data.Date = "2026-03-04"
+	prompt, err := cfg.GetPromptWithData("dev-story", data)
+	require.NoError(t, err)
+	assert.Equal(t, "/dev-story 6-1-setup on branch main (in-progress, 2026-03-04)", prompt)
+
+	// GetPrompt leaves the variables it cannot derive from the key empty
+	prompt, err = cfg.GetPrompt("dev-story", "6-1-setup")
+	require.NoError(t, err)
+	assert.Equal(t, "/dev-story 6-1-setup on branch  (, )", prompt)
+}
+
+func TestConfig_Set(t *testing.T) {
+	cfg := DefaultConfig()
+	require.NoError(t, cfg.Set("claude.binary_path", "/usr/local/bin/claude"))
+	require.NoError(t, cfg.Set("output.truncate_lines", "50"))
+	require.NoError(t, cfg.Set("use_slash_commands", "false"))
+	require.NoError(t, cfg.Set("claude.timeout", "10m"))
+	require.NoError(t, cfg.Set("claude.extra_args", `[--max-turns, "50"]`))
+	require.NoError(t, cfg.Set("output.markdown.style", "light"))
+	require.NoError(t, cfg.Set("workflows.dev-story.model", "opus"))
+	require.NoError(t, cfg.Set("workflows.custom.slash_command", "/custom {{.StoryKey}}"))
+
+	assert.Equal(t, "/usr/local/bin/claude", cfg.Claude.BinaryPath)
+	assert.Equal(t, 50, cfg.Output.TruncateLines)
+	assert.False(t, cfg.UseSlashCommands)
+	assert.Equal(t, 10*time.Minute, cfg.Claude.Timeout)
+	assert.Equal(t, []string{"--max-turns", "50"}, cfg.Claude.ExtraArgs)
+	assert.Equal(t, "light", cfg.Output.Markdown.Style)
+	assert.Equal(t, "opus", cfg.Workflows["dev-story"].Model)
+	assert.Equal(t, DefaultConfig().Workflows["dev-story"].SlashCommand, cfg.Workflows["dev-story"].SlashCommand)
+	assert.Equal(t, "/custom {{.StoryKey}}", cfg.Workflows["custom"].SlashCommand)
+}
+
+func TestConfig_Set_Errors(t *testing.T) {
+	tests := []struct {
+		key, value, err string
+	}{
+		{"claude.binary", "x", `unknown config key "claude.binary"`},
+		{"nonsense", "x", `unknown config key "nonsense"`},
+		{"claude.binary_path.extra", "x", `unknown config key "claude.binary_path.extra"`},
+		{"claude", "x", `config key "claude" is a section, not a setting`},
+		{"workflows.dev-story", "x", `unknown config key "workflows.dev-story": workflow settings are workflows.<name>.<setting>`},
+		{"workflows.dev-story.color", "x", `unknown config key "workflows.dev-story.color"`},
+		{"profiles.work", "x", `config key "profiles.work" cannot be set; edit profiles in the config file`},
+		{"output.truncate_lines", "many", `invalid value "many" for output.truncate_lines`},
+		{"claude.timeout", "soon", `invalid value "soon" for claude.timeout`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			cfg := DefaultConfig()
+			err := cfg.Set(tt.key, tt.value)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.err)
+			assert.Equal(t, DefaultConfig(), cfg, "config must be unchanged")
+		})
+	}
+}
+
+func TestFindConfigFile(t *testing.T) {
+	t.Setenv("BMADUUM_CONFIG_PATH", "/tmp/custom.yaml")
+	path, err := FindConfigFile()
+	require.NoError(t, err)
+	assert.Equal(t, "/tmp/custom.yaml", path)
+}
@@ -0,0 +1,168 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// StoryOverridesFileName is the name of the story overrides sidecar file.
+// It is looked up next to sprint-status.yaml.
+const StoryOverridesFileName = "story-overrides.yaml"
+
+// StoryOverride is a partial configuration applied to a single story's run.
+//
+// Empty fields leave the base configuration unchanged. Workflow entries are
+// deep-merged, so an override can change one workflow's model while keeping
+// its prompt templates.
+type StoryOverride struct {
+	// Model is applied to every workflow run for the story.
+	// Per-workflow models in Workflows take precedence.
+	Model string `yaml:"model"`
+
+	// Timeout limits each workflow run for the story, e.g. 30m.
+	// Per-workflow timeouts in Workflows take precedence.
+	Timeout time.Duration `yaml:"timeout"`
+
+	// SkipSteps lists workflows that are not run for the story.
+	// The step's status transition is still applied.
+	SkipSteps []string `yaml:"skip_steps"`
+
+	// DependsOn lists stories that must be done before this story runs.
+	// Runs with several stories are ordered so dependencies go first.
+	DependsOn []string `yaml:"depends_on"`
+
+	// Workflows holds per-workflow overrides, merged field by field.
+	Workflows map[string]WorkflowConfig `yaml:"workflows"`
+}
+
+// StoryOverrides maps story keys to their [StoryOverride].
+//
+// The sidecar file format is:
+//
+//	stories:
+//	  6-1-special-story:
+//	    model: opus
+//	    timeout: 30m
+//	    skip_steps: [code-review]
+//	    depends_on: [6-0-setup]
+//	    workflows:
+//	      dev-story:
+//	        model: sonnet
+type StoryOverrides struct {
+	// Stories maps story keys to their overrides.
+	Stories map[string]StoryOverride `yaml:"stories"`
+}
+
+// LoadStoryOverrides reads a story overrides sidecar file.
+//
+// Returns an empty [StoryOverrides] (not an error) if the file does not exist,
+// so callers can load the sidecar unconditionally.
+func LoadStoryOverrides(path string) (*StoryOverrides, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return &StoryOverrides{}, nil
+		}
+		return nil, fmt.Errorf("failed to read story overrides: %w", err)
+	}
+
+	return ParseStoryOverrides(data)
+}
+
+// ParseStoryOverrides parses story overrides from YAML bytes.
+func ParseStoryOverrides(data []byte) (*StoryOverrides, error) {
+	var overrides StoryOverrides
+	if err := yaml.Unmarshal(data, &overrides); err != nil {
+		return nil, fmt.Errorf("failed to parse story overrides: %w", err)
+	}
+
+	return &overrides, nil
+}
+
+// Get returns the override for a story key and whether one exists.
+// It is safe to call on a nil receiver.
+func (o *StoryOverrides) Get(storyKey string) (StoryOverride, bool) {
+	if o == nil {
+		return StoryOverride{}, false
+	}
+	override, ok := o.Stories[storyKey]
+	return override, ok
+}
+
+// ShouldSkip reports whether the workflow is listed in the story's SkipSteps.
+func (o *StoryOverrides) ShouldSkip(storyKey, workflowName string) bool {
+	override, ok := o.Get(storyKey)
+	if !ok {
+		return false
+	}
+	for _, step := range override.SkipSteps {
+		if step == workflowName {
+			return true
+		}
+	}
+	return false
+}
+
+// DependsOn returns the story keys the story depends on.
+// It is safe to call on a nil receiver.
+func (o *StoryOverrides) DependsOn(storyKey string) []string {
+	override, _ := o.Get(storyKey)
+	return override.DependsOn
+}
+
+// ForStory returns the configuration to use for a story's run.
+//
+// If overrides has no entry for storyKey, the receiver is returned unchanged.
+// Otherwise a copy is returned with the override deep-merged over it; the
+// receiver is never modified.
+func (c *Config) ForStory(storyKey string, overrides *StoryOverrides) *Config {
+	override, ok := overrides.Get(storyKey)
+	if !ok {
+		return c
+	}
+
+	merged := *c
+	merged.Workflows = make(map[string]WorkflowConfig, len(c.Workflows))
+	for name, wf := range c.Workflows {
+		if override.Model != "" {
+			wf.Model = override.Model
+		}
+		if override.Timeout > 0 {
+			wf.Timeout = override.Timeout
+		}
+		merged.Workflows[name] = wf
+	}
+	// Also covers workflows without a config entry
+	if override.Timeout > 0 {
+		merged.Claude.Timeout = override.Timeout
+	}
+
+	for name, wfOverride := range override.Workflows {
+		wf := merged.Workflows[name]
+		if wfOverride.SlashCommand != "" {
+			wf.SlashCommand = wfOverride.SlashCommand
+		}
+		if wfOverride.PromptTemplate != "" {
+			wf.PromptTemplate = wfOverride.PromptTemplate
+		}
+		if wfOverride.Model != "" {
+			wf.Model = wfOverride.Model
+		}
+		if wfOverride.Timeout > 0 {
+			wf.Timeout = wfOverride.Timeout
+		}
+		if wfOverride.PreCommand != "" {
+			wf.PreCommand = wfOverride.PreCommand
+		}
+		if wfOverride.PostCommand != "" {
+			wf.PostCommand = wfOverride.PostCommand
+		}
+		merged.Workflows[name] = wf
+	}
+
+	return &merged
+}
@@ -0,0 +1,147 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// scaffoldHeader is the comment at the top of the file written by
+// [DefaultYAML].
+const scaffoldHeader = `# bmaduum configuration, generated with the built-in defaults.
+# Edit any setting below; removed settings fall back to their defaults.
+# Environment variables with the BMADUUM_ prefix override this file.
+
+`
+
+// scaffoldComments are written above the settings in the file generated by
+// [DefaultYAML], keyed by dotted setting path.
+var scaffoldComments = map[string]string{
+	"workflows": `Workflows run by the story lifecycle. Each has a BMAD v6 slash command and
+a legacy prompt template; {{.StoryKey}} is replaced with the story key. Add
+model (e.g. opus) or timeout (e.g. 10m) to a workflow to override
+claude.model or claude.timeout for it, and pre_command or post_command to
+run a shell command before or after it (see fail_on_post_command).`,
+	"use_slash_commands": `Use BMAD v6 slash commands (true) or legacy prompt templates (false).
+When unset, this is detected from the project: slash commands for BMAD v6
+(a v6 module manifest or _bmad-output/), legacy prompts for older versions.
+use_slash_commands: true`,
+	"status_path": `Explicit path to sprint-status.yaml. If empty, auto-discovers:
+  1. _bmad-output/implementation-artifacts/sprint-status.yaml (v6)
+  2. sprint-status.yaml (legacy)`,
+	"status_lock_timeout": `How long a status update waits for another bmaduum process to release its
+lock on sprint-status.yaml before failing. 0 tries once.`,
+	"max_stories_per_run": `Safety guard: abort when a run expands to more stories than this, unless
+--assume-yes is passed. Set to 0 to disable.`,
+	"story_numbering": `How the story number in {epic}-{number}-{description} keys is parsed:
+numeric (6-1-foo), dotted (6-1.2-foo), or alpha (6-a-foo).`,
+	"validate_transitions": `Reject status updates the lifecycle chain never makes (e.g. done -> backlog)
+unless --force is passed to story or epic.`,
+	"normalize_statuses": `Treat statuses that differ from a known status only in case or surrounding
+whitespace (e.g. Ready-For-Dev) as that status.`,
+	"track_status_updated_at": `Record when each story's status changes in a status_updated_at mapping next
+to development_status in sprint-status.yaml.`,
+	"status_aliases": `Status words from other BMAD templates and the status each routes as,
+e.g. {qa: review, in_progress: in-progress}.`,
+	"max_review_loops": `Let code-review send a story back to in-progress at most this many times
+per story. Set to 0 to disable.`,
+	"max_iterations": `Fail a story once any one workflow would run more than this many times in a
+single lifecycle execution. Set to 0 to disable.`,
+	"on_done":              "What story does with a story that is already done: skip, error, or rerun.",
+	"claude":               "Claude CLI settings.",
+	"claude.output_format": "Output format passed to Claude CLI. Must be stream-json.",
+	"claude.binary_path":   "Path to the Claude CLI binary.",
+	"claude.max_retries":   "Retry attempts for --auto-retry. Overridden by the --retries flag.",
+	"claude.retry_base_delay": `Backoff between step retries requested with --retries: wait
+retry_base_delay before the first retry, multiply by retry_multiplier
+for each further retry, and never wait longer than retry_max_delay.`,
+	"claude.model": `Default model for workflows without their own model, e.g. sonnet.
+Empty uses Claude CLI's default.`,
+	"claude.resume_sessions": "Retry a failed step in the same Claude session (claude --resume).",
+	"claude.record_path":     "Append Claude's raw stream-json output to this file for bmaduum replay.",
+	"claude.extra_args": `Extra arguments appended to every Claude CLI invocation, e.g.
+["--max-turns", "50"].`,
+	"claude.timeout": "Default time limit for each workflow step, e.g. 10m. 0s means no timeout.",
+	"claude.bmad_help_timeout": `Time limit for the bmad-help fallback that resolves unknown statuses.
+0s means no timeout.`,
+	"claude.bmad_help_cache": `Reuse a bmad-help resolution for every story with the same unknown status
+during a run. watch forgets resolutions each time it handles changes.`,
+	"output":                 "Terminal output settings.",
+	"output.truncate_lines":  "Max lines of tool output shown per event.",
+	"output.truncate_length": "Max characters of command headers.",
+	"output.encoding":        "Output character set: auto (detect from locale), utf8, or ascii.",
+	"output.verbosity":       "How much of each run to print: quiet, normal, or verbose (also prints each prompt).",
+	"output.markdown":        "Markdown rendering of Claude's text output; --no-markdown turns it off for one run.",
+	"output.markdown.style":  "Theme: dark, light, dracula, or tokyo-night.",
+	"notifications":          "Reporting run progress to external services.",
+	"notifications.webhook_url": `URL that receives a JSON event after each step and when a run finishes.
+Empty disables the webhook.`,
+	"notifications.slack_webhook": `Slack incoming webhook URL that gets a message when a story fails or a run
+finishes. Empty disables it.`,
+	"notifications.discord_webhook": `Discord webhook URL that gets a message when a story fails or a run
+finishes. Empty disables it.`,
+}
+
+// DefaultYAML returns [DefaultConfig] as a commented YAML config file, a
+// starting point for a user's workflows.yaml. Loading the result yields the
+// default configuration.
+func DefaultYAML() ([]byte, error) {
+	node, err := configNode(DefaultConfig())
+	if err != nil {
+		return nil, fmt.Errorf("encode default config: %w", err)
+	}
+	annotate(node, "")
+	commentOut(node, "use_slash_commands", "status_path")
+
+	data, err := encodeYAML(node)
+	if err != nil {
+		return nil, fmt.Errorf("encode default config: %w", err)
+	}
+	return separateSections(append([]byte(scaffoldHeader), data...)), nil
+}
+
+// separateSections inserts a blank line before each commented top-level
+// setting so the sections of the file stand apart.
+func separateSections(data []byte) []byte {
+	lines := bytes.SplitAfter(data, []byte("\n"))
+	var out bytes.Buffer
+	for i, line := range lines {
+		if i > 0 && bytes.HasPrefix(line, []byte("# ")) && !bytes.HasPrefix(lines[i-1], []byte("#")) && len(bytes.TrimSpace(lines[i-1])) > 0 {
+			out.WriteByte('\n')
+		}
+		out.Write(line)
+	}
+	return out.Bytes()
+}
+
+// commentOut shows the setting key, which is not in the mapping node n, as
+// its scaffoldComments entry above the key next. The entry ends with the
+// commented-out setting.
+func commentOut(n *yaml.Node, key, next string) {
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == next {
+			n.Content[i].HeadComment = scaffoldComments[key] + "\n\n" + n.Content[i].HeadComment
+			return
+		}
+	}
+}
+
+// annotate sets the comments from scaffoldComments on the keys of the
+// mapping node n, whose own setting path is path, and its descendants.
+func annotate(n *yaml.Node, path string) {
+	if n.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		key, value := n.Content[i], n.Content[i+1]
+		keyPath := key.Value
+		if path != "" {
+			keyPath = path + "." + key.Value
+		}
+		if comment, ok := scaffoldComments[keyPath]; ok {
+			key.HeadComment = comment
+		}
+		annotate(value, keyPath)
+	}
+}
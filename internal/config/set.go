@@ -0,0 +1,88 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Set sets the setting at the dotted key path, such as
+// "claude.binary_path" or "workflows.dev-story.model", from its string
+// form. Keys are the ones used in the config file. Strings are taken as
+// is; other values are parsed as YAML, so durations are written like 10m
+// and lists like [--max-turns, "50"]. Setting a field of a workflow that
+// is not configured adds the workflow.
+//
+// Returns an error if key does not name a setting or value does not parse
+// as the setting's type. c is unchanged on error.
+func (c *Config) Set(key, value string) error {
+	parts := strings.Split(key, ".")
+	if parts[0] == "workflows" {
+		if len(parts) != 3 || parts[1] == "" {
+			return fmt.Errorf("unknown config key %q: workflow settings are workflows.<name>.<setting>", key)
+		}
+		wf := c.Workflows[parts[1]]
+		if err := setField(reflect.ValueOf(&wf).Elem(), key, parts[2:], value); err != nil {
+			return err
+		}
+		if c.Workflows == nil {
+			c.Workflows = make(map[string]WorkflowConfig)
+		}
+		c.Workflows[parts[1]] = wf
+		return nil
+	}
+	if parts[0] == "profiles" {
+		return fmt.Errorf("config key %q cannot be set; edit profiles in the config file", key)
+	}
+	if err := setField(reflect.ValueOf(c).Elem(), key, parts, value); err != nil {
+		return err
+	}
+	if key == "use_slash_commands" {
+		c.UseSlashCommandsSet = true
+	}
+	return nil
+}
+
+// setField sets the field of the struct v at the path parts, named by their
+// mapstructure tags, to value. key is the full key, for errors.
+func setField(v reflect.Value, key string, parts []string, value string) error {
+	for _, name := range parts {
+		if v.Kind() != reflect.Struct {
+			return fmt.Errorf("unknown config key %q", key)
+		}
+		field, ok := fieldByTag(v, name)
+		if !ok {
+			return fmt.Errorf("unknown config key %q", key)
+		}
+		v = field
+	}
+
+	switch v.Kind() {
+	case reflect.Struct, reflect.Map:
+		return fmt.Errorf("config key %q is a section, not a setting", key)
+	case reflect.String:
+		v.SetString(value)
+		return nil
+	}
+
+	parsed := reflect.New(v.Type())
+	if err := yaml.Unmarshal([]byte(value), parsed.Interface()); err != nil {
+		return fmt.Errorf("invalid value %q for %s: %w", value, key, err)
+	}
+	v.Set(parsed.Elem())
+	return nil
+}
+
+// fieldByTag returns the field of the struct v whose mapstructure tag is
+// name.
+func fieldByTag(v reflect.Value, name string) (reflect.Value, bool) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).Tag.Get("mapstructure") == name {
+			return v.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
@@ -23,6 +23,11 @@
 //  6. [DefaultConfig] defaults
 package config
 
+import (
+	"strings"
+	"time"
+)
+
 // Config represents the root configuration structure.
 //
 // This is the main configuration container loaded by [Loader] and used throughout
@@ -30,27 +35,131 @@ package config
 type Config struct {
 	// Workflows maps workflow names to their configurations.
 	// Keys are workflow names (e.g., "create-story", "dev-story").
-	Workflows map[string]WorkflowConfig `mapstructure:"workflows"`
+	Workflows map[string]WorkflowConfig `mapstructure:"workflows" yaml:"workflows"`
 
 	// UseSlashCommands controls whether workflows invoke BMAD v6 slash commands
 	// or use legacy prompt templates. When true (default), GetPrompt returns
 	// the SlashCommand template. When false, it returns the PromptTemplate.
 	// Set to false for pre-v6 BMAD projects that use /bmad-bmm-* commands.
-	UseSlashCommands bool `mapstructure:"use_slash_commands"`
+	// Unless UseSlashCommandsSet is true, the CLI detects the mode from the
+	// project's BMAD version instead.
+	UseSlashCommands bool `mapstructure:"use_slash_commands" yaml:"use_slash_commands"`
+
+	// UseSlashCommandsSet reports whether UseSlashCommands was set in the
+	// config file or environment rather than defaulted. [Loader] and
+	// [Config.Set] set it; [Loader.Save] writes use_slash_commands only
+	// when it is true.
+	UseSlashCommandsSet bool `mapstructure:"-" yaml:"-"`
 
 	// StatusPath is an explicit path to the sprint-status.yaml file.
 	// If empty (default), the status package auto-discovers the file by
 	// checking the v6 path then legacy path. Can also be set via
 	// BMADUUM_SPRINT_STATUS_PATH environment variable (which takes priority).
-	StatusPath string `mapstructure:"status_path"`
+	StatusPath string `mapstructure:"status_path" yaml:"status_path"`
+
+	// StatusLockTimeout is how long a status update waits for another
+	// process to release its lock on sprint-status.yaml before failing.
+	// Zero makes a single attempt.
+	// Default: 10s
+	StatusLockTimeout time.Duration `mapstructure:"status_lock_timeout" yaml:"status_lock_timeout"`
+
+	// MaxStoriesPerRun is a safety guard against accidentally huge runs.
+	// When an epic or story list expands to more stories than this limit,
+	// the command aborts unless --assume-yes is given. Zero disables the guard.
+	// Default: 50
+	MaxStoriesPerRun int `mapstructure:"max_stories_per_run" yaml:"max_stories_per_run"`
+
+	// StoryNumbering selects how the story number in a story key
+	// ({epicID}-{storyNum}-{description}) is parsed when expanding epics:
+	// "numeric" (6-1-foo), "dotted" (6-1.2-foo), or "alpha" (6-a-foo).
+	// Keys that do not match are skipped with a warning.
+	// Default: "numeric"
+	StoryNumbering string `mapstructure:"story_numbering" yaml:"story_numbering"`
+
+	// ValidateTransitions makes story and epic reject status updates the
+	// lifecycle chain never makes, such as done → backlog, unless --force
+	// is given.
+	// Default: false
+	ValidateTransitions bool `mapstructure:"validate_transitions" yaml:"validate_transitions"`
+
+	// NormalizeStatuses makes statuses in sprint-status.yaml that differ
+	// from a known status only in case or surrounding whitespace, such as
+	// "Ready-For-Dev", count as that status.
+	// Default: false
+	NormalizeStatuses bool `mapstructure:"normalize_statuses" yaml:"normalize_statuses"`
+
+	// TrackStatusUpdatedAt makes status updates record when each story's
+	// status changed in a status_updated_at mapping next to
+	// development_status in sprint-status.yaml.
+	// Default: false
+	TrackStatusUpdatedAt bool `mapstructure:"track_status_updated_at" yaml:"track_status_updated_at"`
+
+	// StatusAliases maps status words used by other BMAD templates to the
+	// status they route as, e.g. {"qa": "review", "in_progress":
+	// "in-progress"}, so such stories run without the bmad-help fallback.
+	// Default: none
+	StatusAliases map[string]string `mapstructure:"status_aliases" yaml:"status_aliases,omitempty"`
+
+	// MaxReviewLoops enables review loops: when a branch point workflow
+	// (code-review by default) moves a story back to an earlier status such
+	// as in-progress, the lifecycle continues from that status, up to this
+	// many times per story before failing. Zero disables review loops, so
+	// code-review always advances.
+	// Default: 0
+	MaxReviewLoops int `mapstructure:"max_review_loops" yaml:"max_review_loops"`
+
+	// MaxIterations limits how many times each workflow may run for one
+	// story within a single lifecycle execution, guarding against cycles
+	// such as repeated review loops. Zero disables the guard.
+	// Default: 5
+	MaxIterations int `mapstructure:"max_iterations" yaml:"max_iterations"`
+
+	// OnDone selects what the story command does with a story that is
+	// already done: "skip" it, fail with an "error", or "rerun" its
+	// lifecycle from dev-story. The --on-done flag overrides it.
+	// Default: "skip"
+	OnDone string `mapstructure:"on_done" yaml:"on_done"`
 
 	// Claude contains Claude CLI binary configuration.
-	Claude ClaudeConfig `mapstructure:"claude"`
+	Claude ClaudeConfig `mapstructure:"claude" yaml:"claude"`
 
 	// Output contains terminal output formatting configuration.
-	Output OutputConfig `mapstructure:"output"`
+	Output OutputConfig `mapstructure:"output" yaml:"output"`
+
+	// Notifications contains settings for reporting run progress to
+	// external services.
+	Notifications NotificationsConfig `mapstructure:"notifications" yaml:"notifications"`
+
+	// Profiles holds the config file's profiles map as read, so that
+	// [Loader.Save] writes it back. The selected profile is merged by the
+	// loader; nothing reads this field at run time.
+	Profiles map[string]any `mapstructure:"profiles" yaml:"profiles,omitempty"`
 }
 
+// Behaviors for a story that is already done, selected by [Config.OnDone].
+const (
+	// OnDoneSkip skips the story (the default).
+	OnDoneSkip = "skip"
+
+	// OnDoneError fails the command.
+	OnDoneError = "error"
+
+	// OnDoneRerun runs the story's lifecycle again from dev-story.
+	OnDoneRerun = "rerun"
+)
+
+// Output verbosity levels, selected by [OutputConfig.Verbosity].
+const (
+	// VerbosityQuiet prints only step headers, result boxes, and tool errors.
+	VerbosityQuiet = "quiet"
+
+	// VerbosityNormal also prints Claude's text and tool calls (the default).
+	VerbosityNormal = "normal"
+
+	// VerbosityVerbose prints tool output without truncation.
+	VerbosityVerbose = "verbose"
+)
+
 // WorkflowConfig represents a single workflow configuration.
 //
 // Each workflow has two prompt modes: a SlashCommand for BMAD v6 projects
@@ -60,17 +169,36 @@ type WorkflowConfig struct {
 	// SlashCommand is the BMAD v6 slash command template.
 	// Used when Config.UseSlashCommands is true (default).
 	// Example: "/dev-story {{.StoryKey}}"
-	SlashCommand string `mapstructure:"slash_command"`
+	SlashCommand string `mapstructure:"slash_command" yaml:"slash_command,omitempty"`
 
 	// PromptTemplate is the legacy Go template string for the workflow prompt.
 	// Used when Config.UseSlashCommands is false.
 	// Example: "/bmad-bmm-dev-story - Work on story: {{.StoryKey}}"
-	PromptTemplate string `mapstructure:"prompt_template"`
+	PromptTemplate string `mapstructure:"prompt_template" yaml:"prompt_template,omitempty"`
 
 	// Model is the Claude model to use for this workflow.
 	// If empty, the default model is used.
 	// Examples: "opus", "sonnet", "haiku", "claude-sonnet-4-5-20250929"
-	Model string `mapstructure:"model"`
+	Model string `mapstructure:"model" yaml:"model,omitempty"`
+
+	// Timeout limits how long a single run of this workflow may take
+	// (e.g. "10m"). When exceeded, Claude is killed and the step fails.
+	// If zero, [ClaudeConfig.Timeout] applies.
+	Timeout time.Duration `mapstructure:"timeout" yaml:"timeout,omitempty"`
+
+	// PreCommand is a shell command run before Claude is invoked for this
+	// workflow, with BMADUUM_STORY_KEY and BMADUUM_WORKFLOW set in its
+	// environment. A non-zero exit aborts the step.
+	// Example: "go vet ./..."
+	PreCommand string `mapstructure:"pre_command" yaml:"pre_command,omitempty"`
+
+	// PostCommand is a shell command run after Claude exits, with the same
+	// environment as PreCommand plus BMADUUM_EXIT_CODE, Claude's exit code.
+	// A non-zero exit is reported as a warning unless FailOnPostCommand is set.
+	PostCommand string `mapstructure:"post_command" yaml:"post_command,omitempty"`
+
+	// FailOnPostCommand makes a non-zero PostCommand exit fail the step.
+	FailOnPostCommand bool `mapstructure:"fail_on_post_command" yaml:"fail_on_post_command,omitempty"`
 }
 
 // ClaudeConfig contains Claude CLI configuration.
@@ -79,12 +207,70 @@ type WorkflowConfig struct {
 type ClaudeConfig struct {
 	// OutputFormat is the output format passed to Claude CLI.
 	// Should be "stream-json" for structured event parsing.
-	OutputFormat string `mapstructure:"output_format"`
+	OutputFormat string `mapstructure:"output_format" yaml:"output_format"`
 
 	// BinaryPath is the path to the Claude CLI binary.
 	// Default: "claude" (assumes Claude is in PATH).
 	// Can be overridden with BMADUUM_CLAUDE_PATH environment variable.
-	BinaryPath string `mapstructure:"binary_path"`
+	BinaryPath string `mapstructure:"binary_path" yaml:"binary_path"`
+
+	// MaxRetries is the maximum number of retry attempts when auto-retry
+	// is enabled. The --retries flag overrides this for a single invocation.
+	// Default: 10.
+	MaxRetries int `mapstructure:"max_retries" yaml:"max_retries"`
+
+	// RetryBaseDelay is the wait before the first step retry requested with
+	// the --retries flag. Zero retries immediately.
+	// Default: 0
+	RetryBaseDelay time.Duration `mapstructure:"retry_base_delay" yaml:"retry_base_delay"`
+
+	// RetryMultiplier scales the step retry delay after each attempt.
+	// Values below 1 keep the delay constant.
+	// Default: 2
+	RetryMultiplier float64 `mapstructure:"retry_multiplier" yaml:"retry_multiplier"`
+
+	// RetryMaxDelay caps the step retry delay. Zero means no cap.
+	// Default: 0
+	RetryMaxDelay time.Duration `mapstructure:"retry_max_delay" yaml:"retry_max_delay"`
+
+	// Model is the default Claude model for workflows that do not set their
+	// own [WorkflowConfig.Model]. The --model flag of story and epic
+	// overrides it for a single invocation.
+	// Default: "" (Claude CLI's default model)
+	Model string `mapstructure:"model" yaml:"model"`
+
+	// ResumeSessions makes step retries continue the failed attempt's Claude
+	// session (claude --resume) instead of starting a new one, so Claude
+	// keeps the context of what it already tried.
+	// Default: false
+	ResumeSessions bool `mapstructure:"resume_sessions" yaml:"resume_sessions"`
+
+	// RecordPath is a file that Claude's raw stream-json output is appended
+	// to, for later playback with the replay command. Empty disables recording.
+	// Default: "" (disabled).
+	RecordPath string `mapstructure:"record_path" yaml:"record_path"`
+
+	// ExtraArgs are appended to every Claude CLI invocation, for flags
+	// bmaduum does not expose (e.g. ["--max-turns", "50"]). Flags bmaduum
+	// sets itself, such as --output-format, are rejected. The --claude-arg
+	// flag adds more for a single invocation.
+	// Default: none
+	ExtraArgs []string `mapstructure:"extra_args" yaml:"extra_args"`
+
+	// Timeout is the default per-step time limit for workflows that do not
+	// set their own [WorkflowConfig.Timeout]. Zero means no timeout.
+	// Default: 0
+	Timeout time.Duration `mapstructure:"timeout" yaml:"timeout"`
+
+	// BmadHelpTimeout limits how long the bmad-help fallback may run when
+	// resolving an unknown status. Zero means no timeout.
+	// Default: 5m
+	BmadHelpTimeout time.Duration `mapstructure:"bmad_help_timeout" yaml:"bmad_help_timeout"`
+
+	// BmadHelpCache reuses a bmad-help resolution for every story with the
+	// same unknown status during a run, instead of asking again per story.
+	// Default: true
+	BmadHelpCache bool `mapstructure:"bmad_help_cache" yaml:"bmad_help_cache"`
 }
 
 // OutputConfig contains terminal output formatting configuration.
@@ -94,15 +280,48 @@ type OutputConfig struct {
 	// TruncateLines is the maximum number of lines to display per event.
 	// Additional lines are hidden with a "... (N more lines)" indicator.
 	// Default: 20
-	TruncateLines int `mapstructure:"truncate_lines"`
+	TruncateLines int `mapstructure:"truncate_lines" yaml:"truncate_lines"`
 
 	// TruncateLength is the maximum length of each output line.
 	// Longer lines are truncated with "..." suffix.
 	// Default: 60
-	TruncateLength int `mapstructure:"truncate_length"`
+	TruncateLength int `mapstructure:"truncate_length" yaml:"truncate_length"`
+
+	// Encoding selects the output character set: "auto", "utf8", or "ascii".
+	// With "ascii", box-drawing characters and glyphs are replaced with ASCII
+	// equivalents and emoji are stripped. "auto" detects from the locale.
+	// Default: "auto"
+	Encoding string `mapstructure:"encoding" yaml:"encoding"`
+
+	// Verbosity selects how much of each workflow run is printed: "quiet"
+	// shows only step headers and result boxes (plus tool errors), "normal"
+	// adds Claude's text and tool calls, and "verbose" also shows tool output
+	// without truncation.
+	// Default: "normal"
+	Verbosity string `mapstructure:"verbosity" yaml:"verbosity"`
 
 	// Markdown contains markdown rendering configuration.
-	Markdown MarkdownConfig `mapstructure:"markdown"`
+	Markdown MarkdownConfig `mapstructure:"markdown" yaml:"markdown"`
+}
+
+// NotificationsConfig contains settings for reporting run progress to
+// external services.
+type NotificationsConfig struct {
+	// WebhookURL is an http or https URL that receives a JSON event after
+	// each workflow step and when a story or epic run finishes. Failed
+	// deliveries are reported as warnings and never fail the run.
+	// Default: "" (disabled)
+	WebhookURL string `mapstructure:"webhook_url" yaml:"webhook_url"`
+
+	// SlackWebhook is a Slack incoming webhook URL that receives a message
+	// when a story fails and when a story or epic run finishes.
+	// Default: "" (disabled)
+	SlackWebhook string `mapstructure:"slack_webhook" yaml:"slack_webhook"`
+
+	// DiscordWebhook is a Discord webhook URL that receives a message when a
+	// story fails and when a story or epic run finishes.
+	// Default: "" (disabled)
+	DiscordWebhook string `mapstructure:"discord_webhook" yaml:"discord_webhook"`
 }
 
 // MarkdownConfig contains configuration for markdown rendering in terminal output.
@@ -112,20 +331,20 @@ type OutputConfig struct {
 type MarkdownConfig struct {
 	// Enabled controls whether markdown rendering is active.
 	// Default: true
-	Enabled bool `mapstructure:"enabled"`
+	Enabled bool `mapstructure:"enabled" yaml:"enabled"`
 
 	// Style is the glamour theme to use: "dark", "light", "dracula", "tokyo-night".
 	// Avoid "auto" as it can cause detection delays on some terminals.
 	// Default: "dark"
-	Style string `mapstructure:"style"`
+	Style string `mapstructure:"style" yaml:"style"`
 
 	// WordWrap is the column width for text wrapping.
 	// Default: 100
-	WordWrap int `mapstructure:"word_wrap"`
+	WordWrap int `mapstructure:"word_wrap" yaml:"word_wrap"`
 
 	// Emoji enables emoji shortcode rendering (e.g., :smile: -> 😄).
 	// Default: true
-	Emoji bool `mapstructure:"emoji"`
+	Emoji bool `mapstructure:"emoji" yaml:"emoji"`
 }
 
 // DefaultConfig returns a new [Config] with sensible defaults.
@@ -136,7 +355,12 @@ type MarkdownConfig struct {
 // configuration file.
 func DefaultConfig() *Config {
 	return &Config{
-		UseSlashCommands: true,
+		UseSlashCommands:  true,
+		MaxStoriesPerRun:  50,
+		StoryNumbering:    "numeric",
+		OnDone:            OnDoneSkip,
+		StatusLockTimeout: 10 * time.Second,
+		MaxIterations:     5,
 		Workflows: map[string]WorkflowConfig{
 			"create-story": {
 				SlashCommand:   "/create-story {{.StoryKey}}",
@@ -160,12 +384,18 @@ func DefaultConfig() *Config {
 			},
 		},
 		Claude: ClaudeConfig{
-			OutputFormat: "stream-json",
-			BinaryPath:   "claude",
+			OutputFormat:    "stream-json",
+			BinaryPath:      "claude",
+			MaxRetries:      10,
+			RetryMultiplier: 2,
+			BmadHelpTimeout: 5 * time.Minute,
+			BmadHelpCache:   true,
 		},
 		Output: OutputConfig{
 			TruncateLines:  20,
 			TruncateLength: 60,
+			Encoding:       "auto",
+			Verbosity:      VerbosityNormal,
 			Markdown: MarkdownConfig{
 				Enabled:  true,
 				Style:    "dark",
@@ -184,4 +414,33 @@ type PromptData struct {
 	// StoryKey is the identifier of the story being processed.
 	// Access in templates with {{.StoryKey}}.
 	StoryKey string
+
+	// EpicID is the epic the story belongs to, the first segment of the
+	// story key ("6" for "6-1-setup").
+	// Access in templates with {{.EpicID}}.
+	EpicID string
+
+	// Date is the current date in YYYY-MM-DD format.
+	// Access in templates with {{.Date}}.
+	Date string
+
+	// GitBranch is the checked-out git branch, or empty if it cannot be
+	// determined.
+	// Access in templates with {{.GitBranch}}.
+	GitBranch string
+
+	// CurrentStatus is the story's status in sprint-status.yaml before the
+	// workflow runs, or empty if it is unknown.
+	// Access in templates with {{.CurrentStatus}}.
+	CurrentStatus string
+}
+
+// NewPromptData returns the [PromptData] for storyKey that can be derived
+// from the key alone: StoryKey and EpicID.
+func NewPromptData(storyKey string) PromptData {
+	epicID, _, _ := strings.Cut(storyKey, "-")
+	if epicID == storyKey {
+		epicID = ""
+	}
+	return PromptData{StoryKey: storyKey, EpicID: epicID}
 }
@@ -0,0 +1,124 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"text/template"
+)
+
+// validatePromptData is the sample data used to trial-expand prompt
+// templates.
+var validatePromptData = PromptData{
+	StoryKey:      "1-1-example",
+	EpicID:        "1",
+	Date:          "2006-01-02",
+	GitBranch:     "main",
+	CurrentStatus: "ready-for-dev",
+}
+
+// Validate checks the configuration for problems that would only surface
+// once a workflow runs.
+//
+// Every workflow must have a prompt for the active mode that expands
+// cleanly, both templates must parse, and timeouts and retry settings must
+// not be negative. Problems are returned in a stable order; an empty result
+// means the configuration is valid.
+func (c *Config) Validate() []error {
+	var problems []error
+
+	names := make([]string, 0, len(c.Workflows))
+	for name := range c.Workflows {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		wf := c.Workflows[name]
+		for _, tmpl := range []struct{ key, text string }{
+			{"slash_command", wf.SlashCommand},
+			{"prompt_template", wf.PromptTemplate},
+		} {
+			if tmpl.text == "" {
+				continue
+			}
+			if _, err := template.New(name).Parse(tmpl.text); err != nil {
+				problems = append(problems, fmt.Errorf("workflows.%s.%s: %w", name, tmpl.key, err))
+			}
+		}
+		if _, err := c.GetPromptWithData(name, validatePromptData); err != nil {
+			problems = append(problems, fmt.Errorf("workflows.%s: %w", name, err))
+		}
+		if wf.Timeout < 0 {
+			problems = append(problems, fmt.Errorf("workflows.%s.timeout: must not be negative", name))
+		}
+	}
+
+	switch c.OnDone {
+	case "", OnDoneSkip, OnDoneError, OnDoneRerun:
+	default:
+		problems = append(problems, fmt.Errorf("on_done: must be skip, error, or rerun, got %q", c.OnDone))
+	}
+	if err := ValidateVerbosity(c.Output.Verbosity); err != nil {
+		problems = append(problems, fmt.Errorf("output.verbosity: %w", err))
+	}
+	if c.StatusLockTimeout < 0 {
+		problems = append(problems, fmt.Errorf("status_lock_timeout: must not be negative"))
+	}
+	if c.MaxReviewLoops < 0 {
+		problems = append(problems, fmt.Errorf("max_review_loops: must not be negative"))
+	}
+	if c.MaxIterations < 0 {
+		problems = append(problems, fmt.Errorf("max_iterations: must not be negative"))
+	}
+	if c.MaxStoriesPerRun < 0 {
+		problems = append(problems, fmt.Errorf("max_stories_per_run: must not be negative"))
+	}
+	if c.Claude.MaxRetries < 0 {
+		problems = append(problems, fmt.Errorf("claude.max_retries: must not be negative"))
+	}
+	if c.Claude.Timeout < 0 {
+		problems = append(problems, fmt.Errorf("claude.timeout: must not be negative"))
+	}
+	aliases := make([]string, 0, len(c.StatusAliases))
+	for alias := range c.StatusAliases {
+		aliases = append(aliases, alias)
+	}
+	sort.Strings(aliases)
+	for _, alias := range aliases {
+		if c.StatusAliases[alias] == "" {
+			problems = append(problems, fmt.Errorf("status_aliases.%s: must name a status", alias))
+		}
+	}
+	if c.Claude.BmadHelpTimeout < 0 {
+		problems = append(problems, fmt.Errorf("claude.bmad_help_timeout: must not be negative"))
+	}
+	if c.Claude.RetryBaseDelay < 0 || c.Claude.RetryMaxDelay < 0 {
+		problems = append(problems, fmt.Errorf("claude.retry_base_delay and claude.retry_max_delay: must not be negative"))
+	}
+
+	for _, hook := range []struct{ key, url string }{
+		{"webhook_url", c.Notifications.WebhookURL},
+		{"slack_webhook", c.Notifications.SlackWebhook},
+		{"discord_webhook", c.Notifications.DiscordWebhook},
+	} {
+		if hook.url == "" {
+			continue
+		}
+		if u, err := url.Parse(hook.url); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			problems = append(problems, fmt.Errorf("notifications.%s: must be an http or https URL, got %q", hook.key, hook.url))
+		}
+	}
+
+	return problems
+}
+
+// ValidateVerbosity reports whether verbosity is a known output verbosity
+// level. An empty value means the default, normal.
+func ValidateVerbosity(verbosity string) error {
+	switch verbosity {
+	case "", VerbosityQuiet, VerbosityNormal, VerbosityVerbose:
+		return nil
+	}
+	return fmt.Errorf("must be quiet, normal, or verbose, got %q", verbosity)
+}
@@ -0,0 +1,126 @@
+// Package git provides a minimal helper for inspecting the working tree.
+//
+// The helper shells out to the git binary. It is used for post-run safety
+// checks, such as detecting changes left uncommitted after a story completes,
+// and for recording the commits made by each step in the run manifest.
+//
+// Key types:
+//   - [Client] - Runs git commands in a working directory
+package git
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// Client runs git commands in a working directory.
+//
+// Create instances using [NewClient].
+type Client struct {
+	dir string
+}
+
+// NewClient creates a [Client] for the repository containing dir.
+// Pass an empty string to use the current working directory.
+func NewClient(dir string) *Client {
+	return &Client{dir: dir}
+}
+
+// UncommittedFiles returns the paths with uncommitted changes, including
+// untracked files. An empty result means the working tree is clean.
+//
+// Returns an error if git fails (e.g., dir is not inside a repository).
+func (c *Client) UncommittedFiles(ctx context.Context) ([]string, error) {
+	cmd := exec.CommandContext(ctx, "git", "status", "--porcelain")
+	cmd.Dir = c.dir
+
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git status failed: %w", err)
+	}
+
+	return ParsePorcelain(string(out)), nil
+}
+
+// HeadSHA returns the SHA of the commit currently checked out.
+//
+// Returns an error if git fails, including in a repository with no commits.
+func (c *Client) HeadSHA(ctx context.Context) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", "rev-parse", "HEAD")
+	cmd.Dir = c.dir
+
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git rev-parse failed: %w", err)
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
+
+// CurrentBranch returns the name of the checked-out branch, or an empty
+// string if HEAD is detached.
+func (c *Client) CurrentBranch(ctx context.Context) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", "rev-parse", "--abbrev-ref", "HEAD")
+	cmd.Dir = c.dir
+
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git rev-parse failed: %w", err)
+	}
+
+	branch := strings.TrimSpace(string(out))
+	if branch == "HEAD" {
+		return "", nil
+	}
+	return branch, nil
+}
+
+// RepoRoot returns the top-level directory of the repository.
+//
+// Returns an error if git fails (e.g., dir is not inside a repository).
+func (c *Client) RepoRoot(ctx context.Context) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", "rev-parse", "--show-toplevel")
+	cmd.Dir = c.dir
+
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git rev-parse failed: %w", err)
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
+
+// CommitsSince returns the SHAs of commits reachable from HEAD but not from
+// base, oldest first. An empty result means no commits were made since base.
+func (c *Client) CommitsSince(ctx context.Context, base string) ([]string, error) {
+	cmd := exec.CommandContext(ctx, "git", "rev-list", "--reverse", base+"..HEAD")
+	cmd.Dir = c.dir
+
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git rev-list failed: %w", err)
+	}
+
+	return strings.Fields(string(out)), nil
+}
+
+// ParsePorcelain extracts file paths from `git status --porcelain` output.
+//
+// Each line has the form "XY path"; for renames ("XY old -> new") the new
+// path is returned. Blank lines are ignored.
+func ParsePorcelain(output string) []string {
+	var files []string
+	for _, line := range strings.Split(output, "\n") {
+		if len(line) < 4 {
+			continue
+		}
+		path := line[3:]
+		if i := strings.Index(path, " -> "); i >= 0 {
+			path = path[i+len(" -> "):]
+		}
+		files = append(files, strings.Trim(path, `"`))
+	}
+	return files
+}
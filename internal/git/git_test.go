@@ -0,0 +1,138 @@
+package git
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePorcelain(t *testing.T) {
+	output := " M internal/cli/story.go\n?? notes.txt\nR  old.go -> new.go\nA  \"with space.go\"\n\n"
+
+	assert.Equal(t, []string{"internal/cli/story.go", "notes.txt", "new.go", "with space.go"}, ParsePorcelain(output))
+	assert.Empty(t, ParsePorcelain(""))
+}
+
+func TestClient_UncommittedFiles(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	dir := t.TempDir()
+	require.NoError(t, exec.Command("git", "-C", dir, "init", "-q").Run())
+
+	client := NewClient(dir)
+
+	files, err := client.UncommittedFiles(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, files)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "leftover.txt"), []byte("x"), 0644))
+	files, err = client.UncommittedFiles(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"leftover.txt"}, files)
+}
+
+func TestClient_UncommittedFiles_NotARepo(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	dir := t.TempDir()
+	t.Setenv("GIT_CEILING_DIRECTORIES", filepath.Dir(dir))
+
+	_, err := NewClient(dir).UncommittedFiles(context.Background())
+	assert.Error(t, err)
+}
+
+func TestClient_RepoRoot(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	dir := t.TempDir()
+	t.Setenv("GIT_CEILING_DIRECTORIES", filepath.Dir(dir))
+
+	_, err := NewClient(dir).RepoRoot(context.Background())
+	assert.Error(t, err)
+
+	require.NoError(t, exec.Command("git", "-C", dir, "init", "-q").Run())
+	sub := filepath.Join(dir, "sub")
+	require.NoError(t, os.Mkdir(sub, 0755))
+
+	root, err := NewClient(sub).RepoRoot(context.Background())
+	require.NoError(t, err)
+	want, err := filepath.EvalSymlinks(dir)
+	require.NoError(t, err)
+	assert.Equal(t, want, root)
+}
+
+func TestClient_HeadSHAAndCommitsSince(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	dir := t.TempDir()
+	gitCmd := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", dir, "-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		require.NoError(t, cmd.Run())
+	}
+	gitCmd("init", "-q")
+	gitCmd("commit", "-q", "--allow-empty", "-m", "base")
+
+	client := NewClient(dir)
+	ctx := context.Background()
+
+	base, err := client.HeadSHA(ctx)
+	require.NoError(t, err)
+	assert.Len(t, base, 40)
+
+	commits, err := client.CommitsSince(ctx, base)
+	require.NoError(t, err)
+	assert.Empty(t, commits)
+
+	gitCmd("commit", "-q", "--allow-empty", "-m", "one")
+	first, err := client.HeadSHA(ctx)
+	require.NoError(t, err)
+	gitCmd("commit", "-q", "--allow-empty", "-m", "two")
+	second, err := client.HeadSHA(ctx)
+	require.NoError(t, err)
+
+	commits, err = client.CommitsSince(ctx, base)
+	require.NoError(t, err)
+	assert.Equal(t, []string{first, second}, commits)
+}
+
+func TestClient_CurrentBranch(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	dir := t.TempDir()
+	gitCmd := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", dir, "-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		require.NoError(t, cmd.Run())
+	}
+	gitCmd("init", "-q")
+	gitCmd("commit", "-q", "--allow-empty", "-m", "base")
+	gitCmd("checkout", "-q", "-b", "story/6-1")
+
+	client := NewClient(dir)
+	ctx := context.Background()
+
+	branch, err := client.CurrentBranch(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "story/6-1", branch)
+
+	gitCmd("checkout", "-q", "--detach")
+	branch, err = client.CurrentBranch(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, branch)
+}
@@ -14,8 +14,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"math"
+	"os"
+	"strings"
+	"time"
 
 	"bmaduum/internal/router"
+	"bmaduum/internal/state"
 	"bmaduum/internal/status"
 )
 
@@ -24,6 +30,11 @@ import (
 // returns statuses that the router doesn't recognize.
 const maxBmadHelpDepth = 3
 
+// DefaultMaxIterations is how many times a workflow may run for a story
+// within one execution before the executor gives up. See
+// [Executor.SetMaxIterations].
+const DefaultMaxIterations = 5
+
 // WorkflowRunner is the interface for executing individual workflows.
 //
 // RunSingle executes a named workflow for a story and returns the exit code.
@@ -62,13 +73,119 @@ type BmadHelpFallback interface {
 	ResolveWorkflow(ctx context.Context, storyKey string, currentStatus status.Status) (workflow string, nextStatus status.Status, err error)
 }
 
+// StepSkipper decides whether a lifecycle step should be skipped for a story.
+//
+// Skipped steps do not run their workflow, but their status transition is
+// still applied so the story keeps advancing. The config package's
+// StoryOverrides type implements this interface.
+type StepSkipper interface {
+	ShouldSkip(storyKey, workflowName string) bool
+}
+
+// StoryFileChecker reports whether a story's story file already exists.
+//
+// When set, a backlog story whose story file exists skips create-story and
+// advances straight to its next status. The status package's StoryFiles
+// type implements this interface.
+type StoryFileChecker interface {
+	StoryFileExists(storyKey string) bool
+}
+
+// Checkpointer persists the last completed step of each story so that
+// [Executor.Resume] can continue after a crash. The state package's
+// CheckpointStore type implements this interface.
+type Checkpointer interface {
+	Save(cp state.Checkpoint) error
+	Load(storyKey string) (state.Checkpoint, error)
+	Remove(storyKey string) error
+}
+
+// SessionRunner is implemented by runners that report the Claude session of
+// their last run and can resume it, such as [workflow.Runner]. The executor
+// uses it to record sessions (see [Executor.SetSessionRecorder]) and to
+// resume failed steps (see [Executor.SetResumeSessions]).
+type SessionRunner interface {
+	LastSessionID() string
+	SetResumeSession(sessionID string)
+}
+
+// ModelRunner is implemented by runners that can run a workflow with a
+// specific Claude model, such as [workflow.Runner]. The executor uses it for
+// lifecycle steps that set [router.LifecycleStep.Model]; steps without a
+// model run with [WorkflowRunner.RunSingle] and the workflow's configured
+// model.
+type ModelRunner interface {
+	RunSingleWithModel(ctx context.Context, workflowName, storyKey, model string) int
+}
+
+// FailureReporter is implemented by runners that can explain why their last
+// run failed, such as [workflow.Runner]. The executor wraps the reported
+// error in a failed step's error, so callers can match it with [errors.Is],
+// e.g. against ratelimit.ErrRateLimited.
+type FailureReporter interface {
+	LastError() error
+}
+
+// SessionRecorder persists the last Claude session of each story. The state
+// package's SessionStore type implements this interface.
+type SessionRecorder interface {
+	Save(session state.Session) error
+}
+
+// StepObserver is notified around each workflow that the executor runs.
+//
+// StepStarted is called just before a step's workflow runs and StepFinished
+// just after, with its exit code. Each retry attempt is reported separately.
+// Skipped steps are not reported. The CLI
+// uses this to record per-step artifacts in the run manifest.
+type StepObserver interface {
+	StepStarted(ctx context.Context, storyKey, workflow string)
+	StepFinished(ctx context.Context, storyKey, workflow string, exitCode int)
+}
+
 // ProgressCallback is invoked before each workflow step begins execution.
 //
 // The callback receives stepIndex (1-based), totalSteps count, and the workflow name.
 // This enables progress reporting in the UI. The callback is optional and can be set
-// via [Executor.SetProgressCallback].
+// via [Executor.SetProgressCallback]. When a failed step is retried (see
+// [Executor.SetRetries]), the callback is invoked again with the workflow name
+// suffixed by the attempt, e.g. "dev-story (retry 2/3)".
 type ProgressCallback func(stepIndex, totalSteps int, workflow string)
 
+// Transition records a status change performed by the executor.
+type Transition struct {
+	// Workflow is the workflow whose completion triggered the transition.
+	Workflow string
+
+	// From is the story status before the update.
+	From status.Status
+
+	// To is the status written after the workflow completed.
+	To status.Status
+}
+
+// FormatTransitions renders transitions as a compact trace such as
+// "backlog → ready-for-dev → review → done".
+//
+// Consecutive repeated statuses (e.g., done → done) are collapsed. Returns an
+// empty string if there are no transitions.
+func FormatTransitions(transitions []Transition) string {
+	if len(transitions) == 0 {
+		return ""
+	}
+
+	trace := []string{string(transitions[0].From)}
+	for _, t := range transitions {
+		if string(t.From) != trace[len(trace)-1] {
+			trace = append(trace, string(t.From))
+		}
+		if string(t.To) != trace[len(trace)-1] {
+			trace = append(trace, string(t.To))
+		}
+	}
+	return strings.Join(trace, " → ")
+}
+
 // Executor orchestrates the complete story lifecycle from current status to done.
 //
 // Executor uses dependency injection for testability: [WorkflowRunner] executes workflows,
@@ -84,6 +201,22 @@ type Executor struct {
 	progressCallback ProgressCallback
 	router           *router.Router
 	bmadHelp         BmadHelpFallback
+	skipper          StepSkipper
+	storyFiles       StoryFileChecker
+	observer         StepObserver
+	sessions         SessionRecorder
+	resumeSessions   bool
+	checkpoints      Checkpointer
+	retryPolicy      RetryPolicy
+	maxReviewLoops   int
+	maxIterations    int
+	until            status.Status
+	startStatus      status.Status
+	iterations       map[string]int
+	transitions      []Transition
+	out              io.Writer
+	// singleStep stops a run after its first step; see [Executor.ExecuteStep].
+	singleStep bool
 }
 
 // NewExecutor creates a new Executor with the required dependencies.
@@ -93,9 +226,10 @@ type Executor struct {
 // to enable progress reporting.
 func NewExecutor(runner WorkflowRunner, reader StatusReader, writer StatusWriter) *Executor {
 	return &Executor{
-		runner:       runner,
-		statusReader: reader,
-		statusWriter: writer,
+		runner:        runner,
+		statusReader:  reader,
+		statusWriter:  writer,
+		maxIterations: DefaultMaxIterations,
 	}
 }
 
@@ -119,6 +253,249 @@ func (e *Executor) SetBmadHelp(fb BmadHelpFallback) {
 	e.bmadHelp = fb
 }
 
+// SetStepSkipper configures an optional [StepSkipper] for per-story step skipping.
+//
+// If not set (or set to nil), every step in the lifecycle is run.
+func (e *Executor) SetStepSkipper(s StepSkipper) {
+	e.skipper = s
+}
+
+// SetStoryFileChecker configures an optional [StoryFileChecker].
+//
+// If not set (or set to nil), create-story always runs for backlog stories.
+func (e *Executor) SetStoryFileChecker(c StoryFileChecker) {
+	e.storyFiles = c
+}
+
+// SetStepObserver configures an optional [StepObserver].
+//
+// If not set (or set to nil), steps run without notification.
+func (e *Executor) SetStepObserver(o StepObserver) {
+	e.observer = o
+}
+
+// SetCheckpointer configures an optional [Checkpointer].
+//
+// When set, a checkpoint is saved after each successful step and removed
+// once all of the story's steps have run and it is done. If not set (or set to nil), no checkpoints
+// are written and [Executor.Resume] behaves like [Executor.Execute].
+func (e *Executor) SetCheckpointer(c Checkpointer) {
+	e.checkpoints = c
+}
+
+// SetSessionRecorder configures an optional [SessionRecorder]. When set and
+// the runner is a [SessionRunner], the Claude session of every workflow run
+// is recorded for its story, so a failed step can later be resumed with
+// its conversation context.
+func (e *Executor) SetSessionRecorder(r SessionRecorder) {
+	e.sessions = r
+}
+
+// SetResumeSessions makes step retries (see [Executor.SetRetryPolicy])
+// continue the failed attempt's Claude session instead of starting a new
+// one. It has no effect unless the runner is a [SessionRunner].
+func (e *Executor) SetResumeSessions(resume bool) {
+	e.resumeSessions = resume
+}
+
+// SetOutput sets where notices such as skipped steps, retries, and review
+// loops are printed, e.g. to also record them in a run transcript. If unset,
+// they go to os.Stdout.
+func (e *Executor) SetOutput(w io.Writer) {
+	e.out = w
+}
+
+// Output returns the writer notices are printed to; see [Executor.SetOutput].
+func (e *Executor) Output() io.Writer {
+	if e.out == nil {
+		return os.Stdout
+	}
+	return e.out
+}
+
+// RetryPolicy controls how failed workflow steps are retried.
+//
+// The zero value disables retries: each step runs once.
+type RetryPolicy struct {
+	// MaxRetries is how many times a failed step is re-run. The count
+	// resets for each step.
+	MaxRetries int
+
+	// BaseDelay is the wait before the first retry. Zero retries immediately.
+	BaseDelay time.Duration
+
+	// Multiplier scales the delay after each retry. Values below 1 are
+	// treated as 1 (constant delay).
+	Multiplier float64
+
+	// MaxDelay caps the delay between retries. Zero means no cap.
+	MaxDelay time.Duration
+}
+
+// Delay returns the wait before retry number attempt (1-based):
+// BaseDelay * Multiplier^(attempt-1), capped at MaxDelay.
+func (p RetryPolicy) Delay(attempt int) time.Duration {
+	if p.BaseDelay <= 0 || attempt < 1 {
+		return 0
+	}
+
+	multiplier := math.Max(p.Multiplier, 1)
+	delay := float64(p.BaseDelay) * math.Pow(multiplier, float64(attempt-1))
+	if p.MaxDelay > 0 && delay > float64(p.MaxDelay) {
+		return p.MaxDelay
+	}
+	if delay > math.MaxInt64 {
+		return time.Duration(math.MaxInt64)
+	}
+	return time.Duration(delay)
+}
+
+// sleepContext waits for d or until ctx is done, whichever comes first.
+// Returns ctx.Err() if the wait was cut short. Tests replace it to avoid
+// real delays.
+var sleepContext = func(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// SetRetryPolicy configures how failed workflow steps are retried.
+//
+// A failed step is re-run up to policy.MaxRetries times, waiting
+// [RetryPolicy.Delay] before each attempt. The wait is cut short if the
+// context passed to [Executor.Execute] is canceled. The status only advances
+// after a successful attempt, and the lifecycle still fails fast once a
+// step's retries are exhausted.
+func (e *Executor) SetRetryPolicy(policy RetryPolicy) {
+	e.retryPolicy = policy
+}
+
+// SetRetries configures how many times a failed workflow step is re-run,
+// without any delay between attempts. It is shorthand for
+// [Executor.SetRetryPolicy] with only MaxRetries set.
+func (e *Executor) SetRetries(n int) {
+	e.retryPolicy = RetryPolicy{MaxRetries: n}
+}
+
+// SetMaxReviewLoops enables branching at branch point steps (see
+// [router.Router.SetBranchPoint]) and limits how often a story may loop.
+//
+// With n > 0, the story status is re-read after a branch point such as
+// code-review runs. If the workflow moved the story to another status than
+// the one the step leads to (e.g. back to in-progress because the review found
+// issues), the lifecycle continues from that status instead, so dev-story and
+// code-review run again. A story sent back more than n times fails, which
+// guards against a review that never passes. With n = 0 (the default),
+// branch points always advance to their next status.
+func (e *Executor) SetMaxReviewLoops(n int) {
+	e.maxReviewLoops = n
+}
+
+// SetMaxIterations limits how many times each workflow may run for a story
+// within one execution (e.g. one [Executor.Execute] call).
+//
+// This guards against cycles such as code-review repeatedly sending a story
+// back to dev-story (see [Executor.SetMaxReviewLoops]) or bmad-help detours
+// returning to the same workflow. Retry attempts of a failed step count as a
+// single iteration. It is separate from the bmad-help depth limit. Values of
+// zero or less disable the guard. Defaults to [DefaultMaxIterations].
+func (e *Executor) SetMaxIterations(n int) {
+	e.maxIterations = n
+}
+
+// SetUntil stops each story's lifecycle once it reaches target, even if more
+// steps remain in the chain; e.g. [status.StatusReview] leaves the story for a
+// human to look at before code-review. Execution fails if target cannot be
+// reached from the story's status. [Executor.GetSteps] and
+// [Executor.BuildPlan] honor the target too. An empty target (the default)
+// runs the lifecycle to done.
+func (e *Executor) SetUntil(target status.Status) {
+	e.until = target
+}
+
+// SetStartStatus makes each story's lifecycle start from s instead of the
+// status read from the [StatusReader], e.g. to treat a story as in review when
+// sprint-status.yaml is out of sync with its actual state. Only the initial
+// lookup is bypassed: status updates are still written, and statuses are read
+// as usual once a step has run. An empty s (the default) reads the status.
+func (e *Executor) SetStartStatus(s status.Status) {
+	e.startStatus = s
+}
+
+// storyStatus returns the status a story's lifecycle starts from: the one set
+// with [Executor.SetStartStatus], or else the story's status as read.
+func (e *Executor) storyStatus(storyKey string) (status.Status, error) {
+	if e.startStatus != "" {
+		return e.startStatus, nil
+	}
+	return e.statusReader.GetStoryStatus(storyKey)
+}
+
+// stepsUntil returns steps up to and including the last one that moves the
+// story to the target set with [Executor.SetUntil], or steps unchanged if no
+// target is set. No steps remain for a story already at the target. Fails if
+// steps never reach the target from currentStatus.
+func (e *Executor) stepsUntil(currentStatus status.Status, steps []router.LifecycleStep) ([]router.LifecycleStep, error) {
+	if e.until == "" {
+		return steps, nil
+	}
+	last := -1
+	for i, step := range steps {
+		if step.NextStatus == e.until {
+			last = i
+		}
+	}
+	if last < 0 {
+		if currentStatus == e.until {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("status %s is not reachable from %s", e.until, currentStatus)
+	}
+	return steps[:last+1], nil
+}
+
+// startRun resets the per-execution state recorded by the executor.
+func (e *Executor) startRun() {
+	e.transitions = nil
+	e.iterations = make(map[string]int)
+	e.singleStep = false
+}
+
+// countIteration records a visit to workflow for a story, failing once the
+// workflow has run more than the configured maximum number of times.
+func (e *Executor) countIteration(storyKey, workflow string) error {
+	if e.maxIterations <= 0 {
+		return nil
+	}
+	if e.iterations == nil {
+		e.iterations = make(map[string]int)
+	}
+	e.iterations[workflow]++
+	if e.iterations[workflow] > e.maxIterations {
+		return fmt.Errorf("story %s: %s exceeded %d iterations (max_iterations); the lifecycle may be cycling",
+			storyKey, workflow, e.maxIterations)
+	}
+	return nil
+}
+
+// isBranchPoint delegates to the configured router or falls back to the package-level function.
+func (e *Executor) isBranchPoint(workflow string) bool {
+	if e.router != nil {
+		return e.router.IsBranchPoint(workflow)
+	}
+	return router.IsBranchPoint(workflow)
+}
+
 // getLifecycle delegates to the configured router or falls back to the package-level function.
 func (e *Executor) getLifecycle(s status.Status) ([]router.LifecycleStep, error) {
 	if e.router != nil {
@@ -152,14 +529,163 @@ func (e *Executor) SetProgressCallback(cb ProgressCallback) {
 // Errors can occur from status lookup failure, workflow execution failure (non-zero exit),
 // or status update failure. For stories already done, Execute returns [router.ErrStoryComplete].
 func (e *Executor) Execute(ctx context.Context, storyKey string) error {
+	e.startRun()
 	return e.executeWithDepth(ctx, storyKey, 0)
 }
 
+// Resume continues a story's lifecycle from its checkpoint.
+//
+// Resume loads the story's checkpoint (see [Executor.SetCheckpointer]) and
+// runs the remaining steps for the current status, skipping any up to and
+// including the checkpointed workflow. Without a checkpoint, or if the
+// checkpoint names a workflow that is no longer in the router chain (with a
+// warning), Resume falls back to status-based routing like [Executor.Execute].
+func (e *Executor) Resume(ctx context.Context, storyKey string) error {
+	e.startRun()
+	if e.checkpoints == nil {
+		return e.executeWithDepth(ctx, storyKey, 0)
+	}
+
+	cp, err := e.checkpoints.Load(storyKey)
+	if errors.Is(err, state.ErrNoCheckpoint) {
+		return e.executeWithDepth(ctx, storyKey, 0)
+	}
+	if err != nil {
+		return err
+	}
+
+	if !e.inChain(cp.Workflow) {
+		fmt.Fprintf(e.Output(), "Warning: checkpoint for %s references workflow %s, which is not in the lifecycle; resuming from status\n",
+			storyKey, cp.Workflow)
+		return e.executeWithDepth(ctx, storyKey, 0)
+	}
+
+	currentStatus, err := e.storyStatus(storyKey)
+	if err != nil {
+		return err
+	}
+
+	steps, err := e.getLifecycle(currentStatus)
+	if err != nil {
+		if errors.Is(err, router.ErrStoryComplete) {
+			e.removeCheckpoint(storyKey)
+		}
+		return e.executeWithDepth(ctx, storyKey, 0)
+	}
+
+	steps, err = e.stepsUntil(currentStatus, stepsAfter(steps, cp.Workflow))
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(e.Output(), "Resuming %s after %s (checkpoint from %s)\n", storyKey, cp.Workflow, cp.CompletedAt.Format(time.RFC3339))
+	return e.runSteps(ctx, storyKey, currentStatus, steps)
+}
+
+// Rerun runs a story's lifecycle again from development, whatever its
+// current status.
+//
+// The steps for [status.StatusReadyForDev] (dev-story onward) are run, so a
+// done story is re-developed, re-reviewed, and re-committed. create-story is
+// not repeated since the story file already exists.
+func (e *Executor) Rerun(ctx context.Context, storyKey string) error {
+	e.startRun()
+
+	currentStatus, err := e.storyStatus(storyKey)
+	if err != nil {
+		return err
+	}
+
+	steps, err := e.getLifecycle(status.StatusReadyForDev)
+	if err != nil {
+		return err
+	}
+	steps, err = e.stepsUntil(currentStatus, steps)
+	if err != nil {
+		return err
+	}
+
+	return e.runSteps(ctx, storyKey, currentStatus, steps)
+}
+
+// ExecuteStep runs only the next step of a story's lifecycle: the workflow
+// its current status maps to (see [router.Router.GetWorkflow]), after which
+// the status is updated to the step's next status.
+//
+// Skips, retries, the step observer, and checkpoints apply as in
+// [Executor.Execute], and an unknown status is resolved through the
+// bmad-help fallback if one is configured. When review loops are enabled and
+// a branch point sends the story back, the status it set is kept and no
+// further step runs. For stories already done, ExecuteStep returns
+// [router.ErrStoryComplete].
+func (e *Executor) ExecuteStep(ctx context.Context, storyKey string) error {
+	e.startRun()
+	e.singleStep = true
+
+	currentStatus, err := e.storyStatus(storyKey)
+	if err != nil {
+		return err
+	}
+
+	steps, err := e.getLifecycle(currentStatus)
+	if err != nil {
+		if !errors.Is(err, router.ErrUnknownStatus) || e.bmadHelp == nil {
+			return err
+		}
+		workflow, nextStatus, helpErr := e.bmadHelp.ResolveWorkflow(ctx, storyKey, currentStatus)
+		if helpErr != nil {
+			return fmt.Errorf("unknown status %q and bmad-help fallback failed: %w", currentStatus, helpErr)
+		}
+		steps = []router.LifecycleStep{{Workflow: workflow, NextStatus: nextStatus}}
+	}
+
+	return e.runSteps(ctx, storyKey, currentStatus, steps[:1])
+}
+
+// inChain reports whether workflow is part of the full lifecycle chain.
+func (e *Executor) inChain(workflow string) bool {
+	steps, err := e.getLifecycle(status.StatusBacklog)
+	if err != nil {
+		return false
+	}
+	for _, step := range steps {
+		if step.Workflow == workflow {
+			return true
+		}
+	}
+	return false
+}
+
+// stepsAfter returns the steps following workflow, or steps unchanged if
+// workflow is not among them.
+func stepsAfter(steps []router.LifecycleStep, workflow string) []router.LifecycleStep {
+	for i, step := range steps {
+		if step.Workflow == workflow {
+			return steps[i+1:]
+		}
+	}
+	return steps
+}
+
+// Transitions returns the status transitions performed by the most recent
+// [Executor.Execute] call, in the order they were written.
+//
+// Unlike the planned steps from [Executor.GetSteps], this reflects what
+// actually happened, including bmad-help detours. Transitions from a failed
+// run are retained up to the point of failure.
+func (e *Executor) Transitions() []Transition {
+	return e.transitions
+}
+
 // executeWithDepth is the internal implementation of Execute with depth tracking
 // for bmad-help fallback recursion.
 func (e *Executor) executeWithDepth(ctx context.Context, storyKey string, depth int) error {
-	// Get current story status
-	currentStatus, err := e.statusReader.GetStoryStatus(storyKey)
+	// Get current story status; after a bmad-help step, follow the status it set
+	lookup := e.storyStatus
+	if depth > 0 {
+		lookup = e.statusReader.GetStoryStatus
+	}
+	currentStatus, err := lookup(storyKey)
 	if err != nil {
 		return err
 	}
@@ -186,50 +712,306 @@ func (e *Executor) executeWithDepth(ctx context.Context, storyKey string, depth
 		} else {
 			return err // Returns router.ErrStoryComplete for done stories, or ErrUnknownStatus without fallback
 		}
+	} else {
+		steps, err = e.stepsUntil(currentStatus, steps)
+		if err != nil {
+			return err
+		}
+		if len(steps) == 0 {
+			fmt.Fprintf(e.Output(), "Story %s is already %s\n", storyKey, currentStatus)
+			return nil
+		}
+	}
+
+	if err := e.runSteps(ctx, storyKey, currentStatus, steps); err != nil {
+		return err
+	}
+
+	// If bmad-help bridged us from an unknown status, re-execute to continue
+	// the lifecycle from the new (hopefully recognized) status.
+	if usedBmadHelp {
+		return e.executeWithDepth(ctx, storyKey, depth+1)
 	}
 
+	return nil
+}
+
+// runSteps runs steps in order for a story, starting from currentStatus.
+//
+// Each step's workflow is run (unless skipped by the [StepSkipper]), then the
+// story status is updated and the transition recorded. When review loops are
+// enabled (see [Executor.SetMaxReviewLoops]), a branch point that sends the
+// story back restarts the steps from the status it set. Stops on the first
+// error. If ctx is canceled, the current step's status update is not written
+// and an error wrapping ctx.Err() is returned.
+func (e *Executor) runSteps(ctx context.Context, storyKey string, currentStatus status.Status, steps []router.LifecycleStep) error {
 	// Get total steps count for progress reporting
 	totalSteps := len(steps)
+	reviewLoops := 0
 
 	// Execute each step in sequence
-	for i, step := range steps {
+	for i := 0; i < len(steps); i++ {
+		step := steps[i]
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("workflow canceled: %s: %w", step.Workflow, err)
+		}
+		if err := e.countIteration(storyKey, step.Workflow); err != nil {
+			return err
+		}
+
 		// Call progress callback if set
 		if e.progressCallback != nil {
 			e.progressCallback(i+1, totalSteps, step.Workflow)
 		}
 
-		// Run the workflow
-		exitCode := e.runner.RunSingle(ctx, step.Workflow, storyKey)
-		if exitCode != 0 {
-			return fmt.Errorf("workflow failed: %s returned exit code %d", step.Workflow, exitCode)
+		if e.skipper != nil && e.skipper.ShouldSkip(storyKey, step.Workflow) {
+			fmt.Fprintf(e.Output(), "Skipping %s for story %s (story override)\n", step.Workflow, storyKey)
+		} else if e.storyFileExists(storyKey, currentStatus, step.Workflow) {
+			fmt.Fprintf(e.Output(), "Story file exists for %s, skipping create-story\n", storyKey)
+		} else {
+			// A branch point's verdict is the status it leaves in the file;
+			// note what the file held before, as it may differ from
+			// currentStatus under SetStartStatus
+			var found status.Status
+			if e.maxReviewLoops > 0 && e.isBranchPoint(step.Workflow) {
+				var err error
+				if found, err = e.statusReader.GetStoryStatus(storyKey); err != nil {
+					return err
+				}
+			}
+
+			// Run the workflow, retrying failed attempts if configured
+			maxRetries := e.retryPolicy.MaxRetries
+			exitCode := e.runWorkflow(ctx, storyKey, step.Workflow, step.Model)
+			for attempt := 1; exitCode != 0 && ctx.Err() == nil && attempt <= maxRetries; attempt++ {
+				delay := e.retryPolicy.Delay(attempt)
+				if delay > 0 {
+					fmt.Fprintf(e.Output(), "Retrying %s for story %s in %v (retry %d/%d)\n", step.Workflow, storyKey, delay, attempt, maxRetries)
+				} else {
+					fmt.Fprintf(e.Output(), "Retrying %s for story %s (retry %d/%d)\n", step.Workflow, storyKey, attempt, maxRetries)
+				}
+				if err := sleepContext(ctx, delay); err != nil {
+					return fmt.Errorf("workflow failed: %s retry canceled: %w", step.Workflow, err)
+				}
+
+				if e.progressCallback != nil {
+					e.progressCallback(i+1, totalSteps, fmt.Sprintf("%s (retry %d/%d)", step.Workflow, attempt, maxRetries))
+				}
+				e.resumeFailedSession()
+				exitCode = e.runWorkflow(ctx, storyKey, step.Workflow, step.Model)
+			}
+			// A canceled run leaves the status where it was
+			if err := ctx.Err(); err != nil {
+				return fmt.Errorf("workflow canceled: %s: %w", step.Workflow, err)
+			}
+			if exitCode != 0 {
+				return e.stepFailure(step.Workflow, exitCode, maxRetries)
+			}
+
+			// Follow a branch point that sent the story back
+			sentBack, err := e.sentBack(storyKey, found, currentStatus, step)
+			if err != nil {
+				return err
+			}
+			if sentBack != "" && e.singleStep {
+				fmt.Fprintf(e.Output(), "%s sent story %s back to %s\n", step.Workflow, storyKey, sentBack)
+				e.transitions = append(e.transitions, Transition{
+					Workflow: step.Workflow,
+					From:     currentStatus,
+					To:       sentBack,
+				})
+				if e.checkpoints != nil {
+					e.removeCheckpoint(storyKey)
+				}
+				return nil
+			}
+			if sentBack != "" {
+				reviewLoops++
+				if reviewLoops > e.maxReviewLoops {
+					return fmt.Errorf("review loop limit reached: %s sent story %s back to %s again (max_review_loops: %d)",
+						step.Workflow, storyKey, sentBack, e.maxReviewLoops)
+				}
+				loopSteps, err := e.getLifecycle(sentBack)
+				if err == nil {
+					loopSteps, err = e.stepsUntil(sentBack, loopSteps)
+				}
+				if err != nil {
+					return fmt.Errorf("%s sent story %s back to %s: %w", step.Workflow, storyKey, sentBack, err)
+				}
+				fmt.Fprintf(e.Output(), "%s sent story %s back to %s (review loop %d/%d)\n",
+					step.Workflow, storyKey, sentBack, reviewLoops, e.maxReviewLoops)
+
+				e.transitions = append(e.transitions, Transition{
+					Workflow: step.Workflow,
+					From:     currentStatus,
+					To:       sentBack,
+				})
+				currentStatus = sentBack
+
+				// The checkpoint would skip the steps that must now run again
+				if e.checkpoints != nil {
+					e.removeCheckpoint(storyKey)
+				}
+
+				steps = loopSteps
+				totalSteps = len(steps)
+				i = -1
+				continue
+			}
 		}
 
 		// Update status after successful workflow
 		if err := e.statusWriter.UpdateStatus(storyKey, step.NextStatus); err != nil {
 			return err
 		}
+		e.transitions = append(e.transitions, Transition{
+			Workflow: step.Workflow,
+			From:     currentStatus,
+			To:       step.NextStatus,
+		})
+		currentStatus = step.NextStatus
+		e.saveCheckpoint(storyKey, step.Workflow)
 	}
 
-	// If bmad-help bridged us from an unknown status, re-execute to continue
-	// the lifecycle from the new (hopefully recognized) status.
-	if usedBmadHelp {
-		return e.executeWithDepth(ctx, storyKey, depth+1)
+	// The story is finished once every step has run to done
+	if e.checkpoints != nil && currentStatus == status.StatusDone {
+		e.removeCheckpoint(storyKey)
 	}
 
 	return nil
 }
 
+// stepFailure returns the error for a workflow that failed with exitCode
+// after maxRetries retries, wrapping the cause the runner reports, if any
+// (see [FailureReporter]).
+func (e *Executor) stepFailure(workflow string, exitCode, maxRetries int) error {
+	err := fmt.Errorf("workflow failed: %s returned exit code %d", workflow, exitCode)
+	if maxRetries > 0 {
+		err = fmt.Errorf("workflow failed: %s returned exit code %d after %d retries", workflow, exitCode, maxRetries)
+	}
+	if reporter, ok := e.runner.(FailureReporter); ok {
+		if cause := reporter.LastError(); cause != nil {
+			return fmt.Errorf("%w: %w", err, cause)
+		}
+	}
+	return err
+}
+
+// sentBack reports the status a branch point step moved the story to, if it
+// is neither the status the step started from, the status found in the file
+// before the step, nor the step's NextStatus. It returns "" if review loops
+// are disabled, step is not a branch point, or the story was not sent back.
+func (e *Executor) sentBack(storyKey string, found, currentStatus status.Status, step router.LifecycleStep) (status.Status, error) {
+	if e.maxReviewLoops <= 0 || !e.isBranchPoint(step.Workflow) {
+		return "", nil
+	}
+
+	newStatus, err := e.statusReader.GetStoryStatus(storyKey)
+	if err != nil {
+		return "", err
+	}
+	if newStatus == currentStatus || newStatus == found || newStatus == step.NextStatus || newStatus == status.StatusDone {
+		return "", nil
+	}
+	return newStatus, nil
+}
+
+// saveCheckpoint records workflow as the story's last completed step.
+// Failures are reported as warnings since the status file remains the
+// source of truth.
+func (e *Executor) saveCheckpoint(storyKey, workflow string) {
+	if e.checkpoints == nil {
+		return
+	}
+
+	err := e.checkpoints.Save(state.Checkpoint{
+		StoryKey:    storyKey,
+		Workflow:    workflow,
+		CompletedAt: time.Now(),
+	})
+	if err != nil {
+		fmt.Fprintf(e.Output(), "Warning: %v\n", err)
+	}
+}
+
+// removeCheckpoint deletes the story's checkpoint, warning on failure.
+func (e *Executor) removeCheckpoint(storyKey string) {
+	if err := e.checkpoints.Remove(storyKey); err != nil {
+		fmt.Fprintf(e.Output(), "Warning: %v\n", err)
+	}
+}
+
+// runWorkflow runs a single attempt of a workflow, with model if it is set
+// and the runner supports it, notifying the [StepObserver] if one is set.
+func (e *Executor) runWorkflow(ctx context.Context, storyKey, workflow, model string) int {
+	if e.observer != nil {
+		e.observer.StepStarted(ctx, storyKey, workflow)
+	}
+	var exitCode int
+	if runner, ok := e.runner.(ModelRunner); ok && model != "" {
+		exitCode = runner.RunSingleWithModel(ctx, workflow, storyKey, model)
+	} else {
+		exitCode = e.runner.RunSingle(ctx, workflow, storyKey)
+	}
+	e.recordSession(storyKey, workflow, exitCode)
+	if e.observer != nil {
+		e.observer.StepFinished(ctx, storyKey, workflow, exitCode)
+	}
+	return exitCode
+}
+
+// recordSession saves the Claude session of the run that just finished, if
+// a [SessionRecorder] is set. Failures are reported as warnings.
+func (e *Executor) recordSession(storyKey, workflow string, exitCode int) {
+	runner, ok := e.runner.(SessionRunner)
+	if e.sessions == nil || !ok || runner.LastSessionID() == "" {
+		return
+	}
+
+	err := e.sessions.Save(state.Session{
+		StoryKey:  storyKey,
+		Workflow:  workflow,
+		SessionID: runner.LastSessionID(),
+		ExitCode:  exitCode,
+		UpdatedAt: time.Now(),
+	})
+	if err != nil {
+		fmt.Fprintf(e.Output(), "Warning: %v\n", err)
+	}
+}
+
+// resumeFailedSession makes the next run continue the session of the
+// attempt that just failed, if session resuming is enabled.
+func (e *Executor) resumeFailedSession() {
+	runner, ok := e.runner.(SessionRunner)
+	if !e.resumeSessions || !ok || runner.LastSessionID() == "" {
+		return
+	}
+	runner.SetResumeSession(runner.LastSessionID())
+}
+
+// storyFileExists reports whether a create-story step for a backlog story
+// can be skipped because its story file already exists.
+func (e *Executor) storyFileExists(storyKey string, currentStatus status.Status, workflow string) bool {
+	return e.storyFiles != nil &&
+		workflow == "create-story" &&
+		currentStatus == status.StatusBacklog &&
+		e.storyFiles.StoryFileExists(storyKey)
+}
+
 // GetSteps returns the remaining lifecycle steps for a story without executing them.
 //
 // GetSteps provides dry-run preview functionality, showing what workflows would execute
 // and what status transitions would occur. This is useful for displaying the planned
 // execution path before actually running workflows.
 //
+// Steps stop at the target set with [Executor.SetUntil], if any.
+//
 // Returns an error if status lookup fails. For stories already done, returns
 // [router.ErrStoryComplete].
 func (e *Executor) GetSteps(storyKey string) ([]router.LifecycleStep, error) {
 	// Get current story status
-	currentStatus, err := e.statusReader.GetStoryStatus(storyKey)
+	currentStatus, err := e.storyStatus(storyKey)
 	if err != nil {
 		return nil, err
 	}
@@ -240,5 +1022,5 @@ func (e *Executor) GetSteps(storyKey string) ([]router.LifecycleStep, error) {
 		return nil, err // Returns router.ErrStoryComplete for done stories
 	}
 
-	return steps, nil
+	return e.stepsUntil(currentStatus, steps)
 }
@@ -1,11 +1,17 @@
 package lifecycle
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"fmt"
+	"math"
 	"testing"
+	"time"
 
+	"bmaduum/internal/ratelimit"
 	"bmaduum/internal/router"
+	"bmaduum/internal/state"
 	"bmaduum/internal/status"
 
 	"github.com/stretchr/testify/assert"
@@ -569,3 +575,1008 @@ func TestGetSteps(t *testing.T) {
 		})
 	}
 }
+
+// mockStepSkipper implements StepSkipper for testing.
+type mockStepSkipper map[string][]string
+
+func (m mockStepSkipper) ShouldSkip(storyKey, workflowName string) bool {
+	for _, w := range m[storyKey] {
+		if w == workflowName {
+			return true
+		}
+	}
+	return false
+}
+
+func TestExecute_StepSkipper(t *testing.T) {
+	runner := &MockWorkflowRunner{}
+	reader := &MockStatusReader{
+		GetStoryStatusFunc: func(storyKey string) (status.Status, error) {
+			return status.StatusReview, nil
+		},
+	}
+	writer := &MockStatusWriter{}
+
+	executor := NewExecutor(runner, reader, writer)
+	executor.SetStepSkipper(mockStepSkipper{"special": {"code-review"}})
+
+	require.NoError(t, executor.Execute(context.Background(), "special"))
+	require.Len(t, runner.Calls, 1)
+	assert.Equal(t, "git-commit", runner.Calls[0].WorkflowName)
+	// Skipped step still applies its status transition
+	assert.Len(t, writer.Calls, 2)
+
+	// Stories without an override run every step
+	runner.Calls = nil
+	require.NoError(t, executor.Execute(context.Background(), "normal"))
+	assert.Len(t, runner.Calls, 2)
+}
+
+func TestExecute_Output(t *testing.T) {
+	failed := false
+	runner := &MockWorkflowRunner{
+		RunSingleFunc: func(ctx context.Context, workflowName, storyKey string) int {
+			if workflowName == "git-commit" && !failed {
+				failed = true
+				return 1
+			}
+			return 0
+		},
+	}
+	reader := &MockStatusReader{
+		GetStoryStatusFunc: func(storyKey string) (status.Status, error) {
+			return status.StatusReview, nil
+		},
+	}
+
+	var out bytes.Buffer
+	executor := NewExecutor(runner, reader, &MockStatusWriter{})
+	executor.SetStepSkipper(mockStepSkipper{"7-1": {"code-review"}})
+	executor.SetRetries(1)
+	executor.SetOutput(&out)
+
+	require.NoError(t, executor.Execute(context.Background(), "7-1"))
+	assert.Equal(t, "Skipping code-review for story 7-1 (story override)\n"+
+		"Retrying git-commit for story 7-1 (retry 1/1)\n", out.String())
+	assert.Same(t, &out, executor.Output())
+}
+
+// recordingObserver implements StepObserver for testing.
+type recordingObserver struct {
+	events []string
+}
+
+func (o *recordingObserver) StepStarted(ctx context.Context, storyKey, workflow string) {
+	o.events = append(o.events, "start "+workflow)
+}
+
+func (o *recordingObserver) StepFinished(ctx context.Context, storyKey, workflow string, exitCode int) {
+	o.events = append(o.events, fmt.Sprintf("finish %s %d", workflow, exitCode))
+}
+
+func TestExecute_StepObserver(t *testing.T) {
+	runner := &MockWorkflowRunner{
+		RunSingleFunc: func(ctx context.Context, workflowName, storyKey string) int {
+			if workflowName == "git-commit" {
+				return 2
+			}
+			return 0
+		},
+	}
+	reader := &MockStatusReader{
+		GetStoryStatusFunc: func(storyKey string) (status.Status, error) {
+			return status.StatusReadyForDev, nil
+		},
+	}
+	observer := &recordingObserver{}
+
+	executor := NewExecutor(runner, reader, &MockStatusWriter{})
+	executor.SetStepSkipper(mockStepSkipper{"7-1": {"code-review"}})
+	executor.SetStepObserver(observer)
+
+	err := executor.Execute(context.Background(), "7-1")
+	require.Error(t, err)
+
+	// Skipped steps are not reported; failed steps report their exit code
+	assert.Equal(t, []string{
+		"start dev-story", "finish dev-story 0",
+		"start git-commit", "finish git-commit 2",
+	}, observer.events)
+}
+
+func TestExecute_Retries(t *testing.T) {
+	reader := &MockStatusReader{
+		GetStoryStatusFunc: func(storyKey string) (status.Status, error) {
+			return status.StatusReadyForDev, nil
+		},
+	}
+
+	t.Run("failed steps are retried with a per-step budget", func(t *testing.T) {
+		// dev-story fails twice, code-review fails twice: each needs two of its three retries
+		failures := map[string]int{"dev-story": 2, "code-review": 2}
+		runner := &MockWorkflowRunner{
+			RunSingleFunc: func(ctx context.Context, workflowName, storyKey string) int {
+				if failures[workflowName] > 0 {
+					failures[workflowName]--
+					return 1
+				}
+				return 0
+			},
+		}
+		writer := &MockStatusWriter{}
+
+		var progress []string
+		executor := NewExecutor(runner, reader, writer)
+		executor.SetRetries(3)
+		executor.SetProgressCallback(func(stepIndex, totalSteps int, workflow string) {
+			progress = append(progress, fmt.Sprintf("%d/%d %s", stepIndex, totalSteps, workflow))
+		})
+
+		require.NoError(t, executor.Execute(context.Background(), "7-1"))
+		assert.Len(t, runner.Calls, 7)
+		assert.Equal(t, []string{
+			"1/3 dev-story",
+			"1/3 dev-story (retry 1/3)",
+			"1/3 dev-story (retry 2/3)",
+			"2/3 code-review",
+			"2/3 code-review (retry 1/3)",
+			"2/3 code-review (retry 2/3)",
+			"3/3 git-commit",
+		}, progress)
+
+		// Status advances once per step, only after the successful attempt
+		require.Len(t, writer.Calls, 3)
+		assert.Equal(t, status.StatusReview, writer.Calls[0].NewStatus)
+	})
+
+	t.Run("fails fast once retries are exhausted", func(t *testing.T) {
+		runner := &MockWorkflowRunner{
+			RunSingleFunc: func(ctx context.Context, workflowName, storyKey string) int {
+				return 1
+			},
+		}
+		writer := &MockStatusWriter{}
+
+		executor := NewExecutor(runner, reader, writer)
+		executor.SetRetries(2)
+
+		err := executor.Execute(context.Background(), "7-1")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "dev-story returned exit code 1 after 2 retries")
+		assert.Len(t, runner.Calls, 3)
+		assert.Empty(t, writer.Calls)
+	})
+}
+
+// failingRunner is a MockWorkflowRunner that reports why its runs failed.
+type failingRunner struct {
+	MockWorkflowRunner
+	err error
+}
+
+func (r *failingRunner) LastError() error {
+	return r.err
+}
+
+func TestExecute_FailureCause(t *testing.T) {
+	cause := &ratelimit.Error{Kind: ratelimit.ErrRateLimited, Message: "Claude AI usage limit reached"}
+	runner := &failingRunner{err: cause}
+	runner.RunSingleFunc = func(ctx context.Context, workflowName, storyKey string) int {
+		return 1
+	}
+	reader := &MockStatusReader{
+		GetStoryStatusFunc: func(storyKey string) (status.Status, error) {
+			return status.StatusReview, nil
+		},
+	}
+
+	executor := NewExecutor(runner, reader, &MockStatusWriter{})
+	err := executor.Execute(context.Background(), "7-1")
+
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ratelimit.ErrRateLimited)
+	assert.Equal(t, "workflow failed: code-review returned exit code 1: claude usage limit reached: Claude AI usage limit reached", err.Error())
+}
+
+func TestRetryPolicy_Delay(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  RetryPolicy
+		attempt int
+		want    time.Duration
+	}{
+		{name: "zero policy", policy: RetryPolicy{MaxRetries: 3}, attempt: 1, want: 0},
+		{name: "first retry uses base", policy: RetryPolicy{BaseDelay: time.Second, Multiplier: 2}, attempt: 1, want: time.Second},
+		{name: "exponential growth", policy: RetryPolicy{BaseDelay: time.Second, Multiplier: 2}, attempt: 4, want: 8 * time.Second},
+		{name: "capped at max", policy: RetryPolicy{BaseDelay: time.Second, Multiplier: 2, MaxDelay: 5 * time.Second}, attempt: 4, want: 5 * time.Second},
+		{name: "multiplier below one is constant", policy: RetryPolicy{BaseDelay: time.Second, Multiplier: 0}, attempt: 3, want: time.Second},
+		{name: "huge attempt saturates", policy: RetryPolicy{BaseDelay: time.Second, Multiplier: 10}, attempt: 100, want: time.Duration(math.MaxInt64)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.policy.Delay(tt.attempt))
+		})
+	}
+}
+
+func TestExecute_RetryPolicyBackoff(t *testing.T) {
+	var delays []time.Duration
+	originalSleep := sleepContext
+	sleepContext = func(ctx context.Context, d time.Duration) error {
+		delays = append(delays, d)
+		return nil
+	}
+	t.Cleanup(func() { sleepContext = originalSleep })
+
+	runner := &MockWorkflowRunner{
+		RunSingleFunc: func(ctx context.Context, workflowName, storyKey string) int {
+			return 1
+		},
+	}
+	reader := &MockStatusReader{
+		GetStoryStatusFunc: func(storyKey string) (status.Status, error) {
+			return status.StatusReview, nil
+		},
+	}
+
+	executor := NewExecutor(runner, reader, &MockStatusWriter{})
+	executor.SetRetryPolicy(RetryPolicy{
+		MaxRetries: 4,
+		BaseDelay:  100 * time.Millisecond,
+		Multiplier: 3,
+		MaxDelay:   time.Second,
+	})
+
+	require.Error(t, executor.Execute(context.Background(), "7-1"))
+	assert.Len(t, runner.Calls, 5)
+	assert.Equal(t, []time.Duration{
+		100 * time.Millisecond,
+		300 * time.Millisecond,
+		900 * time.Millisecond,
+		time.Second,
+	}, delays)
+}
+
+func TestExecute_RetryBackoffCanceled(t *testing.T) {
+	runner := &MockWorkflowRunner{
+		RunSingleFunc: func(ctx context.Context, workflowName, storyKey string) int {
+			return 1
+		},
+	}
+	reader := &MockStatusReader{
+		GetStoryStatusFunc: func(storyKey string) (status.Status, error) {
+			return status.StatusReview, nil
+		},
+	}
+
+	executor := NewExecutor(runner, reader, &MockStatusWriter{})
+	executor.SetRetryPolicy(RetryPolicy{MaxRetries: 3, BaseDelay: time.Hour})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+
+	start := time.Now()
+	err := executor.Execute(ctx, "7-1")
+
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Less(t, time.Since(start), 10*time.Second, "backoff wait should end on cancel")
+	assert.Len(t, runner.Calls, 1, "no retry after cancellation")
+}
+
+func TestExecute_CanceledDuringWorkflow(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	runner := &MockWorkflowRunner{
+		RunSingleFunc: func(ctx context.Context, workflowName, storyKey string) int {
+			// Ctrl-C kills Claude mid-step
+			cancel()
+			return 1
+		},
+	}
+	reader := &MockStatusReader{
+		GetStoryStatusFunc: func(storyKey string) (status.Status, error) {
+			return status.StatusReview, nil
+		},
+	}
+	writer := &MockStatusWriter{}
+
+	executor := NewExecutor(runner, reader, writer)
+	executor.SetRetryPolicy(RetryPolicy{MaxRetries: 3})
+
+	err := executor.Execute(ctx, "7-1")
+
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Contains(t, err.Error(), "workflow canceled: code-review")
+	assert.Len(t, runner.Calls, 1, "no retry after cancellation")
+	assert.Empty(t, writer.Calls, "status must not advance")
+}
+
+func TestExecute_Checkpoints(t *testing.T) {
+	store := state.NewCheckpointStore(t.TempDir())
+	var seen []string
+	runner := &MockWorkflowRunner{
+		RunSingleFunc: func(ctx context.Context, workflowName, storyKey string) int {
+			// Record the checkpoint left by the previous step
+			if cp, err := store.Load(storyKey); err == nil {
+				seen = append(seen, cp.Workflow)
+			}
+			return 0
+		},
+	}
+	reader := &MockStatusReader{
+		GetStoryStatusFunc: func(storyKey string) (status.Status, error) {
+			return status.StatusReadyForDev, nil
+		},
+	}
+
+	executor := NewExecutor(runner, reader, &MockStatusWriter{})
+	executor.SetCheckpointer(store)
+
+	require.NoError(t, executor.Execute(context.Background(), "7-1"))
+	assert.Equal(t, []string{"dev-story", "code-review"}, seen)
+
+	// Checkpoint is removed once the story is done
+	_, err := store.Load("7-1")
+	assert.ErrorIs(t, err, state.ErrNoCheckpoint)
+}
+
+func TestResume(t *testing.T) {
+	tests := []struct {
+		name       string
+		checkpoint *state.Checkpoint
+		expected   []string
+	}{
+		{
+			name:       "skips steps completed before the crash",
+			checkpoint: &state.Checkpoint{StoryKey: "7-1", Workflow: "dev-story"},
+			expected:   []string{"code-review", "git-commit"},
+		},
+		{
+			name:     "no checkpoint uses status routing",
+			expected: []string{"dev-story", "code-review", "git-commit"},
+		},
+		{
+			name:       "unknown workflow falls back to status routing",
+			checkpoint: &state.Checkpoint{StoryKey: "7-1", Workflow: "retired-workflow"},
+			expected:   []string{"dev-story", "code-review", "git-commit"},
+		},
+		{
+			name:       "checkpoint behind status is ignored",
+			checkpoint: &state.Checkpoint{StoryKey: "7-1", Workflow: "create-story"},
+			expected:   []string{"dev-story", "code-review", "git-commit"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := state.NewCheckpointStore(t.TempDir())
+			if tt.checkpoint != nil {
+				require.NoError(t, store.Save(*tt.checkpoint))
+			}
+
+			runner := &MockWorkflowRunner{}
+			// Status lags the checkpoint, as when a crash hits before the status write lands
+			reader := &MockStatusReader{
+				GetStoryStatusFunc: func(storyKey string) (status.Status, error) {
+					return status.StatusReadyForDev, nil
+				},
+			}
+
+			executor := NewExecutor(runner, reader, &MockStatusWriter{})
+			executor.SetCheckpointer(store)
+
+			require.NoError(t, executor.Resume(context.Background(), "7-1"))
+
+			var workflows []string
+			for _, call := range runner.Calls {
+				workflows = append(workflows, call.WorkflowName)
+			}
+			assert.Equal(t, tt.expected, workflows)
+
+			_, err := store.Load("7-1")
+			assert.ErrorIs(t, err, state.ErrNoCheckpoint, "checkpoint removed at done")
+		})
+	}
+}
+
+func TestRerun(t *testing.T) {
+	runner := &MockWorkflowRunner{}
+	reader := &MockStatusReader{
+		GetStoryStatusFunc: func(storyKey string) (status.Status, error) {
+			return status.StatusDone, nil
+		},
+	}
+	writer := &MockStatusWriter{}
+
+	executor := NewExecutor(runner, reader, writer)
+	require.NoError(t, executor.Rerun(context.Background(), "7-1"))
+
+	var workflows []string
+	for _, call := range runner.Calls {
+		workflows = append(workflows, call.WorkflowName)
+	}
+	assert.Equal(t, []string{"dev-story", "code-review", "git-commit"}, workflows)
+	assert.Equal(t, "done → review → done", FormatTransitions(executor.Transitions()))
+}
+
+func TestExecute_Until(t *testing.T) {
+	tests := []struct {
+		name              string
+		initialStatus     status.Status
+		until             status.Status
+		expectError       string
+		expectedWorkflows []string
+	}{
+		{
+			name:              "stops at review",
+			initialStatus:     status.StatusBacklog,
+			until:             status.StatusReview,
+			expectedWorkflows: []string{"create-story", "dev-story"},
+		},
+		{
+			name:              "done runs the whole lifecycle",
+			initialStatus:     status.StatusReadyForDev,
+			until:             status.StatusDone,
+			expectedWorkflows: []string{"dev-story", "code-review", "git-commit"},
+		},
+		{
+			name:          "already at the target",
+			initialStatus: status.StatusReview,
+			until:         status.StatusReview,
+		},
+		{
+			name:          "unreachable target",
+			initialStatus: status.StatusReview,
+			until:         status.StatusReadyForDev,
+			expectError:   "status ready-for-dev is not reachable from review",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner := &MockWorkflowRunner{}
+			reader := &MockStatusReader{
+				GetStoryStatusFunc: func(storyKey string) (status.Status, error) {
+					return tt.initialStatus, nil
+				},
+			}
+
+			executor := NewExecutor(runner, reader, &MockStatusWriter{})
+			executor.SetUntil(tt.until)
+
+			steps, stepsErr := executor.GetSteps("7-1")
+			err := executor.Execute(context.Background(), "7-1")
+			if tt.expectError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectError)
+				assert.EqualError(t, stepsErr, err.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.NoError(t, stepsErr)
+
+			var workflows, planned []string
+			for _, call := range runner.Calls {
+				workflows = append(workflows, call.WorkflowName)
+			}
+			for _, step := range steps {
+				planned = append(planned, step.Workflow)
+			}
+			assert.Equal(t, tt.expectedWorkflows, workflows)
+			assert.Equal(t, tt.expectedWorkflows, planned)
+		})
+	}
+}
+
+func TestExecute_StartStatus(t *testing.T) {
+	// sprint-status.yaml is out of sync: the story is actually in review
+	current := status.StatusBacklog
+	runner := &MockWorkflowRunner{}
+	reader := &MockStatusReader{
+		GetStoryStatusFunc: func(storyKey string) (status.Status, error) {
+			return current, nil
+		},
+	}
+	writer := &MockStatusWriter{
+		UpdateStatusFunc: func(storyKey string, newStatus status.Status) error {
+			current = newStatus
+			return nil
+		},
+	}
+
+	executor := NewExecutor(runner, reader, writer)
+	executor.SetStartStatus(status.StatusReview)
+	// An approving review leaves the stale status; it is not a send-back
+	executor.SetMaxReviewLoops(3)
+
+	steps, err := executor.GetSteps("7-1")
+	require.NoError(t, err)
+	require.Len(t, steps, 2)
+	assert.Equal(t, "code-review", steps[0].Workflow)
+
+	require.NoError(t, executor.Execute(context.Background(), "7-1"))
+
+	var workflows []string
+	for _, call := range runner.Calls {
+		workflows = append(workflows, call.WorkflowName)
+	}
+	assert.Equal(t, []string{"code-review", "git-commit"}, workflows)
+	assert.Equal(t, "review → done", FormatTransitions(executor.Transitions()))
+	assert.Equal(t, status.StatusDone, current)
+}
+
+func TestExecuteStep(t *testing.T) {
+	tests := []struct {
+		name             string
+		initialStatus    status.Status
+		expectedWorkflow string
+		expectedStatus   status.Status
+	}{
+		{"backlog runs create-story", status.StatusBacklog, "create-story", status.StatusReadyForDev},
+		{"ready-for-dev runs dev-story", status.StatusReadyForDev, "dev-story", status.StatusReview},
+		{"review runs code-review", status.StatusReview, "code-review", status.StatusDone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner := &MockWorkflowRunner{}
+			reader := &MockStatusReader{
+				GetStoryStatusFunc: func(storyKey string) (status.Status, error) {
+					return tt.initialStatus, nil
+				},
+			}
+			writer := &MockStatusWriter{}
+
+			executor := NewExecutor(runner, reader, writer)
+			require.NoError(t, executor.ExecuteStep(context.Background(), "7-1"))
+
+			require.Len(t, runner.Calls, 1)
+			assert.Equal(t, tt.expectedWorkflow, runner.Calls[0].WorkflowName)
+			require.Len(t, writer.Calls, 1)
+			assert.Equal(t, tt.expectedStatus, writer.Calls[0].NewStatus)
+		})
+	}
+}
+
+func TestExecuteStep_Done(t *testing.T) {
+	runner := &MockWorkflowRunner{}
+	reader := &MockStatusReader{
+		GetStoryStatusFunc: func(storyKey string) (status.Status, error) {
+			return status.StatusDone, nil
+		},
+	}
+
+	executor := NewExecutor(runner, reader, &MockStatusWriter{})
+	err := executor.ExecuteStep(context.Background(), "7-1")
+	assert.ErrorIs(t, err, router.ErrStoryComplete)
+	assert.Empty(t, runner.Calls)
+}
+
+func TestExecuteStep_SentBack(t *testing.T) {
+	current := status.StatusReview
+	runner := &MockWorkflowRunner{
+		RunSingleFunc: func(ctx context.Context, workflowName, storyKey string) int {
+			current = status.StatusInProgress
+			return 0
+		},
+	}
+	reader := &MockStatusReader{
+		GetStoryStatusFunc: func(storyKey string) (status.Status, error) {
+			return current, nil
+		},
+	}
+	writer := &MockStatusWriter{}
+
+	executor := NewExecutor(runner, reader, writer)
+	executor.SetMaxReviewLoops(3)
+	require.NoError(t, executor.ExecuteStep(context.Background(), "7-1"))
+
+	// The review's verdict is kept and dev-story is left for the next step
+	require.Len(t, runner.Calls, 1)
+	assert.Empty(t, writer.Calls)
+	assert.Equal(t, status.StatusInProgress, current)
+	assert.Equal(t, "review → in-progress", FormatTransitions(executor.Transitions()))
+}
+
+// mockStoryFiles implements StoryFileChecker for testing.
+type mockStoryFiles map[string]bool
+
+func (m mockStoryFiles) StoryFileExists(storyKey string) bool {
+	return m[storyKey]
+}
+
+func TestExecute_StoryFileExists(t *testing.T) {
+	tests := []struct {
+		name              string
+		startStatus       status.Status
+		storyFiles        mockStoryFiles
+		expectedWorkflows []string
+	}{
+		{
+			name:              "backlog story with existing file skips create-story",
+			startStatus:       status.StatusBacklog,
+			storyFiles:        mockStoryFiles{"STORY-1": true},
+			expectedWorkflows: []string{"dev-story", "code-review", "git-commit"},
+		},
+		{
+			name:              "backlog story without file runs create-story",
+			startStatus:       status.StatusBacklog,
+			storyFiles:        mockStoryFiles{},
+			expectedWorkflows: []string{"create-story", "dev-story", "code-review", "git-commit"},
+		},
+		{
+			name:              "no checker runs create-story",
+			startStatus:       status.StatusBacklog,
+			expectedWorkflows: []string{"create-story", "dev-story", "code-review", "git-commit"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner := &MockWorkflowRunner{}
+			reader := &MockStatusReader{
+				GetStoryStatusFunc: func(storyKey string) (status.Status, error) {
+					return tt.startStatus, nil
+				},
+			}
+			writer := &MockStatusWriter{}
+
+			executor := NewExecutor(runner, reader, writer)
+			if tt.storyFiles != nil {
+				executor.SetStoryFileChecker(tt.storyFiles)
+			}
+
+			require.NoError(t, executor.Execute(context.Background(), "STORY-1"))
+
+			var workflows []string
+			for _, call := range runner.Calls {
+				workflows = append(workflows, call.WorkflowName)
+			}
+			assert.Equal(t, tt.expectedWorkflows, workflows)
+			// The skipped step still advances the status to ready-for-dev
+			require.NotEmpty(t, writer.Calls)
+			assert.Equal(t, status.StatusReadyForDev, writer.Calls[0].NewStatus)
+			assert.Len(t, writer.Calls, 4)
+		})
+	}
+}
+
+func TestExecute_Transitions(t *testing.T) {
+	t.Run("transitions match writer updates", func(t *testing.T) {
+		runner := &MockWorkflowRunner{}
+		reader := &MockStatusReader{
+			GetStoryStatusFunc: func(storyKey string) (status.Status, error) {
+				return status.StatusBacklog, nil
+			},
+		}
+		writer := &MockStatusWriter{}
+
+		executor := NewExecutor(runner, reader, writer)
+		require.NoError(t, executor.Execute(context.Background(), "STORY-1"))
+
+		transitions := executor.Transitions()
+		require.Len(t, transitions, len(writer.Calls))
+		for i, call := range writer.Calls {
+			assert.Equal(t, call.NewStatus, transitions[i].To)
+			assert.Equal(t, runner.Calls[i].WorkflowName, transitions[i].Workflow)
+		}
+		assert.Equal(t, status.StatusBacklog, transitions[0].From)
+		assert.Equal(t, "backlog → ready-for-dev → review → done", FormatTransitions(transitions))
+	})
+
+	t.Run("bmad-help detour is included in the trace", func(t *testing.T) {
+		callCount := 0
+		reader := &MockStatusReader{
+			GetStoryStatusFunc: func(storyKey string) (status.Status, error) {
+				callCount++
+				if callCount == 1 {
+					return status.Status("pending-qa"), nil
+				}
+				return status.StatusReview, nil
+			},
+		}
+		runner := &MockWorkflowRunner{}
+		writer := &MockStatusWriter{}
+
+		executor := NewExecutor(runner, reader, writer)
+		executor.SetBmadHelp(&MockBmadHelpFallback{Workflow: "code-review", NextStatus: status.StatusReview})
+		require.NoError(t, executor.Execute(context.Background(), "STORY-1"))
+
+		require.Len(t, executor.Transitions(), len(writer.Calls))
+		assert.Equal(t, "pending-qa → review → done", FormatTransitions(executor.Transitions()))
+	})
+
+	t.Run("transitions reset between executions", func(t *testing.T) {
+		runner := &MockWorkflowRunner{}
+		reader := &MockStatusReader{
+			GetStoryStatusFunc: func(storyKey string) (status.Status, error) {
+				return status.StatusReview, nil
+			},
+		}
+		executor := NewExecutor(runner, reader, &MockStatusWriter{})
+
+		require.NoError(t, executor.Execute(context.Background(), "STORY-1"))
+		require.NoError(t, executor.Execute(context.Background(), "STORY-2"))
+		assert.Len(t, executor.Transitions(), 2)
+	})
+}
+
+func TestFormatTransitions_Empty(t *testing.T) {
+	assert.Equal(t, "", FormatTransitions(nil))
+}
+
+func TestExecute_ReviewLoops(t *testing.T) {
+	tests := []struct {
+		name              string
+		maxReviewLoops    int
+		rejections        int
+		expectError       bool
+		expectedWorkflows []string
+		expectedTrace     string
+	}{
+		{
+			name:              "disabled by default",
+			rejections:        1,
+			expectedWorkflows: []string{"code-review", "git-commit"},
+			expectedTrace:     "review → done",
+		},
+		{
+			name:              "approved review advances",
+			maxReviewLoops:    3,
+			expectedWorkflows: []string{"code-review", "git-commit"},
+			expectedTrace:     "review → done",
+		},
+		{
+			name:              "rejected review loops back to dev-story",
+			maxReviewLoops:    3,
+			rejections:        2,
+			expectedWorkflows: []string{"code-review", "dev-story", "code-review", "dev-story", "code-review", "git-commit"},
+			expectedTrace:     "review → in-progress → review → in-progress → review → done",
+		},
+		{
+			name:              "loop guard stops a review that never passes",
+			maxReviewLoops:    1,
+			rejections:        5,
+			expectError:       true,
+			expectedWorkflows: []string{"code-review", "dev-story", "code-review"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			current := status.StatusReview
+			rejections := tt.rejections
+			runner := &MockWorkflowRunner{
+				RunSingleFunc: func(ctx context.Context, workflowName, storyKey string) int {
+					// The review workflow sends the story back by writing its status
+					if workflowName == "code-review" && rejections > 0 {
+						rejections--
+						current = status.StatusInProgress
+					}
+					return 0
+				},
+			}
+			reader := &MockStatusReader{
+				GetStoryStatusFunc: func(storyKey string) (status.Status, error) {
+					return current, nil
+				},
+			}
+			writer := &MockStatusWriter{
+				UpdateStatusFunc: func(storyKey string, newStatus status.Status) error {
+					current = newStatus
+					return nil
+				},
+			}
+
+			executor := NewExecutor(runner, reader, writer)
+			executor.SetMaxReviewLoops(tt.maxReviewLoops)
+			err := executor.Execute(context.Background(), "STORY-1")
+			if tt.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "review loop limit reached")
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedTrace, FormatTransitions(executor.Transitions()))
+			}
+
+			var workflows []string
+			for _, call := range runner.Calls {
+				workflows = append(workflows, call.WorkflowName)
+			}
+			assert.Equal(t, tt.expectedWorkflows, workflows)
+		})
+	}
+}
+
+func TestExecute_MaxIterations(t *testing.T) {
+	tests := []struct {
+		name          string
+		maxIterations int
+		expectedRuns  int
+	}{
+		{name: "default limit stops the cycle", expectedRuns: DefaultMaxIterations},
+		{name: "custom limit", maxIterations: 2, expectedRuns: 2},
+		{name: "disabled guard defers to the review loop limit", maxIterations: -1, expectedRuns: 11},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// code-review always sends the story back
+			current := status.StatusInProgress
+			runner := &MockWorkflowRunner{
+				RunSingleFunc: func(ctx context.Context, workflowName, storyKey string) int {
+					if workflowName == "code-review" {
+						current = status.StatusInProgress
+					}
+					return 0
+				},
+			}
+			reader := &MockStatusReader{
+				GetStoryStatusFunc: func(storyKey string) (status.Status, error) {
+					return current, nil
+				},
+			}
+			writer := &MockStatusWriter{
+				UpdateStatusFunc: func(storyKey string, newStatus status.Status) error {
+					current = newStatus
+					return nil
+				},
+			}
+
+			executor := NewExecutor(runner, reader, writer)
+			executor.SetMaxReviewLoops(10)
+			if tt.maxIterations != 0 {
+				executor.SetMaxIterations(tt.maxIterations)
+			}
+
+			err := executor.Execute(context.Background(), "STORY-1")
+			require.Error(t, err)
+			if tt.maxIterations >= 0 {
+				assert.Contains(t, err.Error(), "story STORY-1: dev-story exceeded")
+			} else {
+				assert.Contains(t, err.Error(), "review loop limit reached")
+			}
+
+			devRuns := 0
+			for _, call := range runner.Calls {
+				if call.WorkflowName == "dev-story" {
+					devRuns++
+				}
+			}
+			assert.Equal(t, tt.expectedRuns, devRuns)
+		})
+	}
+}
+
+func TestExecute_MaxIterationsResetsBetweenExecutions(t *testing.T) {
+	reader := &MockStatusReader{
+		GetStoryStatusFunc: func(storyKey string) (status.Status, error) {
+			return status.StatusReview, nil
+		},
+	}
+	executor := NewExecutor(&MockWorkflowRunner{}, reader, &MockStatusWriter{})
+	executor.SetMaxIterations(1)
+
+	require.NoError(t, executor.Execute(context.Background(), "STORY-1"))
+	require.NoError(t, executor.Execute(context.Background(), "STORY-2"))
+}
+
+// sessionRunner is a MockWorkflowRunner that also implements SessionRunner,
+// starting a new numbered session for each run unless one is resumed.
+type sessionRunner struct {
+	MockWorkflowRunner
+	sessions int
+	last     string
+	resume   string
+	resumed  []string
+}
+
+func (r *sessionRunner) RunSingle(ctx context.Context, workflowName, storyKey string) int {
+	r.resumed = append(r.resumed, r.resume)
+	if r.resume != "" {
+		r.last, r.resume = r.resume, ""
+	} else {
+		r.sessions++
+		r.last = fmt.Sprintf("session-%d", r.sessions)
+	}
+	return r.MockWorkflowRunner.RunSingle(ctx, workflowName, storyKey)
+}
+
+func (r *sessionRunner) LastSessionID() string             { return r.last }
+func (r *sessionRunner) SetResumeSession(sessionID string) { r.resume = sessionID }
+
+// sessionLog records saved sessions.
+type sessionLog []state.Session
+
+func (l *sessionLog) Save(session state.Session) error {
+	*l = append(*l, session)
+	return nil
+}
+
+func TestExecute_ResumeSessionsOnRetry(t *testing.T) {
+	tests := []struct {
+		name        string
+		resume      bool
+		wantResumed []string
+	}{
+		{name: "new session per attempt", resume: false, wantResumed: []string{"", "", "", ""}},
+		{name: "retries resume failed session", resume: true, wantResumed: []string{"", "session-1", "session-1", ""}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			runner := &sessionRunner{MockWorkflowRunner: MockWorkflowRunner{
+				RunSingleFunc: func(ctx context.Context, workflowName, storyKey string) int {
+					if workflowName == "code-review" {
+						attempts++
+						if attempts < 3 {
+							return 1
+						}
+					}
+					return 0
+				},
+			}}
+			reader := &MockStatusReader{
+				GetStoryStatusFunc: func(storyKey string) (status.Status, error) {
+					return status.StatusReview, nil
+				},
+			}
+			var saved sessionLog
+
+			executor := NewExecutor(runner, reader, &MockStatusWriter{})
+			executor.SetRetries(2)
+			executor.SetResumeSessions(tt.resume)
+			executor.SetSessionRecorder(&saved)
+
+			require.NoError(t, executor.Execute(context.Background(), "7-1"))
+			assert.Equal(t, tt.wantResumed, runner.resumed)
+
+			require.Len(t, saved, 4, "every run is recorded")
+			assert.Equal(t, "code-review", saved[0].Workflow)
+			assert.Equal(t, 1, saved[0].ExitCode)
+			assert.Equal(t, "7-1", saved[3].StoryKey)
+			assert.Equal(t, "git-commit", saved[3].Workflow)
+			assert.Equal(t, runner.last, saved[3].SessionID)
+			assert.Zero(t, saved[3].ExitCode)
+		})
+	}
+}
+
+// modelRunner is a MockWorkflowRunner that also implements ModelRunner,
+// recording the model of each run ("" for RunSingle).
+type modelRunner struct {
+	MockWorkflowRunner
+	models []string
+}
+
+func (r *modelRunner) RunSingle(ctx context.Context, workflowName, storyKey string) int {
+	r.models = append(r.models, "")
+	return r.MockWorkflowRunner.RunSingle(ctx, workflowName, storyKey)
+}
+
+func (r *modelRunner) RunSingleWithModel(ctx context.Context, workflowName, storyKey, model string) int {
+	r.models = append(r.models, model)
+	return r.MockWorkflowRunner.RunSingle(ctx, workflowName, storyKey)
+}
+
+func TestExecute_StepModel(t *testing.T) {
+	runner := &modelRunner{}
+	executor := NewExecutor(runner, &MockStatusReader{}, &MockStatusWriter{})
+
+	err := executor.ExecutePlan(context.Background(), StoryPlan{
+		StoryKey:    "7-1-test",
+		StartStatus: status.StatusReadyForDev,
+		Steps: []router.LifecycleStep{
+			{Workflow: "dev-story", NextStatus: status.StatusReview, Model: "opus"},
+			{Workflow: "code-review", NextStatus: status.StatusDone},
+			{Workflow: "git-commit", NextStatus: status.StatusDone, Model: "haiku"},
+		},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"opus", "", "haiku"}, runner.models)
+	assert.Len(t, runner.Calls, 3)
+}
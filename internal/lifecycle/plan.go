@@ -0,0 +1,160 @@
+package lifecycle
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"bmaduum/internal/router"
+	"bmaduum/internal/status"
+)
+
+// PlanVersion is the current plan file format version.
+const PlanVersion = 1
+
+// StoryPlan is the resolved lifecycle for a single story.
+type StoryPlan struct {
+	// StoryKey is the story identifier (e.g., "7-1-define-schema").
+	StoryKey string `json:"story_key"`
+
+	// StartStatus is the story status when the plan was resolved.
+	StartStatus status.Status `json:"start_status"`
+
+	// Steps are the workflows to run, in order.
+	Steps []router.LifecycleStep `json:"steps"`
+}
+
+// Plan is a resolved lifecycle plan for one or more stories.
+//
+// A plan is built with [Executor.BuildPlan], persisted with [SavePlan], and
+// run with [Executor.ExecutePlan]. Executing a saved plan runs exactly the
+// recorded steps, even if sprint-status.yaml has changed since.
+type Plan struct {
+	// Version is the plan file format version.
+	Version int `json:"version"`
+
+	// Stories lists the per-story plans in execution order.
+	Stories []StoryPlan `json:"stories"`
+}
+
+// BuildPlan resolves the remaining lifecycle steps for each story.
+//
+// Stories that are already done are omitted from the plan. Returns an error
+// if any other story's steps cannot be resolved.
+func (e *Executor) BuildPlan(storyKeys []string) (*Plan, error) {
+	plan := &Plan{Version: PlanVersion}
+
+	for _, storyKey := range storyKeys {
+		currentStatus, err := e.storyStatus(storyKey)
+		if err != nil {
+			return nil, err
+		}
+
+		steps, err := e.getLifecycle(currentStatus)
+		if err != nil {
+			if errors.Is(err, router.ErrStoryComplete) {
+				continue
+			}
+			return nil, fmt.Errorf("story %s: %w", storyKey, err)
+		}
+		steps, err = e.stepsUntil(currentStatus, steps)
+		if err != nil {
+			return nil, fmt.Errorf("story %s: %w", storyKey, err)
+		}
+
+		plan.Stories = append(plan.Stories, StoryPlan{
+			StoryKey:    storyKey,
+			StartStatus: currentStatus,
+			Steps:       steps,
+		})
+	}
+
+	return plan, nil
+}
+
+// PlanSteps resolves an explicit subset of lifecycle workflows for a story.
+//
+// The workflows run in the given order regardless of the story's status.
+// Each step applies the status transition it has in the full lifecycle
+// chain. Returns an error if the story's status cannot be read or a
+// workflow is not part of the lifecycle chain.
+func (e *Executor) PlanSteps(storyKey string, workflows []string) (StoryPlan, error) {
+	currentStatus, err := e.storyStatus(storyKey)
+	if err != nil {
+		return StoryPlan{}, err
+	}
+
+	chain, err := e.getLifecycle(status.StatusBacklog)
+	if err != nil {
+		return StoryPlan{}, fmt.Errorf("cannot resolve lifecycle: %w", err)
+	}
+
+	storyPlan := StoryPlan{StoryKey: storyKey, StartStatus: currentStatus}
+	for _, workflow := range workflows {
+		step, ok := findStep(chain, workflow)
+		if !ok {
+			return StoryPlan{}, fmt.Errorf("workflow %s is not a lifecycle step", workflow)
+		}
+		storyPlan.Steps = append(storyPlan.Steps, step)
+	}
+
+	return storyPlan, nil
+}
+
+// findStep returns the step for workflow in steps, if present.
+func findStep(steps []router.LifecycleStep, workflow string) (router.LifecycleStep, bool) {
+	for _, step := range steps {
+		if step.Workflow == workflow {
+			return step, true
+		}
+	}
+	return router.LifecycleStep{}, false
+}
+
+// ExecutePlan runs a previously resolved [StoryPlan] without consulting the router.
+//
+// The recorded steps run in order with the same skipping, status updates,
+// progress reporting, and transition tracking as [Executor.Execute]. The
+// current status file is not used to recompute the steps.
+func (e *Executor) ExecutePlan(ctx context.Context, storyPlan StoryPlan) error {
+	e.startRun()
+	return e.runSteps(ctx, storyPlan.StoryKey, storyPlan.StartStatus, storyPlan.Steps)
+}
+
+// SavePlan writes plan to path as indented JSON.
+func SavePlan(path string, plan *Plan) error {
+	data, err := json.MarshalIndent(plan, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal plan: %w", err)
+	}
+
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write plan: %w", err)
+	}
+
+	return nil
+}
+
+// LoadPlan reads a plan previously written by [SavePlan].
+//
+// Returns an error if the file cannot be read or parsed, or if its version
+// is not supported.
+func LoadPlan(path string) (*Plan, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plan: %w", err)
+	}
+
+	var plan Plan
+	if err := json.Unmarshal(data, &plan); err != nil {
+		return nil, fmt.Errorf("failed to parse plan: %w", err)
+	}
+
+	if plan.Version != PlanVersion {
+		return nil, fmt.Errorf("unsupported plan version %d (expected %d)", plan.Version, PlanVersion)
+	}
+
+	return &plan, nil
+}
@@ -0,0 +1,184 @@
+package lifecycle
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"bmaduum/internal/router"
+	"bmaduum/internal/status"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildPlan(t *testing.T) {
+	reader := &MockStatusReader{
+		GetStoryStatusFunc: func(storyKey string) (status.Status, error) {
+			switch storyKey {
+			case "7-1-done":
+				return status.StatusDone, nil
+			case "7-2-review":
+				return status.StatusReview, nil
+			default:
+				return status.StatusReadyForDev, nil
+			}
+		},
+	}
+	executor := NewExecutor(&MockWorkflowRunner{}, reader, &MockStatusWriter{})
+
+	plan, err := executor.BuildPlan([]string{"7-1-done", "7-2-review", "7-3-ready"})
+
+	require.NoError(t, err)
+	assert.Equal(t, PlanVersion, plan.Version)
+	require.Len(t, plan.Stories, 2, "done stories should be omitted")
+	assert.Equal(t, "7-2-review", plan.Stories[0].StoryKey)
+	assert.Equal(t, status.StatusReview, plan.Stories[0].StartStatus)
+	assert.Equal(t, []router.LifecycleStep{
+		{Workflow: "code-review", NextStatus: status.StatusDone},
+		{Workflow: "git-commit", NextStatus: status.StatusDone},
+	}, plan.Stories[0].Steps)
+	assert.Equal(t, "7-3-ready", plan.Stories[1].StoryKey)
+	assert.Len(t, plan.Stories[1].Steps, 3)
+}
+
+func TestBuildPlan_UnknownStatus(t *testing.T) {
+	reader := &MockStatusReader{
+		GetStoryStatusFunc: func(storyKey string) (status.Status, error) {
+			return status.Status("pending-qa"), nil
+		},
+	}
+	executor := NewExecutor(&MockWorkflowRunner{}, reader, &MockStatusWriter{})
+
+	_, err := executor.BuildPlan([]string{"7-1-test"})
+
+	require.Error(t, err)
+	assert.ErrorIs(t, err, router.ErrUnknownStatus)
+}
+
+func TestSaveLoadPlan_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "plan.json")
+	plan := &Plan{
+		Version: PlanVersion,
+		Stories: []StoryPlan{{
+			StoryKey:    "7-1-test",
+			StartStatus: status.StatusInProgress,
+			Steps: []router.LifecycleStep{
+				{Workflow: "dev-story", NextStatus: status.StatusReview, Model: "opus"},
+				{Workflow: "code-review", NextStatus: status.StatusDone},
+			},
+		}},
+	}
+
+	require.NoError(t, SavePlan(path, plan))
+	loaded, err := LoadPlan(path)
+
+	require.NoError(t, err)
+	assert.Equal(t, plan, loaded)
+}
+
+func TestLoadPlan_Errors(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := LoadPlan(filepath.Join(dir, "missing.json"))
+	assert.Error(t, err)
+
+	invalid := filepath.Join(dir, "invalid.json")
+	require.NoError(t, os.WriteFile(invalid, []byte("{not json"), 0644))
+	_, err = LoadPlan(invalid)
+	assert.Error(t, err)
+
+	future := filepath.Join(dir, "future.json")
+	require.NoError(t, os.WriteFile(future, []byte(`{"version": 99, "stories": []}`), 0644))
+	_, err = LoadPlan(future)
+	assert.ErrorContains(t, err, "unsupported plan version")
+}
+
+func TestExecutePlan_IgnoresCurrentStatus(t *testing.T) {
+	// The status file has moved on since the plan was saved; the plan must
+	// still run exactly as recorded.
+	reader := &MockStatusReader{
+		GetStoryStatusFunc: func(storyKey string) (status.Status, error) {
+			return status.StatusDone, nil
+		},
+	}
+	runner := &MockWorkflowRunner{}
+	writer := &MockStatusWriter{}
+	executor := NewExecutor(runner, reader, writer)
+
+	path := filepath.Join(t.TempDir(), "plan.json")
+	require.NoError(t, SavePlan(path, &Plan{
+		Version: PlanVersion,
+		Stories: []StoryPlan{{
+			StoryKey:    "7-1-test",
+			StartStatus: status.StatusReview,
+			Steps: []router.LifecycleStep{
+				{Workflow: "code-review", NextStatus: status.StatusDone},
+				{Workflow: "git-commit", NextStatus: status.StatusDone},
+			},
+		}},
+	}))
+	plan, err := LoadPlan(path)
+	require.NoError(t, err)
+
+	err = executor.ExecutePlan(context.Background(), plan.Stories[0])
+
+	require.NoError(t, err)
+	require.Len(t, runner.Calls, 2)
+	assert.Equal(t, "code-review", runner.Calls[0].WorkflowName)
+	assert.Equal(t, "git-commit", runner.Calls[1].WorkflowName)
+	assert.Len(t, writer.Calls, 2)
+	assert.Equal(t, "review → done", FormatTransitions(executor.Transitions()))
+}
+
+func TestExecutePlan_StopsOnFailure(t *testing.T) {
+	runner := &MockWorkflowRunner{
+		RunSingleFunc: func(ctx context.Context, workflowName, storyKey string) int {
+			if workflowName == "code-review" {
+				return 1
+			}
+			return 0
+		},
+	}
+	writer := &MockStatusWriter{}
+	executor := NewExecutor(runner, &MockStatusReader{}, writer)
+
+	err := executor.ExecutePlan(context.Background(), StoryPlan{
+		StoryKey:    "7-1-test",
+		StartStatus: status.StatusInProgress,
+		Steps: []router.LifecycleStep{
+			{Workflow: "dev-story", NextStatus: status.StatusReview},
+			{Workflow: "code-review", NextStatus: status.StatusDone},
+			{Workflow: "git-commit", NextStatus: status.StatusDone},
+		},
+	})
+
+	require.Error(t, err)
+	assert.Len(t, runner.Calls, 2)
+	assert.Len(t, writer.Calls, 1)
+}
+
+func TestPlanSteps(t *testing.T) {
+	reader := &MockStatusReader{
+		GetStoryStatusFunc: func(storyKey string) (status.Status, error) {
+			return status.StatusReview, nil
+		},
+	}
+	executor := NewExecutor(&MockWorkflowRunner{}, reader, &MockStatusWriter{})
+
+	storyPlan, err := executor.PlanSteps("7-1-test", []string{"dev-story", "create-story"})
+
+	require.NoError(t, err)
+	assert.Equal(t, StoryPlan{
+		StoryKey:    "7-1-test",
+		StartStatus: status.StatusReview,
+		Steps: []router.LifecycleStep{
+			{Workflow: "dev-story", NextStatus: status.StatusReview},
+			{Workflow: "create-story", NextStatus: status.StatusReadyForDev},
+		},
+	}, storyPlan)
+
+	_, err = executor.PlanSteps("7-1-test", []string{"deploy"})
+	assert.EqualError(t, err, "workflow deploy is not a lifecycle step")
+}
@@ -14,19 +14,53 @@
 //	3,code-review,QA,/code-review,review,done
 //	3,git-commit,,/git-commit,,done
 //
+// Tab-separated manifests with the same columns, such as spreadsheet
+// exports, are also accepted.
+//
 // Rows are ordered by lifecycle execution sequence. A workflow may appear
 // multiple times with different trigger_status values (e.g., dev-story
 // triggers on both ready-for-dev and in-progress).
 package manifest
 
 import (
+	"bufio"
 	"encoding/csv"
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"strings"
+	"unicode"
 )
 
+// WorkflowManifestPath is the canonical location of the workflow manifest
+// relative to the project root.
+const WorkflowManifestPath = "_bmad/_cfg/workflow-manifest.csv"
+
+// ManifestPathEnvVar is the environment variable that overrides the
+// workflow manifest location.
+const ManifestPathEnvVar = "BMADUUM_MANIFEST_PATH"
+
+// ResolvePath returns the workflow manifest location.
+//
+// Resolution order:
+//  1. Explicit manifestPath parameter (if non-empty)
+//  2. BMADUUM_MANIFEST_PATH environment variable (used as-is if set)
+//  3. [WorkflowManifestPath] under basePath
+//
+// The basePath is the project root directory. Pass empty string for cwd.
+// The manifestPath is an explicit override (e.g., from the --manifest
+// flag). Pass empty string for the default.
+func ResolvePath(basePath, manifestPath string) string {
+	if manifestPath != "" {
+		return manifestPath
+	}
+	if envPath := os.Getenv(ManifestPathEnvVar); envPath != "" {
+		return envPath
+	}
+	return filepath.Join(basePath, WorkflowManifestPath)
+}
+
 // WorkflowEntry represents a single row in the workflow manifest CSV.
 //
 // Each entry describes a workflow with its BMAD metadata and routing information.
@@ -59,25 +93,58 @@ type Manifest struct {
 }
 
 // ReadFromFile reads and parses a workflow manifest CSV file.
+//
+// The delimiter is detected from the header line: a header with more tabs
+// than commas is read as tab-separated (TSV), as exported by spreadsheets;
+// anything else as comma-separated.
 func ReadFromFile(path string) (*Manifest, error) {
+	return ReadFromFileWithDelimiter(path, 0)
+}
+
+// ReadFromFileWithDelimiter is like [ReadFromFile], but splits fields on
+// delimiter. A zero delimiter is detected as in [ReadFromFile].
+func ReadFromFileWithDelimiter(path string, delimiter rune) (*Manifest, error) {
 	f, err := os.Open(path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open manifest: %w", err)
 	}
 	defer f.Close()
 
-	return readFromReader(f)
+	return readFromReader(f, delimiter)
 }
 
-// ReadFromString parses a workflow manifest from a CSV string.
+// ReadFromString parses a workflow manifest from a CSV string, detecting
+// the delimiter as in [ReadFromFile].
 // This is useful for testing and for embedding manifest data.
 func ReadFromString(data string) (*Manifest, error) {
-	return readFromReader(strings.NewReader(data))
+	return readFromReader(strings.NewReader(data), 0)
 }
 
-func readFromReader(r io.Reader) (*Manifest, error) {
+// detectDelimiter returns the field delimiter of a manifest whose header
+// line is header: a tab if it has more tabs than commas, else a comma.
+func detectDelimiter(header string) rune {
+	if strings.Count(header, "\t") > strings.Count(header, ",") {
+		return '\t'
+	}
+	return ','
+}
+
+func readFromReader(r io.Reader, delimiter rune) (*Manifest, error) {
+	if delimiter == 0 {
+		br := bufio.NewReader(r)
+		// Peek also returns what it could read, with an error, when the
+		// manifest is shorter than the buffer
+		peeked, _ := br.Peek(br.Size())
+		header, _, _ := strings.Cut(string(peeked), "\n")
+		delimiter = detectDelimiter(header)
+		r = br
+	}
+
 	reader := csv.NewReader(r)
-	reader.TrimLeadingSpace = true
+	reader.Comma = delimiter
+	// Trimming would also swallow a whitespace delimiter after an empty
+	// field; fields are trimmed by getField anyway
+	reader.TrimLeadingSpace = !unicode.IsSpace(delimiter)
 
 	// Read header
 	header, err := reader.Read()
@@ -193,3 +260,15 @@ func (m *Manifest) GetEntriesForStatus(triggerStatus string) []WorkflowEntry {
 	}
 	return entries
 }
+
+// GetEntriesForPhase returns all entries in the given BMAD phase (e.g., "3"
+// for Implementation), in manifest order.
+func (m *Manifest) GetEntriesForPhase(phase string) []WorkflowEntry {
+	var entries []WorkflowEntry
+	for _, e := range m.Entries {
+		if e.Phase == phase {
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
@@ -1,6 +1,7 @@
 package manifest
 
 import (
+	"os"
 	"path/filepath"
 	"testing"
 
@@ -29,6 +30,41 @@ func TestReadFromFile_Valid(t *testing.T) {
 	assert.Equal(t, "done", m.Entries[4].NextStatus)
 }
 
+func TestReadFromFile_TSV(t *testing.T) {
+	tsv, err := ReadFromFile(filepath.Join("testdata", "valid.tsv"))
+	require.NoError(t, err)
+	csv, err := ReadFromFile(filepath.Join("testdata", "valid.csv"))
+	require.NoError(t, err)
+
+	require.Len(t, tsv.Entries, 5)
+	assert.Equal(t, "QA, Lead", tsv.Entries[3].Agent, "commas inside TSV fields are kept")
+	tsv.Entries[3].Agent = "QA"
+	assert.Equal(t, csv, tsv)
+}
+
+func TestReadFromFileWithDelimiter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "manifest.txt")
+	data := "workflow;trigger_status;next_status\ndev-story;ready-for-dev;done\n"
+	require.NoError(t, os.WriteFile(path, []byte(data), 0644))
+
+	m, err := ReadFromFileWithDelimiter(path, ';')
+	require.NoError(t, err)
+	require.Len(t, m.Entries, 1)
+	assert.Equal(t, "dev-story", m.Entries[0].Workflow)
+	assert.Equal(t, "done", m.Entries[0].NextStatus)
+
+	// The tab-separated fixture read as comma-separated has no known columns
+	_, err = ReadFromFileWithDelimiter(filepath.Join("testdata", "valid.tsv"), ',')
+	assert.ErrorContains(t, err, "manifest missing required column")
+}
+
+func TestDetectDelimiter(t *testing.T) {
+	assert.Equal(t, ',', detectDelimiter("phase,workflow,trigger_status,next_status"))
+	assert.Equal(t, '\t', detectDelimiter("phase\tworkflow\ttrigger_status\tnext_status"))
+	assert.Equal(t, ',', detectDelimiter("workflow"), "comma is the default")
+	assert.Equal(t, '\t', detectDelimiter("workflow\ttrigger_status\tnext,status"))
+}
+
 func TestReadFromFile_Minimal(t *testing.T) {
 	m, err := ReadFromFile(filepath.Join("testdata", "minimal.csv"))
 
@@ -141,6 +177,23 @@ func TestManifest_HasWorkflow(t *testing.T) {
 	assert.False(t, m.HasWorkflow("nonexistent"))
 }
 
+func TestManifest_GetEntriesForPhase(t *testing.T) {
+	m, err := ReadFromString(`phase,workflow,trigger_status,next_status
+2,prd,backlog,backlog
+3,create-story,backlog,ready-for-dev
+3,dev-story,ready-for-dev,done
+`)
+	require.NoError(t, err)
+
+	entries := m.GetEntriesForPhase("3")
+	require.Len(t, entries, 2)
+	assert.Equal(t, "create-story", entries[0].Workflow)
+	assert.Equal(t, "dev-story", entries[1].Workflow)
+
+	assert.Len(t, m.GetEntriesForPhase("2"), 1)
+	assert.Empty(t, m.GetEntriesForPhase("4"))
+}
+
 func TestManifest_GetEntriesForStatus(t *testing.T) {
 	m, err := ReadFromFile(filepath.Join("testdata", "valid.csv"))
 	require.NoError(t, err)
@@ -181,3 +234,22 @@ func TestReadFromString_TrimsWhitespace(t *testing.T) {
 	assert.Equal(t, "SM", m.Entries[0].Agent)
 	assert.Equal(t, "backlog", m.Entries[0].TriggerStatus)
 }
+
+func TestResolvePath(t *testing.T) {
+	t.Setenv(ManifestPathEnvVar, "")
+
+	t.Run("falls back to canonical path", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		assert.Equal(t, filepath.Join(tmpDir, WorkflowManifestPath), ResolvePath(tmpDir, ""))
+	})
+
+	t.Run("environment override", func(t *testing.T) {
+		t.Setenv(ManifestPathEnvVar, "/custom/workflows.csv")
+		assert.Equal(t, "/custom/workflows.csv", ResolvePath(t.TempDir(), ""))
+	})
+
+	t.Run("explicit path wins", func(t *testing.T) {
+		t.Setenv(ManifestPathEnvVar, "/custom/workflows.csv")
+		assert.Equal(t, "flag.csv", ResolvePath(t.TempDir(), "flag.csv"))
+	})
+}
@@ -3,11 +3,47 @@ package manifest
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"sort"
 
 	"gopkg.in/yaml.v3"
 )
 
+// ModuleManifestPath is the canonical location of the BMAD module manifest
+// relative to the project root.
+const ModuleManifestPath = "_bmad/_config/manifest.yaml"
+
+// ModuleManifestPaths lists the paths to search (in priority order) when
+// auto-discovering the module manifest. The second entry covers installs
+// that keep all manifests alongside workflow-manifest.csv in _bmad/_cfg.
+var ModuleManifestPaths = []string{
+	ModuleManifestPath,
+	"_bmad/_cfg/manifest.yaml",
+}
+
+// ResolveModulePath discovers the module manifest location.
+//
+// Resolution order:
+//  1. BMADUUM_MODULE_MANIFEST_PATH environment variable (used as-is if set)
+//  2. Auto-discovery: the first of [ModuleManifestPaths] that exists under basePath
+//  3. Falls back to [ModuleManifestPath] (will error on read if the file doesn't exist)
+//
+// The basePath is the project root directory. Pass empty string for cwd.
+func ResolveModulePath(basePath string) string {
+	if envPath := os.Getenv("BMADUUM_MODULE_MANIFEST_PATH"); envPath != "" {
+		return envPath
+	}
+
+	for _, p := range ModuleManifestPaths {
+		fullPath := filepath.Join(basePath, p)
+		if _, err := os.Stat(fullPath); err == nil {
+			return fullPath
+		}
+	}
+
+	return filepath.Join(basePath, ModuleManifestPath)
+}
+
 // Module represents an installed BMAD module.
 type Module struct {
 	// Name is the module identifier (e.g., "bmm", "tea", "sdet", "bmgd", "cis").
@@ -18,15 +54,45 @@ type Module struct {
 
 	// Path is the module's relative path within the _bmad directory.
 	Path string `yaml:"path"`
+
+	// Injects lists lifecycle steps the module adds to the story lifecycle.
+	// If empty, built-in defaults for well-known modules apply.
+	Injects []StepInjection `yaml:"injects,omitempty"`
+}
+
+// StepInjection declares a lifecycle step added on behalf of a module.
+//
+// Exactly one of After and Before names the existing workflow the step is
+// positioned against.
+type StepInjection struct {
+	// Workflow is the workflow to inject.
+	Workflow string `yaml:"workflow"`
+
+	// After inserts the step after this workflow.
+	After string `yaml:"after,omitempty"`
+
+	// Before inserts the step before this workflow.
+	Before string `yaml:"before,omitempty"`
+
+	// NextStatus is the status set after the injected workflow completes.
+	// Defaults to "done" if empty.
+	NextStatus string `yaml:"next_status,omitempty"`
 }
 
 // moduleManifestFile represents the raw YAML structure of _bmad/_config/manifest.yaml.
 type moduleManifestFile struct {
+	Installation struct {
+		Version string `yaml:"version"`
+	} `yaml:"installation"`
 	Modules []Module `yaml:"modules"`
 }
 
 // ModuleManifest holds discovered BMAD modules.
 type ModuleManifest struct {
+	// Version is the installed BMAD version from the manifest's
+	// installation section (e.g., "6.0.0-alpha.0"), or empty if absent.
+	Version string
+
 	// Modules is the list of installed modules.
 	Modules []Module
 }
@@ -36,6 +102,8 @@ type ModuleManifest struct {
 // The expected file location is _bmad/_config/manifest.yaml relative to the
 // project root. The YAML format is:
 //
+//	installation:
+//	  version: "6.0.0"
 //	modules:
 //	  - name: bmm
 //	    version: "6.0.0"
@@ -43,6 +111,10 @@ type ModuleManifest struct {
 //	  - name: sdet
 //	    version: "1.0.0"
 //	    path: modules/sdet
+//	    injects:
+//	      - workflow: test-automation
+//	        after: code-review
+//	        next_status: done
 func ReadModulesFromFile(path string) (*ModuleManifest, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
@@ -63,14 +135,22 @@ func ReadModulesFromBytes(data []byte) (*ModuleManifest, error) {
 		return nil, fmt.Errorf("module manifest contains no modules")
 	}
 
-	// Validate each module has a name
+	// Validate each module has a name and well-formed injections
 	for i, m := range raw.Modules {
 		if m.Name == "" {
 			return nil, fmt.Errorf("module at index %d has no name", i)
 		}
+		for j, inj := range m.Injects {
+			if inj.Workflow == "" {
+				return nil, fmt.Errorf("module %s: injection at index %d has no workflow", m.Name, j)
+			}
+			if (inj.After == "") == (inj.Before == "") {
+				return nil, fmt.Errorf("module %s: injection %s must set exactly one of after or before", m.Name, inj.Workflow)
+			}
+		}
 	}
 
-	return &ModuleManifest{Modules: raw.Modules}, nil
+	return &ModuleManifest{Version: raw.Installation.Version, Modules: raw.Modules}, nil
 }
 
 // HasModule returns true if a module with the given name is installed.
@@ -94,6 +174,20 @@ func (mm *ModuleManifest) GetModule(name string) *Module {
 	return nil
 }
 
+// BMADVersion returns the installed BMAD version: the installation version,
+// else the version of the bmm or core module, else empty.
+func (mm *ModuleManifest) BMADVersion() string {
+	if mm.Version != "" {
+		return mm.Version
+	}
+	for _, name := range []string{"bmm", "core"} {
+		if m := mm.GetModule(name); m != nil && m.Version != "" {
+			return m.Version
+		}
+	}
+	return ""
+}
+
 // Names returns all installed module names in sorted order.
 func (mm *ModuleManifest) Names() []string {
 	names := make([]string, len(mm.Modules))
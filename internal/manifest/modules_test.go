@@ -1,6 +1,7 @@
 package manifest
 
 import (
+	"os"
 	"path/filepath"
 	"testing"
 
@@ -100,6 +101,25 @@ func TestReadModulesFromBytes(t *testing.T) {
 	assert.Equal(t, "sdet", mm.Modules[1].Name)
 }
 
+func TestModuleManifest_BMADVersion(t *testing.T) {
+	mm, err := ReadModulesFromBytes([]byte(`installation:
+  version: 6.0.0-alpha.0
+modules:
+  - name: bmm
+    version: "5.0.0"
+`))
+	require.NoError(t, err)
+	assert.Equal(t, "6.0.0-alpha.0", mm.BMADVersion())
+
+	mm, err = ReadModulesFromFile(filepath.Join("testdata", "modules_full.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, "6.0.0", mm.BMADVersion(), "falls back to the bmm module")
+
+	mm, err = ReadModulesFromBytes([]byte("modules:\n  - name: sdet\n"))
+	require.NoError(t, err)
+	assert.Empty(t, mm.BMADVersion())
+}
+
 func TestModuleManifest_HasModule(t *testing.T) {
 	mm, err := ReadModulesFromFile(filepath.Join("testdata", "modules_full.yaml"))
 	require.NoError(t, err)
@@ -142,3 +162,76 @@ func TestModuleManifest_Names_Minimal(t *testing.T) {
 	names := mm.Names()
 	assert.Equal(t, []string{"bmm"}, names)
 }
+
+func TestResolveModulePath(t *testing.T) {
+	t.Setenv("BMADUUM_MODULE_MANIFEST_PATH", "")
+
+	t.Run("falls back to canonical path", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		assert.Equal(t, filepath.Join(tmpDir, ModuleManifestPath), ResolveModulePath(tmpDir))
+	})
+
+	t.Run("discovers _cfg location", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		cfgPath := filepath.Join(tmpDir, "_bmad", "_cfg", "manifest.yaml")
+		require.NoError(t, os.MkdirAll(filepath.Dir(cfgPath), 0755))
+		require.NoError(t, os.WriteFile(cfgPath, []byte("modules: []"), 0644))
+
+		assert.Equal(t, cfgPath, ResolveModulePath(tmpDir))
+	})
+
+	t.Run("prefers canonical path", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		for _, p := range ModuleManifestPaths {
+			full := filepath.Join(tmpDir, p)
+			require.NoError(t, os.MkdirAll(filepath.Dir(full), 0755))
+			require.NoError(t, os.WriteFile(full, []byte("modules: []"), 0644))
+		}
+
+		assert.Equal(t, filepath.Join(tmpDir, ModuleManifestPath), ResolveModulePath(tmpDir))
+	})
+
+	t.Run("environment override", func(t *testing.T) {
+		t.Setenv("BMADUUM_MODULE_MANIFEST_PATH", "/custom/modules.yaml")
+		assert.Equal(t, "/custom/modules.yaml", ResolveModulePath(t.TempDir()))
+	})
+}
+
+func TestReadModulesFromBytes_Injects(t *testing.T) {
+	data := []byte(`modules:
+  - name: security
+    version: "1.0.0"
+    injects:
+      - workflow: security-review
+        before: git-commit
+        next_status: done
+`)
+	mm, err := ReadModulesFromBytes(data)
+
+	require.NoError(t, err)
+	require.Len(t, mm.Modules[0].Injects, 1)
+	assert.Equal(t, StepInjection{Workflow: "security-review", Before: "git-commit", NextStatus: "done"}, mm.Modules[0].Injects[0])
+}
+
+func TestReadModulesFromBytes_InvalidInjects(t *testing.T) {
+	tests := []struct {
+		name    string
+		inject  string
+		wantErr string
+	}{
+		{"no workflow", "after: code-review", "has no workflow"},
+		{"no anchor", "workflow: security-review", "exactly one of after or before"},
+		{"both anchors", "workflow: security-review\n        after: code-review\n        before: git-commit", "exactly one of after or before"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := []byte("modules:\n  - name: security\n    injects:\n      - " + tt.inject + "\n")
+			mm, err := ReadModulesFromBytes(data)
+
+			assert.Error(t, err)
+			assert.Nil(t, mm)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
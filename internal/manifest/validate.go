@@ -0,0 +1,160 @@
+package manifest
+
+import (
+	"fmt"
+	"strings"
+
+	"bmaduum/internal/status"
+)
+
+// Validate checks that the manifest describes a coherent status chain.
+//
+// Every entry must have a known next_status and, if set, a known
+// trigger_status. A trigger status may start only one workflow, each
+// next_status other than done must trigger a workflow so the story can
+// advance, and the final entry must leave the story done. The chain must
+// also be connected and acyclic; see [chainProblems]. Problems are returned
+// in manifest order; an empty result means the manifest is valid.
+func (m *Manifest) Validate() []error {
+	if len(m.Entries) == 0 {
+		return []error{fmt.Errorf("manifest contains no workflow entries")}
+	}
+
+	var problems []error
+
+	triggers := make(map[string]string)
+	for _, e := range m.Entries {
+		if e.TriggerStatus == "" {
+			continue
+		}
+		if !status.Status(e.TriggerStatus).IsValid() {
+			problems = append(problems, fmt.Errorf("workflow %s: unknown trigger_status %q", e.Workflow, e.TriggerStatus))
+			continue
+		}
+		if prev, ok := triggers[e.TriggerStatus]; ok && prev != e.Workflow {
+			problems = append(problems, fmt.Errorf("workflow %s: trigger_status %s already triggers %s", e.Workflow, e.TriggerStatus, prev))
+			continue
+		}
+		triggers[e.TriggerStatus] = e.Workflow
+	}
+
+	for _, e := range m.Entries {
+		switch {
+		case e.NextStatus == "":
+			problems = append(problems, fmt.Errorf("workflow %s: next_status is required", e.Workflow))
+		case !status.Status(e.NextStatus).IsValid():
+			problems = append(problems, fmt.Errorf("workflow %s: unknown next_status %q", e.Workflow, e.NextStatus))
+		case e.NextStatus != string(status.StatusDone) && triggers[e.NextStatus] == "":
+			problems = append(problems, fmt.Errorf("workflow %s: next_status %s does not trigger any workflow", e.Workflow, e.NextStatus))
+		}
+	}
+
+	if last := m.Entries[len(m.Entries)-1]; last.NextStatus != string(status.StatusDone) {
+		problems = append(problems, fmt.Errorf("workflow %s: last entry must set next_status to done", last.Workflow))
+	}
+
+	return append(problems, chainProblems(m.Entries)...)
+}
+
+// chainProblems checks the status graph in which each trigger_status leads
+// to its entry's next_status.
+//
+// Every trigger_status must be reachable from the first one: as the
+// next_status of a reachable status, or by lying between a reachable status
+// and its next_status in lifecycle order (a workflow that moves a story from
+// ready-for-dev to review passes through in-progress). The graph must also
+// have no cycles; review loops are configured with max_review_loops, not in
+// the manifest. Entries with unknown statuses are reported by
+// [Manifest.Validate] and ignored here.
+func chainProblems(entries []WorkflowEntry) []error {
+	order := make(map[status.Status]int)
+	for i, s := range status.ValidStatuses() {
+		order[s] = i
+	}
+
+	var start status.Status
+	var triggers []WorkflowEntry
+	edges := make(map[status.Status][]status.Status)
+	for _, e := range entries {
+		from, to := status.Status(e.TriggerStatus), status.Status(e.NextStatus)
+		if !from.IsValid() || !to.IsValid() {
+			continue
+		}
+		if start == "" {
+			start = from
+		}
+		if _, ok := edges[from]; !ok {
+			triggers = append(triggers, e)
+		}
+		edges[from] = append(edges[from], to)
+	}
+	if start == "" {
+		return nil
+	}
+
+	var problems []error
+
+	reached := map[status.Status]bool{start: true}
+	queue := []status.Status{start}
+	for len(queue) > 0 {
+		from := queue[0]
+		queue = queue[1:]
+		for _, to := range edges[from] {
+			passed := []status.Status{to}
+			if order[to] > order[from] {
+				passed = status.ValidStatuses()[order[from]+1 : order[to]+1]
+			}
+			for _, s := range passed {
+				if !reached[s] {
+					reached[s] = true
+					queue = append(queue, s)
+				}
+			}
+		}
+	}
+	for _, e := range triggers {
+		if !reached[status.Status(e.TriggerStatus)] {
+			problems = append(problems, fmt.Errorf("workflow %s: trigger_status %s is not reachable from %s", e.Workflow, e.TriggerStatus, start))
+		}
+	}
+
+	// Depth-first search; a status still on the path when it is reached
+	// again closes a cycle
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := make(map[status.Status]int)
+	var path []status.Status
+	var visit func(s status.Status)
+	visit = func(s status.Status) {
+		state[s] = visiting
+		path = append(path, s)
+		for _, next := range edges[s] {
+			switch state[next] {
+			case 0:
+				visit(next)
+			case visiting:
+				i := len(path) - 1
+				for path[i] != next {
+					i--
+				}
+				cycle := make([]string, 0, len(path)-i+1)
+				for _, c := range path[i:] {
+					cycle = append(cycle, string(c))
+				}
+				cycle = append(cycle, string(next))
+				problems = append(problems, fmt.Errorf("status cycle: %s", strings.Join(cycle, " -> ")))
+			}
+		}
+		path = path[:len(path)-1]
+		state[s] = visited
+	}
+	for _, e := range triggers {
+		if s := status.Status(e.TriggerStatus); state[s] == 0 {
+			visit(s)
+		}
+	}
+
+	return problems
+}
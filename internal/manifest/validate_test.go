@@ -0,0 +1,91 @@
+package manifest
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManifest_Validate_Valid(t *testing.T) {
+	for _, name := range []string{"valid.csv", "minimal.csv"} {
+		t.Run(name, func(t *testing.T) {
+			m, err := ReadFromFile(filepath.Join("testdata", name))
+			require.NoError(t, err)
+
+			assert.Empty(t, m.Validate())
+		})
+	}
+}
+
+func TestManifest_Validate_Problems(t *testing.T) {
+	m, err := ReadFromString(`workflow,trigger_status,next_status
+create-story,backlog,ready-for-dev
+dev-story,ready-for-dev,testing
+qa,ready-for-dev,review
+code-review,reviewing,
+git-commit,,review
+`)
+	require.NoError(t, err)
+
+	var messages []string
+	for _, problem := range m.Validate() {
+		messages = append(messages, problem.Error())
+	}
+
+	assert.Equal(t, []string{
+		"workflow qa: trigger_status ready-for-dev already triggers dev-story",
+		`workflow code-review: unknown trigger_status "reviewing"`,
+		`workflow dev-story: unknown next_status "testing"`,
+		"workflow qa: next_status review does not trigger any workflow",
+		"workflow code-review: next_status is required",
+		"workflow git-commit: next_status review does not trigger any workflow",
+		"workflow git-commit: last entry must set next_status to done",
+	}, messages)
+}
+
+func TestManifest_Validate_Unreachable(t *testing.T) {
+	m, err := ReadFromString(`workflow,trigger_status,next_status
+dev-story,ready-for-dev,review
+code-review,review,done
+triage,backlog,done
+`)
+	require.NoError(t, err)
+
+	problems := m.Validate()
+	require.Len(t, problems, 1)
+	assert.EqualError(t, problems[0], "workflow triage: trigger_status backlog is not reachable from ready-for-dev")
+}
+
+func TestManifest_Validate_PassedThroughStatusIsReachable(t *testing.T) {
+	m, err := ReadFromString(`workflow,trigger_status,next_status
+create-story,backlog,ready-for-dev
+dev-story,ready-for-dev,done
+resume,in-progress,done
+`)
+	require.NoError(t, err)
+
+	assert.Empty(t, m.Validate())
+}
+
+func TestManifest_Validate_Cycle(t *testing.T) {
+	m, err := ReadFromString(`workflow,trigger_status,next_status
+dev-story,ready-for-dev,review
+code-review,review,in-progress
+fix,in-progress,review
+git-commit,,done
+`)
+	require.NoError(t, err)
+
+	problems := m.Validate()
+	require.Len(t, problems, 1)
+	assert.EqualError(t, problems[0], "status cycle: review -> in-progress -> review")
+}
+
+func TestManifest_Validate_Empty(t *testing.T) {
+	problems := (&Manifest{}).Validate()
+
+	require.Len(t, problems, 1)
+	assert.Contains(t, problems[0].Error(), "no workflow entries")
+}
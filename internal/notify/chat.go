@@ -0,0 +1,127 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// chat posts a readable message to a chat service's incoming webhook when a
+// story fails or a run finishes. Other events are ignored.
+type chat struct {
+	service string
+	url     string
+	// field is the JSON field of the webhook payload that holds the message.
+	field string
+	// limit is the longest message the service accepts, in bytes.
+	limit  int
+	client *http.Client
+}
+
+// Send posts the message for event, if it is reported.
+//
+// Returns an error if the request fails or the response status is not 2xx.
+// The error names the webhook's host only, never the token in its path.
+func (c *chat) Send(ctx context.Context, event Event) error {
+	text := message(event)
+	if text == "" {
+		return nil
+	}
+	if len(text) > c.limit {
+		cut := c.limit - len("…")
+		for cut > 0 && !utf8.RuneStart(text[cut]) {
+			cut--
+		}
+		text = text[:cut] + "…"
+	}
+	return postJSON(ctx, c.client, c.url, c.service+" message", map[string]string{c.field: text})
+}
+
+// Slack is a [Notifier] that posts to a Slack incoming webhook when a story
+// fails or a run finishes.
+//
+// Create instances using [NewSlack].
+type Slack struct {
+	chat
+}
+
+// NewSlack creates a [Slack] notifier posting to the incoming webhook url.
+func NewSlack(url string) *Slack {
+	return &Slack{chat{service: "Slack", url: url, field: "text", limit: 40000, client: &http.Client{Timeout: DefaultTimeout}}}
+}
+
+// Discord is a [Notifier] that posts to a Discord webhook when a story fails
+// or a run finishes.
+//
+// Create instances using [NewDiscord].
+type Discord struct {
+	chat
+}
+
+// NewDiscord creates a [Discord] notifier posting to the webhook url.
+func NewDiscord(url string) *Discord {
+	return &Discord{chat{service: "Discord", url: url, field: "content", limit: 2000, client: &http.Client{Timeout: DefaultTimeout}}}
+}
+
+// message formats event for a chat service, or returns "" if the event is
+// not reported there.
+func message(event Event) string {
+	switch event.Type {
+	case EventStoryFailed:
+		var b strings.Builder
+		fmt.Fprintf(&b, "❌ bmaduum: story %s failed", event.StoryKey)
+		if event.Workflow != "" {
+			fmt.Fprintf(&b, " at %s (exit code %d)", event.Workflow, event.ExitCode)
+		}
+		if event.Error != "" {
+			fmt.Fprintf(&b, ": %s", event.Error)
+		}
+		return b.String()
+	case EventRunFinished:
+		return runMessage(event)
+	}
+	return ""
+}
+
+// runMessage summarizes a run_finished event: the story counts, the run's
+// duration, and each story that did not complete.
+func runMessage(event Event) string {
+	counts := make(map[string]int)
+	for _, story := range event.Stories {
+		counts[story.Outcome]++
+	}
+
+	var b strings.Builder
+	switch {
+	case counts["cancelled"] > 0:
+		b.WriteString("⚠️ bmaduum run cancelled")
+	case !event.Success:
+		b.WriteString("❌ bmaduum run failed")
+	default:
+		b.WriteString("✅ bmaduum run finished")
+	}
+	duration := (time.Duration(event.DurationMS) * time.Millisecond).Round(time.Second)
+	fmt.Fprintf(&b, " after %s: %d completed", duration, counts["success"])
+	if counts["skipped"] > 0 {
+		fmt.Fprintf(&b, ", %d skipped", counts["skipped"])
+	}
+	fmt.Fprintf(&b, ", %d failed", counts["failed"])
+	if counts["cancelled"] > 0 {
+		fmt.Fprintf(&b, ", %d cancelled", counts["cancelled"])
+	}
+	fmt.Fprintf(&b, ", %d remaining", len(event.Remaining))
+
+	for _, story := range event.Stories {
+		switch story.Outcome {
+		case "failed", "cancelled":
+			fmt.Fprintf(&b, "\n• %s %s", story.StoryKey, story.Outcome)
+			if story.Error != "" {
+				fmt.Fprintf(&b, ": %s", story.Error)
+			}
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,135 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessage(t *testing.T) {
+	tests := []struct {
+		name  string
+		event Event
+		want  string
+	}{
+		{
+			name:  "step events are not reported",
+			event: Event{Type: EventStepFinished, StoryKey: "6-1-setup", Workflow: "dev-story"},
+			want:  "",
+		},
+		{
+			name:  "story failed in a step",
+			event: Event{Type: EventStoryFailed, StoryKey: "6-1-setup", Workflow: "dev-story", ExitCode: 1, Error: "workflow failed"},
+			want:  "❌ bmaduum: story 6-1-setup failed at dev-story (exit code 1): workflow failed",
+		},
+		{
+			name:  "story failed outside a step",
+			event: Event{Type: EventStoryFailed, StoryKey: "6-1-setup", Error: "uncommitted changes"},
+			want:  "❌ bmaduum: story 6-1-setup failed: uncommitted changes",
+		},
+		{
+			name: "run succeeded",
+			event: Event{Type: EventRunFinished, Success: true, DurationMS: 125400, Stories: []StoryOutcome{
+				{StoryKey: "6-1-setup", Outcome: "skipped"},
+				{StoryKey: "6-2-api", Outcome: "success"},
+			}},
+			want: "✅ bmaduum run finished after 2m5s: 1 completed, 1 skipped, 0 failed, 0 remaining",
+		},
+		{
+			name: "run failed",
+			event: Event{Type: EventRunFinished, DurationMS: 3000, Remaining: []string{"6-3-ui", "6-4-docs"}, Stories: []StoryOutcome{
+				{StoryKey: "6-1-setup", Outcome: "success"},
+				{StoryKey: "6-2-api", Outcome: "failed", Error: "dev-story exited 1"},
+			}},
+			want: "❌ bmaduum run failed after 3s: 1 completed, 1 failed, 2 remaining\n• 6-2-api failed: dev-story exited 1",
+		},
+		{
+			name: "run cancelled",
+			event: Event{Type: EventRunFinished, Stories: []StoryOutcome{
+				{StoryKey: "6-1-setup", Outcome: "cancelled"},
+			}},
+			want: "⚠️ bmaduum run cancelled after 0s: 0 completed, 0 failed, 1 cancelled, 0 remaining\n• 6-1-setup cancelled",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, message(tt.event))
+		})
+	}
+}
+
+func TestChat_Send(t *testing.T) {
+	tests := []struct {
+		name     string
+		notifier func(url string) Notifier
+		field    string
+	}{
+		{"slack", func(url string) Notifier { return NewSlack(url) }, "text"},
+		{"discord", func(url string) Notifier { return NewDiscord(url) }, "content"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var bodies []map[string]string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var body map[string]string
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				bodies = append(bodies, body)
+			}))
+			defer server.Close()
+
+			notifier := tt.notifier(server.URL)
+			require.NoError(t, notifier.Send(context.Background(), Event{Type: EventStepFinished}))
+			require.NoError(t, notifier.Send(context.Background(), Event{Type: EventStoryFailed, StoryKey: "6-1-setup"}))
+
+			require.Len(t, bodies, 1)
+			assert.Equal(t, "❌ bmaduum: story 6-1-setup failed", bodies[0][tt.field])
+		})
+	}
+}
+
+func TestChat_SendErrorRedactsToken(t *testing.T) {
+	tests := []struct {
+		name     string
+		notifier func(url string) Notifier
+		path     string
+	}{
+		{"slack", func(url string) Notifier { return NewSlack(url) }, "/services/T000/B000/XXXXSECRETXXXX"},
+		{"discord", func(url string) Notifier { return NewDiscord(url) }, "/api/webhooks/1234/XXXXSECRETXXXX"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+			}))
+			defer server.Close()
+
+			err := tt.notifier(server.URL+tt.path).Send(context.Background(), Event{Type: EventStoryFailed, StoryKey: "6-1-setup"})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "404 Not Found")
+			assert.NotContains(t, err.Error(), tt.path)
+			assert.NotContains(t, err.Error(), "XXXXSECRETXXXX")
+		})
+	}
+}
+
+func TestDiscord_SendTruncatesLongMessages(t *testing.T) {
+	var body map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+	}))
+	defer server.Close()
+
+	event := Event{Type: EventStoryFailed, StoryKey: "6-1-setup", Error: strings.Repeat("x", 3000)}
+	require.NoError(t, NewDiscord(server.URL).Send(context.Background(), event))
+	assert.LessOrEqual(t, len(body["content"]), 2000)
+	assert.True(t, strings.HasSuffix(body["content"], "…"))
+}
@@ -0,0 +1,132 @@
+// Package notify reports lifecycle progress to external services.
+//
+// A [Notifier] receives an [Event] after each workflow step, when a story
+// fails, and when a run finishes. [Webhook] POSTs every event as JSON to a
+// configured URL so dashboards can follow bmaduum runs; the payload carries
+// [SchemaVersion], and fields are only ever added within a version. [Slack]
+// and [Discord] post a readable message when a story fails or a run
+// finishes.
+//
+// Key types:
+//   - [Notifier] - Receives lifecycle events
+//   - [Webhook] - Sends events to a webhook URL as JSON
+//   - [Slack], [Discord] - Send messages to chat incoming webhooks
+//   - [Event] - One lifecycle event
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// SchemaVersion is the version of the [Event] payload. It changes only when
+// a field is removed or its meaning changes.
+const SchemaVersion = 1
+
+// Event types, reported in [Event.Type].
+const (
+	// EventStepFinished is sent after each workflow step.
+	EventStepFinished = "step_finished"
+
+	// EventStoryFailed is sent when a story fails.
+	EventStoryFailed = "story_failed"
+
+	// EventRunFinished is sent when a story or epic run finishes.
+	EventRunFinished = "run_finished"
+)
+
+// DefaultTimeout bounds each request to a notification service.
+const DefaultTimeout = 10 * time.Second
+
+// Notifier receives lifecycle events. Implementations may ignore event
+// types they do not report.
+type Notifier interface {
+	// Send delivers event. Returns an error if delivery fails.
+	Send(ctx context.Context, event Event) error
+}
+
+// Event is one lifecycle event.
+//
+// Step fields are set for [EventStepFinished], and for [EventStoryFailed]
+// when the story failed in a step. Stories and Remaining are set for
+// [EventRunFinished].
+type Event struct {
+	SchemaVersion int       `json:"schema_version"`
+	Type          string    `json:"type"`
+	Time          time.Time `json:"time"`
+
+	StoryKey   string `json:"story_key,omitempty"`
+	Workflow   string `json:"workflow,omitempty"`
+	FromStatus string `json:"from_status,omitempty"`
+	ToStatus   string `json:"to_status,omitempty"`
+	ExitCode   int    `json:"exit_code"`
+	Error      string `json:"error,omitempty"`
+
+	Success    bool  `json:"success"`
+	DurationMS int64 `json:"duration_ms"`
+
+	Stories   []StoryOutcome `json:"stories,omitempty"`
+	Remaining []string       `json:"remaining,omitempty"`
+}
+
+// StoryOutcome is the result of one story in an [EventRunFinished] event:
+// "success", "skipped", "failed", or "cancelled".
+type StoryOutcome struct {
+	StoryKey string `json:"story_key"`
+	Outcome  string `json:"outcome"`
+	Error    string `json:"error,omitempty"`
+}
+
+// postJSON POSTs body as JSON to rawURL. The request is not canceled with
+// ctx, so the event for an interrupted run is still delivered.
+//
+// Returns an error naming what was sent if the request fails or the
+// response status is not 2xx. Webhook URLs carry credentials, so the error
+// names only the URL's scheme and host.
+func postJSON(ctx context.Context, client *http.Client, rawURL, what string, body any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", what, err)
+	}
+	req, err := http.NewRequestWithContext(context.WithoutCancel(ctx), http.MethodPost, rawURL, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to send %s: %w", what, stripURL(err))
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send %s to %s: %w", what, redactURL(rawURL), stripURL(err))
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("failed to send %s: %s returned %s", what, redactURL(rawURL), resp.Status)
+	}
+	return nil
+}
+
+// redactURL returns the scheme and host of rawURL, leaving out the path and
+// query where webhook tokens are kept.
+func redactURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return "webhook"
+	}
+	return u.Scheme + "://" + u.Host
+}
+
+// stripURL returns the cause of a [url.Error], which quotes the full URL, or
+// err itself otherwise.
+func stripURL(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return urlErr.Err
+	}
+	return err
+}
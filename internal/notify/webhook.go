@@ -0,0 +1,33 @@
+package notify
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// Webhook is a [Notifier] that POSTs every event as JSON to a URL.
+//
+// Create instances using [NewWebhook].
+type Webhook struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhook creates a [Webhook] posting to url, with requests bounded by
+// [DefaultTimeout].
+func NewWebhook(url string) *Webhook {
+	return &Webhook{url: url, client: &http.Client{Timeout: DefaultTimeout}}
+}
+
+// Send POSTs event as JSON, filling in its schema version and, if unset,
+// its time.
+//
+// Returns an error if the request fails or the response status is not 2xx.
+func (w *Webhook) Send(ctx context.Context, event Event) error {
+	event.SchemaVersion = SchemaVersion
+	if event.Time.IsZero() {
+		event.Time = time.Now().UTC()
+	}
+	return postJSON(ctx, w.client, w.url, event.Type+" event", event)
+}
@@ -0,0 +1,90 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhook_Send(t *testing.T) {
+	var got map[string]any
+	var contentType string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentType = r.Header.Get("Content-Type")
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+	}))
+	defer server.Close()
+
+	err := NewWebhook(server.URL).Send(context.Background(), Event{
+		Type:       EventStepFinished,
+		StoryKey:   "6-1-setup",
+		Workflow:   "dev-story",
+		FromStatus: "ready-for-dev",
+		ToStatus:   "review",
+		Success:    true,
+		DurationMS: 1500,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "application/json", contentType)
+	assert.Equal(t, float64(SchemaVersion), got["schema_version"])
+	assert.Equal(t, "step_finished", got["type"])
+	assert.NotEmpty(t, got["time"])
+	assert.Equal(t, "6-1-setup", got["story_key"])
+	assert.Equal(t, "ready-for-dev", got["from_status"])
+	assert.Equal(t, "review", got["to_status"])
+	assert.Equal(t, true, got["success"])
+	assert.Equal(t, float64(1500), got["duration_ms"])
+	assert.NotContains(t, got, "stories")
+}
+
+func TestWebhook_Send_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	err := NewWebhook(server.URL).Send(context.Background(), Event{Type: EventRunFinished})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to send run_finished event")
+	assert.Contains(t, err.Error(), "500 Internal Server Error")
+}
+
+func TestWebhook_Send_ErrorRedactsURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+	secretURL := server.URL + "/hooks/s3cr3t-token?key=abc"
+
+	err := NewWebhook(secretURL).Send(context.Background(), Event{Type: EventRunFinished})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), server.URL+" returned 403 Forbidden")
+	assert.NotContains(t, err.Error(), "s3cr3t-token")
+	assert.NotContains(t, err.Error(), "key=abc")
+
+	// Connection errors do not quote the URL either
+	server.Close()
+	err = NewWebhook(secretURL).Send(context.Background(), Event{Type: EventRunFinished})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to send run_finished event to "+server.URL)
+	assert.NotContains(t, err.Error(), "s3cr3t-token")
+}
+
+func TestWebhook_Send_CanceledContext(t *testing.T) {
+	received := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = true
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.NoError(t, NewWebhook(server.URL).Send(ctx, Event{Type: EventRunFinished}))
+	assert.True(t, received)
+}
@@ -27,11 +27,38 @@ type StepResult struct {
 
 // StoryResult represents the result of processing a story in queue or epic operations.
 type StoryResult struct {
-	Key      string
-	Success  bool
-	Duration time.Duration
-	FailedAt string
-	Skipped  bool
+	Key       string
+	Success   bool
+	Duration  time.Duration
+	FailedAt  string
+	Skipped   bool
+	Cancelled bool
+	CostUSD   float64
+
+	// BlockedBy is the dependency whose failure kept the story from running.
+	BlockedBy string
+}
+
+// Usage represents the token usage and cost of one or more Claude sessions.
+// Zero values mean Claude did not report the figure.
+type Usage struct {
+	InputTokens  int
+	OutputTokens int
+	CostUSD      float64
+}
+
+// Add returns the sum of u and other.
+func (u Usage) Add(other Usage) Usage {
+	return Usage{
+		InputTokens:  u.InputTokens + other.InputTokens,
+		OutputTokens: u.OutputTokens + other.OutputTokens,
+		CostUSD:      u.CostUSD + other.CostUSD,
+	}
+}
+
+// IsZero reports whether no usage was recorded.
+func (u Usage) IsZero() bool {
+	return u == Usage{}
 }
 
 // ToolParams contains parameters for a tool invocation.
@@ -83,7 +110,7 @@ type ToolParams struct {
 //   - Text and formatting (Text, Divider)
 //   - Cycle operations (CycleHeader, CycleSummary, CycleFailed)
 //   - Queue operations (QueueHeader, QueueStoryStart, QueueSummary)
-//   - Command operations (CommandHeader, CommandFooter)
+//   - Command operations (CommandHeader, CommandFooter, CommandUsage, CommandFiles)
 type Printer interface {
 	SessionStart()
 	SessionEnd(duration time.Duration, success bool)
@@ -101,4 +128,6 @@ type Printer interface {
 	QueueSummary(results []StoryResult, allKeys []string, totalDuration time.Duration)
 	CommandHeader(label, prompt string, truncateLength int)
 	CommandFooter(duration time.Duration, success bool, exitCode int)
+	CommandUsage(step, total Usage)
+	CommandFiles(changed, created []string)
 }
@@ -6,6 +6,8 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+
+	"bmaduum/internal/output/terminal"
 )
 
 // Renderer renders diffs with rich formatting including background colors,
@@ -18,6 +20,7 @@ type Renderer struct {
 	showGutter    bool   // Whether to show gutter markers (┃) for changes
 	maxContentLen int    // Maximum content length per line (0 = no limit)
 	highlighter   HighlightFunc // Optional syntax highlighter function
+	color         bool   // Whether to render with colors
 	// Styles
 	summaryStyle      lipgloss.Style
 	contextLineStyle  lipgloss.Style
@@ -61,6 +64,14 @@ func WithMaxContentLength(n int) Option {
 	}
 }
 
+// WithColor overrides whether the diff is rendered with colors, which by
+// default depends on the terminal.
+func WithColor(enabled bool) Option {
+	return func(r *Renderer) {
+		r.color = enabled
+	}
+}
+
 // WithHighlighter sets a custom syntax highlighter function.
 func WithHighlighter(h HighlightFunc) Option {
 	return func(r *Renderer) {
@@ -69,7 +80,8 @@ func WithHighlighter(h HighlightFunc) Option {
 }
 
 // NewRenderer creates a renderer with the given options.
-// Default configuration: line numbers enabled, gutter enabled, no syntax highlighting.
+// Default configuration: line numbers enabled, gutter enabled, no syntax
+// highlighting, and colors if the terminal supports them.
 func NewRenderer(opts ...Option) *Renderer {
 	r := &Renderer{
 		lineNumWidth:  4,
@@ -77,6 +89,7 @@ func NewRenderer(opts ...Option) *Renderer {
 		syntaxHL:      false,
 		showGutter:    true,
 		maxContentLen: 0,
+		color:         supportsColor(),
 	}
 	for _, opt := range opts {
 		opt(r)
@@ -87,7 +100,7 @@ func NewRenderer(opts ...Option) *Renderer {
 
 // setStyles configures the lipgloss styles based on terminal capabilities.
 func (r *Renderer) setStyles() {
-	if !supportsColor() {
+	if !r.color {
 		// No colors - use plain styles
 		r.summaryStyle = lipgloss.NewStyle()
 		r.contextLineStyle = lipgloss.NewStyle()
@@ -112,18 +125,14 @@ func (r *Renderer) setStyles() {
 		Bold(true)
 }
 
-// supportsColor checks if the terminal supports color output.
+// supportsColor checks if the terminal supports color output: stdout must
+// be a terminal, colors must not be turned off with NO_COLOR or --no-color,
+// and TERM must be set to something other than "dumb".
 func supportsColor() bool {
-	// Check if NO_COLOR environment variable is set
-	if v := strings.ToLower(getEnv("NO_COLOR", "")); v != "" && v != "0" {
-		return false
-	}
-	// Check if TERM is set to a known value
-	term := getEnv("TERM", "")
-	if term == "dumb" || term == "" {
+	if !terminal.SupportsColor() {
 		return false
 	}
-	return true
+	return getEnv("TERM", "") != ""
 }
 
 // getEnv gets an environment variable with a fallback value.
@@ -146,7 +155,7 @@ func (r *Renderer) Render(diff *Diff) string {
 		return ""
 	}
 
-	if !supportsColor() {
+	if !r.color {
 		return r.renderPlain(diff)
 	}
 
@@ -258,13 +267,7 @@ func (r *Renderer) renderLine(buf *strings.Builder, line *Line) {
 	}
 
 	// Apply syntax highlighting to content if enabled
-	content := line.Content
-	if r.maxContentLen > 0 {
-		runes := []rune(content)
-		if len(runes) > r.maxContentLen {
-			content = string(runes[:r.maxContentLen-3]) + "..."
-		}
-	}
+	content := r.truncate(line.Content)
 	if r.syntaxHL && r.language != "" {
 		content = r.highlightCode(content, r.language)
 	}
@@ -319,8 +322,12 @@ func (r *Renderer) renderHunkSeparator() string {
 func (r *Renderer) renderPlain(diff *Diff) string {
 	var buf strings.Builder
 
-	for _, hunk := range diff.Hunks {
+	for i, hunk := range diff.Hunks {
+		if i > 0 {
+			buf.WriteString("...\n")
+		}
 		for _, line := range hunk.Lines {
+			content := r.truncate(line.Content)
 			var marker string
 			switch line.Type {
 			case LineTypeAdded:
@@ -338,9 +345,9 @@ func (r *Renderer) renderPlain(diff *Diff) string {
 				} else {
 					lineNum = line.NewLineNum
 				}
-				buf.WriteString(fmt.Sprintf("%*d %s %s\n", r.lineNumWidth, lineNum, marker, line.Content))
+				buf.WriteString(fmt.Sprintf("%*d %s %s\n", r.lineNumWidth, lineNum, marker, content))
 			} else {
-				buf.WriteString(fmt.Sprintf("%s %s\n", marker, line.Content))
+				buf.WriteString(fmt.Sprintf("%s %s\n", marker, content))
 			}
 		}
 	}
@@ -348,6 +355,17 @@ func (r *Renderer) renderPlain(diff *Diff) string {
 	return buf.String()
 }
 
+// truncate shortens content to the maximum content length, if set.
+func (r *Renderer) truncate(content string) string {
+	if r.maxContentLen > 0 {
+		runes := []rune(content)
+		if len(runes) > r.maxContentLen {
+			return string(runes[:r.maxContentLen-3]) + "..."
+		}
+	}
+	return content
+}
+
 // applyDiffStyle applies background and optional foreground colors to content.
 // It injects the style codes at the start and after each reset sequence
 // to ensure the styling persists through syntax highlighting color changes.
@@ -246,6 +246,24 @@ func TestRenderer_renderPlain(t *testing.T) {
 	assert.NotContains(t, output, "\x1b[")
 }
 
+func TestRenderer_Render_NoColor(t *testing.T) {
+	diff := &Diff{
+		Hunks: []Hunk{
+			{Lines: []Line{{Type: LineTypeAdded, Content: "first", NewLineNum: 1}}},
+			{Lines: []Line{{Type: LineTypeDeleted, Content: strings.Repeat("x", 100), OldLineNum: 9}}},
+		},
+	}
+
+	renderer := NewRenderer(WithColor(false), WithMaxContentLength(10))
+	output := renderer.Render(diff)
+
+	assert.NotContains(t, output, "\x1b[")
+	assert.NotContains(t, output, "┃")
+	assert.Contains(t, output, "+ first")
+	assert.Contains(t, output, "\n...\n")
+	assert.Contains(t, output, "- xxxxxxx...")
+}
+
 func TestRenderer_renderHunkSeparator(t *testing.T) {
 	t.Run("with gutter", func(t *testing.T) {
 		renderer := NewRenderer(WithGutter(true))
@@ -571,7 +589,7 @@ func TestRenderer_RenderWithGutter(t *testing.T) {
 		},
 	}
 
-	renderer := NewRenderer(WithGutter(true))
+	renderer := NewRenderer(WithGutter(true), WithColor(true))
 	output := renderer.Render(diff)
 
 	// Should have gutter marker for added line
@@ -0,0 +1,178 @@
+package output
+
+import (
+	"io"
+	"os"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Encoding selects the character set used for terminal output.
+type Encoding string
+
+// Encoding values accepted by the output.encoding config option.
+const (
+	// EncodingAuto detects the encoding from the locale environment.
+	EncodingAuto Encoding = "auto"
+
+	// EncodingUTF8 writes box-drawing characters, glyphs, and emoji as-is.
+	EncodingUTF8 Encoding = "utf8"
+
+	// EncodingASCII substitutes ASCII equivalents for box-drawing characters
+	// and glyphs, and strips emoji.
+	EncodingASCII Encoding = "ascii"
+)
+
+// ResolveEncoding maps an output.encoding setting to a concrete [Encoding].
+//
+// "ascii" and "utf8" (or "utf-8") are used as-is. Anything else, including
+// "auto" and the empty string, falls back to [DetectEncoding].
+func ResolveEncoding(setting string) Encoding {
+	switch strings.ToLower(setting) {
+	case "ascii":
+		return EncodingASCII
+	case "utf8", "utf-8":
+		return EncodingUTF8
+	default:
+		return DetectEncoding()
+	}
+}
+
+// DetectEncoding infers whether the output can display UTF-8.
+//
+// The first non-empty of LC_ALL, LC_CTYPE, and LANG is inspected. A locale
+// naming UTF-8 selects [EncodingUTF8]; any other explicit locale (e.g. "C",
+// "POSIX", "en_US.ISO-8859-1") selects [EncodingASCII]. With no locale set,
+// UTF-8 is assumed.
+func DetectEncoding() Encoding {
+	for _, name := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		locale := os.Getenv(name)
+		if locale == "" {
+			continue
+		}
+		lower := strings.ToLower(locale)
+		if strings.Contains(lower, "utf-8") || strings.Contains(lower, "utf8") {
+			return EncodingUTF8
+		}
+		return EncodingASCII
+	}
+	return EncodingUTF8
+}
+
+// asciiReplacements maps the non-ASCII characters used by the renderers to
+// ASCII equivalents.
+var asciiReplacements = map[rune]string{
+	// Box drawing
+	'─': "-", '━': "-", '═': "=",
+	'│': "|", '┃': "|", '║': "|",
+	'┌': "+", '┐': "+", '└': "+", '┘': "+",
+	'╭': "+", '╮': "+", '╰': "+", '╯': "+",
+	'├': "+", '┤': "+", '┬': "+", '┴': "+", '┼': "+",
+	'⎿': "L",
+
+	// Status glyphs
+	'✓': "v", '✔': "v", '✗': "x", '✘': "x",
+	'○': "o", '●': "*", '⏺': "*", '•': "*", '·': ".",
+	'▸': ">", '▶': ">", '❯': ">", '›': ">",
+	'⚡': "!", '⚠': "!", '⏱': "t", '⏸': "||",
+
+	// Arrows and punctuation
+	'→': "->", '←': "<-", '↓': "v", '↑': "^", '↻': "@", '↷': "~>",
+	'…': "...", '–': "-", '—': "-",
+	'‘': "'", '’': "'", '“': "\"", '”': "\"",
+}
+
+// ToASCII converts s to pure ASCII.
+//
+// Known box-drawing characters and glyphs are substituted, emoji and other
+// symbols are stripped, and any remaining non-ASCII character becomes "?".
+func ToASCII(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	for _, r := range s {
+		b.WriteString(asciiRune(r))
+	}
+	return b.String()
+}
+
+// asciiRune returns the ASCII representation of a single rune.
+func asciiRune(r rune) string {
+	if r < utf8.RuneSelf {
+		return string(r)
+	}
+	if repl, ok := asciiReplacements[r]; ok {
+		return repl
+	}
+	if isEmoji(r) {
+		return ""
+	}
+	return "?"
+}
+
+// isEmoji reports whether r is an emoji, a pictographic symbol, or an emoji
+// modifier (variation selectors, zero-width joiner, skin tones).
+func isEmoji(r rune) bool {
+	switch {
+	case r == 0x200d, r >= 0xfe00 && r <= 0xfe0f:
+		return true
+	case r >= 0x1f000 && r <= 0x1faff:
+		return true
+	case r >= 0x2600 && r <= 0x27bf:
+		return true
+	default:
+		return unicode.Is(unicode.So, r)
+	}
+}
+
+// asciiWriter converts everything written through it with [ToASCII].
+//
+// Incomplete UTF-8 sequences at the end of a write are held until the next
+// write so multi-byte characters split across writes are converted correctly.
+type asciiWriter struct {
+	w       io.Writer
+	pending []byte
+}
+
+// NewASCIIWriter returns a writer that converts output to pure ASCII
+// before writing it to w.
+func NewASCIIWriter(w io.Writer) io.Writer {
+	return &asciiWriter{w: w}
+}
+
+// Write converts p to ASCII and writes it to the underlying writer.
+// It reports len(p) on success since all of p was consumed.
+func (a *asciiWriter) Write(p []byte) (int, error) {
+	data := append(a.pending, p...)
+	a.pending = nil
+
+	// Hold back a trailing partial rune
+	end := len(data)
+	for i := len(data) - 1; i >= 0 && i >= len(data)-utf8.UTFMax; i-- {
+		if utf8.RuneStart(data[i]) {
+			if !utf8.FullRune(data[i:]) {
+				end = i
+			}
+			break
+		}
+	}
+	a.pending = append([]byte(nil), data[end:]...)
+
+	if _, err := io.WriteString(a.w, ToASCII(string(data[:end]))); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// NewPrinterWithEncoding creates a new [DefaultPrinter] for the given
+// [Encoding] that renders Claude's text with the markdown configuration cfg.
+//
+// With [EncodingASCII], all output passes through [NewASCIIWriter] and
+// markdown emoji rendering is disabled. Other encodings write output as-is.
+func NewPrinterWithEncoding(w io.Writer, enc Encoding, cfg MarkdownConfig) *DefaultPrinter {
+	if enc == EncodingASCII {
+		cfg.Emoji = false
+		w = NewASCIIWriter(w)
+	}
+	return NewPrinterWithConfig(w, cfg)
+}
@@ -0,0 +1,123 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"bmaduum/internal/output/core"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// assertASCII fails if s contains any non-ASCII byte.
+func assertASCII(t *testing.T, s string) {
+	t.Helper()
+	for i := 0; i < len(s); i++ {
+		if s[i] >= 0x80 {
+			t.Fatalf("non-ASCII byte 0x%x at offset %d in %q", s[i], i, s)
+		}
+	}
+}
+
+func TestResolveEncoding(t *testing.T) {
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_CTYPE", "")
+	t.Setenv("LANG", "en_US.UTF-8")
+
+	assert.Equal(t, EncodingASCII, ResolveEncoding("ascii"))
+	assert.Equal(t, EncodingUTF8, ResolveEncoding("utf8"))
+	assert.Equal(t, EncodingUTF8, ResolveEncoding("UTF-8"))
+	assert.Equal(t, EncodingUTF8, ResolveEncoding("auto"))
+	assert.Equal(t, EncodingUTF8, ResolveEncoding(""))
+}
+
+func TestDetectEncoding(t *testing.T) {
+	tests := []struct {
+		name     string
+		lcAll    string
+		lcCtype  string
+		lang     string
+		expected Encoding
+	}{
+		{"utf-8 lang", "", "", "en_US.UTF-8", EncodingUTF8},
+		{"utf8 lowercase", "", "", "C.utf8", EncodingUTF8},
+		{"posix locale", "", "", "C", EncodingASCII},
+		{"latin1 locale", "", "", "en_US.ISO-8859-1", EncodingASCII},
+		{"LC_ALL wins", "C", "", "en_US.UTF-8", EncodingASCII},
+		{"LC_CTYPE before LANG", "", "en_US.UTF-8", "C", EncodingUTF8},
+		{"no locale", "", "", "", EncodingUTF8},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("LC_ALL", tt.lcAll)
+			t.Setenv("LC_CTYPE", tt.lcCtype)
+			t.Setenv("LANG", tt.lang)
+
+			assert.Equal(t, tt.expected, DetectEncoding())
+		})
+	}
+}
+
+func TestToASCII(t *testing.T) {
+	assert.Equal(t, "+--+", ToASCII("╭──╮"))
+	assert.Equal(t, "v done x failed o pending", ToASCII("✓ done ✗ failed ○ pending"))
+	assert.Equal(t, "a -> b...", ToASCII("a → b…"))
+	assert.Equal(t, "hello  world", ToASCII("hello 🌍 world"))
+	assert.Equal(t, "ok ", ToASCII("ok 👍🏽"))
+	assert.Equal(t, "??", ToASCII("世界"))
+}
+
+func TestASCIIWriter_SplitRunes(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewASCIIWriter(&buf)
+
+	data := []byte("a→b")
+	// Split the 3-byte arrow across writes
+	n, err := w.Write(data[:2])
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	_, err = w.Write(data[2:])
+	require.NoError(t, err)
+
+	assert.Equal(t, "a->b", buf.String())
+}
+
+func TestNewPrinterWithEncoding_ASCII(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewPrinterWithEncoding(&buf, EncodingASCII, DefaultMarkdownConfig())
+
+	p.SessionStart()
+	p.CommandHeader("dev-story", "/dev-story 1-1 → implement", 60)
+	p.ToolUse(core.ToolParams{Name: "Bash", Description: "List files ✓", Command: "ls"})
+	p.ToolResult("file.go\n✓ done\n", "warning ⚠", 20)
+	p.Text("Done! 🎉 Box: ╭─╮ and 世界")
+	p.CommandFooter(2*time.Second, true, 0)
+	p.CycleSummary("1-1-test", []core.StepResult{
+		{Name: "create-story", Duration: time.Second, Success: true},
+		{Name: "dev-story", Duration: time.Second, Success: false},
+	}, 2*time.Second)
+	p.QueueSummary([]core.StoryResult{
+		{Key: "1-1", Success: true, Duration: time.Second},
+		{Key: "1-2", Success: false, Duration: time.Second, FailedAt: "dev-story"},
+	}, []string{"1-1", "1-2", "1-3"}, 2*time.Second)
+	p.SessionEnd(2*time.Second, false)
+
+	out := buf.String()
+	require.NotEmpty(t, out)
+	assertASCII(t, out)
+	assert.Contains(t, out, "1-1-test")
+}
+
+func TestNewPrinterWithEncoding_UTF8(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewPrinterWithEncoding(&buf, EncodingUTF8, DefaultMarkdownConfig())
+
+	p.CycleSummary("1-1-test", []core.StepResult{
+		{Name: "create-story", Duration: time.Second, Success: true},
+	}, time.Second)
+
+	assert.Contains(t, buf.String(), "✓")
+}
@@ -0,0 +1,285 @@
+package output
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	"bmaduum/internal/output/core"
+)
+
+// JSON event types written by [JSONPrinter], one per [core.Printer] method
+// plus the transition and exit events.
+const (
+	EventSessionStart    = "session_start"
+	EventSessionEnd      = "session_end"
+	EventStepStart       = "step_start"
+	EventStepEnd         = "step_end"
+	EventToolUse         = "tool_use"
+	EventToolResult      = "tool_result"
+	EventText            = "text"
+	EventCycleHeader     = "cycle_header"
+	EventCycleSummary    = "cycle_summary"
+	EventCycleFailed     = "cycle_failed"
+	EventQueueHeader     = "queue_header"
+	EventQueueStoryStart = "queue_story_start"
+	EventQueueSummary    = "queue_summary"
+	EventCommandHeader   = "command_header"
+	EventCommandFooter   = "command_footer"
+	EventCommandUsage    = "command_usage"
+	EventCommandFiles    = "command_files"
+	EventTransition      = "transition"
+	EventExit            = "exit"
+)
+
+// JSONEvent is one line of [JSONPrinter] output.
+//
+// Type identifies the event; only the fields relevant to it are set.
+// Durations are in milliseconds.
+type JSONEvent struct {
+	Type string    `json:"type"`
+	Time time.Time `json:"time"`
+
+	Text string `json:"text,omitempty"`
+
+	// Tool uses and results
+	Tool   *JSONTool `json:"tool,omitempty"`
+	Stdout string    `json:"stdout,omitempty"`
+	Stderr string    `json:"stderr,omitempty"`
+
+	// Steps, commands, and sessions
+	Step       int    `json:"step,omitempty"`
+	Total      int    `json:"total,omitempty"`
+	Name       string `json:"name,omitempty"`
+	Label      string `json:"label,omitempty"`
+	Prompt     string `json:"prompt,omitempty"`
+	DurationMS *int64 `json:"duration_ms,omitempty"`
+	Success    *bool  `json:"success,omitempty"`
+	ExitCode   *int   `json:"exit_code,omitempty"`
+
+	// Usage
+	Usage      *JSONUsage `json:"usage,omitempty"`
+	TotalUsage *JSONUsage `json:"total_usage,omitempty"`
+
+	// Files touched by a command
+	ChangedFiles []string `json:"changed_files,omitempty"`
+	CreatedFiles []string `json:"created_files,omitempty"`
+
+	// Stories, cycles, and queues
+	StoryKey   string            `json:"story_key,omitempty"`
+	Stories    []string          `json:"stories,omitempty"`
+	Index      int               `json:"index,omitempty"`
+	FailedStep string            `json:"failed_step,omitempty"`
+	Steps      []JSONStepResult  `json:"steps,omitempty"`
+	Results    []JSONStoryResult `json:"results,omitempty"`
+
+	// Status transitions
+	From string `json:"from,omitempty"`
+	To   string `json:"to,omitempty"`
+}
+
+// JSONTool describes a tool invocation in a [JSONEvent].
+type JSONTool struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	Command     string          `json:"command,omitempty"`
+	FilePath    string          `json:"file_path,omitempty"`
+	Pattern     string          `json:"pattern,omitempty"`
+	Path        string          `json:"path,omitempty"`
+	Query       string          `json:"query,omitempty"`
+	URL         string          `json:"url,omitempty"`
+	Input       json.RawMessage `json:"input,omitempty"`
+}
+
+// JSONUsage is token usage and cost in a [JSONEvent].
+type JSONUsage struct {
+	InputTokens  int     `json:"input_tokens"`
+	OutputTokens int     `json:"output_tokens"`
+	CostUSD      float64 `json:"cost_usd"`
+}
+
+// JSONStepResult is a step of a cycle summary in a [JSONEvent].
+type JSONStepResult struct {
+	Name       string `json:"name"`
+	DurationMS int64  `json:"duration_ms"`
+	Success    bool   `json:"success"`
+}
+
+// JSONStoryResult is a story of a queue summary in a [JSONEvent].
+type JSONStoryResult struct {
+	StoryKey   string  `json:"story_key"`
+	Success    bool    `json:"success"`
+	Skipped    bool    `json:"skipped"`
+	Cancelled  bool    `json:"cancelled,omitempty"`
+	FailedAt   string  `json:"failed_at,omitempty"`
+	BlockedBy  string  `json:"blocked_by,omitempty"`
+	DurationMS int64   `json:"duration_ms"`
+	CostUSD    float64 `json:"cost_usd"`
+}
+
+// JSONPrinter implements [core.Printer] by writing one JSON object per line
+// (JSON Lines) for each event, for consumption by other tools.
+//
+// Tool results are written in full, ignoring truncation limits, and
+// dividers are omitted. JSONPrinter is safe for concurrent use.
+type JSONPrinter struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+	now func() time.Time
+}
+
+// NewJSONPrinter creates a [JSONPrinter] writing to w.
+func NewJSONPrinter(w io.Writer) *JSONPrinter {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	return &JSONPrinter{enc: enc, now: time.Now}
+}
+
+// emit writes event with its type and the current time.
+func (p *JSONPrinter) emit(eventType string, event JSONEvent) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	event.Type = eventType
+	event.Time = p.now()
+	_ = p.enc.Encode(event)
+}
+
+// SessionStart writes a session_start event.
+func (p *JSONPrinter) SessionStart() {
+	p.emit(EventSessionStart, JSONEvent{})
+}
+
+// SessionEnd writes a session_end event.
+func (p *JSONPrinter) SessionEnd(duration time.Duration, success bool) {
+	p.emit(EventSessionEnd, JSONEvent{DurationMS: millis(duration), Success: &success})
+}
+
+// StepStart writes a step_start event.
+func (p *JSONPrinter) StepStart(step, total int, name string) {
+	p.emit(EventStepStart, JSONEvent{Step: step, Total: total, Name: name})
+}
+
+// StepEnd writes a step_end event.
+func (p *JSONPrinter) StepEnd(duration time.Duration, success bool) {
+	p.emit(EventStepEnd, JSONEvent{DurationMS: millis(duration), Success: &success})
+}
+
+// ToolUse writes a tool_use event.
+func (p *JSONPrinter) ToolUse(params core.ToolParams) {
+	p.emit(EventToolUse, JSONEvent{Tool: &JSONTool{
+		Name:        params.Name,
+		Description: params.Description,
+		Command:     params.Command,
+		FilePath:    params.FilePath,
+		Pattern:     params.Pattern,
+		Path:        params.Path,
+		Query:       params.Query,
+		URL:         params.URL,
+		Input:       params.InputRaw,
+	}})
+}
+
+// ToolResult writes a tool_result event with the complete output.
+func (p *JSONPrinter) ToolResult(stdout, stderr string, truncateLines int) {
+	p.emit(EventToolResult, JSONEvent{Stdout: stdout, Stderr: stderr})
+}
+
+// Text writes a text event.
+func (p *JSONPrinter) Text(message string) {
+	p.emit(EventText, JSONEvent{Text: message})
+}
+
+// Divider writes nothing; dividers only separate terminal output.
+func (p *JSONPrinter) Divider() {}
+
+// CycleHeader writes a cycle_header event.
+func (p *JSONPrinter) CycleHeader(storyKey string) {
+	p.emit(EventCycleHeader, JSONEvent{StoryKey: storyKey})
+}
+
+// CycleSummary writes a cycle_summary event.
+func (p *JSONPrinter) CycleSummary(storyKey string, steps []core.StepResult, totalDuration time.Duration) {
+	results := make([]JSONStepResult, len(steps))
+	for i, s := range steps {
+		results[i] = JSONStepResult{Name: s.Name, DurationMS: s.Duration.Milliseconds(), Success: s.Success}
+	}
+	p.emit(EventCycleSummary, JSONEvent{StoryKey: storyKey, Steps: results, DurationMS: millis(totalDuration)})
+}
+
+// CycleFailed writes a cycle_failed event.
+func (p *JSONPrinter) CycleFailed(storyKey string, failedStep string, duration time.Duration) {
+	p.emit(EventCycleFailed, JSONEvent{StoryKey: storyKey, FailedStep: failedStep, DurationMS: millis(duration)})
+}
+
+// QueueHeader writes a queue_header event.
+func (p *JSONPrinter) QueueHeader(count int, stories []string) {
+	p.emit(EventQueueHeader, JSONEvent{Total: count, Stories: stories})
+}
+
+// QueueStoryStart writes a queue_story_start event.
+func (p *JSONPrinter) QueueStoryStart(index, total int, storyKey string) {
+	p.emit(EventQueueStoryStart, JSONEvent{Index: index, Total: total, StoryKey: storyKey})
+}
+
+// QueueSummary writes a queue_summary event.
+func (p *JSONPrinter) QueueSummary(results []core.StoryResult, allKeys []string, totalDuration time.Duration) {
+	stories := make([]JSONStoryResult, len(results))
+	for i, r := range results {
+		stories[i] = JSONStoryResult{
+			StoryKey:   r.Key,
+			Success:    r.Success,
+			Skipped:    r.Skipped,
+			Cancelled:  r.Cancelled,
+			FailedAt:   r.FailedAt,
+			BlockedBy:  r.BlockedBy,
+			DurationMS: r.Duration.Milliseconds(),
+			CostUSD:    r.CostUSD,
+		}
+	}
+	p.emit(EventQueueSummary, JSONEvent{Results: stories, Stories: allKeys, DurationMS: millis(totalDuration)})
+}
+
+// CommandHeader writes a command_header event with the full prompt.
+func (p *JSONPrinter) CommandHeader(label, prompt string, truncateLength int) {
+	p.emit(EventCommandHeader, JSONEvent{Label: label, Prompt: prompt})
+}
+
+// CommandFooter writes a command_footer event.
+func (p *JSONPrinter) CommandFooter(duration time.Duration, success bool, exitCode int) {
+	p.emit(EventCommandFooter, JSONEvent{DurationMS: millis(duration), Success: &success, ExitCode: &exitCode})
+}
+
+// CommandUsage writes a command_usage event.
+func (p *JSONPrinter) CommandUsage(step, total core.Usage) {
+	p.emit(EventCommandUsage, JSONEvent{Usage: jsonUsage(step), TotalUsage: jsonUsage(total)})
+}
+
+// CommandFiles writes a command_files event, unless no files were touched.
+func (p *JSONPrinter) CommandFiles(changed, created []string) {
+	if len(changed) == 0 && len(created) == 0 {
+		return
+	}
+	p.emit(EventCommandFiles, JSONEvent{ChangedFiles: changed, CreatedFiles: created})
+}
+
+// Transition writes a transition event for a story status update.
+func (p *JSONPrinter) Transition(storyKey, from, to string) {
+	p.emit(EventTransition, JSONEvent{StoryKey: storyKey, From: from, To: to})
+}
+
+// Exit writes the final exit event with the process exit code.
+func (p *JSONPrinter) Exit(exitCode int) {
+	p.emit(EventExit, JSONEvent{ExitCode: &exitCode})
+}
+
+// millis returns d in milliseconds, for optional duration fields.
+func millis(d time.Duration) *int64 {
+	ms := d.Milliseconds()
+	return &ms
+}
+
+// jsonUsage converts u for a [JSONEvent].
+func jsonUsage(u core.Usage) *JSONUsage {
+	return &JSONUsage{InputTokens: u.InputTokens, OutputTokens: u.OutputTokens, CostUSD: u.CostUSD}
+}
@@ -0,0 +1,159 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"bmaduum/internal/output/core"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestJSONPrinter returns a JSONPrinter with a fixed clock.
+func newTestJSONPrinter(buf *bytes.Buffer) *JSONPrinter {
+	p := NewJSONPrinter(buf)
+	p.now = func() time.Time { return time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC) }
+	return p
+}
+
+// decodeLines parses each line of buf as a JSONEvent.
+func decodeLines(t *testing.T, buf *bytes.Buffer) []JSONEvent {
+	t.Helper()
+	var events []JSONEvent
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var event JSONEvent
+		require.NoError(t, json.Unmarshal([]byte(line), &event), "line: %s", line)
+		events = append(events, event)
+	}
+	return events
+}
+
+func TestJSONPrinter_ImplementsPrinter(t *testing.T) {
+	var _ core.Printer = NewJSONPrinter(&bytes.Buffer{})
+}
+
+func TestJSONPrinter_OneEventPerLine(t *testing.T) {
+	var buf bytes.Buffer
+	p := newTestJSONPrinter(&buf)
+
+	p.SessionStart()
+	p.Text("hello <world>")
+	p.Divider()
+	p.StepStart(1, 3, "dev-story")
+	p.StepEnd(1500*time.Millisecond, true)
+	p.SessionEnd(2*time.Second, false)
+
+	events := decodeLines(t, &buf)
+	require.Len(t, events, 5)
+	assert.Equal(t, EventSessionStart, events[0].Type)
+	assert.Equal(t, time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC), events[0].Time)
+
+	assert.Equal(t, EventText, events[1].Type)
+	assert.Equal(t, "hello <world>", events[1].Text)
+	assert.Contains(t, buf.String(), "<world>", "HTML characters are not escaped")
+
+	assert.Equal(t, EventStepStart, events[2].Type)
+	assert.Equal(t, 1, events[2].Step)
+	assert.Equal(t, 3, events[2].Total)
+	assert.Equal(t, "dev-story", events[2].Name)
+
+	require.NotNil(t, events[3].DurationMS)
+	assert.Equal(t, int64(1500), *events[3].DurationMS)
+	require.NotNil(t, events[3].Success)
+	assert.True(t, *events[3].Success)
+
+	require.NotNil(t, events[4].Success)
+	assert.False(t, *events[4].Success)
+}
+
+func TestJSONPrinter_ToolEvents(t *testing.T) {
+	var buf bytes.Buffer
+	p := newTestJSONPrinter(&buf)
+
+	p.ToolUse(core.ToolParams{Name: "Bash", Command: "go test ./...", InputRaw: json.RawMessage(`{"command":"go test ./..."}`)})
+	longOutput := strings.Repeat("line\n", 50)
+	p.ToolResult(longOutput, "warn", 5)
+
+	events := decodeLines(t, &buf)
+	require.Len(t, events, 2)
+	require.NotNil(t, events[0].Tool)
+	assert.Equal(t, "Bash", events[0].Tool.Name)
+	assert.Equal(t, "go test ./...", events[0].Tool.Command)
+	assert.JSONEq(t, `{"command":"go test ./..."}`, string(events[0].Tool.Input))
+
+	assert.Equal(t, longOutput, events[1].Stdout, "tool output is not truncated")
+	assert.Equal(t, "warn", events[1].Stderr)
+}
+
+func TestJSONPrinter_CommandEvents(t *testing.T) {
+	var buf bytes.Buffer
+	p := newTestJSONPrinter(&buf)
+
+	prompt := strings.Repeat("x", 100)
+	p.CommandHeader("code-review: 7-1", prompt, 10)
+	p.CommandFooter(time.Second, false, 2)
+	p.CommandUsage(core.Usage{InputTokens: 10, OutputTokens: 5, CostUSD: 0.5}, core.Usage{InputTokens: 20, OutputTokens: 8, CostUSD: 1})
+	p.CommandFiles(nil, nil)
+	p.CommandFiles([]string{"main.go"}, []string{"docs/7-1-story.md"})
+
+	events := decodeLines(t, &buf)
+	require.Len(t, events, 4)
+	assert.Equal(t, "code-review: 7-1", events[0].Label)
+	assert.Equal(t, prompt, events[0].Prompt, "prompt is not truncated")
+
+	require.NotNil(t, events[1].ExitCode)
+	assert.Equal(t, 2, *events[1].ExitCode)
+
+	require.NotNil(t, events[2].Usage)
+	assert.Equal(t, JSONUsage{InputTokens: 10, OutputTokens: 5, CostUSD: 0.5}, *events[2].Usage)
+	require.NotNil(t, events[2].TotalUsage)
+	assert.Equal(t, 20, events[2].TotalUsage.InputTokens)
+
+	assert.Equal(t, EventCommandFiles, events[3].Type)
+	assert.Equal(t, []string{"main.go"}, events[3].ChangedFiles)
+	assert.Equal(t, []string{"docs/7-1-story.md"}, events[3].CreatedFiles)
+}
+
+func TestJSONPrinter_CycleAndQueueEvents(t *testing.T) {
+	var buf bytes.Buffer
+	p := newTestJSONPrinter(&buf)
+
+	p.CycleHeader("7-1")
+	p.CycleSummary("7-1", []core.StepResult{{Name: "dev-story", Duration: time.Second, Success: true}}, 3*time.Second)
+	p.CycleFailed("7-2", "code-review", time.Second)
+	p.QueueHeader(2, []string{"7-1", "7-2"})
+	p.QueueStoryStart(1, 2, "7-1")
+	p.QueueSummary([]core.StoryResult{{Key: "7-1", Success: true, Duration: time.Second, CostUSD: 0.25}}, []string{"7-1", "7-2"}, 4*time.Second)
+
+	events := decodeLines(t, &buf)
+	require.Len(t, events, 6)
+	assert.Equal(t, EventCycleHeader, events[0].Type)
+	assert.Equal(t, []JSONStepResult{{Name: "dev-story", DurationMS: 1000, Success: true}}, events[1].Steps)
+	assert.Equal(t, "code-review", events[2].FailedStep)
+	assert.Equal(t, []string{"7-1", "7-2"}, events[3].Stories)
+	assert.Equal(t, 1, events[4].Index)
+	assert.Equal(t, []JSONStoryResult{{StoryKey: "7-1", Success: true, DurationMS: 1000, CostUSD: 0.25}}, events[5].Results)
+}
+
+func TestJSONPrinter_TransitionAndExit(t *testing.T) {
+	var buf bytes.Buffer
+	p := newTestJSONPrinter(&buf)
+
+	p.Transition("7-1", "review", "done")
+	p.Exit(0)
+
+	events := decodeLines(t, &buf)
+	require.Len(t, events, 2)
+	assert.Equal(t, EventTransition, events[0].Type)
+	assert.Equal(t, "7-1", events[0].StoryKey)
+	assert.Equal(t, "review", events[0].From)
+	assert.Equal(t, "done", events[0].To)
+
+	assert.Equal(t, EventExit, events[1].Type)
+	require.NotNil(t, events[1].ExitCode)
+	assert.Equal(t, 0, *events[1].ExitCode, "a zero exit code is still written")
+}
@@ -80,6 +80,12 @@ func NewPrinterWithConfig(w io.Writer, cfg MarkdownConfig) *DefaultPrinter {
 	return p
 }
 
+// DisableMarkdown makes p print Claude's text as plain text from now on,
+// whatever its markdown configuration.
+func (p *DefaultPrinter) DisableMarkdown() {
+	p.markdown.enabled = false
+}
+
 // SessionStart prints session start indicator.
 func (p *DefaultPrinter) SessionStart() {
 	p.session.SessionStart()
@@ -186,11 +192,14 @@ func (p *DefaultPrinter) QueueSummary(results []core.StoryResult, allKeys []stri
 	renderResults := make([]render.StoryResult, len(results))
 	for i, r := range results {
 		renderResults[i] = render.StoryResult{
-			Key:      r.Key,
-			Success:  r.Success,
-			Duration: r.Duration,
-			FailedAt: r.FailedAt,
-			Skipped:  r.Skipped,
+			Key:       r.Key,
+			Success:   r.Success,
+			Duration:  r.Duration,
+			FailedAt:  r.FailedAt,
+			Skipped:   r.Skipped,
+			Cancelled: r.Cancelled,
+			CostUSD:   r.CostUSD,
+			BlockedBy: r.BlockedBy,
 		}
 	}
 	p.cycle.QueueSummary(renderResults, allKeys, totalDuration)
@@ -206,6 +215,16 @@ func (p *DefaultPrinter) CommandFooter(duration time.Duration, success bool, exi
 	p.session.CommandFooter(duration, success, exitCode)
 }
 
+// CommandUsage prints the token usage and cost of a command and the running total.
+func (p *DefaultPrinter) CommandUsage(step, total core.Usage) {
+	p.session.CommandUsage(step, total)
+}
+
+// CommandFiles prints the files a command changed and created.
+func (p *DefaultPrinter) CommandFiles(changed, created []string) {
+	p.session.CommandFiles(changed, created)
+}
+
 // defaultStyleProvider implements render.StyleProvider using lipgloss styles.
 type defaultStyleProvider struct{}
 
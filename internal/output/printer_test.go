@@ -13,7 +13,9 @@ import (
 	"bmaduum/internal/output/core"
 	"bmaduum/internal/output/diff"
 
+	"github.com/charmbracelet/glamour"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewPrinter(t *testing.T) {
@@ -137,6 +139,24 @@ func TestDefaultPrinter_Text(t *testing.T) {
 	assert.Contains(t, output, "Hello from Claude!")
 }
 
+func TestDefaultPrinter_DisableMarkdown(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewPrinterWithWriter(&buf)
+	// Render regardless of whether the test runs in a terminal
+	r, err := glamour.NewTermRenderer(glamour.WithStandardStyle("dark"))
+	require.NoError(t, err)
+	p.markdown.renderer = r
+	p.markdown.enabled = true
+
+	p.Text("Some **bold text**")
+	assert.NotContains(t, buf.String(), "**bold text**")
+
+	buf.Reset()
+	p.DisableMarkdown()
+	p.Text("Some **bold text**")
+	assert.Contains(t, buf.String(), "Some **bold text**")
+}
+
 func TestDefaultPrinter_Text_Empty(t *testing.T) {
 	var buf bytes.Buffer
 	p := NewPrinterWithWriter(&buf)
@@ -285,6 +305,23 @@ func TestDefaultPrinter_QueueSummary_WithFailure(t *testing.T) {
 	assert.Contains(t, output, "(pending)")
 }
 
+func TestDefaultPrinter_QueueSummary_Cancelled(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewPrinterWithWriter(&buf)
+
+	results := []core.StoryResult{
+		{Key: "story-1", Success: true, Duration: 10 * time.Second},
+		{Key: "story-2", Cancelled: true, Duration: 5 * time.Second},
+	}
+
+	p.QueueSummary(results, []string{"story-1", "story-2", "story-3"}, 15*time.Second)
+
+	output := buf.String()
+	assert.Contains(t, output, "QUEUE CANCELLED")
+	assert.Contains(t, output, "Completed: 1 | Skipped: 0 | Cancelled: 1 | Remaining: 1")
+	assert.Contains(t, output, "(cancelled)")
+}
+
 func TestTruncateString(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -766,3 +803,27 @@ func formatUnknownToolParams(raw json.RawMessage) string {
 	}
 	return result
 }
+
+func TestDefaultPrinter_CommandUsage(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewPrinterWithWriter(&buf)
+
+	step := core.Usage{InputTokens: 1200, OutputTokens: 340, CostUSD: 0.0123}
+	p.CommandUsage(step, step.Add(core.Usage{CostUSD: 0.0333}))
+
+	assert.Contains(t, buf.String(), "Tokens: 1200 in / 340 out | Cost: $0.0123 (total $0.0456)")
+}
+
+func TestDefaultPrinter_QueueSummary_Cost(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewPrinterWithWriter(&buf)
+
+	results := []core.StoryResult{
+		{Key: "story-1", Success: true, Duration: 10 * time.Second, CostUSD: 0.25},
+		{Key: "story-2", Success: true, Duration: 20 * time.Second, CostUSD: 0.5},
+	}
+
+	p.QueueSummary(results, []string{"story-1", "story-2"}, 30*time.Second)
+
+	assert.Contains(t, buf.String(), "Total: 30s | Cost: $0.7500")
+}
@@ -71,14 +71,8 @@ func (a *ActivityLine) Render(height int, state ActivityState) error {
 		activity += " (" + strings.Join(parts, " · ") + ")"
 	}
 
-	// Write activity in orange/red color
-	if _, err := a.cursor.WriteString(terminal.FgActivity + terminal.Bold); err != nil {
-		return err
-	}
-	if _, err := a.cursor.WriteString(activity); err != nil {
-		return err
-	}
-	if _, err := a.cursor.WriteString(terminal.ResetAttrs); err != nil {
+	// Write activity in orange/red color, if colors are enabled
+	if _, err := a.cursor.WriteString(colored(terminal.FgActivity+terminal.Bold, activity)); err != nil {
 		return err
 	}
 
@@ -124,16 +118,19 @@ func (a *ActivityLine) RenderDone(height int, success bool, duration time.Durati
 		}
 	}
 
-	// Write in activity color
-	if _, err := a.cursor.WriteString(terminal.FgActivity + terminal.Bold); err != nil {
-		return err
-	}
-	if _, err := a.cursor.WriteString(msg); err != nil {
-		return err
-	}
-	if _, err := a.cursor.WriteString(terminal.ResetAttrs); err != nil {
+	// Write in activity color, if colors are enabled
+	if _, err := a.cursor.WriteString(colored(terminal.FgActivity+terminal.Bold, msg)); err != nil {
 		return err
 	}
 
 	return nil
 }
+
+// colored wraps s in the terminal attributes attrs, or returns s unchanged
+// if colors are disabled.
+func colored(attrs, s string) string {
+	if !terminal.SupportsColor() {
+		return s
+	}
+	return attrs + s + terminal.ResetAttrs
+}
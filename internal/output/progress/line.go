@@ -15,10 +15,18 @@ import (
 	"bmaduum/internal/output/terminal"
 )
 
+// HeartbeatInterval is how often a [Line] writing to something other than a
+// terminal reports that the step is still running.
+const HeartbeatInterval = time.Minute
+
 // Line manages the fixed status area at the bottom of the terminal.
 // It provides a two-line status display with scrolling output above:
 //   - Activity line (second-to-last): Spinner + activity verb + timer + token count
 //   - Status bar (last row): Operation + step info + story key + model + total timer
+//
+// The status area is set up by [Line.Init] at the start of each step and
+// removed by [Line.Done]. When the output is not a terminal, the step
+// instead prints a "still running" line every [HeartbeatInterval].
 type Line struct {
 	// Terminal control
 	term      *terminal.Terminal
@@ -27,15 +35,17 @@ type Line struct {
 	statusBar *StatusBar
 
 	// State management
-	mu       sync.Mutex
-	state    State
-	enabled  bool
-	initOnce sync.Once
+	mu      sync.Mutex
+	state   State
+	enabled bool
+	active  bool // A step is running: the status area or heartbeat is up
+
+	// heartbeat is the interval of "still running" lines when the output
+	// is not a terminal, or 0 to print none.
+	heartbeat time.Duration
 
 	// Lifecycle
-	ctx            context.Context
 	cancel         context.CancelFunc
-	resizeChan     chan os.Signal
 	verbChangeTick int
 }
 
@@ -43,66 +53,115 @@ type Line struct {
 func NewLine(out io.Writer) *Line {
 	term := terminal.New(out)
 
-	return &Line{
+	l := &Line{
 		term:      term,
 		cursor:    terminal.NewCursor(out),
 		activity:  NewActivityLine(out),
 		statusBar: NewStatusBar(out),
 		enabled:   term.FileDescriptor() >= 0 && terminal.IsTTY(out.(*os.File)),
 	}
+	if !l.enabled && out != io.Discard {
+		l.heartbeat = HeartbeatInterval
+	}
+	return l
 }
 
-// Init initializes the fixed status area at the bottom of the terminal.
+// Init starts the progress display for a step: the fixed status area at
+// the bottom of the terminal, or the "still running" heartbeat when the
+// output is not a terminal. Per-step counters are reset. It does nothing if
+// the display is already up.
 func (l *Line) Init() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.active || (!l.enabled && l.heartbeat == 0) {
+		return
+	}
+
+	// Reset the per-step state
+	now := time.Now()
+	if l.state.StartTime.IsZero() {
+		l.state.StartTime = now
+	}
+	l.state.StepStartTime = now
+	l.state.ActivityStart = now
+	l.state.ThinkingStart = now
+	l.state.ThinkingDuration = 0
+	l.state.HadFirstResponse = false
+	l.state.CurrentTool = ""
+	l.state.InputTokens = 0
+	l.state.OutputTokens = 0
+	l.state.ToolCount = 0
+
 	if !l.enabled {
+		ctx, cancel := context.WithCancel(context.Background())
+		l.cancel = cancel
+		l.active = true
+		go l.heartbeatTicker(ctx)
 		return
 	}
 
-	l.mu.Lock()
-	defer l.mu.Unlock()
+	_, height := l.term.Size()
+	if height < 3 {
+		l.enabled = false
+		return
+	}
 
-	l.initOnce.Do(func() {
-		_, height := l.term.Size()
-		if height < 3 {
-			l.enabled = false
-			return
-		}
+	// Set scrolling region from row 1 to (height-2)
+	// This reserves the bottom 2 rows for the status area (activity line + status bar)
+	if err := l.term.SetScrollRegion(1, height-2); err != nil {
+		l.enabled = false
+		return
+	}
 
-		// Set scrolling region from row 1 to (height-2)
-		// This reserves the bottom 2 rows for the status area (activity line + status bar)
-		if err := l.term.SetScrollRegion(1, height-2); err != nil {
-			l.enabled = false
-			return
-		}
+	// Create context for goroutines
+	ctx, cancel := context.WithCancel(context.Background())
+	l.cancel = cancel
+	l.active = true
 
-		// Create context for goroutines
-		l.ctx, l.cancel = context.WithCancel(context.Background())
+	// Pick random starting verb
+	l.state.VerbIdx = rand.Intn(len(thinkingVerbs))
 
-		// Pick random starting verb
-		l.state.VerbIdx = rand.Intn(len(thinkingVerbs))
+	// Start animation ticker
+	go l.animationTicker(ctx)
 
-		// Initialize start time
-		l.state.StartTime = time.Now()
+	// Handle terminal resize
+	go l.handleResize(ctx)
 
-		// Start animation ticker
-		go l.animationTicker()
+	// Initial render
+	l.render()
+}
 
-		// Handle terminal resize
-		go l.handleResize()
+// heartbeatTicker prints a "still running" line with the step's elapsed
+// time every heartbeat interval until ctx is canceled.
+func (l *Line) heartbeatTicker(ctx context.Context) {
+	ticker := time.NewTicker(l.heartbeat)
+	defer ticker.Stop()
 
-		// Initial render
-		l.render()
-	})
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			l.mu.Lock()
+			if ctx.Err() == nil {
+				fmt.Fprintf(l.term.Writer(), "%s still running (%s)\n",
+					l.state.StepName, formatDurationNatural(time.Since(l.state.StepStartTime)))
+			}
+			l.mu.Unlock()
+		}
+	}
 }
 
-// animationTicker updates the spinner animation every 100ms.
-func (l *Line) animationTicker() {
+// animationTicker updates the spinner animation every 100ms until ctx is
+// canceled.
+func (l *Line) animationTicker(ctx context.Context) {
 	ticker := time.NewTicker(100 * time.Millisecond)
 	defer ticker.Stop()
 
 	for {
 		select {
-		case <-l.ctx.Done():
+		case <-ctx.Done():
 			return
 		case <-ticker.C:
 			l.mu.Lock()
@@ -118,27 +177,31 @@ func (l *Line) animationTicker() {
 	}
 }
 
-// handleResize listens for SIGWINCH and reconfigures the scroll region.
-func (l *Line) handleResize() {
-	l.resizeChan = make(chan os.Signal, 1)
-	signal.Notify(l.resizeChan, syscall.SIGWINCH)
+// handleResize listens for SIGWINCH and reconfigures the scroll region
+// until ctx is canceled.
+func (l *Line) handleResize(ctx context.Context) {
+	resize := make(chan os.Signal, 1)
+	signal.Notify(resize, syscall.SIGWINCH)
+	defer signal.Stop(resize)
 
 	for {
 		select {
-		case <-l.ctx.Done():
-			signal.Stop(l.resizeChan)
-			close(l.resizeChan)
+		case <-ctx.Done():
 			return
-		case <-l.resizeChan:
+		case <-resize:
 			// Debounce: wait a bit for resize to settle
 			time.Sleep(50 * time.Millisecond)
 
 			// Drain any queued resize signals
-			for len(l.resizeChan) > 0 {
-				<-l.resizeChan
+			for len(resize) > 0 {
+				<-resize
 			}
 
 			l.mu.Lock()
+			if ctx.Err() != nil {
+				l.mu.Unlock()
+				return
+			}
 			_, oldHeight := l.term.Size()
 			newWidth, newHeight := l.term.UpdateSize()
 
@@ -319,9 +382,7 @@ func (l *Line) Clear() {
 	_, height := l.term.Size()
 
 	// Stop goroutines
-	if l.cancel != nil {
-		l.cancel()
-	}
+	l.stop()
 
 	// Reset scrolling region
 	fmt.Fprint(out, terminal.ResetScrollRegion)
@@ -336,13 +397,30 @@ func (l *Line) Clear() {
 	fmt.Fprintf(out, terminal.MoveToFormat, height, 1)
 }
 
-// Done shows completion message with token info then clears.
+// stop cancels the step's goroutines (caller must hold lock).
+func (l *Line) stop() {
+	if l.cancel != nil {
+		l.cancel()
+		l.cancel = nil
+	}
+	l.active = false
+}
+
+// Done shows completion message with token info then clears. Without a
+// terminal, it stops the heartbeat.
 func (l *Line) Done(success bool, duration time.Duration) {
 	if !l.enabled {
+		l.mu.Lock()
+		l.stop()
+		l.mu.Unlock()
 		return
 	}
 
 	l.mu.Lock()
+	if !l.active {
+		l.mu.Unlock()
+		return
+	}
 	_, height := l.term.Size()
 	totalTokens := l.state.InputTokens + l.state.OutputTokens
 	toolCount := l.state.ToolCount
@@ -364,9 +442,10 @@ func (l *Line) StartTime() time.Time {
 	return l.state.StartTime
 }
 
-// render writes both the activity line and status bar (caller must hold lock).
+// render writes both the activity line and status bar while a step is
+// running (caller must hold lock).
 func (l *Line) render() {
-	if !l.enabled {
+	if !l.enabled || !l.active {
 		return
 	}
 
@@ -0,0 +1,67 @@
+package progress
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// syncBuffer is a bytes.Buffer safe for the heartbeat goroutine to write.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestLine_Heartbeat(t *testing.T) {
+	var out syncBuffer
+	l := NewLine(&out)
+	if l.heartbeat != HeartbeatInterval {
+		t.Fatalf("heartbeat = %v, want %v", l.heartbeat, HeartbeatInterval)
+	}
+	l.heartbeat = 10 * time.Millisecond
+
+	// Each step starts and stops its own heartbeat
+	for _, step := range []string{"create-story: 6-1", "dev-story: 6-1"} {
+		l.Init()
+		l.SetStepInfo(0, 0, step, "", "")
+		time.Sleep(50 * time.Millisecond)
+		l.Done(true, time.Second)
+
+		if !strings.Contains(out.String(), step+" still running (") {
+			t.Errorf("output = %q, want a heartbeat for %q", out.String(), step)
+		}
+	}
+
+	printed := out.String()
+	time.Sleep(30 * time.Millisecond)
+	if out.String() != printed {
+		t.Errorf("heartbeat printed after Done: %q", strings.TrimPrefix(out.String(), printed))
+	}
+}
+
+func TestLine_NoHeartbeatWhenDiscarded(t *testing.T) {
+	l := NewLine(io.Discard)
+	if l.heartbeat != 0 {
+		t.Errorf("heartbeat = %v, want 0", l.heartbeat)
+	}
+	l.Init()
+	if l.active {
+		t.Error("Init() started a display for io.Discard")
+	}
+	l.Done(true, time.Second)
+}
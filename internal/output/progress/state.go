@@ -4,6 +4,9 @@
 // with scrolling output above. The status area consists of:
 //   - Activity line (second-to-last): Spinner + activity verb + timer + token count
 //   - Status bar (last row): Operation + step info + story key + model + total timer
+//
+// When the output is not a terminal, a periodic "still running" line takes
+// the place of the status area.
 package progress
 
 import "time"
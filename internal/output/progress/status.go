@@ -33,8 +33,11 @@ func (s *StatusBar) Render(width int, state StatusState) error {
 	if err := s.cursor.DisableWrap(); err != nil {
 		return err
 	}
-	if _, err := s.cursor.WriteString(terminal.BgTerracotta + terminal.FgWhite + terminal.Bold); err != nil {
-		return err
+	color := terminal.SupportsColor()
+	if color {
+		if _, err := s.cursor.WriteString(terminal.BgTerracotta + terminal.FgWhite + terminal.Bold); err != nil {
+			return err
+		}
 	}
 	if err := s.cursor.ClearLine(); err != nil {
 		return err
@@ -55,8 +58,10 @@ func (s *StatusBar) Render(width int, state StatusState) error {
 	}
 
 	// Reset
-	if _, err := s.cursor.WriteString(terminal.ResetAttrs); err != nil {
-		return err
+	if color {
+		if _, err := s.cursor.WriteString(terminal.ResetAttrs); err != nil {
+			return err
+		}
 	}
 	if err := s.cursor.EnableWrap(); err != nil {
 		return err
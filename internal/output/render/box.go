@@ -187,13 +187,18 @@ func TruncateToWidth(s string, maxWidth int) string {
 	return ""
 }
 
-// TruncateString truncates a string to maxLen, adding "..." if truncated.
-// This is a simple byte-length truncation, not display-width aware.
+// TruncateString truncates a string to fit within maxLen display cells,
+// adding "..." if truncated. Runes are never split, and wide characters like
+// emoji and CJK count as two cells. If maxLen is too small for the "...",
+// the string is cut without it.
 func TruncateString(s string, maxLen int) string {
-	if len(s) <= maxLen {
-		return s
+	if maxLen <= 0 {
+		return ""
+	}
+	if maxLen < len("...") {
+		return runewidth.Truncate(s, maxLen, "")
 	}
-	return s[:maxLen-3] + "..."
+	return runewidth.Truncate(s, maxLen, "...")
 }
 
 // BoxTop returns the top of a rounded box with a specific width.
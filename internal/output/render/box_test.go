@@ -265,6 +265,14 @@ func TestTruncateString(t *testing.T) {
 		{"truncate", "hello world", 8, "hello..."},
 		{"short", "hi", 5, "hi"},
 		{"empty", "", 5, ""},
+		{"emoji", "🎉🎉🎉🎉", 6, "🎉..."},
+		{"emoji fits", "🎉🎉", 4, "🎉🎉"},
+		{"emoji does not split", "ok 🎉 done", 5, "ok..."},
+		{"cjk", "日本語のテキスト", 7, "日本..."},
+		{"cjk odd width", "日本語のテキスト", 8, "日本..."},
+		{"accented", "café au lait", 7, "café..."},
+		{"too short for ellipsis", "hello", 2, "he"},
+		{"zero", "hello", 0, ""},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
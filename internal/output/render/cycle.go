@@ -132,9 +132,14 @@ func (r *CycleRenderer) QueueSummary(results []StoryResult, allKeys []string, to
 	completed := 0
 	failed := 0
 	skipped := 0
+	cancelled := 0
+	totalCost := 0.0
 	for _, r := range results {
+		totalCost += r.CostUSD
 		if r.Skipped {
 			skipped++
+		} else if r.Cancelled {
+			cancelled++
 		} else if r.Success {
 			completed++
 		} else {
@@ -151,9 +156,16 @@ func (r *CycleRenderer) QueueSummary(results []StoryResult, allKeys []string, to
 	r.writer.Writeln("")
 
 	// Header
-	if failed == 0 && remaining == 0 {
+	if failed == 0 && cancelled == 0 && remaining == 0 {
 		r.writer.Writeln(r.styles.RenderSuccess(BoxTop(width)))
 		r.writer.Writeln(r.styles.RenderSuccess(BoxLine(IconSuccess+" QUEUE COMPLETE", width)))
+	} else if cancelled > 0 {
+		r.writer.Writeln(r.styles.RenderError(BoxTop(width)))
+		r.writer.Writeln(r.styles.RenderError(BoxLine(IconError+" QUEUE CANCELLED", width)))
+	} else if remaining == 0 {
+		// Every story was attempted, e.g. with --continue-on-error
+		r.writer.Writeln(r.styles.RenderError(BoxTop(width)))
+		r.writer.Writeln(r.styles.RenderError(BoxLine(IconError+" QUEUE FINISHED WITH FAILURES", width)))
 	} else {
 		r.writer.Writeln(r.styles.RenderError(BoxTop(width)))
 		r.writer.Writeln(r.styles.RenderError(BoxLine(IconError+" QUEUE STOPPED", width)))
@@ -162,10 +174,18 @@ func (r *CycleRenderer) QueueSummary(results []StoryResult, allKeys []string, to
 	// Summary line
 	summaryLine := fmt.Sprintf("Completed: %d | Skipped: %d | Failed: %d | Remaining: %d",
 		completed, skipped, failed, remaining)
+	switch {
+	case cancelled > 0 && failed == 0:
+		summaryLine = fmt.Sprintf("Completed: %d | Skipped: %d | Cancelled: %d | Remaining: %d",
+			completed, skipped, cancelled, remaining)
+	case cancelled > 0:
+		summaryLine = fmt.Sprintf("Completed: %d | Skipped: %d | Failed: %d | Cancelled: %d | Remaining: %d",
+			completed, skipped, failed, cancelled, remaining)
+	}
 	r.writer.Writeln(r.styles.RenderMuted(BoxLine(summaryLine, width)))
 
 	// Separator
-	if failed == 0 && remaining == 0 {
+	if failed == 0 && cancelled == 0 && remaining == 0 {
 		r.writer.Writeln(r.styles.RenderSuccess("├" + strings.Repeat("─", width-2) + "┤"))
 	} else {
 		r.writer.Writeln(r.styles.RenderError("├" + strings.Repeat("─", width-2) + "┤"))
@@ -177,12 +197,18 @@ func (r *CycleRenderer) QueueSummary(results []StoryResult, allKeys []string, to
 		if result.Skipped {
 			status = r.styles.RenderMuted("↷")
 			suffix = "(done)"
+		} else if result.Cancelled {
+			status = r.styles.RenderError(IconError)
+			suffix = "(cancelled)"
 		} else if result.Success {
 			status = r.styles.RenderSuccess(IconSuccess)
 			suffix = result.Duration.Round(time.Second).String()
+		} else if result.BlockedBy != "" {
+			status = r.styles.RenderError(IconError)
+			suffix = "(blocked by " + result.BlockedBy + ")"
 		} else {
 			status = r.styles.RenderError(IconError)
-			suffix = result.Duration.Round(time.Second).String()
+			suffix = result.Duration.Round(time.Second).String() + " (failed)"
 		}
 		line := fmt.Sprintf("%s %-30s %s", status, result.Key, suffix)
 		r.writer.Writeln(BoxLine(line, width))
@@ -197,13 +223,17 @@ func (r *CycleRenderer) QueueSummary(results []StoryResult, allKeys []string, to
 	}
 
 	// Footer
-	if failed == 0 && remaining == 0 {
+	total := "Total: " + totalDuration.Round(time.Second).String()
+	if totalCost > 0 {
+		total += fmt.Sprintf(" | Cost: $%.4f", totalCost)
+	}
+	if failed == 0 && cancelled == 0 && remaining == 0 {
 		r.writer.Writeln(r.styles.RenderSuccess("├" + strings.Repeat("─", width-2) + "┤"))
-		r.writer.Writeln(r.styles.RenderSuccess(BoxLine("Total: "+totalDuration.Round(time.Second).String(), width)))
+		r.writer.Writeln(r.styles.RenderSuccess(BoxLine(total, width)))
 		r.writer.Writeln(r.styles.RenderSuccess(BoxBottom(width)))
 	} else {
 		r.writer.Writeln(r.styles.RenderError("├" + strings.Repeat("─", width-2) + "┤"))
-		r.writer.Writeln(r.styles.RenderError(BoxLine("Total: "+totalDuration.Round(time.Second).String(), width)))
+		r.writer.Writeln(r.styles.RenderError(BoxLine(total, width)))
 		r.writer.Writeln(r.styles.RenderError(BoxBottom(width)))
 	}
 }
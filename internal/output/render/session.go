@@ -116,6 +116,36 @@ func (r *SessionRenderer) CommandFooter(duration time.Duration, success bool, ex
 	}
 }
 
+// CommandUsage prints the token usage and cost reported for a command.
+// The running total is shown when it differs from the command's own cost.
+// Format: "  Tokens: 1200 in / 340 out | Cost: $0.0123 (total $0.0456)"
+func (r *SessionRenderer) CommandUsage(step, total Usage) {
+	line := fmt.Sprintf("  Tokens: %d in / %d out", step.InputTokens, step.OutputTokens)
+	if step.CostUSD > 0 {
+		line += fmt.Sprintf(" | Cost: $%.4f", step.CostUSD)
+		if total.CostUSD > step.CostUSD {
+			line += fmt.Sprintf(" (total $%.4f)", total.CostUSD)
+		}
+	}
+	r.Writeln("%s", r.styles.RenderMuted(line))
+}
+
+// CommandFiles lists the files a command's tool uses changed and created
+// under a "  Files changed:" heading, one per line, with created files
+// marked "(new)". Nothing is printed if there are none.
+func (r *SessionRenderer) CommandFiles(changed, created []string) {
+	if len(changed) == 0 && len(created) == 0 {
+		return
+	}
+	r.Writeln("%s", r.styles.RenderMuted("  Files changed:"))
+	for _, path := range changed {
+		r.Writeln("%s", r.styles.RenderMuted("    "+path))
+	}
+	for _, path := range created {
+		r.Writeln("%s", r.styles.RenderMuted("    "+path+" (new)"))
+	}
+}
+
 // Text prints a text message from Claude.
 // Format: "  ● text" with 2-space base indent and bullet, matching Claude Code style.
 // Markdown is rendered with proper formatting (bold, code, headers, etc.)
@@ -30,6 +30,9 @@ type (
 
 	// StoryResult represents the result of processing a story in queue or epic operations.
 	StoryResult = core.StoryResult
+
+	// Usage represents the token usage and cost of one or more Claude sessions.
+	Usage = core.Usage
 )
 
 // StyleProvider provides styling functions for rendered output.
@@ -118,12 +121,8 @@ func (r *ToolRenderer) ToolUse(params ToolParams) {
 	case "Task":
 		paramStr := params.SubagentType
 		if params.Prompt != "" {
-			// Truncate prompt to first 60 chars
-			prompt := params.Prompt
-			if len(prompt) > 60 {
-				prompt = prompt[:57] + "..."
-			}
-			paramStr += ": " + prompt
+			// Truncate prompt to 60 cells
+			paramStr += ": " + TruncateString(params.Prompt, 60)
 		}
 		r.printToolHeader(bullet, toolName, paramStr)
 		return
@@ -241,11 +240,7 @@ func formatUnknownToolParams(raw json.RawMessage) string {
 		var valStr string
 		switch val := v.(type) {
 		case string:
-			if len(val) > 30 {
-				valStr = val[:27] + "..."
-			} else {
-				valStr = val
-			}
+			valStr = TruncateString(val, 30)
 		case bool:
 			valStr = fmt.Sprintf("%v", val)
 		case float64:
@@ -269,11 +264,7 @@ func formatUnknownToolParams(raw json.RawMessage) string {
 		parts = append(parts, k+"="+valStr)
 	}
 
-	result := strings.Join(parts, ", ")
-	if len(result) > 80 {
-		result = result[:77] + "..."
-	}
-	return result
+	return TruncateString(strings.Join(parts, ", "), 80)
 }
 
 // renderEditDiff renders the old_string -> new_string diff for Edit tools.
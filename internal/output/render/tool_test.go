@@ -1,6 +1,9 @@
 package render
 
-import "testing"
+import (
+	"testing"
+	"unicode/utf8"
+)
 
 func TestStripLineNumberArrows(t *testing.T) {
 	tests := []struct {
@@ -114,3 +117,14 @@ func TestTruncateOutput(t *testing.T) {
 		})
 	}
 }
+
+func TestFormatUnknownToolParams_MultibyteValues(t *testing.T) {
+	got := formatUnknownToolParams([]byte(`{"message":"🎉 リリース完了 — すべてのテストが合格しました"}`))
+
+	if !utf8.ValidString(got) {
+		t.Fatalf("formatUnknownToolParams() = %q, not valid UTF-8", got)
+	}
+	if want := "message=🎉 リリース完了 — すべての..."; got != want {
+		t.Errorf("formatUnknownToolParams() = %q, want %q", got, want)
+	}
+}
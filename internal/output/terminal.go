@@ -17,6 +17,9 @@ package output
 import (
 	"os"
 
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+
 	"bmaduum/internal/output/terminal"
 )
 
@@ -34,6 +37,7 @@ func IsTTY(f *os.File) bool {
 // SupportsColor returns true if the current terminal supports color output.
 //
 // Color support is disabled when:
+//   - [DisableColor] has been called (the --no-color flag)
 //   - Output is not a TTY (piped to file or another process)
 //   - NO_COLOR environment variable is set (per no-color.org)
 //   - TERM is set to "dumb" (indicating basic terminal capabilities)
@@ -43,6 +47,18 @@ func SupportsColor() bool {
 	return terminal.SupportsColor()
 }
 
+// DisableColor turns off color output for the rest of the process, as if
+// NO_COLOR were set: styles render without escape codes, and syntax
+// highlighting, rich diffs, and markdown rendering are skipped. Box-drawing
+// characters are kept.
+//
+// Markdown renderers created earlier keep rendering; disable them with
+// [DefaultPrinter.DisableMarkdown].
+func DisableColor() {
+	terminal.DisableColor()
+	lipgloss.SetColorProfile(termenv.Ascii)
+}
+
 // TerminalWidth returns the width of the terminal in columns.
 //
 // Returns the number of columns (characters) that fit in the terminal.
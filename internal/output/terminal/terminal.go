@@ -11,6 +11,7 @@ import (
 	"os"
 	"runtime"
 	"sync"
+	"sync/atomic"
 
 	"golang.org/x/term"
 )
@@ -52,16 +53,26 @@ func IsTTY(f *os.File) bool {
 	return term.IsTerminal(int(f.Fd()))
 }
 
+// colorDisabled is set by [DisableColor].
+var colorDisabled atomic.Bool
+
+// DisableColor turns off color output for the rest of the process, as if
+// NO_COLOR were set.
+func DisableColor() {
+	colorDisabled.Store(true)
+}
+
 // SupportsColor returns true if the current terminal supports color output.
 //
 // Color support is disabled when:
+//   - [DisableColor] has been called (the --no-color flag)
 //   - Output is not a TTY (piped to file or another process)
 //   - NO_COLOR environment variable is set (per no-color.org)
 //   - TERM is set to "dumb" (indicating basic terminal capabilities)
 //
 // Returns true otherwise, indicating ANSI color codes can be used.
 func SupportsColor() bool {
-	if !IsTTY(os.Stdout) {
+	if colorDisabled.Load() || !IsTTY(os.Stdout) {
 		return false
 	}
 
@@ -276,3 +276,12 @@ func TestSupportsColor(t *testing.T) {
 	result := SupportsColor()
 	_ = result
 }
+
+func TestDisableColor(t *testing.T) {
+	t.Cleanup(func() { colorDisabled.Store(false) })
+
+	DisableColor()
+	if SupportsColor() {
+		t.Error("SupportsColor() = true after DisableColor()")
+	}
+}
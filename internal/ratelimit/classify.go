@@ -0,0 +1,82 @@
+package ratelimit
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Sentinel errors for the Claude failures [Detector.Classify] recognizes.
+// Match them with [errors.Is].
+var (
+	// ErrRateLimited indicates Claude hit a usage or rate limit. Retrying
+	// after the limit resets can succeed.
+	ErrRateLimited = errors.New("claude usage limit reached")
+
+	// ErrAuthExpired indicates Claude's login or API key is no longer valid.
+	// Retrying cannot succeed until the user logs in again.
+	ErrAuthExpired = errors.New("claude authentication failed")
+
+	// ErrOverloaded indicates the Claude API was temporarily overloaded.
+	// Retrying after a short wait can succeed.
+	ErrOverloaded = errors.New("claude API overloaded")
+)
+
+// Error is a Claude failure classified by [Detector.Classify].
+//
+// It matches its Kind with [errors.Is], e.g. errors.Is(err, ErrRateLimited).
+type Error struct {
+	// Kind is ErrRateLimited, ErrAuthExpired, or ErrOverloaded.
+	Kind error
+
+	// Message is the output line the failure was recognized in.
+	Message string
+
+	// ResetTime is when a rate limit resets. Zero if unknown or not a
+	// rate limit.
+	ResetTime time.Time
+}
+
+// Error describes the failure, with the reset time of a rate limit if known.
+func (e *Error) Error() string {
+	if !e.ResetTime.IsZero() {
+		return fmt.Sprintf("%v until %s: %s", e.Kind, e.ResetTime.Format(time.Kitchen), e.Message)
+	}
+	return fmt.Sprintf("%v: %s", e.Kind, e.Message)
+}
+
+// WaitTime returns how long to wait for a rate limit to reset, with the same
+// buffer as [Detector.WaitTime]. Returns 0 if the reset time is unknown or
+// has passed.
+func (e *Error) WaitTime() time.Duration {
+	if e.ResetTime.IsZero() {
+		return 0
+	}
+	wait := time.Until(e.ResetTime)
+	if wait <= 0 {
+		return 0
+	}
+	return wait + 30*time.Second
+}
+
+// Unwrap returns the error's Kind.
+func (e *Error) Unwrap() error {
+	return e.Kind
+}
+
+// Classify checks a line of Claude output for a known error signature: a
+// usage or rate limit, expired authentication, or API overload.
+//
+// Returns nil if the line matches none of them.
+func (d *Detector) Classify(line string) *Error {
+	if info := d.CheckLine(line); info.IsRateLimit {
+		return &Error{Kind: ErrRateLimited, Message: line, ResetTime: info.ResetTime}
+	}
+	if d.authPattern.MatchString(line) {
+		return &Error{Kind: ErrAuthExpired, Message: line}
+	}
+	if d.overloadedPattern.MatchString(line) {
+		return &Error{Kind: ErrOverloaded, Message: line}
+	}
+	return nil
+}
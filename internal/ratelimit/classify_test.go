@@ -0,0 +1,63 @@
+package ratelimit
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetector_Classify(t *testing.T) {
+	d := NewDetector()
+
+	tests := []struct {
+		name string
+		line string
+		want error
+	}{
+		{"usage limit", "Claude usage limit reached. Your limit will reset at 1pm (Etc/GMT+5)", ErrRateLimited},
+		{"usage limit result", "Claude AI usage limit reached|1760000000", ErrRateLimited},
+		{"API rate limit", `API Error: 429 {"type":"error","error":{"type":"rate_limit_error"}}`, ErrRateLimited},
+		{"expired token", "OAuth token has expired. Please obtain a new token or refresh your existing token.", ErrAuthExpired},
+		{"invalid key", "Invalid API key · Please run /login", ErrAuthExpired},
+		{"overloaded", `API Error: 529 {"type":"error","error":{"type":"overloaded_error","message":"Overloaded"}}`, ErrOverloaded},
+		{"other error", "Error: connection failed", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := d.Classify(tt.line)
+			if tt.want == nil {
+				assert.Nil(t, err)
+				return
+			}
+			require.NotNil(t, err)
+			assert.ErrorIs(t, err, tt.want)
+			assert.Equal(t, tt.line, err.Message)
+		})
+	}
+}
+
+func TestDetector_Classify_ResetEpoch(t *testing.T) {
+	err := NewDetector().Classify("Claude AI usage limit reached|1760000000")
+	require.NotNil(t, err)
+	assert.Equal(t, time.Unix(1760000000, 0), err.ResetTime)
+}
+
+func TestError(t *testing.T) {
+	err := &Error{Kind: ErrOverloaded, Message: "Overloaded"}
+	assert.Equal(t, "claude API overloaded: Overloaded", err.Error())
+	assert.Zero(t, err.WaitTime())
+
+	// The kind survives wrapping
+	wrapped := errors.Join(errors.New("workflow failed"), err)
+	assert.ErrorIs(t, wrapped, ErrOverloaded)
+	assert.NotErrorIs(t, wrapped, ErrRateLimited)
+
+	limited := &Error{Kind: ErrRateLimited, ResetTime: time.Now().Add(time.Hour)}
+	assert.Greater(t, limited.WaitTime(), time.Hour)
+	limited.ResetTime = time.Now().Add(-time.Hour)
+	assert.Zero(t, limited.WaitTime())
+}
@@ -2,11 +2,14 @@
 //
 // The detector parses stderr output from Claude CLI to identify rate limit
 // errors and extract the reset time. This enables automatic retry with
-// intelligent wait times.
+// intelligent wait times. [Detector.Classify] also recognizes expired
+// authentication and API overload, so callers can tell failures worth
+// retrying from those that are not.
 package ratelimit
 
 import (
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -35,6 +38,15 @@ type Detector struct {
 
 	// resetTimePattern extracts the reset time from rate limit messages.
 	resetTimePattern *regexp.Regexp
+
+	// resetEpochPattern extracts the Unix reset time Claude CLI appends to
+	// usage limit results, e.g. "Claude AI usage limit reached|1760000000".
+	resetEpochPattern *regexp.Regexp
+
+	// authPattern and overloadedPattern match the other errors Classify
+	// recognizes.
+	authPattern       *regexp.Regexp
+	overloadedPattern *regexp.Regexp
 }
 
 // NewDetector creates a new rate limit detector.
@@ -42,11 +54,20 @@ func NewDetector() *Detector {
 	return &Detector{
 		// Match rate limit messages like:
 		// "Claude usage limit reached. Your limit will reset at 1pm (Etc/GMT+5)"
-		rateLimitPattern: regexp.MustCompile(`(?i)usage limit reached|rate limit|quota exceeded`),
+		rateLimitPattern: regexp.MustCompile(`(?i)usage limit reached|rate[ _]limit|quota exceeded`),
 
 		// Extract time from messages like:
 		// "Your limit will reset at 1pm (Etc/GMT+5)" - captures just the time part
 		resetTimePattern: regexp.MustCompile(`reset at ([^([]+)`),
+
+		resetEpochPattern: regexp.MustCompile(`limit reached\|(\d+)`),
+
+		// Match messages like "OAuth token has expired" or
+		// "API Error: 401 {"type":"error","error":{"type":"authentication_error",...}}"
+		authPattern: regexp.MustCompile(`(?i)authentication_error|token has expired|invalid api key|please run /login`),
+
+		// Match messages like "API Error: 529 {"type":"error","error":{"type":"overloaded_error",...}}"
+		overloadedPattern: regexp.MustCompile(`(?i)overloaded`),
 	}
 }
 
@@ -65,7 +86,11 @@ func (d *Detector) CheckLine(line string) ErrorInfo {
 	}
 
 	// Try to extract reset time
-	if matches := d.resetTimePattern.FindStringSubmatch(line); len(matches) > 1 {
+	if matches := d.resetEpochPattern.FindStringSubmatch(line); len(matches) > 1 {
+		if epoch, err := strconv.ParseInt(matches[1], 10, 64); err == nil {
+			info.ResetTime = time.Unix(epoch, 0)
+		}
+	} else if matches := d.resetTimePattern.FindStringSubmatch(line); len(matches) > 1 {
 		// Attempt to parse various time formats
 		resetTime := d.parseResetTime(strings.TrimSpace(matches[1]))
 		if !resetTime.IsZero() {
@@ -0,0 +1,80 @@
+package router
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrDependencyCycle is returned by [OrderStories] when stories depend on
+// each other in a cycle.
+var ErrDependencyCycle = errors.New("story dependency cycle")
+
+// ErrUnmetDependency is returned by [OrderStories] when a story depends on a
+// story that is neither in the run nor already satisfied.
+var ErrUnmetDependency = errors.New("unmet story dependency")
+
+// OrderStories sorts storyKeys so that every story runs after the stories it
+// depends on.
+//
+// dependsOn returns the story keys a story depends on. Dependencies that are
+// not in storyKeys must be satisfied, as reported by satisfied (typically,
+// the story is already done); otherwise [ErrUnmetDependency] is returned.
+// The sort is stable: stories keep their given order unless a dependency
+// requires otherwise. [ErrDependencyCycle] is returned if the dependencies
+// among storyKeys form a cycle.
+func OrderStories(storyKeys []string, dependsOn func(storyKey string) []string, satisfied func(storyKey string) bool) ([]string, error) {
+	position := make(map[string]int, len(storyKeys))
+	for i, key := range storyKeys {
+		position[key] = i
+	}
+
+	// Count in-run dependencies and record the reverse edges
+	pending := make([]int, len(storyKeys))
+	dependents := make([][]int, len(storyKeys))
+	for i, key := range storyKeys {
+		for _, dep := range dependsOn(key) {
+			j, inRun := position[dep]
+			if !inRun {
+				if !satisfied(dep) {
+					return nil, fmt.Errorf("%w: story %s depends on %s, which is not done; add it to the run", ErrUnmetDependency, key, dep)
+				}
+				continue
+			}
+			if j == i {
+				return nil, fmt.Errorf("%w: story %s depends on itself", ErrDependencyCycle, key)
+			}
+			pending[i]++
+			dependents[j] = append(dependents[j], i)
+		}
+	}
+
+	// Repeatedly take the earliest story whose dependencies have all run
+	ordered := make([]string, 0, len(storyKeys))
+	placed := make([]bool, len(storyKeys))
+	for len(ordered) < len(storyKeys) {
+		next := -1
+		for i := range storyKeys {
+			if !placed[i] && pending[i] == 0 {
+				next = i
+				break
+			}
+		}
+		if next < 0 {
+			var cycle []string
+			for i, key := range storyKeys {
+				if !placed[i] {
+					cycle = append(cycle, key)
+				}
+			}
+			return nil, fmt.Errorf("%w among stories %s", ErrDependencyCycle, strings.Join(cycle, ", "))
+		}
+		placed[next] = true
+		ordered = append(ordered, storyKeys[next])
+		for _, dependent := range dependents[next] {
+			pending[dependent]--
+		}
+	}
+
+	return ordered, nil
+}
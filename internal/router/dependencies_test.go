@@ -0,0 +1,68 @@
+package router
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestOrderStories(t *testing.T) {
+	deps := map[string][]string{
+		"6-6": {"6-5"},
+		"6-5": {"6-2"},
+		"6-3": {"6-1"},
+	}
+	dependsOn := func(key string) []string { return deps[key] }
+	done := map[string]bool{"6-1": true}
+	satisfied := func(key string) bool { return done[key] }
+
+	tests := []struct {
+		name    string
+		keys    []string
+		want    []string
+		wantErr error
+	}{
+		{"no dependencies keep order", []string{"6-2", "6-4"}, []string{"6-2", "6-4"}, nil},
+		{"dependency moved first", []string{"6-6", "6-5", "6-2"}, []string{"6-2", "6-5", "6-6"}, nil},
+		{"stable for independent stories", []string{"6-4", "6-5", "6-2", "6-7"}, []string{"6-4", "6-2", "6-5", "6-7"}, nil},
+		{"done dependency outside run", []string{"6-3"}, []string{"6-3"}, nil},
+		{"unmet dependency outside run", []string{"6-5"}, nil, ErrUnmetDependency},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := OrderStories(tt.keys, dependsOn, satisfied)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("OrderStories(%v) error = %v, want %v", tt.keys, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("OrderStories(%v) unexpected error: %v", tt.keys, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("OrderStories(%v) = %v, want %v", tt.keys, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOrderStories_Cycle(t *testing.T) {
+	deps := map[string][]string{"6-1": {"6-2"}, "6-2": {"6-1"}, "6-3": {"6-3"}}
+	dependsOn := func(key string) []string { return deps[key] }
+	satisfied := func(string) bool { return false }
+
+	_, err := OrderStories([]string{"6-1", "6-2"}, dependsOn, satisfied)
+	if !errors.Is(err, ErrDependencyCycle) {
+		t.Fatalf("error = %v, want ErrDependencyCycle", err)
+	}
+	if want := "story dependency cycle among stories 6-1, 6-2"; err.Error() != want {
+		t.Errorf("error = %q, want %q", err.Error(), want)
+	}
+
+	_, err = OrderStories([]string{"6-3"}, dependsOn, satisfied)
+	if !errors.Is(err, ErrDependencyCycle) {
+		t.Errorf("self dependency error = %v, want ErrDependencyCycle", err)
+	}
+}
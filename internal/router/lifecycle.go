@@ -12,13 +12,13 @@ import (
 type LifecycleStep struct {
 	// Workflow is the name of the workflow to execute for this step.
 	// Must correspond to a key in the workflows configuration.
-	Workflow string
+	Workflow string `json:"workflow"`
 
 	// NextStatus is the status to set after this step completes successfully.
 	// The final step typically sets status to "done".
-	NextStatus status.Status
+	NextStatus status.Status `json:"next_status"`
 
 	// Model is the Claude model to use for this workflow (optional).
 	// If empty, the default model is used.
-	Model string
+	Model string `json:"model,omitempty"`
 }
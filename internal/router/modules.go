@@ -0,0 +1,85 @@
+package router
+
+import (
+	"bmaduum/internal/manifest"
+	"bmaduum/internal/status"
+)
+
+// ModuleStep describes a lifecycle step injected on behalf of a BMAD module.
+type ModuleStep struct {
+	// Module is the module whose installation triggers the injection.
+	Module string
+
+	// After is the workflow the step is inserted after, if set.
+	After string
+
+	// Before is the workflow the step is inserted before, if set.
+	Before string
+
+	// Workflow is the injected workflow.
+	Workflow string
+
+	// NextStatus is the status set after the injected workflow completes.
+	NextStatus status.Status
+}
+
+// ModuleSteps lists the built-in lifecycle steps injected for well-known
+// modules that do not declare their own injections in the module manifest.
+//
+// Several modules may request the same workflow; it is only inserted once.
+var ModuleSteps = []ModuleStep{
+	{Module: "sdet", After: "code-review", Workflow: "test-automation", NextStatus: status.StatusDone},
+	{Module: "tea", After: "code-review", Workflow: "test-automation", NextStatus: status.StatusDone},
+}
+
+// ModuleStepsFor returns the lifecycle steps to inject for the modules
+// installed according to mm, in module order.
+//
+// A module's declared injections (see [manifest.Module.Injects]) are used
+// when present; otherwise the module's entries in [ModuleSteps] apply.
+// Returns nil if mm is nil.
+func ModuleStepsFor(mm *manifest.ModuleManifest) []ModuleStep {
+	if mm == nil {
+		return nil
+	}
+
+	var steps []ModuleStep
+	for _, m := range mm.Modules {
+		if len(m.Injects) == 0 {
+			for _, step := range ModuleSteps {
+				if step.Module == m.Name {
+					steps = append(steps, step)
+				}
+			}
+			continue
+		}
+
+		for _, inj := range m.Injects {
+			nextStatus := status.Status(inj.NextStatus)
+			if nextStatus == "" {
+				nextStatus = status.StatusDone
+			}
+			steps = append(steps, ModuleStep{
+				Module:     m.Name,
+				After:      inj.After,
+				Before:     inj.Before,
+				Workflow:   inj.Workflow,
+				NextStatus: nextStatus,
+			})
+		}
+	}
+	return steps
+}
+
+// ApplyModules injects the lifecycle steps for the modules installed
+// according to mm (see [ModuleStepsFor]) using [Router.InsertStepAfter]
+// or [Router.InsertStepBefore].
+func (r *Router) ApplyModules(mm *manifest.ModuleManifest) {
+	for _, step := range ModuleStepsFor(mm) {
+		if step.Before != "" {
+			r.InsertStepBefore(step.Before, step.Workflow, step.NextStatus)
+		} else {
+			r.InsertStepAfter(step.After, step.Workflow, step.NextStatus)
+		}
+	}
+}
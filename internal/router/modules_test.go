@@ -0,0 +1,106 @@
+package router
+
+import (
+	"reflect"
+	"testing"
+
+	"bmaduum/internal/manifest"
+	"bmaduum/internal/status"
+)
+
+func TestModuleStepsFor(t *testing.T) {
+	if steps := ModuleStepsFor(nil); steps != nil {
+		t.Errorf("ModuleStepsFor(nil) = %v, want nil", steps)
+	}
+
+	mm := &manifest.ModuleManifest{Modules: []manifest.Module{{Name: "bmm"}, {Name: "sdet"}, {Name: "tea"}}}
+	steps := ModuleStepsFor(mm)
+	if len(steps) != 2 {
+		t.Fatalf("ModuleStepsFor() returned %d steps, want 2", len(steps))
+	}
+	if steps[0].Module != "sdet" || steps[1].Module != "tea" {
+		t.Errorf("ModuleStepsFor() modules = %q, %q, want sdet, tea", steps[0].Module, steps[1].Module)
+	}
+	if steps[0].Workflow != "test-automation" {
+		t.Errorf("ModuleStepsFor()[0].Workflow = %q, want test-automation", steps[0].Workflow)
+	}
+
+	bmmOnly := &manifest.ModuleManifest{Modules: []manifest.Module{{Name: "bmm"}}}
+	if steps := ModuleStepsFor(bmmOnly); len(steps) != 0 {
+		t.Errorf("ModuleStepsFor(bmm only) = %v, want none", steps)
+	}
+}
+
+func TestRouter_ApplyModules(t *testing.T) {
+	r := NewRouter()
+	r.ApplyModules(&manifest.ModuleManifest{Modules: []manifest.Module{{Name: "sdet"}, {Name: "tea"}}})
+
+	steps, err := r.GetLifecycle(status.StatusReview)
+	if err != nil {
+		t.Fatalf("GetLifecycle() unexpected err: %v", err)
+	}
+
+	var workflows []string
+	for _, step := range steps {
+		workflows = append(workflows, step.Workflow)
+	}
+
+	// Injected once even though two modules request it
+	want := []string{"code-review", "test-automation", "git-commit"}
+	if !reflect.DeepEqual(workflows, want) {
+		t.Errorf("GetLifecycle() workflows = %v, want %v", workflows, want)
+	}
+}
+
+func TestRouter_ApplyModules_DeclaredInjects(t *testing.T) {
+	r := NewRouter()
+	r.ApplyModules(&manifest.ModuleManifest{Modules: []manifest.Module{
+		{Name: "security", Injects: []manifest.StepInjection{
+			{Workflow: "security-review", Before: "git-commit"},
+		}},
+		// Declared injections replace the built-in sdet default
+		{Name: "sdet", Injects: []manifest.StepInjection{
+			{Workflow: "sdet-plan", After: "create-story", NextStatus: "ready-for-dev"},
+		}},
+	}})
+
+	steps, err := r.GetLifecycle(status.StatusBacklog)
+	if err != nil {
+		t.Fatalf("GetLifecycle() unexpected err: %v", err)
+	}
+
+	var workflows []string
+	for _, step := range steps {
+		workflows = append(workflows, step.Workflow)
+	}
+
+	want := []string{"create-story", "sdet-plan", "dev-story", "code-review", "security-review", "git-commit"}
+	if !reflect.DeepEqual(workflows, want) {
+		t.Errorf("GetLifecycle() workflows = %v, want %v", workflows, want)
+	}
+	if steps[4].NextStatus != status.StatusDone {
+		t.Errorf("security-review NextStatus = %q, want %q", steps[4].NextStatus, status.StatusDone)
+	}
+}
+
+func TestRouter_InsertStepBefore(t *testing.T) {
+	r := NewRouter()
+	r.InsertStepBefore("code-review", "lint", status.StatusReview)
+
+	// A story in review now starts at the injected step
+	steps, err := r.GetLifecycle(status.StatusReview)
+	if err != nil {
+		t.Fatalf("GetLifecycle() unexpected err: %v", err)
+	}
+	if len(steps) == 0 || steps[0].Workflow != "lint" {
+		t.Fatalf("GetLifecycle(review) = %v, want lint first", steps)
+	}
+
+	// Unknown anchor and duplicate workflow are no-ops
+	before := len(r.chain)
+	r.InsertStepBefore("missing", "other", status.StatusDone)
+	r.InsertStepBefore("git-commit", "lint", status.StatusDone)
+	if len(r.chain) != before {
+		t.Errorf("chain length = %d, want %d", len(r.chain), before)
+	}
+}
@@ -56,6 +56,19 @@ type Router struct {
 
 	// statusChainIndex maps trigger status → index into chain where execution starts.
 	statusChainIndex map[status.Status]int
+
+	// branchPoints holds workflows that may set the story status themselves,
+	// e.g. code-review sending a story back to in-progress.
+	branchPoints map[string]bool
+
+	// aliases maps alternative status words to the status they route as.
+	aliases map[status.Status]status.Status
+}
+
+// defaultBranchPoints returns the workflows treated as branch points by
+// default: code-review, which may send a story back to development.
+func defaultBranchPoints() map[string]bool {
+	return map[string]bool{"code-review": true}
 }
 
 // NewRouter creates a [Router] with the default hardcoded routing rules.
@@ -86,9 +99,41 @@ func NewRouter() *Router {
 			status.StatusInProgress:  1,
 			status.StatusReview:      2,
 		},
+		branchPoints: defaultBranchPoints(),
 	}
 }
 
+// ManifestOption is a functional option for building a [Router] from a
+// workflow manifest.
+type ManifestOption func(*manifestOptions)
+
+// manifestOptions holds the settings of the [ManifestOption] values passed
+// to [NewRouterFromManifest].
+type manifestOptions struct {
+	phase string
+}
+
+// WithPhase routes only the manifest entries of the given BMAD phase (e.g.,
+// "3" for Implementation), so planning workflows of other phases stay out
+// of the lifecycle chain. An empty phase routes every entry.
+func WithPhase(phase string) ManifestOption {
+	return func(o *manifestOptions) {
+		o.phase = phase
+	}
+}
+
+// filterManifest returns the part of m selected by opts.
+func filterManifest(m *manifest.Manifest, opts []ManifestOption) *manifest.Manifest {
+	var o manifestOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.phase == "" {
+		return m
+	}
+	return &manifest.Manifest{Entries: m.GetEntriesForPhase(o.phase)}
+}
+
 // NewRouterFromManifest creates a [Router] from a BMAD v6 workflow manifest.
 //
 // The manifest entries define:
@@ -97,11 +142,14 @@ func NewRouter() *Router {
 //   - Status transitions (from next_status fields)
 //
 // Entries without a trigger_status are included in the lifecycle chain but
-// are not directly triggerable by status (e.g., git-commit).
-func NewRouterFromManifest(m *manifest.Manifest) *Router {
+// are not directly triggerable by status (e.g., git-commit). Options such as
+// [WithPhase] select which entries are routed.
+func NewRouterFromManifest(m *manifest.Manifest, opts ...ManifestOption) *Router {
+	m = filterManifest(m, opts)
 	r := &Router{
 		statusWorkflow:   make(map[status.Status]string),
 		statusChainIndex: make(map[status.Status]int),
+		branchPoints:     defaultBranchPoints(),
 	}
 
 	// Build the chain from unique workflows in manifest order
@@ -141,11 +189,25 @@ func NewRouterFromManifest(m *manifest.Manifest) *Router {
 	return r
 }
 
+// NewValidatedRouterFromManifest is like [NewRouterFromManifest], but first
+// checks the entries selected by opts with [manifest.Manifest.Validate]. If
+// any problem is found, it returns them all joined into one error and no
+// router.
+func NewValidatedRouterFromManifest(m *manifest.Manifest, opts ...ManifestOption) (*Router, error) {
+	m = filterManifest(m, opts)
+	if problems := m.Validate(); len(problems) > 0 {
+		return nil, errors.Join(problems...)
+	}
+	return NewRouterFromManifest(m), nil
+}
+
 // GetWorkflow returns the single workflow name for the given story status.
 //
+// Status aliases are resolved first (see [Router.SetStatusAlias]).
 // Returns [ErrStoryComplete] for done stories (caller should skip, not fail).
 // Returns [ErrUnknownStatus] for unrecognized status values.
 func (r *Router) GetWorkflow(s status.Status) (string, error) {
+	s = r.ResolveAlias(s)
 	if s == status.StatusDone {
 		return "", ErrStoryComplete
 	}
@@ -160,9 +222,11 @@ func (r *Router) GetWorkflow(s status.Status) (string, error) {
 // GetLifecycle returns the complete sequence of lifecycle steps from the given
 // status through to completion.
 //
+// Status aliases are resolved first (see [Router.SetStatusAlias]).
 // Returns [ErrStoryComplete] for done stories (caller should skip, not fail).
 // Returns [ErrUnknownStatus] for unrecognized status values.
 func (r *Router) GetLifecycle(s status.Status) ([]LifecycleStep, error) {
+	s = r.ResolveAlias(s)
 	if s == status.StatusDone {
 		return nil, ErrStoryComplete
 	}
@@ -185,6 +249,33 @@ func (r *Router) GetLifecycle(s status.Status) ([]LifecycleStep, error) {
 	return steps, nil
 }
 
+// Steps returns the full lifecycle chain in order, from the first workflow
+// through to completion, regardless of status.
+func (r *Router) Steps() []LifecycleStep {
+	steps := make([]LifecycleStep, len(r.chain))
+	for i, cs := range r.chain {
+		steps[i] = LifecycleStep{
+			Workflow:   cs.Workflow,
+			NextStatus: cs.NextStatus,
+		}
+	}
+	return steps
+}
+
+// ChainIndex returns the position in the lifecycle chain of the step that
+// status s triggers, for ordering statuses along the lifecycle. Statuses
+// that trigger the same step share an index (ready-for-dev and in-progress
+// by default), and done comes after every step. Status aliases are resolved
+// first. ok is false for statuses the router does not recognize.
+func (r *Router) ChainIndex(s status.Status) (index int, ok bool) {
+	s = r.ResolveAlias(s)
+	if s == status.StatusDone {
+		return len(r.chain), true
+	}
+	index, ok = r.statusChainIndex[s]
+	return index, ok
+}
+
 // InsertStepAfter inserts a new lifecycle step after the named workflow in the chain.
 //
 // This is used to inject module-specific steps (e.g., test-automation after code-review
@@ -232,6 +323,187 @@ func (r *Router) InsertStepAfter(afterWorkflow string, newWorkflow string, nextS
 	}
 }
 
+// InsertStepBefore inserts a new lifecycle step before the named workflow in the chain.
+//
+// Statuses whose lifecycle started at beforeWorkflow now start at the new step,
+// so the injected workflow runs first. Single-step routing via
+// [Router.GetWorkflow] is unchanged.
+//
+// If beforeWorkflow is not found in the chain, InsertStepBefore is a no-op.
+// If the workflow already exists in the chain, InsertStepBefore is a no-op (avoids duplicates).
+func (r *Router) InsertStepBefore(beforeWorkflow string, newWorkflow string, nextStatus status.Status) {
+	// Check if the new workflow already exists in the chain
+	for _, step := range r.chain {
+		if step.Workflow == newWorkflow {
+			return
+		}
+	}
+
+	// Find the index of beforeWorkflow
+	insertIdx := -1
+	for i, step := range r.chain {
+		if step.Workflow == beforeWorkflow {
+			insertIdx = i
+			break
+		}
+	}
+	if insertIdx < 0 {
+		return
+	}
+
+	// Grow the chain and shift elements
+	r.chain = append(r.chain, chainStep{})
+	copy(r.chain[insertIdx+1:], r.chain[insertIdx:])
+	r.chain[insertIdx] = chainStep{
+		Workflow:   newWorkflow,
+		NextStatus: nextStatus,
+	}
+
+	// Update statusChainIndex: indices past insertIdx shift by 1; statuses
+	// starting at insertIdx now start at the new step
+	for s, idx := range r.statusChainIndex {
+		if idx > insertIdx {
+			r.statusChainIndex[s] = idx + 1
+		}
+	}
+}
+
+// RemoveStep removes the named workflow from the lifecycle chain.
+//
+// This is the counterpart of [Router.InsertStepAfter], e.g. to drop
+// code-review in a fast-path mode. The previous step takes over the removed
+// step's NextStatus, so the chain still reaches the same status. Statuses
+// whose lifecycle started at the removed step are no longer routed, by either
+// [Router.GetLifecycle] or [Router.GetWorkflow].
+//
+// If workflow is not found in the chain, RemoveStep is a no-op.
+func (r *Router) RemoveStep(workflow string) {
+	removeIdx := -1
+	for i, step := range r.chain {
+		if step.Workflow == workflow {
+			removeIdx = i
+			break
+		}
+	}
+	if removeIdx < 0 {
+		return
+	}
+
+	// Rewire the previous step to the removed step's transition
+	if removeIdx > 0 {
+		r.chain[removeIdx-1].NextStatus = r.chain[removeIdx].NextStatus
+	}
+	r.chain = append(r.chain[:removeIdx], r.chain[removeIdx+1:]...)
+
+	// Update statusChainIndex: statuses starting at the removed step are
+	// dropped and indices past it shift back by 1
+	for s, idx := range r.statusChainIndex {
+		switch {
+		case idx == removeIdx:
+			delete(r.statusChainIndex, s)
+		case idx > removeIdx:
+			r.statusChainIndex[s] = idx - 1
+		}
+	}
+	for s, w := range r.statusWorkflow {
+		if w == workflow {
+			delete(r.statusWorkflow, s)
+		}
+	}
+}
+
+// ReplaceStep replaces the named workflow in the chain, keeping its position.
+//
+// This lets a module substitute its own workflow for a built-in one (e.g.,
+// ai-review in place of code-review). The replacement step transitions to
+// nextStatus, and statuses that routed to oldWorkflow via
+// [Router.GetWorkflow] now route to newWorkflow. If oldWorkflow was a branch
+// point (see [Router.SetBranchPoint]), newWorkflow becomes one instead.
+//
+// If oldWorkflow is not found in the chain, ReplaceStep is a no-op.
+// If newWorkflow already exists in the chain, ReplaceStep is a no-op (avoids duplicates).
+func (r *Router) ReplaceStep(oldWorkflow string, newWorkflow string, nextStatus status.Status) {
+	replaceIdx := -1
+	for i, step := range r.chain {
+		if step.Workflow == newWorkflow {
+			return
+		}
+		if step.Workflow == oldWorkflow && replaceIdx < 0 {
+			replaceIdx = i
+		}
+	}
+	if replaceIdx < 0 {
+		return
+	}
+
+	r.chain[replaceIdx] = chainStep{
+		Workflow:   newWorkflow,
+		NextStatus: nextStatus,
+	}
+
+	// statusChainIndex is unchanged: the step keeps its position
+	for s, w := range r.statusWorkflow {
+		if w == oldWorkflow {
+			r.statusWorkflow[s] = newWorkflow
+		}
+	}
+	if r.branchPoints[oldWorkflow] {
+		delete(r.branchPoints, oldWorkflow)
+		r.SetBranchPoint(newWorkflow, true)
+	}
+}
+
+// SetBranchPoint marks or unmarks workflow as a branch point.
+//
+// A branch point is a step whose workflow may itself move the story to an
+// earlier status, such as code-review returning a story to in-progress when
+// it finds issues. The lifecycle executor re-reads the story status after a
+// branch point and loops back if the workflow changed it. code-review is a
+// branch point by default.
+func (r *Router) SetBranchPoint(workflow string, branches bool) {
+	if r.branchPoints == nil {
+		r.branchPoints = make(map[string]bool)
+	}
+	if branches {
+		r.branchPoints[workflow] = true
+	} else {
+		delete(r.branchPoints, workflow)
+	}
+}
+
+// IsBranchPoint reports whether workflow is a branch point
+// (see [Router.SetBranchPoint]).
+func (r *Router) IsBranchPoint(workflow string) bool {
+	return r.branchPoints[workflow]
+}
+
+// SetStatusAlias makes the router treat alias as canonical, so a status
+// word from another BMAD template, such as "qa" or "in_progress", routes
+// like "review" or "in-progress" without the bmad-help fallback.
+//
+// Aliases are resolved once, not chained. An empty canonical removes the
+// alias. An alias for a status the router already routes takes precedence
+// over it.
+func (r *Router) SetStatusAlias(alias, canonical status.Status) {
+	if r.aliases == nil {
+		r.aliases = make(map[status.Status]status.Status)
+	}
+	if canonical == "" {
+		delete(r.aliases, alias)
+		return
+	}
+	r.aliases[alias] = canonical
+}
+
+// ResolveAlias returns the status s routes as: its canonical status if s is
+// an alias registered with [Router.SetStatusAlias], and s otherwise.
+func (r *Router) ResolveAlias(s status.Status) status.Status {
+	if canonical, ok := r.aliases[s]; ok {
+		return canonical
+	}
+	return s
+}
+
 // defaultRouter is the package-level router used by backward-compatible functions.
 var defaultRouter = NewRouter()
 
@@ -275,3 +547,9 @@ func GetWorkflow(s status.Status) (string, error) {
 func GetLifecycle(s status.Status) ([]LifecycleStep, error) {
 	return defaultRouter.GetLifecycle(s)
 }
+
+// IsBranchPoint reports whether workflow is a branch point in the default
+// hardcoded router. See [Router.SetBranchPoint].
+func IsBranchPoint(workflow string) bool {
+	return defaultRouter.IsBranchPoint(workflow)
+}
@@ -2,6 +2,7 @@ package router
 
 import (
 	"errors"
+	"strings"
 	"testing"
 
 	"bmaduum/internal/manifest"
@@ -214,6 +215,82 @@ func TestNewRouterFromManifest_GetWorkflow(t *testing.T) {
 	}
 }
 
+func TestNewRouterFromManifest_WithPhase(t *testing.T) {
+	m, err := manifest.ReadFromString(`phase,workflow,agent,command,trigger_status,next_status
+2,create-prd,PM,/create-prd,backlog,backlog
+3,create-story,SM,/create-story,backlog,ready-for-dev
+3,dev-story,Dev,/dev-story,ready-for-dev,review
+3,code-review,QA,/code-review,review,done
+`)
+	if err != nil {
+		t.Fatalf("Failed to parse manifest: %v", err)
+	}
+
+	r := NewRouterFromManifest(m, WithPhase("3"))
+	var got []string
+	for _, step := range r.Steps() {
+		got = append(got, step.Workflow)
+	}
+	want := []string{"create-story", "dev-story", "code-review"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("Steps() = %v, want %v", got, want)
+	}
+	if wf, _ := r.GetWorkflow(status.StatusBacklog); wf != "create-story" {
+		t.Errorf("GetWorkflow(backlog) = %q, want create-story", wf)
+	}
+
+	// Without the option the planning workflow is in the chain too
+	if steps := NewRouterFromManifest(m).Steps(); len(steps) != 4 || steps[0].Workflow != "create-prd" {
+		t.Errorf("unfiltered Steps() = %v, want create-prd first", steps)
+	}
+
+	// Validation applies to the selected phase only
+	if _, err := NewValidatedRouterFromManifest(m, WithPhase("3")); err != nil {
+		t.Errorf("phase 3 should be valid: %v", err)
+	}
+	if _, err := NewValidatedRouterFromManifest(m); err == nil {
+		t.Error("whole manifest should be invalid: backlog triggers two workflows")
+	}
+}
+
+func TestNewValidatedRouterFromManifest(t *testing.T) {
+	m, err := manifest.ReadFromString(`workflow,trigger_status,next_status
+dev-story,ready-for-dev,review
+code-review,review,in-progress
+fix,in-progress,review
+git-commit,,backlog
+`)
+	if err != nil {
+		t.Fatalf("Failed to parse manifest: %v", err)
+	}
+
+	r, err := NewValidatedRouterFromManifest(m)
+	if r != nil {
+		t.Error("expected no router for an invalid manifest")
+	}
+	want := "workflow git-commit: next_status backlog does not trigger any workflow\n" +
+		"workflow git-commit: last entry must set next_status to done\n" +
+		"status cycle: review -> in-progress -> review"
+	if err == nil || err.Error() != want {
+		t.Errorf("error = %v, want %q", err, want)
+	}
+
+	m, err = manifest.ReadFromString(`workflow,trigger_status,next_status
+dev-story,ready-for-dev,review
+code-review,review,done
+`)
+	if err != nil {
+		t.Fatalf("Failed to parse manifest: %v", err)
+	}
+	r, err = NewValidatedRouterFromManifest(m)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, _ := r.GetWorkflow(status.StatusReview); got != "code-review" {
+		t.Errorf("GetWorkflow(review) = %q, want code-review", got)
+	}
+}
+
 func TestNewRouterFromManifest_GetLifecycle(t *testing.T) {
 	csv := `phase,workflow,agent,command,trigger_status,next_status
 3,create-story,SM,/create-story,backlog,ready-for-dev
@@ -474,6 +551,273 @@ func TestRouter_InsertStepAfter_PreservesGetWorkflow(t *testing.T) {
 	}
 }
 
+func TestRouter_RemoveStep(t *testing.T) {
+	r := NewRouter()
+
+	// Remove code-review for a fast path
+	r.RemoveStep("code-review")
+
+	steps, err := r.GetLifecycle(status.StatusBacklog)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	want := []LifecycleStep{
+		{Workflow: "create-story", NextStatus: status.StatusReadyForDev},
+		{Workflow: "dev-story", NextStatus: status.StatusDone},
+		{Workflow: "git-commit", NextStatus: status.StatusDone},
+	}
+	if len(steps) != len(want) {
+		t.Fatalf("got %d steps, want %d", len(steps), len(want))
+	}
+	for i := range want {
+		if steps[i] != want[i] {
+			t.Errorf("step[%d] = %+v, want %+v", i, steps[i], want[i])
+		}
+	}
+
+	// Indices past the removed step shift back
+	devSteps, err := r.GetLifecycle(status.StatusInProgress)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(devSteps) != 2 || devSteps[0].Workflow != "dev-story" {
+		t.Errorf("in-progress steps = %+v, want dev-story, git-commit", devSteps)
+	}
+
+	// review started at the removed step and is no longer routed
+	if _, err := r.GetLifecycle(status.StatusReview); !errors.Is(err, ErrUnknownStatus) {
+		t.Errorf("GetLifecycle(review) err = %v, want ErrUnknownStatus", err)
+	}
+	if _, err := r.GetWorkflow(status.StatusReview); !errors.Is(err, ErrUnknownStatus) {
+		t.Errorf("GetWorkflow(review) err = %v, want ErrUnknownStatus", err)
+	}
+}
+
+func TestRouter_RemoveStep_FirstStep(t *testing.T) {
+	r := NewRouter()
+	r.RemoveStep("create-story")
+
+	if _, err := r.GetLifecycle(status.StatusBacklog); !errors.Is(err, ErrUnknownStatus) {
+		t.Errorf("GetLifecycle(backlog) err = %v, want ErrUnknownStatus", err)
+	}
+	steps, err := r.GetLifecycle(status.StatusReadyForDev)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(steps) != 3 || steps[0].Workflow != "dev-story" {
+		t.Errorf("ready-for-dev steps = %+v, want dev-story first of 3", steps)
+	}
+}
+
+func TestRouter_RemoveStep_WorkflowNotFound(t *testing.T) {
+	r := NewRouter()
+
+	// Removing a non-existent workflow should be a no-op
+	r.RemoveStep("nonexistent")
+
+	steps, err := r.GetLifecycle(status.StatusBacklog)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(steps) != 4 {
+		t.Errorf("chain should be unchanged, got %d steps, want 4", len(steps))
+	}
+}
+
+func TestRouter_ReplaceStep(t *testing.T) {
+	r := NewRouter()
+
+	// Replace code-review with a module's review workflow
+	r.ReplaceStep("code-review", "ai-review", status.StatusDone)
+
+	workflow, err := r.GetWorkflow(status.StatusReview)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if workflow != "ai-review" {
+		t.Errorf("GetWorkflow(review) = %q, want ai-review", workflow)
+	}
+
+	steps, err := r.GetLifecycle(status.StatusBacklog)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	wantWorkflows := []string{"create-story", "dev-story", "ai-review", "git-commit"}
+	if len(steps) != len(wantWorkflows) {
+		t.Fatalf("got %d steps, want %d", len(steps), len(wantWorkflows))
+	}
+	for i, want := range wantWorkflows {
+		if steps[i].Workflow != want {
+			t.Errorf("step[%d].Workflow = %q, want %q", i, steps[i].Workflow, want)
+		}
+	}
+
+	reviewSteps, err := r.GetLifecycle(status.StatusReview)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if reviewSteps[0].Workflow != "ai-review" || reviewSteps[0].NextStatus != status.StatusDone {
+		t.Errorf("review step[0] = %+v, want ai-review → done", reviewSteps[0])
+	}
+}
+
+func TestRouter_ReplaceStep_NoOps(t *testing.T) {
+	tests := []struct {
+		name        string
+		oldWorkflow string
+		newWorkflow string
+	}{
+		{"old workflow not found", "nonexistent", "ai-review"},
+		{"new workflow already in chain", "code-review", "git-commit"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRouter()
+			r.ReplaceStep(tt.oldWorkflow, tt.newWorkflow, status.StatusDone)
+
+			steps, err := r.GetLifecycle(status.StatusBacklog)
+			if err != nil {
+				t.Fatalf("unexpected err: %v", err)
+			}
+			wantWorkflows := []string{"create-story", "dev-story", "code-review", "git-commit"}
+			if len(steps) != len(wantWorkflows) {
+				t.Fatalf("got %d steps, want %d", len(steps), len(wantWorkflows))
+			}
+			for i, want := range wantWorkflows {
+				if steps[i].Workflow != want {
+					t.Errorf("step[%d].Workflow = %q, want %q", i, steps[i].Workflow, want)
+				}
+			}
+			if workflow, _ := r.GetWorkflow(status.StatusReview); workflow != "code-review" {
+				t.Errorf("GetWorkflow(review) = %q, want code-review", workflow)
+			}
+		})
+	}
+}
+
+func TestRouter_BranchPoints(t *testing.T) {
+	r := NewRouter()
+	if !r.IsBranchPoint("code-review") {
+		t.Error("code-review should be a branch point by default")
+	}
+	if r.IsBranchPoint("dev-story") {
+		t.Error("dev-story should not be a branch point")
+	}
+
+	r.SetBranchPoint("test-automation", true)
+	r.SetBranchPoint("code-review", false)
+	if !r.IsBranchPoint("test-automation") || r.IsBranchPoint("code-review") {
+		t.Error("SetBranchPoint did not update branch points")
+	}
+
+	// A replacement takes over the branch point
+	r = NewRouter()
+	r.ReplaceStep("code-review", "ai-review", status.StatusDone)
+	if !r.IsBranchPoint("ai-review") || r.IsBranchPoint("code-review") {
+		t.Error("ReplaceStep should move the branch point to ai-review")
+	}
+}
+
+func TestRouter_Steps(t *testing.T) {
+	r := NewRouter()
+	r.InsertStepAfter("code-review", "test-automation", status.StatusDone)
+
+	steps := r.Steps()
+	wantWorkflows := []string{"create-story", "dev-story", "code-review", "test-automation", "git-commit"}
+	if len(steps) != len(wantWorkflows) {
+		t.Fatalf("got %d steps, want %d", len(steps), len(wantWorkflows))
+	}
+	for i, want := range wantWorkflows {
+		if steps[i].Workflow != want {
+			t.Errorf("step[%d].Workflow = %q, want %q", i, steps[i].Workflow, want)
+		}
+	}
+	if steps[0].NextStatus != status.StatusReadyForDev {
+		t.Errorf("step[0].NextStatus = %q, want ready-for-dev", steps[0].NextStatus)
+	}
+}
+
+func TestRouter_StatusAliases(t *testing.T) {
+	r := NewRouter()
+	r.SetStatusAlias("qa", status.StatusReview)
+	r.SetStatusAlias("in_progress", status.StatusInProgress)
+	r.SetStatusAlias("shipped", status.StatusDone)
+
+	workflow, err := r.GetWorkflow("qa")
+	if err != nil || workflow != "code-review" {
+		t.Errorf("GetWorkflow(qa) = %q, %v, want code-review", workflow, err)
+	}
+	steps, err := r.GetLifecycle("in_progress")
+	if err != nil || len(steps) != 3 || steps[0].Workflow != "dev-story" {
+		t.Errorf("GetLifecycle(in_progress) = %v, %v, want dev-story first of 3", steps, err)
+	}
+	if _, err := r.GetLifecycle("shipped"); !errors.Is(err, ErrStoryComplete) {
+		t.Errorf("GetLifecycle(shipped) error = %v, want ErrStoryComplete", err)
+	}
+	if idx, ok := r.ChainIndex("qa"); idx != 2 || !ok {
+		t.Errorf("ChainIndex(qa) = %d, %v, want 2, true", idx, ok)
+	}
+	if err := r.ValidateTransition("qa", status.StatusDone); err != nil {
+		t.Errorf("ValidateTransition(qa, done) = %v, want nil", err)
+	}
+	if got := r.ResolveAlias("pending-qa"); got != "pending-qa" {
+		t.Errorf("ResolveAlias(pending-qa) = %q, want it unchanged", got)
+	}
+
+	// Removing an alias makes the status unknown again
+	r.SetStatusAlias("qa", "")
+	if _, err := r.GetWorkflow("qa"); !errors.Is(err, ErrUnknownStatus) {
+		t.Errorf("GetWorkflow(qa) after removal error = %v, want ErrUnknownStatus", err)
+	}
+
+	// Aliases are not shared between routers
+	if _, err := NewRouter().GetWorkflow("in_progress"); !errors.Is(err, ErrUnknownStatus) {
+		t.Errorf("new router resolved an alias of another router: %v", err)
+	}
+}
+
+func TestRouter_ChainIndex(t *testing.T) {
+	r := NewRouter()
+	tests := []struct {
+		status status.Status
+		want   int
+		wantOK bool
+	}{
+		{status.StatusBacklog, 0, true},
+		{status.StatusReadyForDev, 1, true},
+		{status.StatusInProgress, 1, true},
+		{status.StatusReview, 2, true},
+		{status.StatusDone, 4, true},
+		{status.Status("pending-qa"), 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := r.ChainIndex(tt.status)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("ChainIndex(%q) = %d, %v, want %d, %v", tt.status, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestNewRouterFromManifest_ChainIndex(t *testing.T) {
+	csv := `workflow,trigger_status,next_status
+plan,review,backlog
+implement,backlog,done
+`
+	m, err := manifest.ReadFromString(csv)
+	if err != nil {
+		t.Fatalf("Failed to parse manifest: %v", err)
+	}
+
+	r := NewRouterFromManifest(m)
+
+	review, _ := r.ChainIndex(status.StatusReview)
+	backlog, _ := r.ChainIndex(status.StatusBacklog)
+	if review >= backlog {
+		t.Errorf("ChainIndex(review) = %d, want before ChainIndex(backlog) = %d", review, backlog)
+	}
+}
+
 func TestNewRouterFromManifest_MatchesDefaultRouter(t *testing.T) {
 	// A manifest that matches the default hardcoded routing should produce identical results
 	csv := `phase,workflow,agent,command,trigger_status,next_status
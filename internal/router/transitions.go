@@ -0,0 +1,62 @@
+package router
+
+import (
+	"errors"
+	"fmt"
+
+	"bmaduum/internal/status"
+)
+
+// ErrIllegalTransition is returned by [Router.ValidateTransition] for a
+// status change the lifecycle chain never makes, such as done → backlog.
+var ErrIllegalTransition = errors.New("illegal status transition")
+
+// Transitions returns the status changes the lifecycle chain makes, keyed by
+// the status a story has before the change.
+//
+// Each trigger status leads to the next_status of the workflow it starts,
+// and each step's next_status leads to the next_status of the step after it,
+// so injected module steps contribute their own edges.
+func (r *Router) Transitions() map[status.Status][]status.Status {
+	transitions := make(map[status.Status][]status.Status)
+	add := func(from, to status.Status) {
+		if from == to {
+			return
+		}
+		for _, existing := range transitions[from] {
+			if existing == to {
+				return
+			}
+		}
+		transitions[from] = append(transitions[from], to)
+	}
+
+	for trigger, idx := range r.statusChainIndex {
+		add(trigger, r.chain[idx].NextStatus)
+	}
+	for i := 1; i < len(r.chain); i++ {
+		add(r.chain[i-1].NextStatus, r.chain[i].NextStatus)
+	}
+
+	return transitions
+}
+
+// ValidateTransition reports whether a story may move from one status to
+// another along the lifecycle chain.
+//
+// Status aliases are resolved first. Keeping the same status is always
+// allowed, as is any change from a status that is not one of
+// [status.ValidStatuses] (the chain cannot judge those). Otherwise returns an error wrapping [ErrIllegalTransition] that names both
+// statuses.
+func (r *Router) ValidateTransition(from, to status.Status) error {
+	from, to = r.ResolveAlias(from), r.ResolveAlias(to)
+	if from == to || !from.IsValid() {
+		return nil
+	}
+	for _, next := range r.Transitions()[from] {
+		if next == to {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s → %s", ErrIllegalTransition, from, to)
+}
@@ -0,0 +1,64 @@
+package router
+
+import (
+	"errors"
+	"testing"
+
+	"bmaduum/internal/status"
+)
+
+func TestValidateTransition(t *testing.T) {
+	tests := []struct {
+		name    string
+		from    status.Status
+		to      status.Status
+		wantErr bool
+	}{
+		{"backlog to ready-for-dev", status.StatusBacklog, status.StatusReadyForDev, false},
+		{"ready-for-dev to review", status.StatusReadyForDev, status.StatusReview, false},
+		{"in-progress to review", status.StatusInProgress, status.StatusReview, false},
+		{"review to done", status.StatusReview, status.StatusDone, false},
+		{"done stays done", status.StatusDone, status.StatusDone, false},
+		{"unknown from status", status.Status("blocked"), status.StatusDone, false},
+		{"done to backlog", status.StatusDone, status.StatusBacklog, true},
+		{"backlog skips to done", status.StatusBacklog, status.StatusDone, true},
+		{"review back to ready-for-dev", status.StatusReview, status.StatusReadyForDev, true},
+	}
+
+	r := NewRouter()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := r.ValidateTransition(tt.from, tt.to)
+			if tt.wantErr {
+				if !errors.Is(err, ErrIllegalTransition) {
+					t.Fatalf("ValidateTransition(%s, %s) = %v, want ErrIllegalTransition", tt.from, tt.to, err)
+				}
+				want := "illegal status transition: " + string(tt.from) + " → " + string(tt.to)
+				if err.Error() != want {
+					t.Errorf("error = %q, want %q", err.Error(), want)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("ValidateTransition(%s, %s) = %v, want nil", tt.from, tt.to, err)
+			}
+		})
+	}
+}
+
+func TestValidateTransition_InjectedStep(t *testing.T) {
+	r := NewRouter()
+	r.InsertStepBefore("git-commit", "security-review", status.StatusDone)
+	r.InsertStepAfter("dev-story", "test-automation", status.StatusInProgress)
+
+	// dev-story (review) → test-automation (in-progress) → code-review (done)
+	if err := r.ValidateTransition(status.StatusReview, status.StatusInProgress); err != nil {
+		t.Errorf("injected edge review → in-progress rejected: %v", err)
+	}
+	if err := r.ValidateTransition(status.StatusInProgress, status.StatusDone); err != nil {
+		t.Errorf("injected edge in-progress → done rejected: %v", err)
+	}
+	if err := r.ValidateTransition(status.StatusDone, status.StatusBacklog); err == nil {
+		t.Error("done → backlog allowed")
+	}
+}
@@ -0,0 +1,201 @@
+// Package runlog provides access to the run log written during lifecycle execution.
+//
+// The run log is a plain-text transcript of a bmaduum run. This package
+// resolves its default location and provides a [Follower] that streams
+// appended lines (like tail -f), enabling users to watch a detached or
+// background run from another terminal, and a [Transcript] that writes the
+// timestamped log itself. It also defines the run
+// [Manifest], a JSON record of each executed step and the artifacts
+// (files and commits) it produced.
+//
+// Key types:
+//   - [Follower] - Polls a log file and emits newly appended lines
+//   - [Transcript] - Writes the timestamped run log for --log-file
+//   - [Manifest] - Per-step record of a run, written with [SaveManifest]
+package runlog
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"bmaduum/internal/status"
+)
+
+// DefaultLogName is the file name of the run log within [status.OutputDir].
+const DefaultLogName = "bmaduum-run.log"
+
+// DefaultPollInterval is how often a [Follower] checks for new data.
+const DefaultPollInterval = 250 * time.Millisecond
+
+// DefaultPath returns the default run log path under basePath, used by
+// --log-file and tail-log when no path is given.
+//
+// The basePath is the project root directory. Pass empty string for cwd.
+func DefaultPath(basePath string) string {
+	return filepath.Join(basePath, status.OutputDir, DefaultLogName)
+}
+
+// Follower follows a log file, emitting each complete line as it is appended.
+//
+// The follower handles truncation (file shrinks below the read offset) by
+// rewinding to the start, and rotation (path now refers to a different file)
+// by reading the old file to its end and then reopening the path. If the file
+// does not exist yet, the follower waits for it to appear.
+//
+// Create instances using [NewFollower].
+type Follower struct {
+	path     string
+	interval time.Duration
+	waiting  func()
+}
+
+// NewFollower creates a [Follower] for the log file at path.
+//
+// The follower polls every [DefaultPollInterval]. Use [Follower.SetPollInterval]
+// to adjust the interval (primarily for tests).
+func NewFollower(path string) *Follower {
+	return &Follower{
+		path:     path,
+		interval: DefaultPollInterval,
+	}
+}
+
+// SetPollInterval configures how often the follower checks for new data.
+// Non-positive values are ignored.
+func (f *Follower) SetPollInterval(d time.Duration) {
+	if d > 0 {
+		f.interval = d
+	}
+}
+
+// SetWaitingHandler sets a function called when the follower starts waiting
+// for the log file to appear, at the start or after the file was removed, so
+// callers can tell the user why nothing is printed. nil (the default) calls
+// nothing.
+func (f *Follower) SetWaitingHandler(fn func()) {
+	f.waiting = fn
+}
+
+// Follow streams lines from the log file to emit until ctx is canceled.
+//
+// Lines are emitted without their trailing newline. A partial line (not yet
+// terminated by a newline) is held back until it is completed, or emitted
+// as is once the file has been rotated away. Follow reads the file from the
+// beginning so the full transcript so far is shown.
+//
+// Returns nil when ctx is canceled, or an error if the file cannot be read.
+func (f *Follower) Follow(ctx context.Context, emit func(line string)) error {
+	var (
+		file    *os.File
+		info    os.FileInfo
+		reader  *bufio.Reader
+		offset  int64
+		partial strings.Builder
+		waiting bool
+	)
+	defer func() {
+		if file != nil {
+			file.Close()
+		}
+	}()
+
+	// drain emits every complete line currently available
+	drain := func() error {
+		for {
+			chunk, err := reader.ReadString('\n')
+			offset += int64(len(chunk))
+			partial.WriteString(chunk)
+			if err != nil {
+				if !errors.Is(err, io.EOF) {
+					return err
+				}
+				return nil
+			}
+			emit(strings.TrimRight(partial.String(), "\r\n"))
+			partial.Reset()
+		}
+	}
+
+	// closeFile reads the file to its end, including a final unterminated
+	// line, and closes it, once the path no longer refers to it
+	closeFile := func() error {
+		err := drain()
+		if partial.Len() > 0 {
+			emit(strings.TrimRight(partial.String(), "\r\n"))
+			partial.Reset()
+		}
+		file.Close()
+		file = nil
+		return err
+	}
+
+	for {
+		// Open (or reopen after rotation) the log file
+		if file == nil {
+			opened, err := os.Open(f.path)
+			if err != nil && !errors.Is(err, os.ErrNotExist) {
+				return err
+			}
+			if opened == nil && !waiting {
+				waiting = true
+				if f.waiting != nil {
+					f.waiting()
+				}
+			}
+			if opened != nil {
+				file = opened
+				waiting = false
+				info, err = file.Stat()
+				if err != nil {
+					return err
+				}
+				reader = bufio.NewReader(file)
+				offset = 0
+				partial.Reset()
+			}
+		}
+
+		// Drain everything currently available
+		if file != nil {
+			if err := drain(); err != nil {
+				return err
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(f.interval):
+		}
+
+		if file == nil {
+			continue
+		}
+
+		current, err := os.Stat(f.path)
+		if err != nil || !os.SameFile(info, current) {
+			// Removed (mid-rotation) or rotated: finish the old file, then
+			// reopen the path once it exists
+			if err := closeFile(); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if current.Size() < offset {
+			// Truncated: rewind to the beginning
+			if _, err := file.Seek(0, io.SeekStart); err != nil {
+				return err
+			}
+			reader.Reset(file)
+			offset = 0
+			partial.Reset()
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package runlog
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// collectLines runs a follower in the background and returns a channel of emitted lines.
+func collectLines(t *testing.T, path string) (<-chan string, context.CancelFunc) {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	lines := make(chan string, 100)
+
+	follower := NewFollower(path)
+	follower.SetPollInterval(10 * time.Millisecond)
+
+	go func() {
+		_ = follower.Follow(ctx, func(line string) {
+			lines <- line
+		})
+	}()
+
+	t.Cleanup(cancel)
+	return lines, cancel
+}
+
+// nextLine waits for the next emitted line or fails the test.
+func nextLine(t *testing.T, lines <-chan string) string {
+	t.Helper()
+
+	select {
+	case line := <-lines:
+		return line
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for line")
+		return ""
+	}
+}
+
+func appendToFile(t *testing.T, path, data string) {
+	t.Helper()
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY|os.O_CREATE, 0644)
+	require.NoError(t, err)
+	_, err = f.WriteString(data)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+}
+
+func TestDefaultPath(t *testing.T) {
+	assert.Equal(t, filepath.Join("/project", "_bmad-output", "bmaduum-run.log"), DefaultPath("/project"))
+}
+
+func TestFollower_EmitsAppendedLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run.log")
+	require.NoError(t, os.WriteFile(path, []byte("existing line\n"), 0644))
+
+	lines, _ := collectLines(t, path)
+	assert.Equal(t, "existing line", nextLine(t, lines))
+
+	appendToFile(t, path, "first appended\nsecond ")
+	assert.Equal(t, "first appended", nextLine(t, lines))
+
+	// The partial line is held back until completed
+	appendToFile(t, path, "appended\n")
+	assert.Equal(t, "second appended", nextLine(t, lines))
+}
+
+func TestFollower_WaitsForFileToAppear(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run.log")
+
+	lines, _ := collectLines(t, path)
+	time.Sleep(30 * time.Millisecond)
+
+	appendToFile(t, path, "created later\n")
+	assert.Equal(t, "created later", nextLine(t, lines))
+}
+
+func TestFollower_HandlesTruncation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run.log")
+	require.NoError(t, os.WriteFile(path, []byte("a fairly long first line\n"), 0644))
+
+	lines, _ := collectLines(t, path)
+	assert.Equal(t, "a fairly long first line", nextLine(t, lines))
+
+	require.NoError(t, os.WriteFile(path, []byte("short\n"), 0644))
+	assert.Equal(t, "short", nextLine(t, lines))
+}
+
+func TestFollower_HandlesRotation(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "run.log")
+	require.NoError(t, os.WriteFile(path, []byte("before rotation\n"), 0644))
+
+	lines, _ := collectLines(t, path)
+	assert.Equal(t, "before rotation", nextLine(t, lines))
+
+	require.NoError(t, os.Rename(path, filepath.Join(dir, "run.log.1")))
+	require.NoError(t, os.WriteFile(path, []byte("after rotation, a longer line\n"), 0644))
+	assert.Equal(t, "after rotation, a longer line", nextLine(t, lines))
+}
+
+func TestFollower_DrainsOldFileOnRotation(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "run.log")
+	require.NoError(t, os.WriteFile(path, []byte("before rotation\n"), 0644))
+
+	lines, _ := collectLines(t, path)
+	assert.Equal(t, "before rotation", nextLine(t, lines))
+
+	// Written to the old file after the follower's last read
+	appendToFile(t, path, "last line of old file\nunterminated")
+	require.NoError(t, os.Rename(path, filepath.Join(dir, "run.log.1")))
+	require.NoError(t, os.WriteFile(path, []byte("after rotation\n"), 0644))
+
+	assert.Equal(t, "last line of old file", nextLine(t, lines))
+	assert.Equal(t, "unterminated", nextLine(t, lines))
+	assert.Equal(t, "after rotation", nextLine(t, lines))
+}
+
+func TestFollower_WaitingHandler(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run.log")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	follower := NewFollower(path)
+	follower.SetPollInterval(10 * time.Millisecond)
+	waits := make(chan struct{}, 10)
+	follower.SetWaitingHandler(func() { waits <- struct{}{} })
+	lines := make(chan string, 10)
+	go func() {
+		_ = follower.Follow(ctx, func(line string) { lines <- line })
+	}()
+
+	select {
+	case <-waits:
+	case <-time.After(2 * time.Second):
+		t.Fatal("waiting handler not called for a missing file")
+	}
+
+	// Called once per wait, not on every poll
+	time.Sleep(50 * time.Millisecond)
+	assert.Empty(t, waits)
+
+	appendToFile(t, path, "created later\n")
+	assert.Equal(t, "created later", nextLine(t, lines))
+}
+
+func TestFollower_StopsOnCancel(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run.log")
+	require.NoError(t, os.WriteFile(path, nil, 0644))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	follower := NewFollower(path)
+	follower.SetPollInterval(10 * time.Millisecond)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- follower.Follow(ctx, func(string) {})
+	}()
+
+	cancel()
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("follower did not stop after cancel")
+	}
+}
@@ -0,0 +1,103 @@
+package runlog
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// ManifestVersion is the current run manifest format version.
+const ManifestVersion = 1
+
+// Artifacts lists what a single workflow step produced.
+type Artifacts struct {
+	// ChangedFiles are existing files modified by the step (Edit-style tool uses).
+	ChangedFiles []string `json:"changed_files,omitempty"`
+
+	// CreatedFiles are files written by the step (Write tool uses), such as
+	// story files and review reports.
+	CreatedFiles []string `json:"created_files,omitempty"`
+
+	// Commits are the SHAs of git commits made during the step, oldest first.
+	Commits []string `json:"commits,omitempty"`
+}
+
+// StepEntry records a single workflow step executed during a run.
+type StepEntry struct {
+	// StoryKey is the story the step ran for.
+	StoryKey string `json:"story_key"`
+
+	// Workflow is the workflow that ran.
+	Workflow string `json:"workflow"`
+
+	// ExitCode is the exit code returned by the workflow.
+	ExitCode int `json:"exit_code"`
+
+	// StartedAt is when the step began.
+	StartedAt time.Time `json:"started_at"`
+
+	// Duration is how long the step took.
+	Duration time.Duration `json:"duration"`
+
+	// Artifacts lists the files and commits the step produced.
+	Artifacts Artifacts `json:"artifacts"`
+}
+
+// Manifest is a machine-readable record of the steps run by a bmaduum
+// invocation and what each of them touched.
+type Manifest struct {
+	// Version is the manifest format version.
+	Version int `json:"version"`
+
+	// StartedAt is when the run began.
+	StartedAt time.Time `json:"started_at"`
+
+	// Steps lists the executed steps in order.
+	Steps []StepEntry `json:"steps"`
+}
+
+// NewManifest creates an empty [Manifest] for a run starting at startedAt.
+func NewManifest(startedAt time.Time) *Manifest {
+	return &Manifest{
+		Version:   ManifestVersion,
+		StartedAt: startedAt,
+		Steps:     []StepEntry{},
+	}
+}
+
+// SaveManifest writes m to path as indented JSON.
+func SaveManifest(path string, m *Manifest) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal run manifest: %w", err)
+	}
+
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write run manifest: %w", err)
+	}
+
+	return nil
+}
+
+// LoadManifest reads a manifest previously written by [SaveManifest].
+//
+// Returns an error if the file cannot be read or parsed, or if its version
+// is not supported.
+func LoadManifest(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read run manifest: %w", err)
+	}
+
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse run manifest: %w", err)
+	}
+
+	if m.Version != ManifestVersion {
+		return nil, fmt.Errorf("unsupported run manifest version %d (expected %d)", m.Version, ManifestVersion)
+	}
+
+	return &m, nil
+}
@@ -0,0 +1,69 @@
+package runlog
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManifest_SaveLoadRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "manifest.json")
+	started := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	m := NewManifest(started)
+	m.Steps = append(m.Steps, StepEntry{
+		StoryKey:  "7-1-define-schema",
+		Workflow:  "dev-story",
+		StartedAt: started,
+		Duration:  2 * time.Second,
+		Artifacts: Artifacts{
+			ChangedFiles: []string{"internal/schema.go"},
+			CreatedFiles: []string{"internal/schema_test.go"},
+		},
+	}, StepEntry{
+		StoryKey:  "7-1-define-schema",
+		Workflow:  "git-commit",
+		StartedAt: started,
+		Artifacts: Artifacts{Commits: []string{"abc123"}},
+	})
+
+	require.NoError(t, SaveManifest(path, m))
+
+	loaded, err := LoadManifest(path)
+	require.NoError(t, err)
+	assert.Equal(t, m, loaded)
+}
+
+func TestManifest_EmptyArtifactsOmitted(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "manifest.json")
+
+	m := NewManifest(time.Now())
+	m.Steps = append(m.Steps, StepEntry{StoryKey: "7-1-x", Workflow: "code-review"})
+	require.NoError(t, SaveManifest(path, m))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"artifacts": {}`)
+	assert.NotContains(t, string(data), "changed_files")
+}
+
+func TestLoadManifest_Errors(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := LoadManifest(filepath.Join(dir, "missing.json"))
+	assert.ErrorContains(t, err, "failed to read run manifest")
+
+	bad := filepath.Join(dir, "bad.json")
+	require.NoError(t, os.WriteFile(bad, []byte("{"), 0644))
+	_, err = LoadManifest(bad)
+	assert.ErrorContains(t, err, "failed to parse run manifest")
+
+	future := filepath.Join(dir, "future.json")
+	require.NoError(t, os.WriteFile(future, []byte(`{"version": 99}`), 0644))
+	_, err = LoadManifest(future)
+	assert.ErrorContains(t, err, "unsupported run manifest version 99")
+}
@@ -0,0 +1,154 @@
+package runlog
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"time"
+)
+
+// ansiSequence matches terminal escape sequences (CSI and OSC), which are
+// stripped from transcript lines.
+var ansiSequence = regexp.MustCompile(`\x1b\[[0-9;?]*[ -/]*[@-~]|\x1b\][^\x07\x1b]*(?:\x07|\x1b\\)`)
+
+// Transcript writes a timestamped plain-text transcript of a run, the format
+// read back by [Follower].
+//
+// Transcript is an [io.Writer]: each complete line written to it is stripped
+// of terminal escape sequences and prefixed with the current time in
+// RFC 3339 format. A partial line is held back until it is completed or the
+// transcript is closed. Transcript is safe for concurrent use.
+//
+// Create instances using [OpenTranscript] or [NewTranscript].
+type Transcript struct {
+	mu      sync.Mutex
+	w       io.Writer
+	closer  io.Closer
+	partial []byte
+	now     func() time.Time
+}
+
+// OpenTranscript opens the transcript file at path for appending, creating
+// it and its parent directory if needed.
+func OpenTranscript(path string) (*Transcript, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("create log directory: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("open log file: %w", err)
+	}
+	t := NewTranscript(f)
+	t.closer = f
+	return t, nil
+}
+
+// NewTranscript creates a [Transcript] that writes to w.
+func NewTranscript(w io.Writer) *Transcript {
+	return &Transcript{w: w, now: time.Now}
+}
+
+// Write timestamps and writes each complete line in p. It always reports
+// len(p) bytes written unless the underlying writer fails.
+func (t *Transcript) Write(p []byte) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.partial = append(t.partial, p...)
+	for {
+		i := bytes.IndexByte(t.partial, '\n')
+		if i < 0 {
+			break
+		}
+		line := t.partial[:i]
+		t.partial = t.partial[i+1:]
+		if err := t.writeLine(line); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// Header writes a header line marking the start of a workflow run, so
+// failures can be correlated with the story and workflow that caused them.
+// Any partial line is completed first.
+func (t *Transcript) Header(workflow, storyKey string, start time.Time) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if err := t.flush(); err != nil {
+		return err
+	}
+	if storyKey == "" {
+		storyKey = "-"
+	}
+	header := fmt.Sprintf("=== workflow=%s story=%s started=%s ===", workflow, storyKey, start.Format(time.RFC3339))
+	return t.writeLine([]byte(header))
+}
+
+// Stream returns a writer that records each line written to it in t,
+// prefixed with prefix, such as Claude's raw stream-json output. Every write
+// is taken as whole lines, a final unterminated line included, so lines
+// written to t itself and to any stream never mix.
+func (t *Transcript) Stream(prefix string) io.Writer {
+	return &transcriptStream{t: t, prefix: prefix}
+}
+
+// transcriptStream is the writer returned by [Transcript.Stream].
+type transcriptStream struct {
+	t      *Transcript
+	prefix string
+}
+
+func (s *transcriptStream) Write(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	s.t.mu.Lock()
+	defer s.t.mu.Unlock()
+
+	for _, line := range bytes.Split(bytes.TrimSuffix(p, []byte("\n")), []byte("\n")) {
+		if err := s.t.writeLine(append([]byte(s.prefix), line...)); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// Close writes any partial line and closes the file opened by
+// [OpenTranscript]. Writers passed to [NewTranscript] are not closed.
+func (t *Transcript) Close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	err := t.flush()
+	if t.closer != nil {
+		if closeErr := t.closer.Close(); err == nil {
+			err = closeErr
+		}
+		t.closer = nil
+	}
+	return err
+}
+
+// flush writes the held-back partial line, if any. The caller must hold mu.
+func (t *Transcript) flush() error {
+	if len(t.partial) == 0 {
+		return nil
+	}
+	line := t.partial
+	t.partial = nil
+	return t.writeLine(line)
+}
+
+// writeLine writes one timestamped line without escape sequences or a
+// trailing carriage return. The caller must hold mu.
+func (t *Transcript) writeLine(line []byte) error {
+	line = ansiSequence.ReplaceAll(bytes.TrimRight(line, "\r"), nil)
+	_, err := fmt.Fprintf(t.w, "%s %s\n", t.now().Format(time.RFC3339), line)
+	return err
+}
@@ -0,0 +1,95 @@
+package runlog
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var transcriptTime = time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+
+// newTestTranscript returns a Transcript writing to buf with a fixed clock.
+func newTestTranscript(buf *bytes.Buffer) *Transcript {
+	t := NewTranscript(buf)
+	t.now = func() time.Time { return transcriptTime }
+	return t
+}
+
+func TestTranscript_TimestampsLines(t *testing.T) {
+	var buf bytes.Buffer
+	tr := newTestTranscript(&buf)
+
+	fmt.Fprint(tr, "first line\nsecond ")
+	assert.Equal(t, "2026-03-04T05:06:07Z first line\n", buf.String(), "partial line is held back")
+
+	fmt.Fprint(tr, "line\r\n")
+	assert.Equal(t, "2026-03-04T05:06:07Z first line\n2026-03-04T05:06:07Z second line\n", buf.String())
+}
+
+func TestTranscript_StripsEscapeSequences(t *testing.T) {
+	var buf bytes.Buffer
+	tr := newTestTranscript(&buf)
+
+	fmt.Fprint(tr, "\x1b[1;32m✓ done\x1b[0m \x1b]8;;https://example.com\x07link\x1b]8;;\x07\n")
+
+	assert.Equal(t, "2026-03-04T05:06:07Z ✓ done link\n", buf.String())
+}
+
+func TestTranscript_Header(t *testing.T) {
+	var buf bytes.Buffer
+	tr := newTestTranscript(&buf)
+
+	fmt.Fprint(tr, "pending")
+	require.NoError(t, tr.Header("dev-story", "7-1-login", transcriptTime))
+	require.NoError(t, tr.Header("raw", "", transcriptTime))
+
+	assert.Equal(t, "2026-03-04T05:06:07Z pending\n"+
+		"2026-03-04T05:06:07Z === workflow=dev-story story=7-1-login started=2026-03-04T05:06:07Z ===\n"+
+		"2026-03-04T05:06:07Z === workflow=raw story=- started=2026-03-04T05:06:07Z ===\n", buf.String())
+}
+
+func TestTranscript_Stream(t *testing.T) {
+	var buf bytes.Buffer
+	tr := newTestTranscript(&buf)
+	stream := tr.Stream("[claude] ")
+
+	fmt.Fprint(tr, "Running ")
+	fmt.Fprint(stream, "{\"type\":\"system\"}\n{\"type\":\"result\"}\n")
+	fmt.Fprint(stream, "unterminated")
+	fmt.Fprint(tr, "dev-story\n")
+
+	assert.Equal(t, "2026-03-04T05:06:07Z [claude] {\"type\":\"system\"}\n"+
+		"2026-03-04T05:06:07Z [claude] {\"type\":\"result\"}\n"+
+		"2026-03-04T05:06:07Z [claude] unterminated\n"+
+		"2026-03-04T05:06:07Z Running dev-story\n", buf.String(), "stream lines do not split the pending line")
+}
+
+func TestOpenTranscript(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "run.log")
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte("earlier run\n"), 0644))
+
+	tr, err := OpenTranscript(path)
+	require.NoError(t, err)
+	fmt.Fprint(tr, "unterminated")
+	require.NoError(t, tr.Close())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Regexp(t, `^earlier run\n\S+ unterminated\n$`, string(data), "appends and flushes the partial line on close")
+}
+
+func TestOpenTranscript_CreatesDirectory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "_bmad-output", "bmaduum-run.log")
+
+	tr, err := OpenTranscript(path)
+	require.NoError(t, err)
+	require.NoError(t, tr.Close())
+	assert.FileExists(t, path)
+}
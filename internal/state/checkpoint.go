@@ -0,0 +1,170 @@
+package state
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// CheckpointFileName is the name of the checkpoint file. It lives next to
+// sprint-status.yaml and holds one [Checkpoint] per in-flight story.
+const CheckpointFileName = ".bmaduum-checkpoint.json"
+
+// ErrNoCheckpoint is returned by [CheckpointStore.Load] when no checkpoint
+// exists for a story. Callers should fall back to status-based routing.
+var ErrNoCheckpoint = errors.New("no checkpoint exists")
+
+// Checkpoint records the last lifecycle step completed for a story.
+type Checkpoint struct {
+	// StoryKey is the story the checkpoint belongs to.
+	StoryKey string `json:"story_key"`
+
+	// Workflow is the most recently completed workflow.
+	Workflow string `json:"workflow"`
+
+	// CompletedAt is when the workflow completed.
+	CompletedAt time.Time `json:"completed_at"`
+}
+
+// CheckpointStore persists per-story checkpoints in a single JSON file.
+//
+// A store is safe for concurrent use by multiple goroutines; each update is
+// a read-modify-write of the whole file, so concurrent lifecycles should
+// share one store. Create instances using [NewCheckpointStore].
+type CheckpointStore struct {
+	dir string
+	mu  sync.Mutex
+}
+
+// NewCheckpointStore creates a [CheckpointStore] keeping [CheckpointFileName]
+// in dir, normally the directory containing sprint-status.yaml.
+func NewCheckpointStore(dir string) *CheckpointStore {
+	return &CheckpointStore{dir: dir}
+}
+
+// Path returns the full path of the checkpoint file.
+func (s *CheckpointStore) Path() string {
+	return filepath.Join(s.dir, CheckpointFileName)
+}
+
+// Save records cp, replacing any earlier checkpoint for the same story.
+// The file is written atomically using a temp file and rename.
+func (s *CheckpointStore) Save(cp Checkpoint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	checkpoints, err := s.readAll()
+	if err != nil {
+		return err
+	}
+	checkpoints[cp.StoryKey] = cp
+	return s.writeAll(checkpoints)
+}
+
+// Load returns the checkpoint for storyKey.
+//
+// Returns [ErrNoCheckpoint] if there is none.
+func (s *CheckpointStore) Load(storyKey string) (Checkpoint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	checkpoints, err := s.readAll()
+	if err != nil {
+		return Checkpoint{}, err
+	}
+
+	cp, ok := checkpoints[storyKey]
+	if !ok {
+		return Checkpoint{}, ErrNoCheckpoint
+	}
+	return cp, nil
+}
+
+// Remove deletes the checkpoint for storyKey. The file itself is removed
+// once no checkpoints remain. Removing a missing checkpoint is not an error.
+func (s *CheckpointStore) Remove(storyKey string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	checkpoints, err := s.readAll()
+	if err != nil {
+		return err
+	}
+	if _, ok := checkpoints[storyKey]; !ok {
+		return nil
+	}
+
+	delete(checkpoints, storyKey)
+	if len(checkpoints) == 0 {
+		if err := os.Remove(s.Path()); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	return s.writeAll(checkpoints)
+}
+
+// readAll loads every checkpoint. A missing file yields an empty map.
+func (s *CheckpointStore) readAll() (map[string]Checkpoint, error) {
+	checkpoints := make(map[string]Checkpoint)
+	if err := readJSONMap(s.Path(), "checkpoint", &checkpoints); err != nil {
+		return nil, err
+	}
+	return checkpoints, nil
+}
+
+// writeAll atomically replaces the checkpoint file with checkpoints.
+func (s *CheckpointStore) writeAll(checkpoints map[string]Checkpoint) error {
+	if err := writeJSONFile(s.dir, CheckpointFileName, checkpoints); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	return nil
+}
+
+// readJSONMap decodes the JSON file at path into m, leaving m unchanged if
+// the file does not exist. Errors name the file's contents as noun.
+func readJSONMap(path, noun string, m any) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to read %s: %w", noun, err)
+	}
+	if err := json.Unmarshal(data, m); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", noun, err)
+	}
+	return nil
+}
+
+// writeJSONFile atomically replaces dir/name with v as indented JSON, using
+// a temp file and rename.
+func writeJSONFile(dir, name string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(dir, name+".*.tmp")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmpPath, filepath.Join(dir, name))
+	}
+	if err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package state
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCheckpointStore_SaveLoadRemove(t *testing.T) {
+	dir := t.TempDir()
+	store := NewCheckpointStore(dir)
+	completed := time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+
+	if _, err := store.Load("7-1"); !errors.Is(err, ErrNoCheckpoint) {
+		t.Fatalf("Load() on empty store err = %v, want ErrNoCheckpoint", err)
+	}
+
+	if err := store.Save(Checkpoint{StoryKey: "7-1", Workflow: "create-story", CompletedAt: completed}); err != nil {
+		t.Fatalf("Save() failed: %v", err)
+	}
+	if err := store.Save(Checkpoint{StoryKey: "7-2", Workflow: "dev-story", CompletedAt: completed}); err != nil {
+		t.Fatalf("Save() failed: %v", err)
+	}
+	if err := store.Save(Checkpoint{StoryKey: "7-1", Workflow: "dev-story", CompletedAt: completed}); err != nil {
+		t.Fatalf("Save() failed: %v", err)
+	}
+
+	cp, err := store.Load("7-1")
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	if cp.Workflow != "dev-story" || !cp.CompletedAt.Equal(completed) {
+		t.Errorf("Load() = %+v, want latest dev-story checkpoint", cp)
+	}
+
+	if err := store.Remove("7-1"); err != nil {
+		t.Fatalf("Remove() failed: %v", err)
+	}
+	if _, err := store.Load("7-1"); !errors.Is(err, ErrNoCheckpoint) {
+		t.Errorf("Load() after Remove() err = %v, want ErrNoCheckpoint", err)
+	}
+	if _, err := store.Load("7-2"); err != nil {
+		t.Errorf("Remove() dropped another story's checkpoint: %v", err)
+	}
+
+	// Removing the last checkpoint removes the file
+	if err := store.Remove("7-2"); err != nil {
+		t.Fatalf("Remove() failed: %v", err)
+	}
+	if _, err := os.Stat(store.Path()); !os.IsNotExist(err) {
+		t.Errorf("checkpoint file still exists after last Remove(): %v", err)
+	}
+
+	// Removing a missing checkpoint is not an error
+	if err := store.Remove("7-3"); err != nil {
+		t.Errorf("Remove() of missing checkpoint err = %v, want nil", err)
+	}
+}
+
+func TestCheckpointStore_InvalidJSON(t *testing.T) {
+	dir := t.TempDir()
+	store := NewCheckpointStore(dir)
+
+	if err := os.WriteFile(store.Path(), []byte("not json"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	if _, err := store.Load("7-1"); err == nil || errors.Is(err, ErrNoCheckpoint) {
+		t.Errorf("Load() err = %v, want parse error", err)
+	}
+}
+
+func TestCheckpointStore_ConcurrentSaves(t *testing.T) {
+	store := NewCheckpointStore(t.TempDir())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(storyKey string) {
+			defer wg.Done()
+			if err := store.Save(Checkpoint{StoryKey: storyKey, Workflow: "dev-story"}); err != nil {
+				t.Errorf("Save(%s) failed: %v", storyKey, err)
+			}
+		}(fmt.Sprintf("7-%d", i))
+	}
+	wg.Wait()
+
+	// No save was lost to an interleaved read-modify-write
+	for i := 0; i < 20; i++ {
+		if _, err := store.Load(fmt.Sprintf("7-%d", i)); err != nil {
+			t.Errorf("Load(7-%d) failed: %v", i, err)
+		}
+	}
+}
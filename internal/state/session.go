@@ -0,0 +1,102 @@
+package state
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// SessionFileName is the name of the session file. It lives next to
+// sprint-status.yaml and holds the last Claude [Session] of each story.
+const SessionFileName = ".bmaduum-sessions.json"
+
+// ErrNoSession is returned by [SessionStore.Load] when no Claude session has
+// been recorded for a story.
+var ErrNoSession = errors.New("no session recorded")
+
+// Session records the last Claude session a workflow ran in for a story, so
+// a failed step can be resumed with its conversation context.
+type Session struct {
+	// StoryKey is the story the session belongs to.
+	StoryKey string `json:"story_key"`
+
+	// Workflow is the workflow that ran in the session.
+	Workflow string `json:"workflow"`
+
+	// SessionID is the Claude CLI session ID, as passed to --resume.
+	SessionID string `json:"session_id"`
+
+	// ExitCode is the exit code of the workflow run.
+	ExitCode int `json:"exit_code"`
+
+	// UpdatedAt is when the session was recorded.
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// SessionStore persists the last Claude session of each story in a single
+// JSON file.
+//
+// A store is safe for concurrent use by multiple goroutines; concurrent
+// lifecycles should share one store. Create instances using
+// [NewSessionStore].
+type SessionStore struct {
+	dir string
+	mu  sync.Mutex
+}
+
+// NewSessionStore creates a [SessionStore] keeping [SessionFileName] in dir,
+// normally the directory containing sprint-status.yaml.
+func NewSessionStore(dir string) *SessionStore {
+	return &SessionStore{dir: dir}
+}
+
+// Path returns the full path of the session file.
+func (s *SessionStore) Path() string {
+	return filepath.Join(s.dir, SessionFileName)
+}
+
+// Save records session as its story's last session, replacing any earlier
+// one. The file is written atomically using a temp file and rename.
+func (s *SessionStore) Save(session Session) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sessions, err := s.readAll()
+	if err != nil {
+		return err
+	}
+	sessions[session.StoryKey] = session
+	if err := writeJSONFile(s.dir, SessionFileName, sessions); err != nil {
+		return fmt.Errorf("failed to write sessions: %w", err)
+	}
+	return nil
+}
+
+// Load returns the last session recorded for storyKey.
+//
+// Returns [ErrNoSession] if there is none.
+func (s *SessionStore) Load(storyKey string) (Session, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sessions, err := s.readAll()
+	if err != nil {
+		return Session{}, err
+	}
+	session, ok := sessions[storyKey]
+	if !ok {
+		return Session{}, ErrNoSession
+	}
+	return session, nil
+}
+
+// readAll loads every session. A missing file yields an empty map.
+func (s *SessionStore) readAll() (map[string]Session, error) {
+	sessions := make(map[string]Session)
+	if err := readJSONMap(s.Path(), "sessions", &sessions); err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
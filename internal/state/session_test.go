@@ -0,0 +1,53 @@
+package state
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSessionStore_SaveLoad(t *testing.T) {
+	dir := t.TempDir()
+	store := NewSessionStore(dir)
+	updated := time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+
+	if _, err := store.Load("7-1"); !errors.Is(err, ErrNoSession) {
+		t.Fatalf("Load() on empty store err = %v, want ErrNoSession", err)
+	}
+
+	for _, session := range []Session{
+		{StoryKey: "7-1", Workflow: "dev-story", SessionID: "a", ExitCode: 1, UpdatedAt: updated},
+		{StoryKey: "7-2", Workflow: "create-story", SessionID: "b", UpdatedAt: updated},
+		{StoryKey: "7-1", Workflow: "code-review", SessionID: "c", UpdatedAt: updated},
+	} {
+		if err := store.Save(session); err != nil {
+			t.Fatalf("Save() failed: %v", err)
+		}
+	}
+
+	session, err := store.Load("7-1")
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	if session.Workflow != "code-review" || session.SessionID != "c" || !session.UpdatedAt.Equal(updated) {
+		t.Errorf("Load() = %+v, want latest code-review session", session)
+	}
+	if session, err := store.Load("7-2"); err != nil || session.SessionID != "b" {
+		t.Errorf("Load(7-2) = %+v, %v; want session b", session, err)
+	}
+}
+
+func TestSessionStore_InvalidJSON(t *testing.T) {
+	store := NewSessionStore(t.TempDir())
+
+	if err := os.WriteFile(store.Path(), []byte("not json"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	_, err := store.Load("7-1")
+	if err == nil || errors.Is(err, ErrNoSession) || !strings.Contains(err.Error(), "failed to parse sessions") {
+		t.Errorf("Load() err = %v, want parse error", err)
+	}
+}
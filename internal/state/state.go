@@ -8,6 +8,9 @@
 // Key types:
 //   - [State] represents the persisted execution state (story key, step index, etc.)
 //   - [Manager] handles state persistence operations (save, load, clear)
+//   - [Checkpoint] records the last completed lifecycle step of a story
+//   - [CheckpointStore] persists checkpoints next to sprint-status.yaml
+//   - [SessionStore] persists each story's last Claude session for resuming
 //
 // The state file is stored as a hidden JSON file ([StateFileName]) in the working
 // directory. State is written atomically using a temp file and rename pattern
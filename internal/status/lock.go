@@ -0,0 +1,65 @@
+package status
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+// DefaultLockTimeout is how long [Writer.UpdateStatus] waits for the status
+// file lock before giving up.
+const DefaultLockTimeout = 10 * time.Second
+
+// lockPollInterval is how often a held lock is retried while waiting.
+const lockPollInterval = 50 * time.Millisecond
+
+// ErrLockTimeout is returned when the status file lock cannot be acquired
+// within the writer's lock timeout, typically because another bmaduum process
+// is updating the same sprint-status.yaml.
+var ErrLockTimeout = errors.New("timed out waiting for sprint status lock")
+
+// errLocked reports that a non-blocking lock attempt found the lock held.
+var errLocked = errors.New("lock held")
+
+// lockPath returns the path of the lock file guarding statusPath.
+//
+// The lock is taken on a separate file because the status file itself is
+// replaced by rename on every write, which would orphan a lock held on it.
+func lockPath(statusPath string) string {
+	return statusPath + ".lock"
+}
+
+// acquireLock takes an exclusive advisory lock on the lock file for
+// statusPath, retrying until timeout elapses.
+//
+// A timeout of zero or less makes a single attempt. The returned function
+// releases the lock. Returns an error wrapping [ErrLockTimeout] if the lock
+// is still held by another process when the timeout expires.
+func acquireLock(statusPath string, timeout time.Duration) (func(), error) {
+	path := lockPath(statusPath)
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open lock file: %w", err)
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		err := tryLockFile(f)
+		if err == nil {
+			return func() {
+				unlockFile(f)
+				f.Close()
+			}, nil
+		}
+		if !errors.Is(err, errLocked) {
+			f.Close()
+			return nil, fmt.Errorf("failed to lock %s: %w", path, err)
+		}
+		if !time.Now().Before(deadline) {
+			f.Close()
+			return nil, fmt.Errorf("%w after %s: another process holds %s", ErrLockTimeout, timeout, path)
+		}
+		time.Sleep(lockPollInterval)
+	}
+}
@@ -0,0 +1,13 @@
+//go:build !unix && !windows
+
+package status
+
+import "os"
+
+// tryLockFile is a no-op on platforms without advisory file locks.
+func tryLockFile(f *os.File) error {
+	return nil
+}
+
+// unlockFile is a no-op on platforms without advisory file locks.
+func unlockFile(f *os.File) {}
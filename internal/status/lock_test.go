@@ -0,0 +1,42 @@
+package status
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriter_UpdateStatus_WaitsForLock(t *testing.T) {
+	statusPath := filepath.Join(t.TempDir(), "sprint-status.yaml")
+	require.NoError(t, os.WriteFile(statusPath, []byte("development_status:\n  7-1: backlog\n"), 0644))
+
+	unlock, err := acquireLock(statusPath, 0)
+	require.NoError(t, err)
+
+	writer := NewWriterWithPath("", statusPath)
+	writer.SetLockTimeout(100 * time.Millisecond)
+
+	err = writer.UpdateStatus("7-1", StatusReview)
+	require.ErrorIs(t, err, ErrLockTimeout)
+	assert.Contains(t, err.Error(), "sprint-status.yaml.lock")
+
+	status, err := NewReaderWithPath("", statusPath).GetStoryStatus("7-1")
+	require.NoError(t, err)
+	assert.Equal(t, StatusBacklog, status, "status unchanged while locked")
+
+	// Released within the timeout: the update goes through
+	writer.SetLockTimeout(5 * time.Second)
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		unlock()
+	}()
+	require.NoError(t, writer.UpdateStatus("7-1", StatusReview))
+
+	status, err = NewReaderWithPath("", statusPath).GetStoryStatus("7-1")
+	require.NoError(t, err)
+	assert.Equal(t, StatusReview, status)
+}
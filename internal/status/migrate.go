@@ -0,0 +1,49 @@
+package status
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// ErrV6StatusExists is returned by [MigrateLegacyStatus] when a status file
+// already exists at [V6StatusPath]. Migration never overwrites it.
+var ErrV6StatusExists = errors.New("v6 sprint-status.yaml already exists")
+
+// MigrateLegacyStatus moves the legacy root-level sprint-status.yaml under
+// basePath to the v6 location, creating the directory structure as needed.
+//
+// It returns the source and destination paths. When dryRun is true, the paths
+// are validated and returned but nothing is created or moved.
+//
+// Returns an error if no legacy file exists, or [ErrV6StatusExists] if a v6
+// file is already present.
+func MigrateLegacyStatus(basePath string, dryRun bool) (from, to string, err error) {
+	from = filepath.Join(basePath, LegacyStatusPath)
+	to = filepath.Join(basePath, V6StatusPath)
+
+	if _, err := os.Stat(from); err != nil {
+		return from, to, fmt.Errorf("no legacy status file to migrate: %w", err)
+	}
+
+	if _, err := os.Stat(to); err == nil {
+		return from, to, fmt.Errorf("%w: %s", ErrV6StatusExists, to)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return from, to, fmt.Errorf("failed to check v6 status file: %w", err)
+	}
+
+	if dryRun {
+		return from, to, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(to), 0755); err != nil {
+		return from, to, fmt.Errorf("failed to create status directory: %w", err)
+	}
+
+	if err := os.Rename(from, to); err != nil {
+		return from, to, fmt.Errorf("failed to move status file: %w", err)
+	}
+
+	return from, to, nil
+}
@@ -0,0 +1,70 @@
+package status
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const migrateContent = `# sprint board
+development_status:
+  7-1-define-schema: review
+`
+
+func TestMigrateLegacyStatus_MovesFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	legacyPath := filepath.Join(tmpDir, LegacyStatusPath)
+	require.NoError(t, os.WriteFile(legacyPath, []byte(migrateContent), 0644))
+
+	from, to, err := MigrateLegacyStatus(tmpDir, false)
+
+	require.NoError(t, err)
+	assert.Equal(t, legacyPath, from)
+	assert.Equal(t, filepath.Join(tmpDir, V6StatusPath), to)
+	assert.NoFileExists(t, legacyPath)
+	assert.DirExists(t, filepath.Join(tmpDir, "_bmad-output", "implementation-artifacts"))
+
+	data, err := os.ReadFile(to)
+	require.NoError(t, err)
+	assert.Equal(t, migrateContent, string(data))
+}
+
+func TestMigrateLegacyStatus_DryRun(t *testing.T) {
+	tmpDir := t.TempDir()
+	legacyPath := filepath.Join(tmpDir, LegacyStatusPath)
+	require.NoError(t, os.WriteFile(legacyPath, []byte(migrateContent), 0644))
+
+	_, to, err := MigrateLegacyStatus(tmpDir, true)
+
+	require.NoError(t, err)
+	assert.FileExists(t, legacyPath)
+	assert.NoFileExists(t, to)
+	assert.NoDirExists(t, filepath.Join(tmpDir, "_bmad-output"))
+}
+
+func TestMigrateLegacyStatus_RefusesOverwrite(t *testing.T) {
+	tmpDir := t.TempDir()
+	legacyPath := filepath.Join(tmpDir, LegacyStatusPath)
+	require.NoError(t, os.WriteFile(legacyPath, []byte(migrateContent), 0644))
+
+	v6Path := filepath.Join(tmpDir, V6StatusPath)
+	require.NoError(t, os.MkdirAll(filepath.Dir(v6Path), 0755))
+	require.NoError(t, os.WriteFile(v6Path, []byte("v6"), 0644))
+
+	_, _, err := MigrateLegacyStatus(tmpDir, false)
+
+	assert.ErrorIs(t, err, ErrV6StatusExists)
+	assert.FileExists(t, legacyPath)
+	data, err := os.ReadFile(v6Path)
+	require.NoError(t, err)
+	assert.Equal(t, "v6", string(data))
+}
+
+func TestMigrateLegacyStatus_NoLegacyFile(t *testing.T) {
+	_, _, err := MigrateLegacyStatus(t.TempDir(), false)
+
+	assert.ErrorContains(t, err, "no legacy status file")
+}
@@ -0,0 +1,92 @@
+package status
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// NumberScheme selects how the story number segment of a story key
+// ({epicID}-{storyNum}-{description}) is parsed and ordered.
+type NumberScheme string
+
+// NumberScheme values accepted by the story_numbering config option.
+const (
+	// SchemeNumeric parses integer story numbers ("6-1-foo", "6-10-bar").
+	SchemeNumeric NumberScheme = "numeric"
+
+	// SchemeDotted parses dot-separated integer story numbers
+	// ("6-1-foo", "6-1.2-bar"), ordered component by component.
+	SchemeDotted NumberScheme = "dotted"
+
+	// SchemeAlpha parses alphabetic story numbers ("6-a-foo", "6-aa-bar"),
+	// ordered like spreadsheet columns (a..z, aa..az, ...), ignoring case.
+	SchemeAlpha NumberScheme = "alpha"
+)
+
+// ParseNumberScheme validates a story_numbering setting.
+//
+// The empty string selects [SchemeNumeric]. Returns an error for unknown
+// schemes.
+func ParseNumberScheme(s string) (NumberScheme, error) {
+	switch scheme := NumberScheme(strings.ToLower(s)); scheme {
+	case "":
+		return SchemeNumeric, nil
+	case SchemeNumeric, SchemeDotted, SchemeAlpha:
+		return scheme, nil
+	default:
+		return "", fmt.Errorf("unknown story numbering scheme %q (expected numeric, dotted, or alpha)", s)
+	}
+}
+
+// parseStoryNumber parses a story number segment under scheme.
+//
+// The result is a sort key compared component by component; ok is false if
+// the segment does not match the scheme.
+func parseStoryNumber(scheme NumberScheme, segment string) (num []int, ok bool) {
+	switch scheme {
+	case SchemeDotted:
+		for _, part := range strings.Split(segment, ".") {
+			n, err := strconv.Atoi(part)
+			if err != nil || n < 0 {
+				return nil, false
+			}
+			num = append(num, n)
+		}
+		return num, true
+
+	case SchemeAlpha:
+		if segment == "" {
+			return nil, false
+		}
+		n := 0
+		for _, r := range strings.ToLower(segment) {
+			if r < 'a' || r > 'z' {
+				return nil, false
+			}
+			n = n*26 + int(r-'a') + 1
+		}
+		return []int{n}, true
+
+	default:
+		n, err := strconv.Atoi(segment)
+		if err != nil {
+			return nil, false
+		}
+		return []int{n}, true
+	}
+}
+
+// compareStoryNumbers orders two sort keys from [parseStoryNumber].
+// A shorter key sorts before a longer one it prefixes (1 before 1.1).
+func compareStoryNumbers(a, b []int) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			if a[i] < b[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return len(a) - len(b)
+}
@@ -0,0 +1,124 @@
+package status
+
+import (
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// storyKeyPattern matches story keys of the form {epicID}-{storyNum}-{description}.
+// Epic and retrospective entries (e.g., "epic-1", "epic-1-retrospective") do not match.
+var storyKeyPattern = regexp.MustCompile(`^\d+-\d+-.+$`)
+
+// IsStoryKey reports whether key has the {epicID}-{storyNum}-{description} form.
+func IsStoryKey(key string) bool {
+	return storyKeyPattern.MatchString(key)
+}
+
+// OrphanReport describes drift between story files and sprint-status.yaml.
+type OrphanReport struct {
+	// FilesWithoutEntries lists story keys that have a story file on disk
+	// but no entry in development_status.
+	FilesWithoutEntries []string
+
+	// EntriesWithoutFiles lists story keys that have an entry in
+	// development_status but no story file on disk. Backlog entries are
+	// excluded because their story file is only written by create-story.
+	EntriesWithoutFiles []string
+}
+
+// HasOrphans reports whether the report contains any orphans.
+func (r *OrphanReport) HasOrphans() bool {
+	return len(r.FilesWithoutEntries) > 0 || len(r.EntriesWithoutFiles) > 0
+}
+
+// DiscoverStoryFiles walks dir and returns the story keys of all story files.
+//
+// A story file is a markdown file whose name (without the .md extension) is a
+// story key, e.g. "7-1-define-schema.md". Subdirectories are searched too.
+// Results are sorted and deduplicated.
+func DiscoverStoryFiles(dir string) ([]string, error) {
+	seen := make(map[string]bool)
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || filepath.Ext(d.Name()) != ".md" {
+			return nil
+		}
+
+		key := strings.TrimSuffix(d.Name(), ".md")
+		if IsStoryKey(key) {
+			seen[key] = true
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(seen))
+	for key := range seen {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys, nil
+}
+
+// StoryFiles checks for story files under an artifacts directory.
+//
+// Create instances using [NewStoryFiles].
+type StoryFiles struct {
+	dir string
+}
+
+// NewStoryFiles creates a [StoryFiles] that searches dir (recursively).
+func NewStoryFiles(dir string) *StoryFiles {
+	return &StoryFiles{dir: dir}
+}
+
+// StoryFileExists reports whether a story file for storyKey exists.
+// A missing or unreadable directory is treated as no story file.
+func (s *StoryFiles) StoryFileExists(storyKey string) bool {
+	keys, err := DiscoverStoryFiles(s.dir)
+	if err != nil {
+		return false
+	}
+	i := sort.SearchStrings(keys, storyKey)
+	return i < len(keys) && keys[i] == storyKey
+}
+
+// FindOrphans cross-references story file keys with the sprint status board.
+//
+// Only development_status keys matching the story key form are considered,
+// so epic and retrospective entries are never reported. Both lists in the
+// returned [OrphanReport] are sorted.
+func FindOrphans(sprintStatus *SprintStatus, storyFileKeys []string) *OrphanReport {
+	report := &OrphanReport{}
+
+	files := make(map[string]bool, len(storyFileKeys))
+	for _, key := range storyFileKeys {
+		files[key] = true
+		if _, ok := sprintStatus.DevelopmentStatus[key]; !ok {
+			report.FilesWithoutEntries = append(report.FilesWithoutEntries, key)
+		}
+	}
+
+	for key, st := range sprintStatus.DevelopmentStatus {
+		if !IsStoryKey(key) || st == StatusBacklog {
+			continue
+		}
+		if !files[key] {
+			report.EntriesWithoutFiles = append(report.EntriesWithoutFiles, key)
+		}
+	}
+
+	sort.Strings(report.FilesWithoutEntries)
+	sort.Strings(report.EntriesWithoutFiles)
+
+	return report
+}
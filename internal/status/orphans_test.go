@@ -0,0 +1,92 @@
+package status
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsStoryKey(t *testing.T) {
+	assert.True(t, IsStoryKey("7-1-define-schema"))
+	assert.True(t, IsStoryKey("12-10-x"))
+	assert.False(t, IsStoryKey("epic-7"))
+	assert.False(t, IsStoryKey("epic-7-retrospective"))
+	assert.False(t, IsStoryKey("7-1"))
+	assert.False(t, IsStoryKey("sprint-status"))
+}
+
+func TestDiscoverStoryFiles(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "stories"), 0755))
+
+	for _, name := range []string{
+		"7-2-create-api.md",
+		"7-1-define-schema.md",
+		"stories/8-1-nested.md",
+		"sprint-status.yaml",
+		"epic-7-retrospective.md",
+		"7-3-not-markdown.txt",
+	} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("# story\n"), 0644))
+	}
+
+	keys, err := DiscoverStoryFiles(dir)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"7-1-define-schema", "7-2-create-api", "8-1-nested"}, keys)
+}
+
+func TestDiscoverStoryFiles_MissingDir(t *testing.T) {
+	_, err := DiscoverStoryFiles(filepath.Join(t.TempDir(), "missing"))
+	assert.Error(t, err)
+}
+
+func TestFindOrphans(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"7-1-define-schema.md", "7-2-create-api.md", "9-1-untracked.md"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("# story\n"), 0644))
+	}
+
+	sprintStatus := &SprintStatus{DevelopmentStatus: map[string]Status{
+		"epic-7":               "in-progress",
+		"7-1-define-schema":    StatusDone,
+		"7-2-create-api":       StatusReview,
+		"7-3-build-ui":         StatusInProgress,
+		"7-4-not-started":      StatusBacklog,
+		"epic-7-retrospective": "optional",
+	}}
+
+	keys, err := DiscoverStoryFiles(dir)
+	require.NoError(t, err)
+	report := FindOrphans(sprintStatus, keys)
+
+	assert.True(t, report.HasOrphans())
+	assert.Equal(t, []string{"9-1-untracked"}, report.FilesWithoutEntries)
+	assert.Equal(t, []string{"7-3-build-ui"}, report.EntriesWithoutFiles)
+}
+
+func TestFindOrphans_NoDrift(t *testing.T) {
+	sprintStatus := &SprintStatus{DevelopmentStatus: map[string]Status{
+		"7-1-define-schema": StatusDone,
+	}}
+
+	report := FindOrphans(sprintStatus, []string{"7-1-define-schema"})
+
+	assert.False(t, report.HasOrphans())
+	assert.Empty(t, report.FilesWithoutEntries)
+	assert.Empty(t, report.EntriesWithoutFiles)
+}
+
+func TestStoryFiles_StoryFileExists(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "7-1-define-schema.md"), []byte("# story\n"), 0644))
+
+	files := NewStoryFiles(dir)
+
+	assert.True(t, files.StoryFileExists("7-1-define-schema"))
+	assert.False(t, files.StoryFileExists("7-2-create-api"))
+	assert.False(t, NewStoryFiles(filepath.Join(dir, "missing")).StoryFileExists("7-1-define-schema"))
+}
@@ -2,6 +2,7 @@ package status
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"sort"
@@ -11,9 +12,12 @@ import (
 	"gopkg.in/yaml.v3"
 )
 
+// OutputDir is the BMAD v6 output directory relative to the project root.
+const OutputDir = "_bmad-output"
+
 // V6StatusPath is the BMAD v6 canonical location of sprint-status.yaml
 // relative to the project root.
-const V6StatusPath = "_bmad-output/implementation-artifacts/sprint-status.yaml"
+const V6StatusPath = OutputDir + "/implementation-artifacts/sprint-status.yaml"
 
 // LegacyStatusPath is the pre-v6 location of sprint-status.yaml at the
 // project root.
@@ -41,26 +45,33 @@ var StatusPaths = []string{
 // The statusPath is an explicit override (e.g., from config). Pass empty
 // string for auto-discovery.
 func ResolvePath(basePath, statusPath string) string {
+	path, _ := resolvePath(basePath, statusPath)
+	return path
+}
+
+// resolvePath implements [ResolvePath], additionally reporting whether
+// auto-discovery fell back to [LegacyStatusPath].
+func resolvePath(basePath, statusPath string) (string, bool) {
 	// 1. Environment variable takes highest priority
 	if envPath := os.Getenv("BMADUUM_SPRINT_STATUS_PATH"); envPath != "" {
-		return envPath
+		return envPath, false
 	}
 
 	// 2. Explicit path from config
 	if statusPath != "" {
-		return statusPath
+		return statusPath, false
 	}
 
 	// 3. Auto-discover by checking each path
 	for _, p := range StatusPaths {
 		fullPath := filepath.Join(basePath, p)
 		if _, err := os.Stat(fullPath); err == nil {
-			return fullPath
+			return fullPath, p == LegacyStatusPath
 		}
 	}
 
 	// 4. Default to v6 path
-	return filepath.Join(basePath, V6StatusPath)
+	return filepath.Join(basePath, V6StatusPath), false
 }
 
 // Reader reads sprint status from YAML files.
@@ -69,6 +80,10 @@ func ResolvePath(basePath, statusPath string) string {
 // Use [NewReader] for auto-discovery or [NewReaderWithPath] for an explicit path.
 type Reader struct {
 	statusPath string
+	legacy     bool
+	scheme     NumberScheme
+	warnings   io.Writer
+	normalize  bool
 }
 
 // NewReader creates a new [Reader] that auto-discovers the status file.
@@ -78,8 +93,12 @@ type Reader struct {
 // at the v6 path first, then falls back to the legacy root-level path.
 // The BMADUUM_SPRINT_STATUS_PATH environment variable overrides all discovery.
 func NewReader(basePath string) *Reader {
+	path, legacy := resolvePath(basePath, "")
 	return &Reader{
-		statusPath: ResolvePath(basePath, ""),
+		statusPath: path,
+		legacy:     legacy,
+		scheme:     SchemeNumeric,
+		warnings:   os.Stderr,
 	}
 }
 
@@ -88,15 +107,56 @@ func NewReader(basePath string) *Reader {
 // The statusPath can be an absolute path or a path relative to the working directory.
 // The BMADUUM_SPRINT_STATUS_PATH environment variable still takes priority if set.
 func NewReaderWithPath(basePath, statusPath string) *Reader {
+	path, legacy := resolvePath(basePath, statusPath)
 	return &Reader{
-		statusPath: ResolvePath(basePath, statusPath),
+		statusPath: path,
+		legacy:     legacy,
+		scheme:     SchemeNumeric,
+		warnings:   os.Stderr,
 	}
 }
 
+// SetNumberScheme configures how [Reader.GetEpicStories] parses and orders
+// story numbers. The default is [SchemeNumeric].
+func (r *Reader) SetNumberScheme(scheme NumberScheme) {
+	r.scheme = scheme
+}
+
+// SetNormalizeStatuses makes [Reader.Read] accept known statuses written
+// with different case or surrounding whitespace, such as "Ready-For-Dev",
+// reporting them as the canonical [Status]; see [ParseStatus]. Values that
+// are not known statuses are returned unchanged. The file is not modified.
+func (r *Reader) SetNormalizeStatuses(normalize bool) {
+	r.normalize = normalize
+}
+
+// SetWarningOutput sets where warnings about story keys that do not match
+// the number scheme are written. The default is os.Stderr; nil discards them.
+func (r *Reader) SetWarningOutput(w io.Writer) {
+	r.warnings = w
+}
+
+// Path returns the resolved path of the sprint-status.yaml file.
+func (r *Reader) Path() string {
+	return r.statusPath
+}
+
+// UsesLegacyPath reports whether auto-discovery resolved to the pre-v6
+// [LegacyStatusPath] rather than [V6StatusPath]. Paths set explicitly via
+// config or BMADUUM_SPRINT_STATUS_PATH are never reported as legacy.
+func (r *Reader) UsesLegacyPath() bool {
+	return r.legacy
+}
+
 // Read reads and parses the complete sprint status file.
 //
 // It returns the full [SprintStatus] structure containing all story statuses.
-// Returns an error if the file cannot be read or parsed.
+// development_status is usually at the root of the file but may be nested
+// under a project key; the shallowest one is used. A file without it has no
+// stories. The [StatusUpdatedAtKey] mapping next to development_status, if
+// any, fills [SprintStatus.StatusUpdatedAt]. Statuses are normalized if
+// enabled with [Reader.SetNormalizeStatuses]. Returns an error if the file
+// cannot be read or parsed.
 func (r *Reader) Read() (*SprintStatus, error) {
 	fullPath := r.statusPath
 
@@ -105,11 +165,32 @@ func (r *Reader) Read() (*SprintStatus, error) {
 		return nil, fmt.Errorf("failed to read sprint status: %w", err)
 	}
 
-	var status SprintStatus
-	if err := yaml.Unmarshal(data, &status); err != nil {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to read sprint status: %w", err)
+	}
+	devStatusNode, parent, err := lookupDevelopmentStatusNode(&doc)
+	if err != nil {
 		return nil, fmt.Errorf("failed to read sprint status: %w", err)
 	}
 
+	var status SprintStatus
+	if devStatusNode != nil {
+		if err := devStatusNode.Decode(&status.DevelopmentStatus); err != nil {
+			return nil, fmt.Errorf("failed to read sprint status: %w", err)
+		}
+		if status.StatusUpdatedAt, err = decodeUpdatedAt(parent); err != nil {
+			return nil, fmt.Errorf("failed to read sprint status: %w", err)
+		}
+	}
+	if r.normalize {
+		for key, value := range status.DevelopmentStatus {
+			if parsed, err := ParseStatus(string(value)); err == nil {
+				status.DevelopmentStatus[key] = parsed
+			}
+		}
+	}
+
 	return &status, nil
 }
 
@@ -133,8 +214,11 @@ func (r *Reader) GetStoryStatus(storyKey string) (Status, error) {
 
 // GetEpicStories returns all story keys belonging to an epic, sorted by story number.
 //
-// Story keys are matched using the pattern {epicID}-{N}-*, where N is a numeric
-// story number. Results are sorted numerically by story number (1, 2, 10 not 1, 10, 2).
+// Story keys are matched using the pattern {epicID}-{N}-*, where N is parsed
+// according to the reader's [NumberScheme] (see [Reader.SetNumberScheme]).
+// Results are sorted by story number (1, 2, 10 not 1, 10, 2; 1, 1.2, 2 for
+// dotted numbers). Keys under the epic whose story number does not match the
+// scheme are left out, with a warning written to the warning output.
 //
 // Returns an error if the file cannot be read or if no stories are found for the epic.
 func (r *Reader) GetEpicStories(epicID string) ([]string, error) {
@@ -146,9 +230,10 @@ func (r *Reader) GetEpicStories(epicID string) ([]string, error) {
 	// Collect all keys matching the epic ID pattern
 	type storyWithNum struct {
 		key string
-		num int
+		num []int
 	}
 	var stories []storyWithNum
+	var mismatched []string
 
 	prefix := epicID + "-"
 	for key := range sprintStatus.DevelopmentStatus {
@@ -160,26 +245,33 @@ func (r *Reader) GetEpicStories(epicID string) ([]string, error) {
 		// Format: {epicID}-{storyNum}-{rest}
 		remainder := strings.TrimPrefix(key, prefix)
 		parts := strings.SplitN(remainder, "-", 2)
-		if len(parts) < 1 {
-			continue
-		}
 
-		num, err := strconv.Atoi(parts[0])
-		if err != nil {
-			// Not a numeric story number, skip
+		num, ok := parseStoryNumber(r.scheme, parts[0])
+		if !ok {
+			mismatched = append(mismatched, key)
 			continue
 		}
 
 		stories = append(stories, storyWithNum{key: key, num: num})
 	}
 
+	if len(mismatched) > 0 && r.warnings != nil {
+		sort.Strings(mismatched)
+		for _, key := range mismatched {
+			fmt.Fprintf(r.warnings, "Warning: skipping %s: story number does not match %s numbering\n", key, r.scheme)
+		}
+	}
+
 	if len(stories) == 0 {
 		return nil, fmt.Errorf("no stories found for epic: %s", epicID)
 	}
 
-	// Sort by story number
+	// Sort by story number, then key for a stable order
 	sort.Slice(stories, func(i, j int) bool {
-		return stories[i].num < stories[j].num
+		if c := compareStoryNumbers(stories[i].num, stories[j].num); c != 0 {
+			return c < 0
+		}
+		return stories[i].key < stories[j].key
 	})
 
 	// Extract just the keys
@@ -191,6 +283,81 @@ func (r *Reader) GetEpicStories(epicID string) ([]string, error) {
 	return result, nil
 }
 
+// GetStoriesByStatus returns the keys of all stories with status s, sorted
+// by epic and then by story number.
+//
+// Epic IDs are ordered numerically and story numbers are parsed and ordered
+// as in [Reader.GetEpicStories]. Epic and retrospective entries are not
+// stories and never match. Stories whose number does not match the reader's
+// [NumberScheme] are left out, with a warning written to the warning output.
+//
+// Returns an empty slice if no story has the status, and an error if the file
+// cannot be read.
+func (r *Reader) GetStoriesByStatus(s Status) ([]string, error) {
+	sprintStatus, err := r.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	type storyWithNum struct {
+		key     string
+		epicID  string
+		epicNum int
+		num     []int
+	}
+	var stories []storyWithNum
+	var mismatched []string
+
+	for key, storyStatus := range sprintStatus.DevelopmentStatus {
+		if storyStatus != s || strings.HasPrefix(key, "epic-") {
+			continue
+		}
+
+		// Format: {epicID}-{storyNum}-{rest}
+		parts := strings.SplitN(key, "-", 3)
+		if len(parts) < 2 {
+			mismatched = append(mismatched, key)
+			continue
+		}
+		num, ok := parseStoryNumber(r.scheme, parts[1])
+		if !ok {
+			mismatched = append(mismatched, key)
+			continue
+		}
+
+		epicNum, _ := strconv.Atoi(parts[0])
+		stories = append(stories, storyWithNum{key: key, epicID: parts[0], epicNum: epicNum, num: num})
+	}
+
+	if len(mismatched) > 0 && r.warnings != nil {
+		sort.Strings(mismatched)
+		for _, key := range mismatched {
+			fmt.Fprintf(r.warnings, "Warning: skipping %s: story number does not match %s numbering\n", key, r.scheme)
+		}
+	}
+
+	// Sort by epic, then story number, then key for a stable order
+	sort.Slice(stories, func(i, j int) bool {
+		if stories[i].epicNum != stories[j].epicNum {
+			return stories[i].epicNum < stories[j].epicNum
+		}
+		if stories[i].epicID != stories[j].epicID {
+			return stories[i].epicID < stories[j].epicID
+		}
+		if c := compareStoryNumbers(stories[i].num, stories[j].num); c != 0 {
+			return c < 0
+		}
+		return stories[i].key < stories[j].key
+	})
+
+	result := make([]string, len(stories))
+	for i, story := range stories {
+		result[i] = story.key
+	}
+
+	return result, nil
+}
+
 // GetAllEpics returns all epic IDs with active status, sorted numerically.
 //
 // An epic is considered "active" if its status is not "done", "deferred", or "optional".
@@ -1,6 +1,7 @@
 package status
 
 import (
+	"bytes"
 	"os"
 	"path/filepath"
 	"testing"
@@ -16,6 +17,32 @@ func TestNewReader(t *testing.T) {
 	assert.Contains(t, reader.statusPath, "sprint-status.yaml")
 }
 
+func TestReader_Read_NormalizeStatuses(t *testing.T) {
+	statusPath := filepath.Join(t.TempDir(), "sprint-status.yaml")
+	statusContent := `development_status:
+  7-1-define-schema: Ready-For-Dev
+  7-2-create-api: "  DONE "
+  7-3-build-ui: pending-qa
+`
+	require.NoError(t, os.WriteFile(statusPath, []byte(statusContent), 0644))
+
+	reader := NewReaderWithPath("", statusPath)
+	status, err := reader.Read()
+	require.NoError(t, err)
+	assert.Equal(t, Status("Ready-For-Dev"), status.DevelopmentStatus["7-1-define-schema"], "off by default")
+
+	reader.SetNormalizeStatuses(true)
+	status, err = reader.Read()
+	require.NoError(t, err)
+	assert.Equal(t, StatusReadyForDev, status.DevelopmentStatus["7-1-define-schema"])
+	assert.Equal(t, StatusDone, status.DevelopmentStatus["7-2-create-api"])
+	assert.Equal(t, Status("pending-qa"), status.DevelopmentStatus["7-3-build-ui"], "unknown statuses are kept")
+
+	got, err := reader.GetStoryStatus("7-1-define-schema")
+	require.NoError(t, err)
+	assert.Equal(t, StatusReadyForDev, got)
+}
+
 func TestReader_Read_Success(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -81,6 +108,42 @@ func TestReader_Read_InvalidYAML(t *testing.T) {
 	assert.Contains(t, err.Error(), "failed to read sprint status")
 }
 
+func TestReader_Read_Fixtures(t *testing.T) {
+	for _, fixture := range []string{"sprint_status_flat.yaml", "sprint_status_nested.yaml"} {
+		t.Run(fixture, func(t *testing.T) {
+			reader := NewReaderWithPath("", filepath.Join("testdata", fixture))
+			status, err := reader.Read()
+
+			require.NoError(t, err)
+			assert.Equal(t, map[string]Status{
+				"epic-7":            StatusInProgress,
+				"7-1-define-schema": StatusDone,
+				"7-2-create-api":    StatusReview,
+				"7-3-build-ui":      StatusBacklog,
+			}, status.DevelopmentStatus)
+		})
+	}
+}
+
+func TestReader_Read_NestedPrefersShallowest(t *testing.T) {
+	tmpDir := t.TempDir()
+	statusPath := filepath.Join(tmpDir, "sprint-status.yaml")
+	content := `a:
+  b:
+    development_status:
+      7-1-deep: done
+other:
+  development_status:
+    7-1-shallow: review
+`
+	require.NoError(t, os.WriteFile(statusPath, []byte(content), 0644))
+
+	status, err := NewReaderWithPath("", statusPath).Read()
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]Status{"7-1-shallow": StatusReview}, status.DevelopmentStatus)
+}
+
 func TestReader_GetStoryStatus_Found(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -364,6 +427,34 @@ func TestResolvePath_V6TakesPriorityOverLegacy(t *testing.T) {
 	assert.Equal(t, filepath.Join(tmpDir, V6StatusPath), path)
 }
 
+func TestReader_UsesLegacyPath(t *testing.T) {
+	t.Setenv("BMADUUM_SPRINT_STATUS_PATH", "")
+
+	t.Run("legacy file only", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, LegacyStatusPath), []byte("{}"), 0644))
+
+		assert.True(t, NewReader(tmpDir).UsesLegacyPath())
+	})
+
+	t.Run("v6 file", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		statusDir := filepath.Join(tmpDir, "_bmad-output", "implementation-artifacts")
+		require.NoError(t, os.MkdirAll(statusDir, 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(statusDir, "sprint-status.yaml"), []byte("{}"), 0644))
+
+		assert.False(t, NewReader(tmpDir).UsesLegacyPath())
+	})
+
+	t.Run("explicit path", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		legacyPath := filepath.Join(tmpDir, LegacyStatusPath)
+		require.NoError(t, os.WriteFile(legacyPath, []byte("{}"), 0644))
+
+		assert.False(t, NewReaderWithPath(tmpDir, legacyPath).UsesLegacyPath())
+	})
+}
+
 func TestNewReaderWithPath_UsesExplicitPath(t *testing.T) {
 	t.Setenv("BMADUUM_SPRINT_STATUS_PATH", "")
 
@@ -440,3 +531,153 @@ func TestReader_EnvVarOverride_ReadSuccess(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, StatusReview, status)
 }
+
+func TestReader_GetEpicStories_DottedNumbering(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	statusDir := filepath.Join(tmpDir, "_bmad-output", "implementation-artifacts")
+	require.NoError(t, os.MkdirAll(statusDir, 0755))
+
+	statusContent := `development_status:
+  6-2-middle: backlog
+  6-1.10-late-sub: backlog
+  6-1.2-early-sub: ready-for-dev
+  6-1-first: in-progress
+  6-a-lettered: backlog
+`
+	require.NoError(t, os.WriteFile(filepath.Join(statusDir, "sprint-status.yaml"), []byte(statusContent), 0644))
+
+	reader := NewReader(tmpDir)
+	reader.SetNumberScheme(SchemeDotted)
+	warnings := &bytes.Buffer{}
+	reader.SetWarningOutput(warnings)
+
+	stories, err := reader.GetEpicStories("6")
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"6-1-first", "6-1.2-early-sub", "6-1.10-late-sub", "6-2-middle"}, stories)
+	assert.Equal(t, "Warning: skipping 6-a-lettered: story number does not match dotted numbering\n", warnings.String())
+}
+
+func TestReader_GetEpicStories_NumericWarnsOnMismatch(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	statusDir := filepath.Join(tmpDir, "_bmad-output", "implementation-artifacts")
+	require.NoError(t, os.MkdirAll(statusDir, 0755))
+
+	statusContent := `development_status:
+  6-1-first: backlog
+  6-1.2-dotted: backlog
+  6-a-lettered: backlog
+`
+	require.NoError(t, os.WriteFile(filepath.Join(statusDir, "sprint-status.yaml"), []byte(statusContent), 0644))
+
+	reader := NewReader(tmpDir)
+	warnings := &bytes.Buffer{}
+	reader.SetWarningOutput(warnings)
+
+	stories, err := reader.GetEpicStories("6")
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"6-1-first"}, stories)
+	assert.Contains(t, warnings.String(), "skipping 6-1.2-dotted")
+	assert.Contains(t, warnings.String(), "skipping 6-a-lettered")
+}
+
+func TestReader_GetEpicStories_AlphaNumbering(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	statusDir := filepath.Join(tmpDir, "_bmad-output", "implementation-artifacts")
+	require.NoError(t, os.MkdirAll(statusDir, 0755))
+
+	statusContent := `development_status:
+  6-aa-twenty-seventh: backlog
+  6-b-second: backlog
+  6-A-first: backlog
+`
+	require.NoError(t, os.WriteFile(filepath.Join(statusDir, "sprint-status.yaml"), []byte(statusContent), 0644))
+
+	reader := NewReader(tmpDir)
+	reader.SetNumberScheme(SchemeAlpha)
+	reader.SetWarningOutput(nil)
+
+	stories, err := reader.GetEpicStories("6")
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"6-A-first", "6-b-second", "6-aa-twenty-seventh"}, stories)
+}
+
+func TestReader_GetStoriesByStatus(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	statusDir := filepath.Join(tmpDir, "_bmad-output", "implementation-artifacts")
+	require.NoError(t, os.MkdirAll(statusDir, 0755))
+
+	statusContent := `development_status:
+  epic-6: in-progress
+  10-1-later-epic: review
+  6-10-last: review
+  6-2-middle: review
+  6-3-other: in-progress
+  7-1-next-epic: review
+  6-a-lettered: review
+`
+	require.NoError(t, os.WriteFile(filepath.Join(statusDir, "sprint-status.yaml"), []byte(statusContent), 0644))
+
+	reader := NewReader(tmpDir)
+	warnings := &bytes.Buffer{}
+	reader.SetWarningOutput(warnings)
+
+	stories, err := reader.GetStoriesByStatus(StatusReview)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"6-2-middle", "6-10-last", "7-1-next-epic", "10-1-later-epic"}, stories)
+	assert.Equal(t, "Warning: skipping 6-a-lettered: story number does not match numeric numbering\n", warnings.String())
+}
+
+func TestReader_GetStoriesByStatus_NoneFound(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	statusDir := filepath.Join(tmpDir, "_bmad-output", "implementation-artifacts")
+	require.NoError(t, os.MkdirAll(statusDir, 0755))
+
+	statusContent := `development_status:
+  epic-6: in-progress
+  6-1-first: in-progress
+`
+	require.NoError(t, os.WriteFile(filepath.Join(statusDir, "sprint-status.yaml"), []byte(statusContent), 0644))
+
+	stories, err := NewReader(tmpDir).GetStoriesByStatus(StatusInProgress)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"6-1-first"}, stories)
+
+	stories, err = NewReader(tmpDir).GetStoriesByStatus(StatusReview)
+	require.NoError(t, err)
+	assert.Empty(t, stories)
+}
+
+func TestParseNumberScheme(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    NumberScheme
+		wantErr bool
+	}{
+		{input: "", want: SchemeNumeric},
+		{input: "numeric", want: SchemeNumeric},
+		{input: "Dotted", want: SchemeDotted},
+		{input: "alpha", want: SchemeAlpha},
+		{input: "roman", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseNumberScheme(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
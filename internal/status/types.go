@@ -9,11 +9,18 @@
 //   - [SprintStatus] - Parsed representation of sprint-status.yaml
 //   - [Reader] - Reads and queries sprint status from YAML files
 //   - [Writer] - Updates status values while preserving YAML formatting
+//   - [OrphanReport] - Drift between story files and the status board
 //
 // The package uses yaml.v3's Node API for writes to preserve comments, ordering,
 // and formatting in the status file.
 package status
 
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
 // Status represents a story's development status in the workflow lifecycle.
 //
 // A story progresses through statuses as it moves through development:
@@ -46,23 +53,69 @@ const (
 	StatusDone Status = "done"
 )
 
+// validStatuses lists the known status values in lifecycle order.
+var validStatuses = []Status{StatusBacklog, StatusReadyForDev, StatusInProgress, StatusReview, StatusDone}
+
+// ValidStatuses returns the known status values in lifecycle order.
+//
+// This is the single list of recognized statuses; use it for validation
+// messages and shell completion rather than repeating the values.
+func ValidStatuses() []Status {
+	return append([]Status(nil), validStatuses...)
+}
+
 // IsValid reports whether the status is one of the known valid status values.
 // It returns true for backlog, ready-for-dev, in-progress, review, and done.
 func (s Status) IsValid() bool {
-	switch s {
-	case StatusBacklog, StatusReadyForDev, StatusInProgress, StatusReview, StatusDone:
-		return true
-	default:
-		return false
+	for _, valid := range validStatuses {
+		if s == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseStatus parses s as one of the known statuses, ignoring case and
+// surrounding whitespace, so " Ready-For-Dev" is [StatusReadyForDev].
+//
+// Returns an [*InvalidStatusError] if s is not a known status.
+func ParseStatus(s string) (Status, error) {
+	parsed := Status(strings.ToLower(strings.TrimSpace(s)))
+	if !parsed.IsValid() {
+		return "", &InvalidStatusError{Value: s}
+	}
+	return parsed, nil
+}
+
+// InvalidStatusError is returned by [ParseStatus] for a value that is not a
+// known status.
+type InvalidStatusError struct {
+	// Value is the value as it was given.
+	Value string
+}
+
+// Error names the value and lists the valid statuses.
+func (e *InvalidStatusError) Error() string {
+	names := make([]string, len(validStatuses))
+	for i, s := range validStatuses {
+		names[i] = string(s)
 	}
+	return fmt.Sprintf("unknown status %q (expected %s, or %s)", e.Value,
+		strings.Join(names[:len(names)-1], ", "), names[len(names)-1])
 }
 
 // SprintStatus represents the parsed contents of a sprint-status.yaml file.
 //
 // The file structure contains a development_status map where keys are story
 // identifiers (e.g., "7-1-define-schema") and values are their current [Status].
+// The map is usually at the root of the file but may be nested under a
+// project key; see [Reader.Read].
 type SprintStatus struct {
 	// DevelopmentStatus maps story keys to their current development status.
 	// Story keys follow the pattern: {epicID}-{storyNum}-{description}.
 	DevelopmentStatus map[string]Status `yaml:"development_status"`
+
+	// StatusUpdatedAt maps story keys to when their status last changed,
+	// for stories whose changes were recorded; see [StatusUpdatedAtKey].
+	StatusUpdatedAt map[string]time.Time `yaml:"status_updated_at,omitempty"`
 }
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestStatus_IsValid(t *testing.T) {
@@ -74,3 +75,43 @@ func TestStatus_Constants(t *testing.T) {
 	assert.Equal(t, Status("review"), StatusReview)
 	assert.Equal(t, Status("done"), StatusDone)
 }
+
+func TestValidStatuses(t *testing.T) {
+	assert.Equal(t, []Status{StatusBacklog, StatusReadyForDev, StatusInProgress, StatusReview, StatusDone}, ValidStatuses())
+
+	for _, s := range ValidStatuses() {
+		assert.True(t, s.IsValid(), "%s should be valid", s)
+	}
+
+	// The returned slice is a copy
+	ValidStatuses()[0] = "mutated"
+	assert.Equal(t, StatusBacklog, ValidStatuses()[0])
+}
+
+func TestParseStatus(t *testing.T) {
+	tests := []struct {
+		input string
+		want  Status
+	}{
+		{"backlog", StatusBacklog},
+		{"Ready-For-Dev", StatusReadyForDev},
+		{"  in-progress\n", StatusInProgress},
+		{"REVIEW", StatusReview},
+		{"done", StatusDone},
+	}
+	for _, tt := range tests {
+		got, err := ParseStatus(tt.input)
+		require.NoError(t, err, tt.input)
+		assert.Equal(t, tt.want, got, tt.input)
+	}
+
+	for _, input := range []string{"", "pending-qa", "ready for dev"} {
+		_, err := ParseStatus(input)
+		var invalid *InvalidStatusError
+		require.ErrorAs(t, err, &invalid, input)
+		assert.Equal(t, input, invalid.Value)
+	}
+
+	_, err := ParseStatus("pending-qa")
+	assert.EqualError(t, err, `unknown status "pending-qa" (expected backlog, ready-for-dev, in-progress, review, or done)`)
+}
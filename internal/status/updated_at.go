@@ -0,0 +1,201 @@
+package status
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// StatusUpdatedAtKey is the key of the mapping, next to development_status,
+// in which a [Writer] with [Writer.SetTrackUpdatedAt] enabled records when
+// each story's status last changed, as an RFC 3339 UTC timestamp:
+//
+//	development_status:
+//	  6-1-setup: review
+//	status_updated_at:
+//	  6-1-setup: 2026-10-18T09:12:44Z
+const StatusUpdatedAtKey = "status_updated_at"
+
+// GetStoryUpdatedAt returns when the status of a specific story key last
+// changed, as recorded by a [Writer] with [Writer.SetTrackUpdatedAt]
+// enabled. It returns the zero time if no change has been recorded.
+//
+// Returns an error if the file cannot be read or if the story key is not
+// found in the file.
+func (r *Reader) GetStoryUpdatedAt(storyKey string) (time.Time, error) {
+	sprintStatus, err := r.Read()
+	if err != nil {
+		return time.Time{}, err
+	}
+	if _, ok := sprintStatus.DevelopmentStatus[storyKey]; !ok {
+		return time.Time{}, fmt.Errorf("story not found: %s", storyKey)
+	}
+	return sprintStatus.StatusUpdatedAt[storyKey], nil
+}
+
+// decodeUpdatedAt returns the timestamps of the [StatusUpdatedAtKey]
+// mapping within parent, or nil if there is none. Entries that are not RFC
+// 3339 timestamps are left out.
+func decodeUpdatedAt(parent *yaml.Node) (map[string]time.Time, error) {
+	node := mappingValue(parent, StatusUpdatedAtKey)
+	if node == nil || isNullNode(node) {
+		return nil, nil
+	}
+
+	var raw map[string]string
+	if err := node.Decode(&raw); err != nil {
+		return nil, fmt.Errorf("%s: %w", StatusUpdatedAtKey, err)
+	}
+	updatedAt := make(map[string]time.Time, len(raw))
+	for key, value := range raw {
+		if t, err := time.Parse(time.RFC3339, value); err == nil {
+			updatedAt[key] = t
+		}
+	}
+	return updatedAt, nil
+}
+
+// updatedAtStamps records the time of a status change for a set of stories
+// in the [StatusUpdatedAtKey] mapping of a sprint status node tree.
+//
+// Create instances using [newUpdatedAtStamps], which stamps the stories
+// that already have an entry; [updatedAtStamps.insert] or
+// [updatedAtStamps.addNodes] adds entries for the rest.
+type updatedAtStamps struct {
+	root      *yaml.Node // root mapping of the document
+	parent    *yaml.Node // mapping containing development_status
+	devStatus *yaml.Node
+	mapping   *yaml.Node // the status_updated_at value, or nil
+	stamp     string
+	pending   []string // story keys without an entry
+}
+
+// newUpdatedAtStamps stamps each of storyKeys with now, adding the
+// replacement of each existing entry to replacements.
+//
+// Returns an error if the status_updated_at value is not a mapping or one of
+// the entries is not a scalar.
+func newUpdatedAtStamps(root, parent, devStatus *yaml.Node, storyKeys []string, now time.Time, replacements map[*yaml.Node]string) (*updatedAtStamps, error) {
+	s := &updatedAtStamps{
+		root:      root,
+		parent:    parent,
+		devStatus: devStatus,
+		mapping:   mappingValue(parent, StatusUpdatedAtKey),
+		stamp:     now.UTC().Format(time.RFC3339),
+	}
+	if s.mapping != nil && s.mapping.Kind != yaml.MappingNode && !isNullNode(s.mapping) {
+		return nil, fmt.Errorf("%s is not a mapping", StatusUpdatedAtKey)
+	}
+
+	for _, storyKey := range storyKeys {
+		var valueNode *yaml.Node
+		if s.mapping != nil {
+			valueNode = mappingValue(s.mapping, storyKey)
+		}
+		if valueNode == nil {
+			s.pending = append(s.pending, storyKey)
+			continue
+		}
+		if valueNode.Kind != yaml.ScalarNode {
+			return nil, fmt.Errorf("%s.%s is not a timestamp", StatusUpdatedAtKey, storyKey)
+		}
+		replacements[valueNode] = s.stamp
+	}
+	return s, nil
+}
+
+// insert returns a copy of data with an entry added for each pending story,
+// appended after the last entry of the status_updated_at mapping or, if
+// there is none, in a new mapping at the end of the file.
+//
+// data must have the line layout the node tree was parsed from. Returns
+// false if the entries cannot be placed without re-marshaling: the mapping
+// is empty or in flow style, or development_status is nested under another
+// key and there is no mapping yet.
+func (s *updatedAtStamps) insert(data []byte) ([]byte, bool) {
+	if len(s.pending) == 0 {
+		return data, true
+	}
+
+	var text strings.Builder
+	offset := len(data)
+	indent := 2
+	switch {
+	case s.mapping != nil && s.mapping.Kind == yaml.MappingNode && s.mapping.Style&yaml.FlowStyle == 0 && len(s.mapping.Content) > 0:
+		last := s.mapping.Content[len(s.mapping.Content)-1]
+		if last.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0 {
+			return nil, false
+		}
+		offset = lineEnd(data, last.Line)
+		indent = s.mapping.Content[0].Column - 1
+	case s.mapping == nil && s.parent == s.root && s.parent.Style&yaml.FlowStyle == 0:
+		if len(s.devStatus.Content) > 0 {
+			indent = s.devStatus.Content[0].Column - 1
+		}
+		text.WriteString(StatusUpdatedAtKey + ":\n")
+	default:
+		return nil, false
+	}
+
+	for _, storyKey := range s.pending {
+		// Quote the key as it is quoted in development_status
+		keyNode := &yaml.Node{}
+		for i := 0; i+1 < len(s.devStatus.Content); i += 2 {
+			if s.devStatus.Content[i].Value == storyKey {
+				keyNode = s.devStatus.Content[i]
+				break
+			}
+		}
+		fmt.Fprintf(&text, "%s%s: %s\n", strings.Repeat(" ", indent), quoteLike(keyNode, storyKey), s.stamp)
+	}
+
+	out := make([]byte, 0, len(data)+text.Len()+1)
+	out = append(out, data[:offset]...)
+	if offset > 0 && data[offset-1] != '\n' {
+		out = append(out, '\n')
+	}
+	out = append(out, text.String()...)
+	return append(out, data[offset:]...), true
+}
+
+// addNodes adds an entry for each pending story to the node tree, creating
+// the status_updated_at mapping if needed, for when [updatedAtStamps.insert]
+// cannot place them.
+func (s *updatedAtStamps) addNodes() {
+	if len(s.pending) == 0 {
+		return
+	}
+
+	switch {
+	case s.mapping == nil:
+		s.mapping = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		s.parent.Content = append(s.parent.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: StatusUpdatedAtKey}, s.mapping)
+	case isNullNode(s.mapping):
+		s.mapping.Kind = yaml.MappingNode
+		s.mapping.Tag = "!!map"
+		s.mapping.Value = ""
+	}
+	for _, storyKey := range s.pending {
+		s.mapping.Content = append(s.mapping.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: storyKey},
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!timestamp", Value: s.stamp})
+	}
+}
+
+// lineEnd returns the offset just past the end of the given 1-based line of
+// data, or len(data) if it is the last line.
+func lineEnd(data []byte, line int) int {
+	offset := 0
+	for ; line > 0; line-- {
+		i := bytes.IndexByte(data[offset:], '\n')
+		if i < 0 {
+			return len(data)
+		}
+		offset += i + 1
+	}
+	return offset
+}
@@ -0,0 +1,181 @@
+package status
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriter_TrackUpdatedAt(t *testing.T) {
+	now := time.Date(2026, 10, 18, 11, 12, 44, 0, time.FixedZone("CEST", 2*60*60))
+
+	tests := []struct {
+		name     string
+		content  string
+		updates  map[string]Status
+		expected string
+	}{
+		{
+			name: "creates the mapping at the end of the file",
+			content: `# Sprint
+development_status:
+  7-1-define-schema: ready-for-dev   # first
+  7-2-create-api: backlog
+`,
+			updates: map[string]Status{"7-1-define-schema": StatusInProgress},
+			expected: `# Sprint
+development_status:
+  7-1-define-schema: in-progress   # first
+  7-2-create-api: backlog
+status_updated_at:
+  7-1-define-schema: 2026-10-18T09:12:44Z
+`,
+		},
+		{
+			name: "updates and appends entries in place",
+			content: `development_status:
+  7-1-define-schema: ready-for-dev
+  7-2-create-api: backlog
+  7-3-build-ui: review
+status_updated_at:
+  7-1-define-schema: 2026-10-01T08:00:00Z # created
+  7-3-build-ui: 2026-10-02T08:00:00Z
+
+# trailing comment
+`,
+			updates: map[string]Status{"7-1-define-schema": StatusInProgress, "7-2-create-api": StatusReadyForDev},
+			expected: `development_status:
+  7-1-define-schema: in-progress
+  7-2-create-api: ready-for-dev
+  7-3-build-ui: review
+status_updated_at:
+  7-1-define-schema: 2026-10-18T09:12:44Z # created
+  7-3-build-ui: 2026-10-02T08:00:00Z
+  7-2-create-api: 2026-10-18T09:12:44Z
+
+# trailing comment
+`,
+		},
+		{
+			name: "leaves unchanged statuses unstamped",
+			content: `development_status:
+  7-1-define-schema: ready-for-dev
+`,
+			updates: map[string]Status{"7-1-define-schema": StatusReadyForDev},
+			expected: `development_status:
+  7-1-define-schema: ready-for-dev
+`,
+		},
+		{
+			name: "adds the mapping to a nested development_status",
+			content: `projects:
+  myapp:
+    development_status:
+      7-1-define-schema: ready-for-dev
+`,
+			updates: map[string]Status{"7-1-define-schema": StatusDone},
+			expected: `projects:
+    myapp:
+        development_status:
+            7-1-define-schema: done
+        status_updated_at:
+            7-1-define-schema: 2026-10-18T09:12:44Z
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statusPath := filepath.Join(t.TempDir(), "sprint-status.yaml")
+			require.NoError(t, os.WriteFile(statusPath, []byte(tt.content), 0644))
+
+			writer := NewWriterWithPath("", statusPath)
+			writer.SetTrackUpdatedAt(true)
+			writer.now = func() time.Time { return now }
+			require.NoError(t, writer.UpdateStatusBatch(tt.updates))
+
+			updated, err := os.ReadFile(statusPath)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, string(updated))
+		})
+	}
+}
+
+func TestWriter_TrackUpdatedAt_Disabled(t *testing.T) {
+	const content = `development_status:
+  7-1-define-schema: ready-for-dev
+`
+	statusPath := filepath.Join(t.TempDir(), "sprint-status.yaml")
+	require.NoError(t, os.WriteFile(statusPath, []byte(content), 0644))
+
+	require.NoError(t, NewWriterWithPath("", statusPath).UpdateStatus("7-1-define-schema", StatusDone))
+
+	updated, err := os.ReadFile(statusPath)
+	require.NoError(t, err)
+	assert.Equal(t, "development_status:\n  7-1-define-schema: done\n", string(updated))
+}
+
+func TestWriter_TrackUpdatedAt_NotAMapping(t *testing.T) {
+	const content = `development_status:
+  7-1-define-schema: ready-for-dev
+status_updated_at: yesterday
+`
+	statusPath := filepath.Join(t.TempDir(), "sprint-status.yaml")
+	require.NoError(t, os.WriteFile(statusPath, []byte(content), 0644))
+
+	writer := NewWriterWithPath("", statusPath)
+	writer.SetTrackUpdatedAt(true)
+	err := writer.UpdateStatus("7-1-define-schema", StatusDone)
+
+	assert.EqualError(t, err, "status_updated_at is not a mapping")
+	updated, err := os.ReadFile(statusPath)
+	require.NoError(t, err)
+	assert.Equal(t, content, string(updated))
+}
+
+func TestReader_GetStoryUpdatedAt(t *testing.T) {
+	statusPath := filepath.Join(t.TempDir(), "sprint-status.yaml")
+	require.NoError(t, os.WriteFile(statusPath, []byte(`development_status:
+  7-1-define-schema: in-progress
+  7-2-create-api: backlog
+  7-3-build-ui: review
+status_updated_at:
+  7-1-define-schema: 2026-10-18T09:12:44Z
+  7-3-build-ui: "last week"
+`), 0644))
+	reader := NewReaderWithPath("", statusPath)
+
+	updatedAt, err := reader.GetStoryUpdatedAt("7-1-define-schema")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 10, 18, 9, 12, 44, 0, time.UTC), updatedAt)
+
+	updatedAt, err = reader.GetStoryUpdatedAt("7-2-create-api")
+	require.NoError(t, err)
+	assert.True(t, updatedAt.IsZero(), "story without an entry")
+
+	updatedAt, err = reader.GetStoryUpdatedAt("7-3-build-ui")
+	require.NoError(t, err)
+	assert.True(t, updatedAt.IsZero(), "entry that is not a timestamp")
+
+	_, err = reader.GetStoryUpdatedAt("9-9-missing")
+	assert.EqualError(t, err, "story not found: 9-9-missing")
+}
+
+func TestTrackUpdatedAt_RoundTrip(t *testing.T) {
+	now := time.Date(2026, 10, 18, 9, 12, 44, 0, time.UTC)
+	statusPath := filepath.Join(t.TempDir(), "sprint-status.yaml")
+	require.NoError(t, os.WriteFile(statusPath, []byte("development_status:\n  7-1-define-schema: backlog\n"), 0644))
+
+	writer := NewWriterWithPath("", statusPath)
+	writer.SetTrackUpdatedAt(true)
+	writer.now = func() time.Time { return now }
+	require.NoError(t, writer.UpdateStatus("7-1-define-schema", StatusReadyForDev))
+
+	updatedAt, err := NewReaderWithPath("", statusPath).GetStoryUpdatedAt("7-1-define-schema")
+	require.NoError(t, err)
+	assert.True(t, now.Equal(updatedAt))
+}
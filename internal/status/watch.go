@@ -0,0 +1,84 @@
+package status
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// DefaultDebounce is how long a [Watcher] waits for writes to the status file
+// to settle before reporting a change.
+const DefaultDebounce = 500 * time.Millisecond
+
+// Watcher reports changes to a sprint-status.yaml file.
+//
+// The file's directory is watched rather than the file itself, so atomic
+// updates that replace the file by renaming a temp file over it (as
+// [Writer] does) are seen. Bursts of writes within the debounce interval
+// are reported once.
+//
+// Create instances using [NewWatcher].
+type Watcher struct {
+	path     string
+	debounce time.Duration
+}
+
+// NewWatcher creates a [Watcher] for the status file at path, with
+// [DefaultDebounce].
+func NewWatcher(path string) *Watcher {
+	return &Watcher{path: path, debounce: DefaultDebounce}
+}
+
+// SetDebounce configures how long writes must be quiet before a change is
+// reported.
+func (w *Watcher) SetDebounce(d time.Duration) {
+	w.debounce = d
+}
+
+// Watch calls onChange each time the status file is written, created, or
+// replaced, once writes have settled. onChange runs on the calling
+// goroutine, so calls never overlap; changes made while it runs are
+// reported by a later call.
+//
+// Watch blocks until ctx is canceled, returning nil, or the watch fails.
+func (w *Watcher) Watch(ctx context.Context, onChange func()) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to watch sprint status: %w", err)
+	}
+	defer watcher.Close()
+
+	if err := watcher.Add(filepath.Dir(w.path)); err != nil {
+		return fmt.Errorf("failed to watch sprint status: %w", err)
+	}
+
+	target := filepath.Clean(w.path)
+	timer := time.NewTimer(w.debounce)
+	timer.Stop()
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if filepath.Clean(event.Name) != target || event.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+				continue
+			}
+			timer.Reset(w.debounce)
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			return fmt.Errorf("failed to watch sprint status: %w", err)
+		case <-timer.C:
+			onChange()
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package status
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatcher_DebouncesWrites(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sprint-status.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("development_status: {}\n"), 0644))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	changes := make(chan struct{}, 10)
+	watcher := NewWatcher(path)
+	watcher.SetDebounce(100 * time.Millisecond)
+	done := make(chan error, 1)
+	go func() {
+		done <- watcher.Watch(ctx, func() { changes <- struct{}{} })
+	}()
+
+	// Keep writing until the watcher is running and reports the burst
+	writer := NewWriterWithPath("", path)
+	deadline := time.After(5 * time.Second)
+	for reported := false; !reported; {
+		require.NoError(t, os.WriteFile(path, []byte("development_status:\n  7-1-a: backlog\n"), 0644))
+		require.NoError(t, writer.UpdateStatus("7-1-a", StatusReadyForDev))
+		select {
+		case <-changes:
+			reported = true
+		case <-time.After(300 * time.Millisecond):
+		case <-deadline:
+			t.Fatal("change not reported")
+		}
+	}
+
+	// Other files in the directory are ignored
+	require.NoError(t, os.WriteFile(filepath.Join(filepath.Dir(path), "other.yaml"), []byte("x"), 0644))
+	select {
+	case <-changes:
+		t.Fatal("change to another file reported")
+	case <-time.After(300 * time.Millisecond):
+	}
+
+	cancel()
+	assert.NoError(t, <-done)
+}
@@ -1,19 +1,32 @@
 package status
 
 import (
+	"bytes"
 	"fmt"
 	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"gopkg.in/yaml.v3"
 )
 
 // Writer writes sprint status updates to YAML files.
 //
-// It uses yaml.v3's Node API to preserve comments, ordering, and formatting
-// when updating status values. Writes are performed atomically using a
-// temporary file and rename pattern to prevent corruption.
+// It uses yaml.v3's Node API to locate the status value and rewrites only
+// that value in the original bytes, so comments, blank lines, indentation,
+// and key ordering are left exactly as they were. Writes are performed atomically using a
+// temporary file and rename pattern to prevent corruption, and each
+// read-modify-write is serialized across processes by an exclusive advisory
+// lock on a sibling ".lock" file.
 type Writer struct {
-	statusPath string
+	statusPath     string
+	lockTimeout    time.Duration
+	trackUpdatedAt bool
+	now            func() time.Time
 }
 
 // NewWriter creates a new [Writer] that auto-discovers the status file.
@@ -24,7 +37,9 @@ type Writer struct {
 // The BMADUUM_SPRINT_STATUS_PATH environment variable overrides all discovery.
 func NewWriter(basePath string) *Writer {
 	return &Writer{
-		statusPath: ResolvePath(basePath, ""),
+		statusPath:  ResolvePath(basePath, ""),
+		lockTimeout: DefaultLockTimeout,
+		now:         time.Now,
 	}
 }
 
@@ -34,28 +49,85 @@ func NewWriter(basePath string) *Writer {
 // The BMADUUM_SPRINT_STATUS_PATH environment variable still takes priority if set.
 func NewWriterWithPath(basePath, statusPath string) *Writer {
 	return &Writer{
-		statusPath: ResolvePath(basePath, statusPath),
+		statusPath:  ResolvePath(basePath, statusPath),
+		lockTimeout: DefaultLockTimeout,
+		now:         time.Now,
 	}
 }
 
+// SetLockTimeout sets how long [Writer.UpdateStatus] waits for another
+// process to release the status file lock. Zero or less makes a single
+// attempt. The default is [DefaultLockTimeout].
+func (w *Writer) SetLockTimeout(timeout time.Duration) {
+	w.lockTimeout = timeout
+}
+
+// SetTrackUpdatedAt makes [Writer.UpdateStatusBatch] record the time each
+// story's status changes in a [StatusUpdatedAtKey] mapping next to
+// development_status, creating the mapping if needed. Read it back with
+// [Reader.GetStoryUpdatedAt]. It is off by default, leaving files without
+// the mapping untouched.
+func (w *Writer) SetTrackUpdatedAt(track bool) {
+	w.trackUpdatedAt = track
+}
+
 // UpdateStatus atomically updates the [Status] for a specific story key.
 //
+// It is a batch of one; see [Writer.UpdateStatusBatch] for the update
+// process. Returns an error if the status is invalid, the file cannot be
+// read/written, or the story key is not found. Returns an error wrapping
+// [ErrLockTimeout] if the lock is not acquired within the writer's lock timeout.
+func (w *Writer) UpdateStatus(storyKey string, newStatus Status) error {
+	return w.UpdateStatusBatch(map[string]Status{storyKey: newStatus})
+}
+
+// UpdateStatusBatch atomically updates the [Status] of several stories with
+// a single read and a single write.
+//
 // The update process:
-//  1. Validates that newStatus is a known valid status
-//  2. Reads the existing file into a yaml.Node tree (preserves formatting)
-//  3. Locates and updates the story's status value
-//  4. Writes to a temporary file, then renames for atomic update
+//  1. Validates that every new status is a known valid status
+//  2. Takes an exclusive lock so concurrent processes cannot interleave
+//  3. Reads the existing file into a yaml.Node tree to locate the values
+//  4. Checks that every story key exists before changing anything
+//  5. Replaces each story's status value in place in the original bytes,
+//     and records the time of each change if enabled with
+//     [Writer.SetTrackUpdatedAt]
+//  6. Writes to a uniquely named temporary file, then renames for atomic update
 //
-// Returns an error if the status is invalid, the file cannot be read/written,
-// or the story key is not found.
-func (w *Writer) UpdateStatus(storyKey string, newStatus Status) error {
-	// Validate the new status
-	if !newStatus.IsValid() {
-		return fmt.Errorf("invalid status: %s", newStatus)
+// The batch is all or nothing: if any status is invalid or any story key is
+// missing, the file is left unchanged. An empty batch does nothing.
+func (w *Writer) UpdateStatusBatch(updates map[string]Status) error {
+	if len(updates) == 0 {
+		return nil
+	}
+
+	// Sorted keys keep validation errors deterministic
+	keys := make([]string, 0, len(updates))
+	for storyKey := range updates {
+		keys = append(keys, storyKey)
+	}
+	sort.Strings(keys)
+
+	// Validate the new statuses
+	for _, storyKey := range keys {
+		if newStatus := updates[storyKey]; !newStatus.IsValid() {
+			return fmt.Errorf("invalid status: %s", newStatus)
+		}
 	}
 
 	fullPath := w.statusPath
 
+	// Check the file exists before creating a lock file next to it
+	if _, err := os.Stat(fullPath); err != nil {
+		return fmt.Errorf("failed to read sprint status: %w", err)
+	}
+
+	unlock, err := acquireLock(fullPath, w.lockTimeout)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	// Read existing file
 	data, err := os.ReadFile(fullPath)
 	if err != nil {
@@ -68,72 +140,265 @@ func (w *Writer) UpdateStatus(storyKey string, newStatus Status) error {
 		return fmt.Errorf("failed to parse sprint status: %w", err)
 	}
 
-	// Find and update the story status in the node tree
-	if err := updateStoryStatusInNode(&doc, storyKey, newStatus); err != nil {
+	// Find every story's status node before changing anything
+	devStatusNode, parent, err := findDevelopmentStatusNode(&doc)
+	if err != nil {
 		return err
 	}
+	replacements := make(map[*yaml.Node]string, len(keys))
+	var missing, changed []string
+	for _, storyKey := range keys {
+		valueNode := findStoryStatusNode(devStatusNode, storyKey)
+		if valueNode == nil {
+			missing = append(missing, storyKey)
+			continue
+		}
+		if valueNode.Value != string(updates[storyKey]) {
+			changed = append(changed, storyKey)
+		}
+		replacements[valueNode] = string(updates[storyKey])
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("story not found: %s", strings.Join(missing, ", "))
+	}
 
-	// Marshal the node tree back to YAML (preserves formatting)
-	updatedData, err := yaml.Marshal(&doc)
-	if err != nil {
-		return fmt.Errorf("failed to marshal sprint status: %w", err)
+	var stamps *updatedAtStamps
+	if w.trackUpdatedAt && len(changed) > 0 {
+		stamps, err = newUpdatedAtStamps(doc.Content[0], parent, devStatusNode, changed, w.now(), replacements)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Replace just the values; fall back to re-marshaling the node tree if
+	// a value cannot be located in the source (e.g. a tagged scalar)
+	updatedData, ok := replaceScalars(data, replacements)
+	if ok && stamps != nil {
+		updatedData, ok = stamps.insert(updatedData)
+	}
+	if !ok {
+		for valueNode, value := range replacements {
+			valueNode.Value = value
+			valueNode.Style = 0
+		}
+		if stamps != nil {
+			stamps.addNodes()
+		}
+		updatedData, err = yaml.Marshal(&doc)
+		if err != nil {
+			return fmt.Errorf("failed to marshal sprint status: %w", err)
+		}
 	}
 
 	// Write back to file atomically (write to temp, then rename)
-	tmpPath := fullPath + ".tmp"
-	if err := os.WriteFile(tmpPath, updatedData, 0644); err != nil {
+	if err := writeFileAtomic(fullPath, updatedData); err != nil {
 		return fmt.Errorf("failed to write sprint status: %w", err)
 	}
 
-	if err := os.Rename(tmpPath, fullPath); err != nil {
+	return nil
+}
+
+// writeFileAtomic writes data to a uniquely named temp file in the same
+// directory as path, then renames it over path.
+//
+// The unique name (PID plus a random suffix via [os.CreateTemp]) keeps concurrent writers from
+// clobbering each other's temp files. The temp file is removed on failure.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), fmt.Sprintf("%s.%d.*.tmp", filepath.Base(path), os.Getpid()))
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := tmp.Chmod(0644); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
 		// Clean up temp file on rename failure
 		os.Remove(tmpPath)
-		return fmt.Errorf("failed to write sprint status: %w", err)
+		return err
 	}
 
 	return nil
 }
 
-// updateStoryStatusInNode finds and updates a story's status within a yaml.Node tree.
-func updateStoryStatusInNode(doc *yaml.Node, storyKey string, newStatus Status) error {
-	// Document node contains the root content node
-	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
-		return fmt.Errorf("invalid YAML document structure")
+// replaceScalars returns a copy of data with the scalar at each node's
+// position replaced by its new value, keeping each scalar's quoting style.
+//
+// Returns false if any node is not a plain or quoted scalar or the source at
+// its position does not match it, in which case data is not modified.
+func replaceScalars(data []byte, replacements map[*yaml.Node]string) ([]byte, bool) {
+	type span struct {
+		start, end int
+		value      string
 	}
 
-	root := doc.Content[0]
-	if root.Kind != yaml.MappingNode {
-		return fmt.Errorf("expected mapping at root level")
+	spans := make([]span, 0, len(replacements))
+	for node, value := range replacements {
+		start, raw, ok := scalarSource(data, node)
+		if !ok {
+			return nil, false
+		}
+		spans = append(spans, span{start: start, end: start + len(raw), value: quoteLike(node, value)})
 	}
+	sort.Slice(spans, func(i, j int) bool { return spans[i].start < spans[j].start })
 
-	// Find development_status key in root mapping
-	var devStatusNode *yaml.Node
-	for i := 0; i < len(root.Content); i += 2 {
-		keyNode := root.Content[i]
-		if keyNode.Value == "development_status" {
-			devStatusNode = root.Content[i+1]
-			break
+	out := make([]byte, 0, len(data))
+	prev := 0
+	for _, sp := range spans {
+		out = append(out, data[prev:sp.start]...)
+		out = append(out, sp.value...)
+		prev = sp.end
+	}
+	return append(out, data[prev:]...), true
+}
+
+// scalarSource returns the byte offset of node in data and the source text
+// of the scalar there.
+//
+// Returns false if node is not a plain or quoted scalar or the source at its
+// position does not match it.
+func scalarSource(data []byte, node *yaml.Node) (int, string, bool) {
+	if node.Kind != yaml.ScalarNode {
+		return 0, "", false
+	}
+
+	// Line is 1-based; Column is a 1-based character (not byte) offset
+	start := 0
+	for line := 1; line < node.Line; line++ {
+		i := bytes.IndexByte(data[start:], '\n')
+		if i < 0 {
+			return 0, "", false
 		}
+		start += i + 1
+	}
+	for col := 1; col < node.Column; col++ {
+		if start >= len(data) || data[start] == '\n' {
+			return 0, "", false
+		}
+		_, size := utf8.DecodeRune(data[start:])
+		start += size
 	}
 
+	var raw string
+	switch node.Style {
+	case yaml.DoubleQuotedStyle:
+		raw = strconv.Quote(node.Value)
+	case yaml.SingleQuotedStyle:
+		raw = "'" + strings.ReplaceAll(node.Value, "'", "''") + "'"
+	case 0:
+		raw = node.Value
+	default:
+		return 0, "", false
+	}
+	if !bytes.HasPrefix(data[start:], []byte(raw)) {
+		return 0, "", false
+	}
+	return start, raw, true
+}
+
+// quoteLike formats value in the quoting style of node.
+func quoteLike(node *yaml.Node, value string) string {
+	switch node.Style {
+	case yaml.DoubleQuotedStyle:
+		return strconv.Quote(value)
+	case yaml.SingleQuotedStyle:
+		return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+	default:
+		return value
+	}
+}
+
+// findDevelopmentStatusNode returns the development_status mapping within a
+// yaml.Node tree and the mapping that contains it, as found by
+// [lookupDevelopmentStatusNode].
+func findDevelopmentStatusNode(doc *yaml.Node) (devStatusNode, parent *yaml.Node, err error) {
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		return nil, nil, fmt.Errorf("invalid YAML document structure")
+	}
+	if doc.Content[0].Kind != yaml.MappingNode {
+		return nil, nil, fmt.Errorf("expected mapping at root level")
+	}
+
+	devStatusNode, parent, err = lookupDevelopmentStatusNode(doc)
+	if err != nil {
+		return nil, nil, err
+	}
 	if devStatusNode == nil {
-		return fmt.Errorf("development_status not found in file")
+		return nil, nil, fmt.Errorf("development_status not found in file")
 	}
+	return devStatusNode, parent, nil
+}
 
-	if devStatusNode.Kind != yaml.MappingNode {
-		return fmt.Errorf("development_status is not a mapping")
+// lookupDevelopmentStatusNode returns the development_status mapping within
+// a yaml.Node tree and the mapping that contains it, or nil if there is none.
+//
+// The key is usually at the root, but some teams wrap it under a project
+// key (e.g. projects.myapp.development_status). Mappings are searched
+// breadth first, so the shallowest development_status wins and a root-level
+// one always takes precedence. Returns an error if that development_status
+// is not a mapping.
+func lookupDevelopmentStatusNode(doc *yaml.Node) (devStatusNode, parent *yaml.Node, err error) {
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		return nil, nil, nil
 	}
 
-	// Find the story key within development_status
-	for i := 0; i < len(devStatusNode.Content); i += 2 {
-		keyNode := devStatusNode.Content[i]
-		if keyNode.Value == storyKey {
-			// Update the value node
-			valueNode := devStatusNode.Content[i+1]
-			valueNode.Value = string(newStatus)
-			return nil
+	queue := []*yaml.Node{doc.Content[0]}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		if node.Kind != yaml.MappingNode {
+			continue
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			keyNode, valueNode := node.Content[i], node.Content[i+1]
+			if keyNode.Value != "development_status" {
+				queue = append(queue, valueNode)
+				continue
+			}
+			if isNullNode(valueNode) {
+				return &yaml.Node{Kind: yaml.MappingNode}, node, nil
+			}
+			if valueNode.Kind != yaml.MappingNode {
+				return nil, nil, fmt.Errorf("development_status is not a mapping")
+			}
+			return valueNode, node, nil
 		}
 	}
+	return nil, nil, nil
+}
+
+// isNullNode reports whether node is a null scalar, as for a key with no
+// value.
+func isNullNode(node *yaml.Node) bool {
+	return node.Kind == yaml.ScalarNode && node.Tag == "!!null"
+}
 
-	return fmt.Errorf("story not found: %s", storyKey)
+// findStoryStatusNode returns the status value node for a story within the
+// development_status mapping, or nil if the story is not present.
+func findStoryStatusNode(devStatusNode *yaml.Node, storyKey string) *yaml.Node {
+	return mappingValue(devStatusNode, storyKey)
+}
+
+// mappingValue returns the value node for key within mapping, or nil if the
+// key is not present.
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
 }
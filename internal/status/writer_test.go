@@ -3,6 +3,8 @@ package status
 import (
 	"os"
 	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -55,6 +57,20 @@ func TestWriter_UpdateStatus_Success(t *testing.T) {
 	assert.Equal(t, StatusBacklog, status)
 }
 
+func TestWriter_UpdateStatus_Nested(t *testing.T) {
+	original, err := os.ReadFile(filepath.Join("testdata", "sprint_status_nested.yaml"))
+	require.NoError(t, err)
+	statusPath := filepath.Join(t.TempDir(), "sprint-status.yaml")
+	require.NoError(t, os.WriteFile(statusPath, original, 0644))
+
+	writer := NewWriterWithPath("", statusPath)
+	require.NoError(t, writer.UpdateStatus("7-2-create-api", StatusDone))
+
+	updated, err := os.ReadFile(statusPath)
+	require.NoError(t, err)
+	assert.Equal(t, strings.Replace(string(original), "7-2-create-api: review", "7-2-create-api: done", 1), string(updated))
+}
+
 func TestWriter_UpdateStatus_StoryNotFound(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -167,6 +183,136 @@ development_status:
 	assert.True(t, idx81 < idx82, "8-1 should come before 8-2")
 }
 
+func TestWriter_UpdateStatus_ChangesOnlyValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		storyKey string
+		content  string
+		expected string
+	}{
+		{
+			name:     "plain value with blank lines and two-space indent",
+			storyKey: "7-2-create-api",
+			content:  "# Sprint\n\ndevelopment_status:\n  7-1-define-schema: done\n\n  7-2-create-api: in-progress   # working\n  7-3-build-ui: backlog\n",
+			expected: "# Sprint\n\ndevelopment_status:\n  7-1-define-schema: done\n\n  7-2-create-api: review   # working\n  7-3-build-ui: backlog\n",
+		},
+		{
+			name:     "double-quoted value keeps quotes",
+			storyKey: "7-1",
+			content:  "development_status:\n    \"7-1\": \"backlog\"\n",
+			expected: "development_status:\n    \"7-1\": \"review\"\n",
+		},
+		{
+			name:     "single-quoted value keeps quotes",
+			storyKey: "7-1",
+			content:  "development_status:\n  7-1: 'backlog'\n",
+			expected: "development_status:\n  7-1: 'review'\n",
+		},
+		{
+			name:     "flow mapping",
+			storyKey: "7-2",
+			content:  "development_status: {7-1: done, 7-2: backlog}\n",
+			expected: "development_status: {7-1: done, 7-2: review}\n",
+		},
+		{
+			name:     "non-ASCII comment before value",
+			storyKey: "7-1",
+			content:  "# Épica ✓\ndevelopment_status: {é: done, 7-1: backlog}\n",
+			expected: "# Épica ✓\ndevelopment_status: {é: done, 7-1: review}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statusPath := filepath.Join(t.TempDir(), "sprint-status.yaml")
+			require.NoError(t, os.WriteFile(statusPath, []byte(tt.content), 0644))
+
+			writer := NewWriterWithPath("", statusPath)
+			require.NoError(t, writer.UpdateStatus(tt.storyKey, StatusReview))
+
+			updated, err := os.ReadFile(statusPath)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, string(updated))
+		})
+	}
+}
+
+func TestWriter_UpdateStatusBatch(t *testing.T) {
+	const content = `# Sprint
+development_status:
+  7-1-define-schema: ready-for-dev   # first
+  7-2-create-api: "backlog"
+
+  7-3-build-ui: backlog
+`
+
+	tests := []struct {
+		name     string
+		updates  map[string]Status
+		wantErr  string
+		expected string
+	}{
+		{
+			name: "applies every update in one write",
+			updates: map[string]Status{
+				"7-3-build-ui":      StatusReadyForDev,
+				"7-1-define-schema": StatusDone,
+				"7-2-create-api":    StatusInProgress,
+			},
+			expected: `# Sprint
+development_status:
+  7-1-define-schema: done   # first
+  7-2-create-api: "in-progress"
+
+  7-3-build-ui: ready-for-dev
+`,
+		},
+		{
+			name:     "empty batch leaves file unchanged",
+			updates:  map[string]Status{},
+			expected: content,
+		},
+		{
+			name: "missing story fails the whole batch",
+			updates: map[string]Status{
+				"7-1-define-schema": StatusDone,
+				"9-9-missing":       StatusDone,
+				"8-1-missing":       StatusDone,
+			},
+			wantErr:  "story not found: 8-1-missing, 9-9-missing",
+			expected: content,
+		},
+		{
+			name: "invalid status fails the whole batch",
+			updates: map[string]Status{
+				"7-1-define-schema": StatusDone,
+				"7-2-create-api":    Status("shipped"),
+			},
+			wantErr:  "invalid status: shipped",
+			expected: content,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statusPath := filepath.Join(t.TempDir(), "sprint-status.yaml")
+			require.NoError(t, os.WriteFile(statusPath, []byte(content), 0644))
+
+			err := NewWriterWithPath("", statusPath).UpdateStatusBatch(tt.updates)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Equal(t, tt.wantErr, err.Error())
+			} else {
+				require.NoError(t, err)
+			}
+
+			updated, err := os.ReadFile(statusPath)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, string(updated))
+		})
+	}
+}
+
 // indexOf returns the index of substr in s, or -1 if not found
 func indexOf(s, substr string) int {
 	for i := 0; i <= len(s)-len(substr); i++ {
@@ -213,3 +359,48 @@ func TestWriter_UpdateStatus_AllStatusTransitions(t *testing.T) {
 		})
 	}
 }
+
+func TestWriter_UpdateStatus_ConcurrentWriters(t *testing.T) {
+	tmpDir := t.TempDir()
+	statusDir := filepath.Join(tmpDir, "_bmad-output", "implementation-artifacts")
+	require.NoError(t, os.MkdirAll(statusDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(statusDir, "sprint-status.yaml"), []byte(`development_status:
+  7-1-define-schema: ready-for-dev
+  7-2-create-api: ready-for-dev
+`), 0644))
+
+	writer := NewWriter(tmpDir)
+	stories := []string{"7-1-define-schema", "7-2-create-api"}
+
+	const rounds = 20
+	var wg sync.WaitGroup
+	errs := make(chan error, len(stories)*rounds)
+	for _, storyKey := range stories {
+		wg.Add(1)
+		go func(storyKey string) {
+			defer wg.Done()
+			for i := 0; i < rounds; i++ {
+				errs <- writer.UpdateStatus(storyKey, StatusInProgress)
+			}
+		}(storyKey)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	// The file is still valid and no temp files are left behind
+	sprintStatus, err := NewReader(tmpDir).Read()
+	require.NoError(t, err)
+	assert.Len(t, sprintStatus.DevelopmentStatus, 2)
+
+	entries, err := os.ReadDir(statusDir)
+	require.NoError(t, err)
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	assert.Equal(t, []string{"sprint-status.yaml", "sprint-status.yaml.lock"}, names)
+}
@@ -0,0 +1,72 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+
+	"bmaduum/internal/config"
+)
+
+// hookCommand returns the command that runs the shell command line command:
+// sh -c on Unix and cmd /C on Windows.
+func hookCommand(ctx context.Context, command string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.CommandContext(ctx, "cmd", "/C", command)
+	}
+	return exec.CommandContext(ctx, "sh", "-c", command)
+}
+
+// runHook runs a pre_command or post_command hook of a workflow with the
+// story key and workflow name in its environment, plus extraEnv. Its output
+// goes to the terminal. Returns an error if the hook cannot start or exits
+// non-zero.
+func runHook(ctx context.Context, kind, command, workflowName, storyKey string, extraEnv ...string) error {
+	cmd := hookCommand(ctx, command)
+	cmd.Env = append(os.Environ(),
+		"BMADUUM_STORY_KEY="+storyKey,
+		"BMADUUM_WORKFLOW="+workflowName,
+	)
+	cmd.Env = append(cmd.Env, extraEnv...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s for %s failed: %w", kind, workflowName, err)
+	}
+	return nil
+}
+
+// runWithHooks runs the workflow's pre_command, then run, then its
+// post_command, and returns the step's exit code.
+//
+// A failing pre_command skips run and fails the step. A failing
+// post_command is reported as a warning, or fails an otherwise successful
+// step if fail_on_post_command is set.
+func (r *Runner) runWithHooks(ctx context.Context, wf config.WorkflowConfig, workflowName, storyKey string, run func() int) int {
+	if wf.PreCommand != "" {
+		if err := runHook(ctx, "pre_command", wf.PreCommand, workflowName, storyKey); err != nil {
+			r.printError(fmt.Sprintf("Error: %v", err))
+			return 1
+		}
+	}
+
+	exitCode := run()
+
+	if wf.PostCommand != "" {
+		err := runHook(ctx, "post_command", wf.PostCommand, workflowName, storyKey, "BMADUUM_EXIT_CODE="+strconv.Itoa(exitCode))
+		switch {
+		case err == nil:
+		case wf.FailOnPostCommand:
+			r.printError(fmt.Sprintf("Error: %v", err))
+			if exitCode == 0 {
+				exitCode = 1
+			}
+		default:
+			r.printError(fmt.Sprintf("Warning: %v", err))
+		}
+	}
+	return exitCode
+}
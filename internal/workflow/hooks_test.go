@@ -0,0 +1,88 @@
+package workflow
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunner_RunSingle_Hooks(t *testing.T) {
+	tests := []struct {
+		name           string
+		pre            string
+		post           string
+		failOnPost     bool
+		claudeExitCode int
+		wantExitCode   int
+		wantClaude     bool
+		wantLog        string
+	}{
+		{
+			name:       "hooks run around Claude with the story in the environment",
+			pre:        `echo "pre $BMADUUM_STORY_KEY $BMADUUM_WORKFLOW" >> "$LOG"`,
+			post:       `echo "post $BMADUUM_EXIT_CODE" >> "$LOG"`,
+			wantClaude: true,
+			wantLog:    "pre 7-1 dev-story\npost 0\n",
+		},
+		{
+			name:         "failing pre_command aborts the step",
+			pre:          `echo pre >> "$LOG"; exit 3`,
+			post:         `echo post >> "$LOG"`,
+			wantExitCode: 1,
+			wantLog:      "pre\n",
+		},
+		{
+			name:           "post_command sees Claude's exit code",
+			post:           `echo "post $BMADUUM_EXIT_CODE" >> "$LOG"`,
+			claudeExitCode: 2,
+			wantExitCode:   2,
+			wantClaude:     true,
+			wantLog:        "post 2\n",
+		},
+		{
+			name:       "failing post_command only warns by default",
+			post:       `echo post >> "$LOG"; exit 1`,
+			wantClaude: true,
+			wantLog:    "post\n",
+		},
+		{
+			name:         "failing post_command fails the step when configured",
+			post:         `echo post >> "$LOG"; exit 1`,
+			failOnPost:   true,
+			wantExitCode: 1,
+			wantClaude:   true,
+			wantLog:      "post\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logPath := filepath.Join(t.TempDir(), "hooks.log")
+			t.Setenv("LOG", logPath)
+
+			runner, mockExecutor, _ := setupTestRunner()
+			mockExecutor.ExitCode = tt.claudeExitCode
+			wf := runner.config.Workflows["dev-story"]
+			wf.PreCommand = tt.pre
+			wf.PostCommand = tt.post
+			wf.FailOnPostCommand = tt.failOnPost
+			runner.config.Workflows["dev-story"] = wf
+
+			exitCode := runner.RunSingle(context.Background(), "dev-story", "7-1")
+
+			assert.Equal(t, tt.wantExitCode, exitCode)
+			if tt.wantClaude {
+				assert.Len(t, mockExecutor.RecordedPrompts, 1)
+			} else {
+				assert.Empty(t, mockExecutor.RecordedPrompts)
+			}
+			log, err := os.ReadFile(logPath)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantLog, string(log))
+		})
+	}
+}
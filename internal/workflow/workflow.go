@@ -2,17 +2,35 @@ package workflow
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"strings"
 	"time"
 
 	"bmaduum/internal/claude"
 	"bmaduum/internal/config"
+	"bmaduum/internal/output"
 	"bmaduum/internal/output/core"
 	"bmaduum/internal/output/progress"
 	"bmaduum/internal/ratelimit"
+	"bmaduum/internal/runlog"
+	"bmaduum/internal/status"
 )
 
+// StoryStatusReader looks up a story's status for the {{.CurrentStatus}}
+// prompt variable. [status.Reader] implements it.
+type StoryStatusReader interface {
+	GetStoryStatus(storyKey string) (status.Status, error)
+}
+
+// BranchReader reports the checked-out git branch for the {{.GitBranch}}
+// prompt variable. The git package's Client type implements it.
+type BranchReader interface {
+	CurrentBranch(ctx context.Context) (string, error)
+}
+
 // Runner orchestrates workflow execution using Claude CLI.
 //
 // Runner is the primary executor for development workflows. It combines a
@@ -27,6 +45,41 @@ type Runner struct {
 	config     *config.Config
 	detector   *ratelimit.Detector
 	correlator *ToolCorrelator // Correlates tool uses with their results
+	overrides  *config.StoryOverrides
+	transcript *runlog.Transcript
+
+	// Sources of the prompt template variables beyond the story key
+	statuses StoryStatusReader
+	branches BranchReader
+	now      func() time.Time
+
+	// Files touched by tool uses during the most recent run
+	changedFiles []string
+	createdFiles []string
+
+	// Usage reported by the most recent run and accumulated across runs
+	lastUsage  core.Usage
+	totalUsage core.Usage
+
+	// Claude session of the most recent run, and the session the next run
+	// resumes
+	lastSessionID string
+	resumeSession string
+
+	// Known failure recognized in the most recent run's output; see
+	// [Runner.LastError]
+	lastErr *ratelimit.Error
+
+	// Subtype of the error result that ended the most recent run, if any
+	errorResult string
+
+	// First error event Claude reported during the most recent run, if any
+	errorMessage string
+
+	// Printer and progress line saved while quiet mode replaces them
+	quiet        bool
+	loudPrinter  core.Printer
+	loudProgress *progress.Line
 }
 
 // NewRunner creates a new workflow runner with the specified dependencies.
@@ -46,6 +99,7 @@ func NewRunner(executor claude.Executor, printer core.Printer, cfg *config.Confi
 		config:     cfg,
 		detector:   ratelimit.NewDetector(),
 		correlator: NewToolCorrelator(),
+		now:        time.Now,
 	}
 }
 
@@ -56,23 +110,140 @@ func (r *Runner) SetOperation(operation string) {
 	r.progress.SetOperation(operation)
 }
 
+// SetQuiet suppresses all of the runner's terminal output: command headers
+// and footers, streamed Claude events, usage lines, and the progress line.
+//
+// Workflows still run normally and usage and artifacts are still tracked.
+// Errors from launching Claude are still printed to stdout.
+func (r *Runner) SetQuiet(quiet bool) {
+	if quiet == r.quiet {
+		return
+	}
+	r.quiet = quiet
+	if quiet {
+		r.loudPrinter, r.loudProgress = r.printer, r.progress
+		r.printer = output.NewPrinterWithWriter(io.Discard)
+		r.progress = progress.NewLine(io.Discard)
+		return
+	}
+	r.printer, r.progress = r.loudPrinter, r.loudProgress
+}
+
+// SetPrinter replaces the printer that command headers, streamed Claude
+// events, and usage lines are written to, e.g. with an
+// [output.JSONPrinter] for machine-readable output. Call it before
+// [Runner.SetQuiet].
+func (r *Runner) SetPrinter(printer core.Printer) {
+	r.printer = printer
+}
+
+// SetProgressWriter redirects the progress line to w. Use [io.Discard] to
+// hide it, e.g. when stdout carries machine-readable output. Call it before
+// [Runner.SetQuiet].
+func (r *Runner) SetProgressWriter(w io.Writer) {
+	r.progress = progress.NewLine(w)
+}
+
+// SetTranscript records a header line in t at the start of every workflow
+// run, along with Claude launch errors. To capture the printed output as
+// well, the runner's printer must also write to t.
+func (r *Runner) SetTranscript(t *runlog.Transcript) {
+	r.transcript = t
+}
+
+// SetResumeSession makes the next run continue the Claude session with the
+// given ID instead of starting a new one, so Claude keeps the context of the
+// earlier conversation. It applies to one run only. If the executor does not
+// implement [claude.SessionResumer], a warning is printed and a new session
+// is started.
+func (r *Runner) SetResumeSession(sessionID string) {
+	r.resumeSession = sessionID
+}
+
+// SetPromptSources sets where the {{.CurrentStatus}} and {{.GitBranch}}
+// prompt variables are read from before each workflow run. Either may be
+// nil, and lookup failures are ignored; the variable is then empty.
+func (r *Runner) SetPromptSources(statuses StoryStatusReader, branches BranchReader) {
+	r.statuses = statuses
+	r.branches = branches
+}
+
+// SetStoryOverrides configures per-story config overrides.
+//
+// When set, [Runner.RunSingle] merges the story's override (if any) over the
+// base configuration before expanding the prompt and selecting the model.
+func (r *Runner) SetStoryOverrides(overrides *config.StoryOverrides) {
+	r.overrides = overrides
+}
+
 // RunSingle executes a single named workflow for a story.
 //
 // The workflowName must match a workflow defined in the configuration (e.g.,
-// "analyze", "implement", "test"). The storyKey is substituted into the
-// workflow's prompt template.
+// "analyze", "implement", "test"). The storyKey and the other
+// [config.PromptData] variables (see [Runner.SetPromptSources]) are
+// substituted into the workflow's prompt template. Story overrides (see [Runner.SetStoryOverrides])
+// are applied before the prompt and model are resolved.
+//
+// If the workflow has a timeout (see [config.Config.GetTimeout]), Claude is
+// killed when it expires and the run fails.
+//
+// The workflow's pre_command and post_command hooks, if configured, run
+// before and after Claude; a failing pre_command fails the run without
+// invoking Claude.
 //
 // Returns the exit code from Claude CLI (0 for success, non-zero for failure).
 func (r *Runner) RunSingle(ctx context.Context, workflowName, storyKey string) int {
-	prompt, err := r.config.GetPrompt(workflowName, storyKey)
+	return r.RunSingleWithModel(ctx, workflowName, storyKey, "")
+}
+
+// RunSingleWithModel is like [Runner.RunSingle], but runs Claude with the
+// given model instead of the workflow's configured one. An empty model
+// falls back to the configured model, and then to Claude's default.
+func (r *Runner) RunSingleWithModel(ctx context.Context, workflowName, storyKey, model string) int {
+	cfg := r.config.ForStory(storyKey, r.overrides)
+
+	prompt, err := cfg.GetPromptWithData(workflowName, r.promptData(ctx, storyKey))
 	if err != nil {
 		fmt.Printf("Error: %v\n", err)
 		return 1
 	}
 
-	label := fmt.Sprintf("%s: %s", workflowName, storyKey)
-	model := r.config.GetModel(workflowName)
-	return r.runClaude(ctx, prompt, label, model)
+	if model == "" {
+		model = cfg.GetModel(workflowName)
+	}
+	if r.config.Output.Verbosity == config.VerbosityVerbose && !r.quiet {
+		r.printPrompt(prompt, cfg.PromptMode(workflowName), model)
+	}
+	return r.runWithHooks(ctx, cfg.Workflows[workflowName], workflowName, storyKey, func() int {
+		return r.runClaude(ctx, prompt, workflowName, storyKey, model, cfg.GetTimeout(workflowName))
+	})
+}
+
+// Prompt returns the prompt [Runner.RunSingle] would send to Claude for
+// workflowName on storyKey, without running anything. Template variables
+// are resolved as they are now, so {{.CurrentStatus}} is the story's
+// current status even for later steps of a lifecycle.
+func (r *Runner) Prompt(ctx context.Context, workflowName, storyKey string) (string, error) {
+	cfg := r.config.ForStory(storyKey, r.overrides)
+	return cfg.GetPromptWithData(workflowName, r.promptData(ctx, storyKey))
+}
+
+// promptData returns the template variables for a workflow run on
+// storyKey. Variables whose source is unset or fails are left empty.
+func (r *Runner) promptData(ctx context.Context, storyKey string) config.PromptData {
+	data := config.NewPromptData(storyKey)
+	data.Date = r.now().Format("2006-01-02")
+	if r.statuses != nil {
+		if s, err := r.statuses.GetStoryStatus(storyKey); err == nil {
+			data.CurrentStatus = string(s)
+		}
+	}
+	if r.branches != nil {
+		if branch, err := r.branches.CurrentBranch(ctx); err == nil {
+			data.GitBranch = branch
+		}
+	}
+	return data
 }
 
 // RunRaw executes an arbitrary prompt without template expansion.
@@ -82,17 +253,38 @@ func (r *Runner) RunSingle(ctx context.Context, workflowName, storyKey string) i
 //
 // Returns the exit code from Claude CLI (0 for success, non-zero for failure).
 func (r *Runner) RunRaw(ctx context.Context, prompt string) int {
-	return r.runClaude(ctx, prompt, "raw", "")
+	return r.runClaude(ctx, prompt, "raw", "", "", 0)
 }
 
 // runClaude executes Claude CLI with the given prompt and handles streaming output.
 //
 // This is the core execution method used by all public Runner methods.
 // It displays a command header, streams events to the printer via handleEvent,
-// updates the progress line, and displays a footer with timing and exit status.
-func (r *Runner) runClaude(ctx context.Context, prompt, label, model string) int {
-	// Reset correlator for new execution
+// updates the progress line, and displays a footer with timing and exit status,
+// followed by the files the run's tool uses changed and created.
+// A positive timeout bounds the Claude subprocess; zero means no limit. An
+// empty storyKey labels the run with the workflow name alone.
+func (r *Runner) runClaude(ctx context.Context, prompt, workflowName, storyKey, model string, timeout time.Duration) int {
+	// Reset correlator and file tracking for new execution
 	r.correlator.Reset()
+	r.changedFiles = nil
+	r.createdFiles = nil
+	r.lastUsage = core.Usage{}
+	r.lastSessionID = ""
+	r.lastErr = nil
+	r.errorResult = ""
+	r.errorMessage = ""
+	resumeSession := r.resumeSession
+	r.resumeSession = ""
+
+	label := workflowName
+	if storyKey != "" {
+		label = fmt.Sprintf("%s: %s", workflowName, storyKey)
+	}
+	startTime := time.Now()
+	if r.transcript != nil {
+		_ = r.transcript.Header(workflowName, storyKey, startTime)
+	}
 
 	// Initialize progress line FIRST (sets up scroll region at bottom)
 	// This must happen before any output so content flows naturally
@@ -104,8 +296,6 @@ func (r *Runner) runClaude(ctx context.Context, prompt, label, model string) int
 	// Now print header (it will scroll within the scroll region)
 	r.printer.CommandHeader(label, prompt, r.config.Output.TruncateLength)
 
-	startTime := time.Now()
-
 	// Event handler that routes events and updates progress
 	handler := func(event claude.Event) {
 		// Track token usage - estimate from text if actual counts are 0
@@ -118,6 +308,38 @@ func (r *Runner) runClaude(ctx context.Context, prompt, label, model string) int
 			r.progress.AddTokens(0, estimatedTokens)
 		}
 
+		// Recognize known failures in stderr and in an error result
+		if text := event.Stderr + event.ErrorText + event.ErrorMessage; text != "" && r.lastErr == nil {
+			r.lastErr = r.detector.Classify(text)
+		}
+
+		// Remember a session that completed in error, e.g. at the turn limit
+		if event.IsError() {
+			r.errorResult = event.ResultSubtype
+			if r.errorResult == "" {
+				r.errorResult = "error"
+			}
+		}
+
+		// Remember the first error Claude reported mid-stream
+		if event.Type == claude.EventTypeError && r.errorMessage == "" {
+			r.errorMessage = event.ErrorMessage
+		}
+
+		// Remember the session so a failed run can be resumed
+		if event.SessionID != "" && r.lastSessionID == "" {
+			r.lastSessionID = event.SessionID
+		}
+
+		// Record the session totals reported by the result event
+		if event.SessionComplete {
+			r.lastUsage = core.Usage{
+				InputTokens:  event.InputTokens,
+				OutputTokens: event.OutputTokens,
+				CostUSD:      event.CostUSD,
+			}
+		}
+
 		// Record first response for thinking time calculation
 		if event.IsText() || event.IsToolUse() {
 			r.progress.RecordFirstResponse()
@@ -128,6 +350,7 @@ func (r *Runner) runClaude(ctx context.Context, prompt, label, model string) int
 		case event.IsToolUse():
 			r.progress.SetCurrentTool(event.ToolName)
 			r.progress.IncrementToolCount()
+			r.trackFile(event)
 		case event.IsToolResult():
 			r.progress.SetCurrentTool("") // Back to thinking
 		}
@@ -147,31 +370,214 @@ func (r *Runner) runClaude(ctx context.Context, prompt, label, model string) int
 		}
 	}
 
-	exitCode, err := r.executor.ExecuteWithResult(ctx, prompt, handler, model)
-	if err != nil {
-		fmt.Printf("Error executing claude: %v\n", err)
+	runCtx := ctx
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		runCtx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	exitCode, err := r.execute(runCtx, prompt, handler, model, resumeSession)
+	if timeout > 0 && ctx.Err() == nil && errors.Is(runCtx.Err(), context.DeadlineExceeded) {
+		r.printError(fmt.Sprintf("Error: workflow timed out after %s", formatTimeout(timeout)))
+		exitCode = 1
+	} else if ctx.Err() != nil {
+		r.printError("Error: workflow canceled")
 		exitCode = 1
+	} else if err != nil {
+		r.printError(fmt.Sprintf("Error executing claude: %v", err))
+		exitCode = 1
+	} else if exitCode != 0 && r.lastErr != nil {
+		r.printError(fmt.Sprintf("Error: %v", r.lastErr))
+	} else if r.errorResult != "" || r.errorMessage != "" {
+		// Claude can exit 0 after a session that ended in error
+		switch {
+		case r.lastErr != nil:
+			r.printError(fmt.Sprintf("Error: %v", r.lastErr))
+		case r.errorMessage != "":
+			r.printError(fmt.Sprintf("Error: Claude reported an error: %s", r.errorMessage))
+		default:
+			r.printError(fmt.Sprintf("Error: Claude session ended with %s", r.errorResult))
+		}
+		exitCode = 1
+	}
+	// Only a failure Claude reported itself is explained by its output
+	if exitCode == 0 || err != nil || runCtx.Err() != nil {
+		r.lastErr = nil
 	}
 
 	duration := time.Since(startTime)
 	r.progress.Done(exitCode == 0, duration)
 	r.printer.CommandFooter(duration, exitCode == 0, exitCode)
+	if !r.lastUsage.IsZero() {
+		r.totalUsage = r.totalUsage.Add(r.lastUsage)
+		r.printer.CommandUsage(r.lastUsage, r.totalUsage)
+	}
+	r.printer.CommandFiles(r.changedFiles, r.createdFiles)
 
 	return exitCode
 }
 
+// execute runs Claude, resuming sessionID if set and supported by the
+// executor.
+func (r *Runner) execute(ctx context.Context, prompt string, handler claude.EventHandler, model, sessionID string) (int, error) {
+	if sessionID != "" {
+		if resumer, ok := r.executor.(claude.SessionResumer); ok {
+			fmt.Printf("Resuming Claude session %s\n", sessionID)
+			return resumer.ResumeWithResult(ctx, sessionID, prompt, handler, model)
+		}
+		fmt.Println("Warning: executor cannot resume Claude sessions; starting a new session")
+	}
+	return r.executor.ExecuteWithResult(ctx, prompt, handler, model)
+}
+
+// printError prints an error line to stdout and records it in the
+// transcript, if one is set.
+func (r *Runner) printError(line string) {
+	fmt.Println(line)
+	if r.transcript != nil {
+		fmt.Fprintln(r.transcript, line)
+	}
+}
+
+// printNotice prints a warning about the run's output to stdout, unless the
+// runner is quiet, and records it in the transcript, if one is set.
+func (r *Runner) printNotice(line string) {
+	if !r.quiet {
+		fmt.Println(line)
+	}
+	if r.transcript != nil {
+		fmt.Fprintln(r.transcript, line)
+	}
+}
+
+// printPrompt prints the full prompt about to be sent to Claude, with the
+// template mode and model it was resolved with, and records it in the
+// transcript, if one is set.
+func (r *Runner) printPrompt(prompt, mode, model string) {
+	if model == "" {
+		model = "default"
+	}
+	text := fmt.Sprintf("Prompt (%s, model %s):\n%s\n", mode, model, strings.TrimRight(prompt, "\n"))
+	fmt.Print(text)
+	if r.transcript != nil {
+		fmt.Fprint(r.transcript, text)
+	}
+}
+
+// LastArtifacts returns the files touched by the most recent workflow run.
+//
+// Files targeted by Write tool uses are reported as created; files targeted
+// by Edit, MultiEdit, and NotebookEdit tool uses are reported as changed.
+// Each path appears at most once per list, in first-touched order.
+func (r *Runner) LastArtifacts() (changed, created []string) {
+	return r.changedFiles, r.createdFiles
+}
+
+// LastUsage returns the token usage and cost Claude reported for the most
+// recent workflow run. It is zero if Claude did not report usage.
+func (r *Runner) LastUsage() core.Usage {
+	return r.lastUsage
+}
+
+// LastError returns the known failure recognized in the output of the most
+// recent run, if it failed: a [*ratelimit.Error] matching
+// [ratelimit.ErrRateLimited], [ratelimit.ErrAuthExpired], or
+// [ratelimit.ErrOverloaded]. Returns nil if the run succeeded or failed for
+// another reason.
+func (r *Runner) LastError() error {
+	if r.lastErr == nil {
+		return nil
+	}
+	return r.lastErr
+}
+
+// LastSessionID returns the ID of the Claude session started by the most
+// recent run, or "" if Claude did not report one. Pass it to
+// [Runner.SetResumeSession] to continue that session.
+func (r *Runner) LastSessionID() string {
+	return r.lastSessionID
+}
+
+// TotalUsage returns the token usage and cost accumulated across all runs
+// of this Runner.
+func (r *Runner) TotalUsage() core.Usage {
+	return r.totalUsage
+}
+
+// trackFile records the file targeted by a file-modifying tool use.
+func (r *Runner) trackFile(event claude.Event) {
+	if event.ToolFilePath == "" {
+		return
+	}
+	switch event.ToolName {
+	case "Write":
+		r.createdFiles = appendUnique(r.createdFiles, event.ToolFilePath)
+	case "Edit", "MultiEdit", "NotebookEdit":
+		r.changedFiles = appendUnique(r.changedFiles, event.ToolFilePath)
+	}
+}
+
+// formatTimeout renders a timeout without trailing zero units (10m, not 10m0s).
+func formatTimeout(d time.Duration) string {
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
+
+// appendUnique appends s to list unless it is already present.
+func appendUnique(list []string, s string) []string {
+	for _, existing := range list {
+		if existing == s {
+			return list
+		}
+	}
+	return append(list, s)
+}
+
+// Replay feeds a recorded Claude stream through the output pipeline.
+//
+// The reader supplies stream-json lines as recorded via
+// [claude.ExecutorConfig.Recorder]. Events are parsed, correlated, and
+// printed exactly as during a live run, but Claude is not invoked and no
+// progress line or timing footer is shown, so the output is deterministic.
+func (r *Runner) Replay(reader io.Reader) {
+	r.correlator.Reset()
+
+	for event := range claude.NewParser().Parse(reader) {
+		r.handleEvent(event)
+	}
+
+	// Print any tool uses left without a result
+	r.flushPendingTools()
+}
+
 // handleEvent routes a Claude streaming event to the appropriate printer method.
 // Tool uses are buffered and correlated with their results to print them together,
 // matching Claude Code's display behavior.
+//
+// At quiet verbosity only tool results with stderr output are printed, with
+// their tool use and without stdout, so errors still surface.
 func (r *Runner) handleEvent(event claude.Event) {
+	quiet := r.config.Output.Verbosity == config.VerbosityQuiet
+
 	switch {
 	case event.SessionStarted:
-		r.printer.SessionStart()
+		if !quiet {
+			r.printer.SessionStart()
+		}
 
 	case event.IsText():
 		// Flush any pending tools before printing text
 		r.flushPendingTools()
-		r.printer.Text(event.Text)
+		if !quiet {
+			r.printer.Text(event.Text)
+		}
 
 	case event.IsToolUse():
 		// Buffer tool use for correlation with its result
@@ -179,26 +585,49 @@ func (r *Runner) handleEvent(event claude.Event) {
 		r.correlator.AddToolUse(event.ToolID, params)
 
 	case event.IsToolResult():
+		stdout := event.ToolStdout
+		if quiet {
+			if event.ToolStderr == "" {
+				r.correlator.MatchResult(event.ToolUseID)
+				return
+			}
+			stdout = ""
+		}
 		// Match result with pending tool use and print together
 		if params, found := r.correlator.MatchResult(event.ToolUseID); found {
 			r.printer.ToolUse(params)
-			r.printer.ToolResult(event.ToolStdout, event.ToolStderr, r.config.Output.TruncateLines)
-		} else {
-			// No matching tool use found, just print the result
-			r.printer.ToolResult(event.ToolStdout, event.ToolStderr, r.config.Output.TruncateLines)
 		}
+		r.printer.ToolResult(stdout, event.ToolStderr, r.truncateLines())
+
+	case event.Type == claude.EventTypeNotice:
+		r.flushPendingTools()
+		r.printNotice("Warning: Claude output incomplete: " + event.Notice)
 
 	case event.SessionComplete:
 		// Flush any remaining pending tools
 		r.flushPendingTools()
-		r.printer.SessionEnd(0, true)
+		if !quiet {
+			r.printer.SessionEnd(0, true)
+		}
 	}
 }
 
+// truncateLines returns the tool output line limit for the configured
+// verbosity: unlimited (0) when verbose, otherwise output.truncate_lines.
+func (r *Runner) truncateLines() int {
+	if r.config.Output.Verbosity == config.VerbosityVerbose {
+		return 0
+	}
+	return r.config.Output.TruncateLines
+}
+
 // flushPendingTools prints any buffered tool uses without waiting for results.
-// This is called when text arrives or the session ends.
+// This is called when text arrives or the session ends. At quiet verbosity
+// the tool uses are discarded instead.
 func (r *Runner) flushPendingTools() {
 	for _, tool := range r.correlator.Flush() {
-		r.printer.ToolUse(tool.Params)
+		if r.config.Output.Verbosity != config.VerbosityQuiet {
+			r.printer.ToolUse(tool.Params)
+		}
 	}
 }
@@ -3,7 +3,11 @@ package workflow
 import (
 	"bytes"
 	"context"
+	"errors"
+	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -11,6 +15,9 @@ import (
 	"bmaduum/internal/claude"
 	"bmaduum/internal/config"
 	"bmaduum/internal/output"
+	"bmaduum/internal/output/core"
+	"bmaduum/internal/ratelimit"
+	"bmaduum/internal/status"
 )
 
 func setupTestRunner() (*Runner, *claude.MockExecutor, *bytes.Buffer) {
@@ -59,6 +66,34 @@ func TestRunner_RunSingle_UnknownWorkflow(t *testing.T) {
 	assert.Equal(t, 1, exitCode)
 }
 
+func TestRunner_LastArtifacts(t *testing.T) {
+	runner, mockExecutor, buf := setupTestRunner()
+	mockExecutor.Events = []claude.Event{
+		{Type: claude.EventTypeAssistant, ToolID: "1", ToolName: "Read", ToolFilePath: "README.md"},
+		{Type: claude.EventTypeAssistant, ToolID: "2", ToolName: "Write", ToolFilePath: "docs/7-1-story.md"},
+		{Type: claude.EventTypeAssistant, ToolID: "3", ToolName: "Edit", ToolFilePath: "main.go"},
+		{Type: claude.EventTypeAssistant, ToolID: "4", ToolName: "MultiEdit", ToolFilePath: "main.go"},
+		{Type: claude.EventTypeAssistant, ToolID: "5", ToolName: "Bash", ToolCommand: "go test ./..."},
+	}
+
+	runner.RunSingle(context.Background(), "dev-story", "7-1")
+
+	changed, created := runner.LastArtifacts()
+	assert.Equal(t, []string{"main.go"}, changed)
+	assert.Equal(t, []string{"docs/7-1-story.md"}, created)
+	assert.Contains(t, buf.String(), "Files changed:\n    main.go\n    docs/7-1-story.md (new)\n")
+
+	// A new run resets the tracked files
+	mockExecutor.Events = nil
+	buf.Reset()
+	runner.RunSingle(context.Background(), "code-review", "7-1")
+	assert.NotContains(t, buf.String(), "Files changed")
+
+	changed, created = runner.LastArtifacts()
+	assert.Empty(t, changed)
+	assert.Empty(t, created)
+}
+
 func TestRunner_RunRaw(t *testing.T) {
 	runner, mockExecutor, _ := setupTestRunner()
 
@@ -126,3 +161,490 @@ func TestRunner_HandleEvent_ToolUseFlushedOnText(t *testing.T) {
 	assert.Contains(t, buf.String(), "Bash")
 	assert.Contains(t, buf.String(), "Done!")
 }
+
+func TestRunner_HandleEvent_QuietVerbosity(t *testing.T) {
+	runner, mockExecutor, buf := setupTestRunner()
+	runner.config.Output.Verbosity = config.VerbosityQuiet
+	mockExecutor.Events = []claude.Event{
+		{Type: claude.EventTypeSystem, SessionStarted: true},
+		{Type: claude.EventTypeAssistant, Text: "Working on it..."},
+		{Type: claude.EventTypeAssistant, ToolID: "1", ToolName: "Bash", ToolCommand: "ls"},
+		{Type: claude.EventTypeUser, ToolUseID: "1", ToolStdout: "file1.go", HasToolResult: true},
+		{Type: claude.EventTypeAssistant, ToolID: "2", ToolName: "Bash", ToolCommand: "go build"},
+		{Type: claude.EventTypeUser, ToolUseID: "2", ToolStdout: "partial", ToolStderr: "undefined: foo", HasToolResult: true},
+		{Type: claude.EventTypeAssistant, ToolID: "3", ToolName: "Glob", ToolPattern: "*.go"},
+		{Type: claude.EventTypeResult, SessionComplete: true},
+	}
+
+	assert.Equal(t, 0, runner.RunSingle(context.Background(), "dev-story", "7-1"))
+
+	out := buf.String()
+	assert.Contains(t, out, "dev-story", "step header still printed")
+	assert.NotContains(t, out, "Session started")
+	assert.NotContains(t, out, "Working on it...")
+	assert.NotContains(t, out, "file1.go")
+	assert.NotContains(t, out, "Glob", "unresolved tool uses are not flushed")
+	assert.Contains(t, out, "go build", "tool with stderr is still shown")
+	assert.Contains(t, out, "undefined: foo")
+	assert.NotContains(t, out, "partial")
+}
+
+func TestRunner_HandleEvent_VerboseVerbosity(t *testing.T) {
+	longOutput := strings.TrimSuffix(strings.Repeat("line\n", 30), "\n") + "\nlast-line"
+
+	for _, tt := range []struct {
+		verbosity string
+		full      bool
+	}{
+		{config.VerbosityNormal, false},
+		{config.VerbosityVerbose, true},
+	} {
+		t.Run(tt.verbosity, func(t *testing.T) {
+			runner, _, buf := setupTestRunner()
+			runner.config.Output.Verbosity = tt.verbosity
+
+			runner.handleEvent(claude.Event{Type: claude.EventTypeUser, ToolUseID: "1", ToolStdout: longOutput, HasToolResult: true})
+
+			if tt.full {
+				assert.Contains(t, buf.String(), "last-line")
+			} else {
+				assert.NotContains(t, buf.String(), "last-line")
+			}
+		})
+	}
+}
+
+func TestRunner_RunSingle_StoryOverrides(t *testing.T) {
+	runner, mockExecutor, _ := setupTestRunner()
+	runner.SetStoryOverrides(&config.StoryOverrides{Stories: map[string]config.StoryOverride{
+		"6-1-special": {Model: "opus"},
+	}})
+
+	ctx := context.Background()
+	assert.Equal(t, 0, runner.RunSingle(ctx, "dev-story", "6-1-special"))
+	assert.Equal(t, 0, runner.RunSingle(ctx, "dev-story", "6-2-normal"))
+
+	assert.Equal(t, []string{"opus", ""}, mockExecutor.RecordedModels)
+}
+
+func TestRunner_RunSingleWithModel(t *testing.T) {
+	runner, mockExecutor, _ := setupTestRunner()
+	runner.config.Workflows["git-commit"] = config.WorkflowConfig{SlashCommand: "/git-commit {{.StoryKey}}", Model: "haiku"}
+
+	ctx := context.Background()
+	assert.Equal(t, 0, runner.RunSingleWithModel(ctx, "git-commit", "6-1-test", "sonnet"))
+	assert.Equal(t, 0, runner.RunSingleWithModel(ctx, "git-commit", "6-1-test", ""))
+	assert.Equal(t, 0, runner.RunSingle(ctx, "git-commit", "6-1-test"))
+
+	assert.Equal(t, []string{"sonnet", "haiku", "haiku"}, mockExecutor.RecordedModels)
+}
+
+// promptSources is a fixed StoryStatusReader and BranchReader.
+type promptSources struct {
+	status    status.Status
+	branch    string
+	branchErr error
+}
+
+func (p promptSources) GetStoryStatus(storyKey string) (status.Status, error) {
+	if p.status == "" {
+		return "", errors.New("story not found")
+	}
+	return p.status, nil
+}
+
+func (p promptSources) CurrentBranch(ctx context.Context) (string, error) {
+	return p.branch, p.branchErr
+}
+
+func TestRunner_RunSingle_PromptVariables(t *testing.T) {
+	tests := []struct {
+		name     string
+		sources  *promptSources
+		expected string
+	}{
+		{
+			name:     "all sources",
+			sources:  &promptSources{status: status.StatusReview, branch: "story/6-1"},
+			expected: "/dev-story 6-1-test epic=6 date=2026-03-04 branch=story/6-1 status=review",
+		},
+		{
+			name:     "lookups fail",
+			sources:  &promptSources{branchErr: errors.New("not a git repository")},
+			expected: "/dev-story 6-1-test epic=6 date=2026-03-04 branch= status=",
+		},
+		{
+			name:     "no sources",
+			expected: "/dev-story 6-1-test epic=6 date=2026-03-04 branch= status=",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner, mockExecutor, _ := setupTestRunner()
+			runner.config.Workflows["dev-story"] = config.WorkflowConfig{
+				SlashCommand: "/dev-story {{.StoryKey}} epic={{.EpicID}} date={{.Date}} branch={{.GitBranch}} status={{.CurrentStatus}}",
+			}
+			runner.now = func() time.Time { return time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC) }
+			if tt.sources != nil {
+				runner.SetPromptSources(tt.sources, tt.sources)
+			}
+
+			assert.Equal(t, 0, runner.RunSingle(context.Background(), "dev-story", "6-1-test"))
+			assert.Equal(t, []string{tt.expected}, mockExecutor.RecordedPrompts)
+		})
+	}
+}
+
+func TestRunner_Prompt(t *testing.T) {
+	runner, mockExecutor, _ := setupTestRunner()
+	runner.config.Workflows["dev-story"] = config.WorkflowConfig{
+		SlashCommand: "/dev-story {{.StoryKey}} status={{.CurrentStatus}}",
+	}
+	runner.SetPromptSources(&promptSources{status: status.StatusReview}, nil)
+
+	prompt, err := runner.Prompt(context.Background(), "dev-story", "6-1-test")
+
+	require.NoError(t, err)
+	assert.Equal(t, "/dev-story 6-1-test status=review", prompt)
+	assert.Empty(t, mockExecutor.RecordedPrompts, "Prompt must not run Claude")
+}
+
+const recordedSession = `{"type":"system","subtype":"init"}
+{"type":"assistant","message":{"content":[{"type":"text","text":"Listing files."}]}}
+{"type":"assistant","message":{"content":[{"type":"tool_use","id":"tool-1","name":"Bash","input":{"command":"ls","description":"List files"}}]}}
+{"type":"user","message":{"content":[{"type":"tool_result","tool_use_id":"tool-1"}]},"tool_use_result":{"stdout":"main.go","stderr":""}}
+{"type":"assistant","message":{"content":[{"type":"text","text":"All done."}]}}
+{"type":"result"}
+`
+
+func TestRunner_Replay_Deterministic(t *testing.T) {
+	replay := func() string {
+		buf := &bytes.Buffer{}
+		mockExecutor := &claude.MockExecutor{}
+		runner := NewRunner(mockExecutor, output.NewPrinterWithWriter(buf), config.DefaultConfig())
+		runner.Replay(strings.NewReader(recordedSession))
+		assert.Empty(t, mockExecutor.RecordedPrompts, "replay must not call Claude")
+		return buf.String()
+	}
+
+	first := replay()
+
+	assert.Contains(t, first, "Session started")
+	assert.Contains(t, first, "Listing files.")
+	assert.Contains(t, first, "Bash")
+	assert.Contains(t, first, "main.go")
+	assert.Contains(t, first, "All done.")
+	assert.Equal(t, first, replay())
+}
+
+// blockingExecutor simulates a hung Claude process that runs until its
+// context is canceled.
+type blockingExecutor struct {
+	claude.MockExecutor
+}
+
+func (b *blockingExecutor) ExecuteWithResult(ctx context.Context, prompt string, handler claude.EventHandler, model string) (int, error) {
+	<-ctx.Done()
+	return -1, ctx.Err()
+}
+
+func TestRunner_RunSingle_VerbosePrintsPrompt(t *testing.T) {
+	tests := []struct {
+		name      string
+		verbosity string
+		want      string
+	}{
+		{name: "verbose", verbosity: config.VerbosityVerbose, want: "Prompt (slash command, model opus):\n/dev-story 7-1\n"},
+		{name: "normal", verbosity: config.VerbosityNormal, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.DefaultConfig()
+			cfg.Output.Verbosity = tt.verbosity
+			cfg.Claude.Model = "opus"
+			runner := NewRunner(&claude.MockExecutor{}, output.NewPrinterWithWriter(&bytes.Buffer{}), cfg)
+
+			// Capture stdout
+			oldStdout := os.Stdout
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			exitCode := runner.RunSingle(context.Background(), "dev-story", "7-1")
+
+			w.Close()
+			os.Stdout = oldStdout
+			var stdout bytes.Buffer
+			_, _ = stdout.ReadFrom(r)
+
+			assert.Equal(t, 0, exitCode)
+			if tt.want == "" {
+				assert.NotContains(t, stdout.String(), "Prompt (")
+			} else {
+				assert.Contains(t, stdout.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestRunner_RunSingle_Timeout(t *testing.T) {
+	cfg := config.DefaultConfig()
+	wf := cfg.Workflows["dev-story"]
+	wf.Timeout = 10 * time.Millisecond
+	cfg.Workflows["dev-story"] = wf
+
+	runner := NewRunner(&blockingExecutor{}, output.NewPrinterWithWriter(&bytes.Buffer{}), cfg)
+
+	// Capture stdout
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	exitCode := runner.RunSingle(context.Background(), "dev-story", "7-1")
+
+	w.Close()
+	os.Stdout = oldStdout
+	var stdout bytes.Buffer
+	_, _ = stdout.ReadFrom(r)
+
+	assert.Equal(t, 1, exitCode)
+	assert.Contains(t, stdout.String(), "Error: workflow timed out after 10ms")
+}
+
+func TestRunner_RunSingle_Canceled(t *testing.T) {
+	runner := NewRunner(&blockingExecutor{}, output.NewPrinterWithWriter(&bytes.Buffer{}), config.DefaultConfig())
+
+	// Capture stdout
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancel)
+	exitCode := runner.RunSingle(ctx, "dev-story", "7-1")
+
+	w.Close()
+	os.Stdout = oldStdout
+	var stdout bytes.Buffer
+	_, _ = stdout.ReadFrom(r)
+
+	assert.Equal(t, 1, exitCode)
+	assert.Contains(t, stdout.String(), "Error: workflow canceled")
+}
+
+func TestRunner_RunSingle_NoTimeoutByDefault(t *testing.T) {
+	runner, _, _ := setupTestRunner()
+
+	exitCode := runner.RunSingle(context.Background(), "dev-story", "7-1")
+
+	assert.Equal(t, 0, exitCode)
+}
+
+func TestFormatTimeout(t *testing.T) {
+	assert.Equal(t, "10m", formatTimeout(10*time.Minute))
+	assert.Equal(t, "1h", formatTimeout(time.Hour))
+	assert.Equal(t, "1h30m", formatTimeout(90*time.Minute))
+	assert.Equal(t, "45s", formatTimeout(45*time.Second))
+}
+
+func TestRunner_Usage(t *testing.T) {
+	runner, mockExecutor, buf := setupTestRunner()
+	mockExecutor.Events = []claude.Event{
+		{Type: claude.EventTypeResult, SessionComplete: true, InputTokens: 100, OutputTokens: 20, CostUSD: 0.01},
+	}
+
+	runner.RunSingle(context.Background(), "dev-story", "7-1")
+	runner.RunSingle(context.Background(), "code-review", "7-1")
+
+	assert.Equal(t, core.Usage{InputTokens: 100, OutputTokens: 20, CostUSD: 0.01}, runner.LastUsage())
+	assert.Equal(t, core.Usage{InputTokens: 200, OutputTokens: 40, CostUSD: 0.02}, runner.TotalUsage())
+	assert.Contains(t, buf.String(), "Cost: $0.0100 (total $0.0200)")
+
+	// A run without a usage report leaves the total unchanged
+	mockExecutor.Events = nil
+	runner.RunSingle(context.Background(), "git-commit", "7-1")
+
+	assert.True(t, runner.LastUsage().IsZero())
+	assert.Equal(t, 0.02, runner.TotalUsage().CostUSD)
+}
+
+func TestRunner_LastError(t *testing.T) {
+	tests := []struct {
+		name     string
+		events   []claude.Event
+		exitCode int
+		want     error
+	}{
+		{
+			name:     "usage limit result",
+			events:   []claude.Event{{Type: claude.EventTypeResult, SessionComplete: true, ErrorText: "Claude AI usage limit reached|1760000000"}},
+			exitCode: 1,
+			want:     ratelimit.ErrRateLimited,
+		},
+		{
+			name:     "expired login on stderr",
+			events:   []claude.Event{{Type: claude.EventTypeStderr, Stderr: "OAuth token has expired"}},
+			exitCode: 1,
+			want:     ratelimit.ErrAuthExpired,
+		},
+		{
+			name:     "unrecognized failure",
+			events:   []claude.Event{{Type: claude.EventTypeStderr, Stderr: "something broke"}},
+			exitCode: 1,
+		},
+		{
+			name:   "successful run",
+			events: []claude.Event{{Type: claude.EventTypeStderr, Stderr: "API Error: 529 overloaded, retrying"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner, mockExecutor, _ := setupTestRunner()
+			mockExecutor.Events = tt.events
+			mockExecutor.ExitCode = tt.exitCode
+
+			// Capture stdout
+			oldStdout := os.Stdout
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			runner.RunSingle(context.Background(), "dev-story", "7-1")
+
+			w.Close()
+			os.Stdout = oldStdout
+			var stdout bytes.Buffer
+			_, _ = stdout.ReadFrom(r)
+
+			if tt.want == nil {
+				assert.NoError(t, runner.LastError())
+				return
+			}
+			assert.ErrorIs(t, runner.LastError(), tt.want)
+			assert.Contains(t, stdout.String(), "Error: "+tt.want.Error())
+		})
+	}
+}
+
+func TestRunner_ReportedErrorFailsRun(t *testing.T) {
+	tests := []struct {
+		name       string
+		event      claude.Event
+		wantCode   int
+		wantOutput string
+	}{
+		{
+			name:       "max turns",
+			event:      claude.Event{Type: claude.EventTypeResult, SessionComplete: true, ResultSubtype: claude.ResultSubtypeErrorMaxTurns},
+			wantCode:   1,
+			wantOutput: "Error: Claude session ended with error_max_turns",
+		},
+		{
+			name:       "flagged error without subtype",
+			event:      claude.Event{Type: claude.EventTypeResult, SessionComplete: true, ErrorText: "something failed"},
+			wantCode:   1,
+			wantOutput: "Error: Claude session ended with error",
+		},
+		{
+			name:       "error event",
+			event:      claude.Event{Type: claude.EventTypeError, ErrorMessage: "Stream interrupted"},
+			wantCode:   1,
+			wantOutput: "Error: Claude reported an error: Stream interrupted",
+		},
+		{
+			name:       "classified error event",
+			event:      claude.Event{Type: claude.EventTypeError, ErrorMessage: "Overloaded"},
+			wantCode:   1,
+			wantOutput: "Error: claude API overloaded",
+		},
+		{
+			name:     "success",
+			event:    claude.Event{Type: claude.EventTypeResult, SessionComplete: true, ResultSubtype: claude.ResultSubtypeSuccess},
+			wantCode: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner, mockExecutor, _ := setupTestRunner()
+			mockExecutor.Events = []claude.Event{tt.event}
+
+			// Capture stdout
+			oldStdout := os.Stdout
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			exitCode := runner.RunSingle(context.Background(), "dev-story", "7-1")
+
+			w.Close()
+			os.Stdout = oldStdout
+			var stdout bytes.Buffer
+			_, _ = stdout.ReadFrom(r)
+
+			assert.Equal(t, tt.wantCode, exitCode, "exit code 0 is overridden by an error result")
+			if tt.wantOutput != "" {
+				assert.Contains(t, stdout.String(), tt.wantOutput)
+			} else {
+				assert.NotContains(t, stdout.String(), "Error:")
+			}
+		})
+	}
+}
+
+func TestRunner_NoticeEvent(t *testing.T) {
+	runner, mockExecutor, _ := setupTestRunner()
+	mockExecutor.Events = []claude.Event{
+		{Type: claude.EventTypeNotice, Notice: "skipped 200-byte line exceeding the 100-byte buffer"},
+		{Type: claude.EventTypeResult, SessionComplete: true, ResultSubtype: claude.ResultSubtypeSuccess},
+	}
+
+	// Capture stdout
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	exitCode := runner.RunSingle(context.Background(), "dev-story", "7-1")
+
+	w.Close()
+	os.Stdout = oldStdout
+	var stdout bytes.Buffer
+	_, _ = stdout.ReadFrom(r)
+
+	assert.Equal(t, 0, exitCode, "a notice does not fail the run")
+	assert.Contains(t, stdout.String(), "Warning: Claude output incomplete: skipped 200-byte line exceeding the 100-byte buffer")
+}
+
+func TestRunner_SetQuiet(t *testing.T) {
+	runner, mockExecutor, buf := setupTestRunner()
+	mockExecutor.Events = append(mockExecutor.Events, claude.Event{Type: claude.EventTypeResult, SessionComplete: true, InputTokens: 10, OutputTokens: 2, CostUSD: 0.01})
+
+	runner.SetQuiet(true)
+	assert.Equal(t, 0, runner.RunSingle(context.Background(), "dev-story", "7-1"))
+	assert.Empty(t, buf.String(), "quiet runner prints nothing")
+	assert.Equal(t, 0.01, runner.LastUsage().CostUSD, "usage still tracked")
+
+	runner.SetQuiet(false)
+	runner.RunSingle(context.Background(), "dev-story", "7-1")
+	assert.Contains(t, buf.String(), "Working on it...")
+}
+
+func TestRunner_ResumeSession(t *testing.T) {
+	runner, mockExecutor, _ := setupTestRunner()
+	mockExecutor.Events = []claude.Event{
+		{Type: claude.EventTypeSystem, SessionStarted: true, SessionID: "session-1"},
+		{Type: claude.EventTypeResult, SessionComplete: true, SessionID: "session-1"},
+	}
+
+	runner.RunSingle(context.Background(), "dev-story", "7-1")
+	assert.Equal(t, "session-1", runner.LastSessionID())
+
+	runner.SetResumeSession(runner.LastSessionID())
+	runner.RunSingle(context.Background(), "dev-story", "7-1")
+	runner.RunSingle(context.Background(), "dev-story", "7-1")
+	assert.Equal(t, []string{"", "session-1", ""}, mockExecutor.RecordedSessions, "resume applies to one run")
+
+	mockExecutor.Events = nil
+	runner.RunSingle(context.Background(), "dev-story", "7-1")
+	assert.Empty(t, runner.LastSessionID(), "reset for each run")
+}